@@ -0,0 +1,240 @@
+package admin_controller_test
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	admin_controller "github.com/sunthewhat/easy-cert-api/api/controllers/admin"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+type fakeMailer struct {
+	err        error
+	recipients []string
+}
+
+func (m *fakeMailer) Send(message *util.MailMessage) error {
+	m.recipients = append(m.recipients, message.To)
+	return m.err
+}
+
+func TestAdminController_TestMail(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	defer util.SetMailer(nil)
+
+	sender := "noreply@example.com"
+	common.Config = &shared.Config{MailUser: &sender}
+
+	tests := []struct {
+		name           string
+		body           string
+		withUser       bool
+		sendErr        error
+		wantStatusCode int
+		wantSent       int
+	}{
+		{
+			name:           "successful test email",
+			body:           `{"email":"owner@example.com"}`,
+			withUser:       true,
+			wantStatusCode: fiber.StatusOK,
+			wantSent:       1,
+		},
+		{
+			name:           "failed - mailer error",
+			body:           `{"email":"owner@example.com"}`,
+			withUser:       true,
+			sendErr:        errors.New("535 authentication failed"),
+			wantStatusCode: fiber.StatusInternalServerError,
+			wantSent:       1,
+		},
+		{
+			name:           "failed - invalid email",
+			body:           `{"email":"not-an-email"}`,
+			withUser:       true,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:           "defaults to the caller's address",
+			body:           `{}`,
+			withUser:       true,
+			wantStatusCode: fiber.StatusOK,
+			wantSent:       1,
+		},
+		{
+			name:           "failed - someone else's address",
+			body:           `{"email":"victim@example.com"}`,
+			withUser:       true,
+			wantStatusCode: fiber.StatusForbidden,
+		},
+		{
+			name:           "failed - no user in context",
+			body:           `{"email":"owner@example.com"}`,
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mailer := &fakeMailer{err: tt.sendErr}
+			util.SetMailer(mailer)
+
+			app := fiber.New()
+			ctrl := admin_controller.NewAdminController(certificatemodel.NewMockCertificateRepository())
+			app.Post("/admin/test-mail", func(c *fiber.Ctx) error {
+				if tt.withUser {
+					c.Locals("user_id", "owner@example.com")
+				}
+				return ctrl.TestMail(c)
+			})
+
+			req := httptest.NewRequest("POST", "/admin/test-mail", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if len(mailer.recipients) != tt.wantSent {
+				t.Errorf("Expected %d emails sent, got %d", tt.wantSent, len(mailer.recipients))
+			}
+			if tt.sendErr != nil {
+				responseBody, _ := io.ReadAll(resp.Body)
+				if strings.Contains(string(responseBody), tt.sendErr.Error()) {
+					t.Errorf("Expected the provider error to stay out of the response, got %s", responseBody)
+				}
+			}
+		})
+	}
+}
+
+func TestAdminController_TestMail_RateLimited(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	defer util.SetMailer(nil)
+
+	sender := "noreply@example.com"
+	common.Config = &shared.Config{MailUser: &sender}
+
+	mailer := &fakeMailer{}
+	util.SetMailer(mailer)
+
+	app := fiber.New()
+	ctrl := admin_controller.NewAdminController(certificatemodel.NewMockCertificateRepository())
+	app.Post("/admin/test-mail", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.TestMail(c)
+	})
+
+	for i, wantStatusCode := range []int{fiber.StatusOK, fiber.StatusTooManyRequests} {
+		req := httptest.NewRequest("POST", "/admin/test-mail", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to execute request: %v", err)
+		}
+		if resp.StatusCode != wantStatusCode {
+			t.Errorf("Request %d: expected status code %d, got %d", i+1, wantStatusCode, resp.StatusCode)
+		}
+	}
+
+	if len(mailer.recipients) != 1 {
+		t.Errorf("Expected 1 email sent, got %d", len(mailer.recipients))
+	}
+}
+
+func TestAdminController_GetFailedEmails(t *testing.T) {
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.GetFailedEmailsByOwnerFunc = func(userId string, includeResolved bool) ([]*model.FailedEmail, error) {
+		if userId != "owner@example.com" || !includeResolved {
+			t.Errorf("Unexpected query user=%s includeResolved=%v", userId, includeResolved)
+		}
+		return []*model.FailedEmail{{ID: "fe1", CertificateID: "cert1", Attempts: 2}}, nil
+	}
+
+	app := fiber.New()
+	ctrl := admin_controller.NewAdminController(mockCertRepo)
+	app.Get("/admin/failed-emails", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.GetFailedEmails(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/admin/failed-emails?include_resolved=true", nil))
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", resp.StatusCode)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), `"id":"fe1"`) {
+		t.Errorf("Expected failed email in response, got %s", body)
+	}
+}
+
+func TestAdminController_RetryFailedEmail(t *testing.T) {
+	tests := []struct {
+		name           string
+		failedEmail    *model.FailedEmail
+		wantStatusCode int
+	}{
+		{
+			name:           "failed - not found",
+			wantStatusCode: fiber.StatusNotFound,
+		},
+		{
+			name:           "failed - not the owner",
+			failedEmail:    &model.FailedEmail{ID: "fe1", CertificateID: "other-cert"},
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "failed - already resolved",
+			failedEmail:    &model.FailedEmail{ID: "fe1", CertificateID: "cert1", Resolved: true},
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetFailedEmailByIdFunc = func(id string) (*model.FailedEmail, error) {
+				return tt.failedEmail, nil
+			}
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				owner := "owner@example.com"
+				if certId == "other-cert" {
+					owner = "other@example.com"
+				}
+				return &model.Certificate{ID: certId, UserID: owner}, nil
+			}
+
+			app := fiber.New()
+			ctrl := admin_controller.NewAdminController(mockCertRepo)
+			app.Post("/admin/failed-emails/:id/retry", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.RetryFailedEmail(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("POST", "/admin/failed-emails/fe1/retry", nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+		})
+	}
+}
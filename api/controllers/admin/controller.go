@@ -0,0 +1,28 @@
+package admin_controller
+
+import (
+	"sync"
+	"time"
+
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+// AdminController handles operational endpoints for owners and admins
+type AdminController struct {
+	certRepo   certificatemodel.ICertificateRepository
+	retryEmail func(failedEmail *model.FailedEmail) error
+
+	testMailMu   sync.Mutex
+	lastTestMail map[string]time.Time
+}
+
+// NewAdminController creates a new admin controller
+func NewAdminController(certRepo certificatemodel.ICertificateRepository) *AdminController {
+	return &AdminController{
+		certRepo:     certRepo,
+		retryEmail:   util.RetryFailedEmail,
+		lastTestMail: make(map[string]time.Time),
+	}
+}
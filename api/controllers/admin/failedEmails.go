@@ -0,0 +1,81 @@
+package admin_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetFailedEmails lists the queued emails of the user's certificates that could not be sent, most recent
+// attempt first. Resolved entries are included with ?include_resolved=true.
+func (ctrl *AdminController) GetFailedEmails(c *fiber.Ctx) error {
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Admin GetFailedEmails UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	includeResolved := c.QueryBool("include_resolved", false)
+
+	failedEmails, err := ctrl.certRepo.GetFailedEmailsByOwner(userId, includeResolved)
+	if err != nil {
+		slog.Error("Admin GetFailedEmails failed", "error", err, "user_id", userId)
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Failed emails fetched", fiber.Map{
+		"max_attempts":  util.FailedEmailMaxAttempts(),
+		"failed_emails": failedEmails,
+	})
+}
+
+// RetryFailedEmail resends a queued failed email immediately, regardless of how often it was attempted
+func (ctrl *AdminController) RetryFailedEmail(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if id == "" {
+		return response.SendFailed(c, "Failed email ID is required")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Admin RetryFailedEmail UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	failedEmail, err := ctrl.certRepo.GetFailedEmailById(id)
+	if err != nil {
+		slog.Error("Admin RetryFailedEmail GetFailedEmailById failed", "error", err, "id", id)
+		return response.SendInternalError(c, err)
+	}
+
+	if failedEmail == nil {
+		return response.SendNotFound(c, "Failed email not found")
+	}
+
+	cert, err := ctrl.certRepo.GetById(failedEmail.CertificateID)
+	if err != nil {
+		slog.Error("Admin RetryFailedEmail GetById failed", "error", err, "cert_id", failedEmail.CertificateID)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil || cert.UserID != userId {
+		slog.Warn("Wrong Owner Request RetryFailedEmail", "user", userId, "failed_email", id)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	if failedEmail.Resolved {
+		return response.SendFailed(c, "Failed email is already resolved")
+	}
+
+	if err := ctrl.retryEmail(failedEmail); err != nil {
+		slog.Warn("Admin RetryFailedEmail failed", "error", err, "id", id, "user_id", userId)
+		return response.SendError(c, "Retry failed: "+err.Error())
+	}
+
+	slog.Info("Admin RetryFailedEmail resolved", "id", id, "user_id", userId)
+	return response.SendSuccess(c, "Email sent", fiber.Map{"id": id})
+}
@@ -0,0 +1,76 @@
+package admin_controller
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// testMailCooldown is how long a user has to wait between two test emails
+const testMailCooldown = time.Minute
+
+// TestMail sends a test email through the configured mailer, the same path distributions use,
+// so misconfigured mail settings surface before a real distribution fails halfway through.
+// The email only goes to the caller's own address and is rate limited per user.
+func (ctrl *AdminController) TestMail(c *fiber.Ctx) error {
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Admin TestMail UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	body := new(payload.TestMailPayload)
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	recipient, ok := middleware.GetUserEmailFromContext(c)
+	if !ok {
+		slog.Warn("Admin TestMail caller has no email address", "user_id", userId)
+		return response.SendFailed(c, "Your account has no email address to send the test to")
+	}
+
+	if body.Email != "" && !strings.EqualFold(body.Email, recipient) {
+		slog.Warn("Admin TestMail rejected foreign recipient", "user_id", userId, "recipient", body.Email)
+		return response.SendForbidden(c, "Test email can only be sent to your own address")
+	}
+
+	if wait := ctrl.reserveTestMail(userId); wait > 0 {
+		return response.SendTooManyRequests(c, "Please wait before sending another test email", fiber.Map{
+			"retry_after_seconds": int(wait.Round(time.Second).Seconds()),
+		})
+	}
+
+	if err := util.SendTestMail(recipient); err != nil {
+		slog.Warn("Admin TestMail failed", "error", err, "user_id", userId, "recipient", recipient)
+		return response.SendError(c, "Test email failed, check the server mail configuration")
+	}
+
+	slog.Info("Admin TestMail sent", "user_id", userId, "recipient", recipient)
+	return response.SendSuccess(c, "Test email sent", fiber.Map{"email": recipient})
+}
+
+// reserveTestMail records a test email for userId and returns how long the user still has to wait
+// when the previous one was sent less than testMailCooldown ago
+func (ctrl *AdminController) reserveTestMail(userId string) time.Duration {
+	ctrl.testMailMu.Lock()
+	defer ctrl.testMailMu.Unlock()
+
+	now := time.Now()
+	if last, ok := ctrl.lastTestMail[userId]; ok && now.Sub(last) < testMailCooldown {
+		return testMailCooldown - now.Sub(last)
+	}
+	ctrl.lastTestMail[userId] = now
+	return 0
+}
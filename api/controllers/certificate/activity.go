@@ -0,0 +1,68 @@
+package certificate_controller
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// recordActivity adds an entry to the certificate's activity log on behalf of the requesting user.
+// Failures are only logged since the action itself already succeeded.
+func (ctrl *CertificateController) recordActivity(c *fiber.Ctx, certId, action, detail string) {
+	actor, _ := middleware.GetUserFromContext(c)
+	if err := ctrl.certRepo.RecordActivity(certId, actor, action, detail); err != nil {
+		slog.Warn("Failed to record certificate activity", "error", err, "cert_id", certId, "action", action)
+	}
+}
+
+// GetActivity returns the certificate's most recent activity (edits, distributions, revocations and signatures),
+// newest first. ?limit= caps the number of entries, up to MaxActivityLimit.
+func (ctrl *CertificateController) GetActivity(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	limit := certificatemodel.DefaultActivityLimit
+	if rawLimit := c.Query("limit"); rawLimit != "" {
+		parsed, err := strconv.Atoi(rawLimit)
+		if err != nil || parsed < 1 || parsed > certificatemodel.MaxActivityLimit {
+			return response.SendFailed(c, "limit must be between 1 and "+strconv.Itoa(certificatemodel.MaxActivityLimit))
+		}
+		limit = parsed
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate GetActivity GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate GetActivity UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request GetActivity", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	activities, err := ctrl.certRepo.GetRecentActivity(certId, limit)
+	if err != nil {
+		slog.Error("Certificate GetActivity failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Certificate activity fetched", activities)
+}
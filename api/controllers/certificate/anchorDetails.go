@@ -0,0 +1,115 @@
+package certificate_controller
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// AnchorDetail describes a design anchor with the sample text shown in the editor
+type AnchorDetail struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Sample   string `json:"sample"`
+	Optional bool   `json:"optional"`
+}
+
+// extractAnchorDetails returns the anchors of a design with their object type, sample text and optional flag.
+// Grouped anchors take their sample from the textbox inside the group.
+func extractAnchorDetails(designJSON string) ([]AnchorDetail, error) {
+	anchorObjects, order, err := certificatemodel.ParseDesignAnchorObjects(designJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	anchors := make([]AnchorDetail, 0, len(anchorObjects))
+	for _, anchor := range anchorObjects {
+		objType, _ := anchor.Object["type"].(string)
+		optional, _ := anchor.Object["optional"].(bool)
+
+		anchors = append(anchors, AnchorDetail{
+			Name:     anchor.Name,
+			Type:     strings.ToLower(objType),
+			Sample:   anchorSampleText(anchor.Object),
+			Optional: optional,
+		})
+	}
+
+	return orderAnchorDetails(anchors, order), nil
+}
+
+// orderAnchorDetails sorts details the same way as the design's anchor list
+func orderAnchorDetails(details []AnchorDetail, order []string) []AnchorDetail {
+	names := make([]string, len(details))
+	for i, detail := range details {
+		names[i] = detail.Name
+	}
+
+	position := make(map[string]int, len(names))
+	for i, name := range certificatemodel.OrderAnchors(names, order) {
+		if _, seen := position[name]; !seen {
+			position[name] = i
+		}
+	}
+
+	sort.SliceStable(details, func(i, j int) bool {
+		return position[details[i].Name] < position[details[j].Name]
+	})
+	return details
+}
+
+// anchorSampleText returns the object's text, or the text of the first textbox in a group
+func anchorSampleText(obj map[string]any) string {
+	if text, ok := obj["text"].(string); ok {
+		return text
+	}
+
+	children, _ := obj["objects"].([]any)
+	for _, child := range children {
+		childMap, ok := child.(map[string]any)
+		if !ok {
+			continue
+		}
+		childType, _ := childMap["type"].(string)
+		if strings.EqualFold(childType, "textbox") {
+			text, _ := childMap["text"].(string)
+			return text
+		}
+	}
+
+	return ""
+}
+
+// GetAnchorDetails returns the certificate's anchors with type, sample value and optional flag
+// so the editor can pre-fill participant forms
+func (ctrl *CertificateController) GetAnchorDetails(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		slog.Warn("Certificate GetAnchorDetails attempt with empty ID")
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Error getting certificate", "certId", certId, "error", err)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		slog.Warn("Getting non-existing certificate", "certId", certId)
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	anchors, err := extractAnchorDetails(cert.Design)
+	if err != nil {
+		slog.Warn("Invalid certificate design for anchor details", "certId", certId, "error", err)
+		return response.SendFailed(c, "Invalid certificate design format")
+	}
+
+	return response.SendSuccess(c, "Anchor details retrieved successfully", anchors)
+}
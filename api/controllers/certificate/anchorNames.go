@@ -0,0 +1,58 @@
+package certificate_controller
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+// defaultAnchorNamePattern allows only letters, digits and underscores in anchor names
+const defaultAnchorNamePattern = `^[A-Za-z0-9_]+$`
+
+// anchorNamePattern returns the configured anchor name pattern, or nil when anchor name validation is disabled.
+// An invalid configured pattern falls back to the default.
+func anchorNamePattern() *regexp.Regexp {
+	if common.Config == nil || common.Config.AnchorNameValidation == nil || !*common.Config.AnchorNameValidation {
+		return nil
+	}
+
+	if common.Config.AnchorNamePattern != nil && *common.Config.AnchorNamePattern != "" {
+		pattern, err := regexp.Compile(*common.Config.AnchorNamePattern)
+		if err == nil {
+			return pattern
+		}
+		slog.Error("Invalid anchor_name_pattern, using default", "error", err, "pattern", *common.Config.AnchorNamePattern)
+	}
+
+	return regexp.MustCompile(defaultAnchorNamePattern)
+}
+
+// validateDesignAnchorNames rejects designs whose PLACEHOLDER anchor names don't match the configured pattern.
+// Designs that can't be parsed are left to the existing design handling.
+func validateDesignAnchorNames(designJSON string) error {
+	pattern := anchorNamePattern()
+	if pattern == nil || designJSON == "" {
+		return nil
+	}
+
+	anchors, err := certificatemodel.ExtractDesignAnchors(designJSON)
+	if err != nil {
+		return nil
+	}
+
+	var disallowed []string
+	for _, anchor := range anchors {
+		if !pattern.MatchString(anchor) {
+			disallowed = append(disallowed, anchor)
+		}
+	}
+
+	if len(disallowed) > 0 {
+		return fmt.Errorf("anchor names not allowed: %s (must match %s)", strings.Join(disallowed, ", "), pattern.String())
+	}
+	return nil
+}
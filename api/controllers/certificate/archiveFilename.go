@@ -0,0 +1,65 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// SetArchiveFilenameTemplate sets the template used to name PDFs inside archives generated from now on.
+// Placeholders like {{name}} resolve against participant data; an empty template restores certificate_<id>.pdf.
+func (ctrl *CertificateController) SetArchiveFilenameTemplate(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	body := new(payload.SetArchiveFilenameTemplatePayload)
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	if err := renderer.ValidateArchiveFilenameTemplate(body.Template); err != nil {
+		return response.SendFailed(c, err.Error())
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate SetArchiveFilenameTemplate GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate SetArchiveFilenameTemplate UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request SetArchiveFilenameTemplate", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	if err := ctrl.certRepo.SetArchiveFilenameTemplate(certId, body.Template); err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Archive filename template updated", map[string]any{
+		"archive_filename_template": body.Template,
+	})
+}
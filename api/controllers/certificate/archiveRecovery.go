@@ -0,0 +1,164 @@
+package certificate_controller
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// Participant recovery states reported by GetArchiveRecovery
+const (
+	RecoveryStateIntact      = "intact"      // MongoDB data still exists
+	RecoveryStateRecoverable = "recoverable" // data is lost but the generated certificate survives
+	RecoveryStateLost        = "lost"        // data is lost and no generated certificate was found
+)
+
+type participantRecovery struct {
+	ParticipantID     string `json:"participant_id"`
+	State             string `json:"state"`
+	IsRevoked         bool   `json:"is_revoked"`
+	HasData           bool   `json:"has_data"`
+	ArchiveEntry      string `json:"archive_entry,omitempty"`
+	HasCertificateURL bool   `json:"has_certificate_url"`
+}
+
+// participantRecoveryState classifies a participant from its MongoDB data and surviving certificate copies
+func participantRecoveryState(status *participantmodel.ParticipantRecoveryStatus, archiveEntry string) string {
+	switch {
+	case status.HasData:
+		return RecoveryStateIntact
+	case archiveEntry != "" || status.CertificateURL != "":
+		return RecoveryStateRecoverable
+	default:
+		return RecoveryStateLost
+	}
+}
+
+// listCertificateArchive downloads a certificate's ZIP archive and lists its entries. The returned message
+// explains why the archive couldn't be read and is empty on success.
+func listCertificateArchive(certId string, archiveURL string) ([]string, string) {
+	objectPath, err := util.ExtractObjectNameFromURL(archiveURL, *common.Config.BucketCertificate)
+	if err != nil {
+		slog.Error("Certificate GetArchiveRecovery invalid archive URL", "error", err, "cert_id", certId, "archive_url", archiveURL)
+		return nil, "Invalid archive URL"
+	}
+
+	object, err := util.DownloadFile(context.Background(), *common.Config.BucketCertificate, objectPath)
+	if err != nil {
+		slog.Error("Certificate GetArchiveRecovery download failed", "error", err, "cert_id", certId, "object_path", objectPath)
+		return nil, "Archive file not found"
+	}
+	defer object.Close()
+
+	objectInfo, err := object.Stat()
+	if err != nil {
+		slog.Warn("Certificate GetArchiveRecovery archive missing from storage", "error", err, "cert_id", certId, "object_path", objectPath)
+		return nil, "Archive file not found in storage"
+	}
+
+	entries, err := renderer.ListArchiveEntries(object, objectInfo.Size)
+	if err != nil {
+		slog.Warn("Certificate GetArchiveRecovery archive is corrupt", "error", err, "cert_id", certId, "object_path", objectPath)
+		return nil, err.Error()
+	}
+	return entries, ""
+}
+
+// GetArchiveRecovery is a disaster recovery aid for when MongoDB participant data is lost but the MinIO archive
+// survives. It cross-references the participants PostgreSQL still knows of with the MongoDB documents and the
+// archive's entry names, which contain participant IDs, to report which participants can be recovered from a
+// generated certificate and which are gone. A missing or unreadable archive is reported, not treated as an error.
+func (ctrl *CertificateController) GetArchiveRecovery(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate GetArchiveRecovery GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate GetArchiveRecovery UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request GetArchiveRecovery", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	statuses, err := ctrl.participantRepo.GetRecoveryStatusByCertId(certId)
+	if err != nil {
+		slog.Error("Certificate GetArchiveRecovery GetRecoveryStatusByCertId failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	var entries []string
+	archiveError := ""
+	if cert.ArchiveURL == "" {
+		archiveError = "Certificate archive not available"
+	} else {
+		entries, archiveError = listCertificateArchive(certId, cert.ArchiveURL)
+	}
+
+	participantIDs := make([]string, len(statuses))
+	for i, status := range statuses {
+		participantIDs[i] = status.ID
+	}
+	matched, unmatched := renderer.MatchArchiveEntries(entries, participantIDs)
+
+	counts := map[string]int{
+		RecoveryStateIntact:      0,
+		RecoveryStateRecoverable: 0,
+		RecoveryStateLost:        0,
+	}
+	participants := make([]participantRecovery, 0, len(statuses))
+	for _, status := range statuses {
+		state := participantRecoveryState(status, matched[status.ID])
+		counts[state]++
+		participants = append(participants, participantRecovery{
+			ParticipantID:     status.ID,
+			State:             state,
+			IsRevoked:         status.IsRevoke,
+			HasData:           status.HasData,
+			ArchiveEntry:      matched[status.ID],
+			HasCertificateURL: status.CertificateURL != "",
+		})
+	}
+
+	slog.Info("Certificate GetArchiveRecovery completed",
+		"cert_id", certId,
+		"participants", len(statuses),
+		"intact", counts[RecoveryStateIntact],
+		"recoverable", counts[RecoveryStateRecoverable],
+		"lost", counts[RecoveryStateLost])
+
+	result := map[string]any{
+		"certificate_id":    certId,
+		"archive_available": archiveError == "",
+		"total":             len(statuses),
+		"counts":            counts,
+		"participants":      participants,
+		"unmatched_entries": unmatched,
+	}
+	if archiveError != "" {
+		result["archive_error"] = archiveError
+	}
+	return response.SendSuccess(c, "Certificate archive recovery report fetched", result)
+}
@@ -0,0 +1,72 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+// BatchGet returns the requested certificates owned by the caller in one query.
+// IDs that don't exist or belong to another user are listed in unavailable_ids.
+func (ctrl *CertificateController) BatchGet(c *fiber.Ctx) error {
+	body := new(payload.BatchGetCertificatePayload)
+
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Failed to parse body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate BatchGet UserToken not found")
+		return response.SendUnauthorized(c, "User token not found")
+	}
+
+	certs, err := ctrl.certRepo.GetByIds(body.Ids)
+	if err != nil {
+		slog.Error("Certificate BatchGet controller failed", "error", err, "count", len(body.Ids))
+		return response.SendInternalError(c, err)
+	}
+
+	owned := make(map[string]*model.Certificate)
+	for _, cert := range certs {
+		if cert.UserID == userId {
+			owned[cert.ID] = cert
+		}
+	}
+
+	certificates := make([]*model.Certificate, 0, len(owned))
+	unavailableIds := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, id := range body.Ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if cert, ok := owned[id]; ok {
+			certificates = append(certificates, cert)
+		} else {
+			unavailableIds = append(unavailableIds, id)
+		}
+	}
+
+	slog.Info("Certificate BatchGet successful",
+		"requested", len(body.Ids),
+		"returned", len(certificates),
+		"unavailable", len(unavailableIds))
+
+	return response.SendSuccess(c, "Certificate fetched", fiber.Map{
+		"certificates":    certificates,
+		"unavailable_ids": unavailableIds,
+	})
+}
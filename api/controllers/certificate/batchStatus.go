@@ -0,0 +1,129 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+type batchStatusItem struct {
+	CertificateID string `json:"certificate_id"`
+	responseStruct
+}
+
+// generationStatus builds the CheckGenerateStatus response of a certificate from its aggregated
+// signature progress and participant generation counts
+func generationStatus(cert *model.Certificate, signatures signaturemodel.SignatureProgress, counts participantmodel.GenerationCounts, pdfSigningEnabled bool) responseStruct {
+	generationState, queuePosition := renderer.Generations().State(cert.ID)
+	status := responseStruct{
+		GenerationState:   generationState,
+		QueuePosition:     queuePosition,
+		PdfSigningEnabled: pdfSigningEnabled,
+	}
+
+	if !cert.IsSigned && !signatures.Complete() {
+		return status
+	}
+	status.IsSigned = true
+
+	if !cert.IsDistributed {
+		return status
+	}
+	status.IsGenerated = true
+	status.IsPartialGenerated = counts.Pending > 0
+	status.PdfSignedCount = int(counts.PdfSigned)
+	status.PdfUnsignedCount = int(counts.PdfUnsigned)
+	status.PdfUnknownCount = int(counts.PdfUnknown)
+
+	return status
+}
+
+// BatchStatus returns the generation status of many certificates owned by the caller, using one grouped
+// query for signatures and one for participants instead of a CheckGenerateStatus call per certificate.
+// IDs that don't exist or belong to another user are listed in unavailable_ids.
+func (ctrl *CertificateController) BatchStatus(c *fiber.Ctx) error {
+	body := new(payload.BatchGetCertificatePayload)
+
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Failed to parse body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate BatchStatus UserToken not found")
+		return response.SendUnauthorized(c, "User token not found")
+	}
+
+	certs, err := ctrl.certRepo.GetByIds(body.Ids)
+	if err != nil {
+		slog.Error("Certificate BatchStatus GetByIds failed", "error", err, "count", len(body.Ids))
+		return response.SendInternalError(c, err)
+	}
+
+	owned := make(map[string]*model.Certificate)
+	for _, cert := range certs {
+		if cert.UserID == userId {
+			owned[cert.ID] = cert
+		}
+	}
+
+	ownedIds := make([]string, 0, len(owned))
+	unavailableIds := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, id := range body.Ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if _, ok := owned[id]; ok {
+			ownedIds = append(ownedIds, id)
+		} else {
+			unavailableIds = append(unavailableIds, id)
+		}
+	}
+
+	signatureProgress, err := ctrl.signatureRepo.GetSignatureProgressByCertificates(ownedIds)
+	if err != nil {
+		slog.Error("Certificate BatchStatus GetSignatureProgressByCertificates failed", "error", err, "count", len(ownedIds))
+		return response.SendInternalError(c, err)
+	}
+
+	generationCounts, err := ctrl.participantRepo.CountGenerationByCertificates(ownedIds)
+	if err != nil {
+		slog.Error("Certificate BatchStatus CountGenerationByCertificates failed", "error", err, "count", len(ownedIds))
+		return response.SendInternalError(c, err)
+	}
+
+	pdfSigningEnabled := renderer.GetSigningCertificateInfo().Enabled
+	statuses := make([]batchStatusItem, 0, len(ownedIds))
+	for _, id := range ownedIds {
+		statuses = append(statuses, batchStatusItem{
+			CertificateID:  id,
+			responseStruct: generationStatus(owned[id], signatureProgress[id], generationCounts[id], pdfSigningEnabled),
+		})
+	}
+
+	slog.Info("Certificate BatchStatus successful",
+		"requested", len(body.Ids),
+		"returned", len(statuses),
+		"unavailable", len(unavailableIds))
+
+	return response.SendSuccess(c, "Certificate statuses fetched", fiber.Map{
+		"statuses":        statuses,
+		"unavailable_ids": unavailableIds,
+	})
+}
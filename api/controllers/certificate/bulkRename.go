@@ -0,0 +1,86 @@
+package certificate_controller
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// BulkRename renames several of the user's certificates at once. Owned certificates are renamed in a
+// single transaction; every item gets a status of renamed, not_found, not_owned or duplicate.
+func (ctrl *CertificateController) BulkRename(c *fiber.Ctx) error {
+	body := new(payload.BulkRenameCertificatesPayload)
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendValidationFailed(c, errors[0], util.GetFieldValidationErrors(err))
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate BulkRename UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	lookupIds := make([]string, len(body.Items))
+	for i, item := range body.Items {
+		lookupIds[i] = item.Id
+	}
+
+	certs, err := ctrl.certRepo.GetByIds(lookupIds)
+	if err != nil {
+		slog.Error("Certificate BulkRename GetByIds failed", "error", err, "count", len(lookupIds))
+		return response.SendInternalError(c, err)
+	}
+
+	owners := make(map[string]string, len(certs))
+	for _, cert := range certs {
+		owners[cert.ID] = cert.UserID
+	}
+
+	results := make([]map[string]string, len(body.Items))
+	names := make(map[string]string, len(body.Items))
+	for i, item := range body.Items {
+		name := strings.TrimSpace(item.Name)
+		results[i] = map[string]string{"id": item.Id, "name": name}
+
+		owner, found := owners[item.Id]
+		switch {
+		case !found:
+			results[i]["status"] = "not_found"
+		case owner != userId:
+			slog.Warn("Wrong Owner Request BulkRename", "user", userId, "certificate-owner", owner, "cert_id", item.Id)
+			results[i]["status"] = "not_owned"
+		case names[item.Id] != "":
+			results[i]["status"] = "duplicate"
+		case name == "":
+			results[i]["status"] = "invalid_name"
+		default:
+			results[i]["status"] = "renamed"
+			names[item.Id] = name
+		}
+	}
+
+	if len(names) > 0 {
+		if _, err := ctrl.certRepo.BulkRename(names); err != nil {
+			slog.Error("Certificate BulkRename failed", "error", err, "count", len(names))
+			return response.SendInternalError(c, err)
+		}
+	}
+
+	slog.Info("Certificate BulkRename completed", "user", userId, "requested", len(body.Items), "renamed", len(names))
+
+	return response.SendSuccess(c, "Certificates renamed", fiber.Map{
+		"renamed_count": len(names),
+		"failed_count":  len(body.Items) - len(names),
+		"results":       results,
+	})
+}
@@ -0,0 +1,87 @@
+package certificate_controller
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// BulkRevoke revokes a subset of the certificate's participants and reports the outcome per participant ID
+func (ctrl *CertificateController) BulkRevoke(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	body := new(payload.BulkRevokeParticipantsPayload)
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate BulkRevoke GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate BulkRevoke UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request BulkRevoke", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	result, err := ctrl.participantRepo.BulkRevoke(certId, body.ParticipantIds)
+	if err != nil {
+		slog.Error("Certificate BulkRevoke failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	results := make([]map[string]string, 0, len(body.ParticipantIds))
+	for _, id := range result.Revoked {
+		results = append(results, map[string]string{"participant_id": id, "status": "revoked"})
+	}
+	for _, id := range result.AlreadyRevoked {
+		results = append(results, map[string]string{"participant_id": id, "status": "already_revoked"})
+	}
+	for _, id := range result.NotFound {
+		results = append(results, map[string]string{"participant_id": id, "status": "not_found"})
+	}
+
+	if len(result.Revoked) > 0 {
+		ctrl.recordActivity(c, certId, certificatemodel.ActivityRevoked, fmt.Sprintf("%d participants", len(result.Revoked)))
+	}
+
+	slog.Info("Certificate BulkRevoke completed",
+		"cert_id", certId,
+		"revoked_count", len(result.Revoked),
+		"already_revoked_count", len(result.AlreadyRevoked),
+		"not_found_count", len(result.NotFound))
+
+	return response.SendSuccess(c, "Participants revoked", map[string]any{
+		"revoked_count":         len(result.Revoked),
+		"already_revoked_count": len(result.AlreadyRevoked),
+		"not_found_count":       len(result.NotFound),
+		"results":               results,
+	})
+}
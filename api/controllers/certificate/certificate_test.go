@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,7 +15,10 @@ import (
 	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
 	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
 	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
 	"github.com/sunthewhat/easy-cert-api/type/shared/model"
 )
 
@@ -241,6 +245,24 @@ func TestCertificateController_Create(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "failed - certificate quota exceeded",
+			requestBody: payload.CreateCertificatePayload{
+				Name:   "New Certificate",
+				Design: "design.html",
+			},
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "user123@example.com")
+			},
+			setupMock: func() *certificatemodel.MockCertificateRepository {
+				mock := certificatemodel.NewMockCertificateRepository()
+				mock.CreateFunc = func(certData payload.CreateCertificatePayload, userId string) (*model.Certificate, error) {
+					return nil, certificatemodel.ErrCertificateQuotaExceeded
+				}
+				return mock
+			},
+			wantStatusCode: fiber.StatusForbidden,
+		},
 		{
 			name:        "failed - invalid request body",
 			requestBody: "invalid json",
@@ -282,6 +304,21 @@ func TestCertificateController_Create(t *testing.T) {
 				if response["success"] != false {
 					t.Errorf("Expected success=false, got %v", response["success"])
 				}
+				if response["msg"] != "Name is required" {
+					t.Errorf("Expected msg='Name is required', got %v", response["msg"])
+				}
+				data, ok := response["data"].(map[string]any)
+				if !ok {
+					t.Fatal("Expected data to be a map")
+				}
+				fieldErrors, ok := data["errors"].([]any)
+				if !ok || len(fieldErrors) != 1 {
+					t.Fatalf("Expected one field error, got %v", data["errors"])
+				}
+				fieldError := fieldErrors[0].(map[string]any)
+				if fieldError["field"] != "name" || fieldError["tag"] != "required" {
+					t.Errorf("Expected required error on field 'name', got %v", fieldError)
+				}
 			},
 		},
 		{
@@ -1189,7 +1226,7 @@ func TestCertificateController_Update(t *testing.T) {
 			},
 			setupMock: func() (*certificatemodel.MockCertificateRepository, *signaturemodel.MockSignatureRepository, *participantmodel.MockParticipantRepository) {
 				mockCert := certificatemodel.NewMockCertificateRepository()
-				mockCert.UpdateFunc = func(id string, name string, design string) (*model.Certificate, error) {
+				mockCert.UpdateFunc = func(id string, name string, design string, autosave bool) (*model.Certificate, error) {
 					return &model.Certificate{
 						ID:     id,
 						Name:   name,
@@ -1254,7 +1291,7 @@ func TestCertificateController_Update(t *testing.T) {
 			setupContext: func(c *fiber.Ctx) {},
 			setupMock: func() (*certificatemodel.MockCertificateRepository, *signaturemodel.MockSignatureRepository, *participantmodel.MockParticipantRepository) {
 				mockCert := certificatemodel.NewMockCertificateRepository()
-				mockCert.UpdateFunc = func(id string, name string, design string) (*model.Certificate, error) {
+				mockCert.UpdateFunc = func(id string, name string, design string, autosave bool) (*model.Certificate, error) {
 					return nil, errors.New("certificate not found")
 				}
 
@@ -1568,3 +1605,2878 @@ func TestCertificateController_DownloadArchive(t *testing.T) {
 		})
 	}
 }
+
+func TestCertificateController_ResetStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		certId         string
+		confirm        bool
+		setupContext   func(c *fiber.Ctx)
+		setupMock      func() (*certificatemodel.MockCertificateRepository, *participantmodel.MockParticipantRepository)
+		wantStatusCode int
+		checkResponse  func(t *testing.T, body []byte)
+	}{
+		{
+			name:    "successful reset",
+			certId:  "cert123",
+			confirm: true,
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "owner@example.com")
+			},
+			setupMock: func() (*certificatemodel.MockCertificateRepository, *participantmodel.MockParticipantRepository) {
+				mockCert := certificatemodel.NewMockCertificateRepository()
+				mockCert.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+					return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+				}
+				mockParticipant := participantmodel.NewMockParticipantRepository()
+				mockParticipant.GetParticipantsByCertIdFunc = func(certId string) ([]*participantmodel.CombinedParticipant, error) {
+					return []*participantmodel.CombinedParticipant{{ID: "p1"}, {ID: "p2"}}, nil
+				}
+				mockParticipant.ResetParticipantStatusesFunc = func(participantIds []string) error {
+					if len(participantIds) != 2 {
+						t.Errorf("Expected 2 participant IDs, got %d", len(participantIds))
+					}
+					return nil
+				}
+				return mockCert, mockParticipant
+			},
+			wantStatusCode: fiber.StatusOK,
+			checkResponse: func(t *testing.T, body []byte) {
+				var response map[string]any
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				data, ok := response["data"].(map[string]any)
+				if !ok {
+					t.Fatal("Expected data to be an object")
+				}
+				if data["reset_count"] != float64(2) {
+					t.Errorf("Expected reset_count=2, got %v", data["reset_count"])
+				}
+			},
+		},
+		{
+			name:    "failed - missing confirm flag",
+			certId:  "cert123",
+			confirm: false,
+			setupMock: func() (*certificatemodel.MockCertificateRepository, *participantmodel.MockParticipantRepository) {
+				return certificatemodel.NewMockCertificateRepository(), participantmodel.NewMockParticipantRepository()
+			},
+			wantStatusCode: fiber.StatusBadRequest,
+			checkResponse: func(t *testing.T, body []byte) {
+				var response map[string]any
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if response["msg"] != "Resetting statuses requires confirm=true" {
+					t.Errorf("Expected confirm error, got %v", response["msg"])
+				}
+			},
+		},
+		{
+			name:    "failed - not the owner",
+			certId:  "cert123",
+			confirm: true,
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "other@example.com")
+			},
+			setupMock: func() (*certificatemodel.MockCertificateRepository, *participantmodel.MockParticipantRepository) {
+				mockCert := certificatemodel.NewMockCertificateRepository()
+				mockCert.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+					return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+				}
+				return mockCert, participantmodel.NewMockParticipantRepository()
+			},
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:    "failed - certificate not found",
+			certId:  "nonexistent",
+			confirm: true,
+			setupMock: func() (*certificatemodel.MockCertificateRepository, *participantmodel.MockParticipantRepository) {
+				return certificatemodel.NewMockCertificateRepository(), participantmodel.NewMockParticipantRepository()
+			},
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			mockCertRepo, mockParticipantRepo := tt.setupMock()
+			mockSignatureRepo := signaturemodel.NewMockSignatureRepository()
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, mockSignatureRepo, mockParticipantRepo)
+
+			app.Post("/certificate/:certId/reset-status", func(c *fiber.Ctx) error {
+				if tt.setupContext != nil {
+					tt.setupContext(c)
+				}
+				return ctrl.ResetStatus(c)
+			})
+
+			url := "/certificate/" + tt.certId + "/reset-status"
+			if tt.confirm {
+				url += "?confirm=true"
+			}
+
+			req := httptest.NewRequest("POST", url, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, body)
+			}
+		})
+	}
+}
+
+func TestCertificateController_BatchGet(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    any
+		setupContext   func(c *fiber.Ctx)
+		setupMock      func() *certificatemodel.MockCertificateRepository
+		wantStatusCode int
+		checkResponse  func(t *testing.T, body []byte)
+	}{
+		{
+			name:        "successful batch get - flags certificates not owned",
+			requestBody: payload.BatchGetCertificatePayload{Ids: []string{"cert1", "cert2", "cert3"}},
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "owner@example.com")
+			},
+			setupMock: func() *certificatemodel.MockCertificateRepository {
+				mock := certificatemodel.NewMockCertificateRepository()
+				mock.GetByIdsFunc = func(certIds []string) ([]*model.Certificate, error) {
+					return []*model.Certificate{
+						{ID: "cert1", UserID: "owner@example.com", Name: "One"},
+						{ID: "cert2", UserID: "other@example.com", Name: "Two"},
+					}, nil
+				}
+				return mock
+			},
+			wantStatusCode: fiber.StatusOK,
+			checkResponse: func(t *testing.T, body []byte) {
+				var response map[string]any
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				data, ok := response["data"].(map[string]any)
+				if !ok {
+					t.Fatal("Expected data to be an object")
+				}
+				certs, _ := data["certificates"].([]any)
+				if len(certs) != 1 {
+					t.Errorf("Expected 1 certificate, got %d", len(certs))
+				}
+				unavailable, _ := data["unavailable_ids"].([]any)
+				if len(unavailable) != 2 {
+					t.Errorf("Expected 2 unavailable ids, got %d", len(unavailable))
+				}
+			},
+		},
+		{
+			name:        "failed - empty id list",
+			requestBody: payload.BatchGetCertificatePayload{Ids: []string{}},
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "owner@example.com")
+			},
+			setupMock: func() *certificatemodel.MockCertificateRepository {
+				return certificatemodel.NewMockCertificateRepository()
+			},
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:        "failed - database error",
+			requestBody: payload.BatchGetCertificatePayload{Ids: []string{"cert1"}},
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "owner@example.com")
+			},
+			setupMock: func() *certificatemodel.MockCertificateRepository {
+				mock := certificatemodel.NewMockCertificateRepository()
+				mock.GetByIdsFunc = func(certIds []string) ([]*model.Certificate, error) {
+					return nil, errors.New("database error")
+				}
+				return mock
+			},
+			wantStatusCode: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			mockCertRepo := tt.setupMock()
+			mockSignatureRepo := signaturemodel.NewMockSignatureRepository()
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, mockSignatureRepo, mockParticipantRepo)
+
+			app.Post("/certificate/batch-get", func(c *fiber.Ctx) error {
+				if tt.setupContext != nil {
+					tt.setupContext(c)
+				}
+				return ctrl.BatchGet(c)
+			})
+
+			bodyBytes, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest("POST", "/certificate/batch-get", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, body)
+			}
+		})
+	}
+}
+
+func TestCertificateController_GetSignatureInfo(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	common.Config = &shared.Config{}
+
+	tests := []struct {
+		name           string
+		certId         string
+		setupMock      func() *certificatemodel.MockCertificateRepository
+		wantStatusCode int
+		wantMsg        string
+	}{
+		{
+			name:   "failed - signing disabled",
+			certId: "cert123",
+			setupMock: func() *certificatemodel.MockCertificateRepository {
+				mock := certificatemodel.NewMockCertificateRepository()
+				mock.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+					return &model.Certificate{ID: certId}, nil
+				}
+				return mock
+			},
+			wantStatusCode: fiber.StatusBadRequest,
+			wantMsg:        "PDF signing is not enabled",
+		},
+		{
+			name:   "failed - certificate not found",
+			certId: "nonexistent",
+			setupMock: func() *certificatemodel.MockCertificateRepository {
+				return certificatemodel.NewMockCertificateRepository()
+			},
+			wantStatusCode: fiber.StatusBadRequest,
+			wantMsg:        "Certificate not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			mockCertRepo := tt.setupMock()
+			mockSignatureRepo := signaturemodel.NewMockSignatureRepository()
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, mockSignatureRepo, mockParticipantRepo)
+
+			app.Get("/certificate/:certId/signature-info", ctrl.GetSignatureInfo)
+
+			req := httptest.NewRequest("GET", "/certificate/"+tt.certId+"/signature-info", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			var response map[string]any
+			if err := json.Unmarshal(body, &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response["msg"] != tt.wantMsg {
+				t.Errorf("Expected msg='%s', got %v", tt.wantMsg, response["msg"])
+			}
+		})
+	}
+}
+
+func TestCertificateController_ExportDefinition(t *testing.T) {
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+		return &model.Certificate{
+			ID:     certId,
+			UserID: "owner@example.com",
+			Name:   "Exported",
+			Design: `{"objects":[{"id":"PLACEHOLDER-name"},{"id":"SIGNATURE-signer1"}]}`,
+		}, nil
+	}
+
+	ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+	tests := []struct {
+		name           string
+		userId         string
+		wantStatusCode int
+	}{
+		{name: "successful export", userId: "owner@example.com", wantStatusCode: fiber.StatusOK},
+		{name: "failed - not the owner", userId: "other@example.com", wantStatusCode: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/certificate/:certId/export-definition", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.ExportDefinition(c)
+			})
+
+			req := httptest.NewRequest("GET", "/certificate/cert123/export-definition", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			if tt.wantStatusCode != fiber.StatusOK {
+				return
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			var response struct {
+				Data payload.CertificateDefinition `json:"data"`
+			}
+			if err := json.Unmarshal(body, &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Data.Name != "Exported" {
+				t.Errorf("Expected name 'Exported', got %s", response.Data.Name)
+			}
+			if len(response.Data.Anchors) != 1 || response.Data.Anchors[0] != "name" {
+				t.Errorf("Expected anchors [name], got %v", response.Data.Anchors)
+			}
+			if len(response.Data.SignerIds) != 1 {
+				t.Errorf("Expected 1 signer id, got %v", response.Data.SignerIds)
+			}
+		})
+	}
+}
+
+func TestCertificateController_ImportDefinition_InvalidDesign(t *testing.T) {
+	app := fiber.New()
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.CreateFunc = func(certData payload.CreateCertificatePayload, userId string) (*model.Certificate, error) {
+		t.Error("Create should not be called for an invalid design")
+		return nil, nil
+	}
+
+	ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+	app.Post("/certificate/import-definition", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.ImportDefinition(c)
+	})
+
+	bodyBytes, _ := json.Marshal(payload.CertificateDefinition{Version: 1, Name: "Imported", Design: `{"no_objects":true}`})
+	req := httptest.NewRequest("POST", "/certificate/import-definition", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestCertificateController_RemindDownloads(t *testing.T) {
+	tests := []struct {
+		name           string
+		certId         string
+		setupContext   func(c *fiber.Ctx)
+		setupMock      func() (*certificatemodel.MockCertificateRepository, *participantmodel.MockParticipantRepository)
+		wantStatusCode int
+		checkResponse  func(t *testing.T, body []byte)
+	}{
+		{
+			name:   "participant without email is reported as failed",
+			certId: "cert123",
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "owner@example.com")
+			},
+			setupMock: func() (*certificatemodel.MockCertificateRepository, *participantmodel.MockParticipantRepository) {
+				mockCert := certificatemodel.NewMockCertificateRepository()
+				mockCert.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+					return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+				}
+				mockParticipant := participantmodel.NewMockParticipantRepository()
+				mockParticipant.GetNotDownloadedByCertIdFunc = func(certId string) ([]*participantmodel.CombinedParticipant, error) {
+					return []*participantmodel.CombinedParticipant{
+						{ID: "p1", DynamicData: map[string]any{"name": "Alice"}},
+					}, nil
+				}
+				return mockCert, mockParticipant
+			},
+			wantStatusCode: fiber.StatusOK,
+			checkResponse: func(t *testing.T, body []byte) {
+				var response map[string]any
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				data, ok := response["data"].(map[string]any)
+				if !ok {
+					t.Fatal("Expected data to be an object")
+				}
+				if data["total_participants"] != float64(1) {
+					t.Errorf("Expected total_participants=1, got %v", data["total_participants"])
+				}
+				if data["failed_count"] != float64(1) {
+					t.Errorf("Expected failed_count=1, got %v", data["failed_count"])
+				}
+			},
+		},
+		{
+			name:   "failed - not the owner",
+			certId: "cert123",
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "other@example.com")
+			},
+			setupMock: func() (*certificatemodel.MockCertificateRepository, *participantmodel.MockParticipantRepository) {
+				mockCert := certificatemodel.NewMockCertificateRepository()
+				mockCert.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+					return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+				}
+				return mockCert, participantmodel.NewMockParticipantRepository()
+			},
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:   "failed - certificate not found",
+			certId: "nonexistent",
+			setupMock: func() (*certificatemodel.MockCertificateRepository, *participantmodel.MockParticipantRepository) {
+				return certificatemodel.NewMockCertificateRepository(), participantmodel.NewMockParticipantRepository()
+			},
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			mockCertRepo, mockParticipantRepo := tt.setupMock()
+			mockSignatureRepo := signaturemodel.NewMockSignatureRepository()
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, mockSignatureRepo, mockParticipantRepo)
+
+			app.Post("/certificate/:certId/remind-downloads", func(c *fiber.Ctx) error {
+				if tt.setupContext != nil {
+					tt.setupContext(c)
+				}
+				return ctrl.RemindDownloads(c)
+			})
+
+			req := httptest.NewRequest("POST", "/certificate/"+tt.certId+"/remind-downloads", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, body)
+			}
+		})
+	}
+}
+
+func TestCertificateController_SetVerifyHost(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	defaultHost := "https://verify.example.com"
+	common.Config = &shared.Config{VerifyHost: &defaultHost}
+
+	tests := []struct {
+		name           string
+		body           string
+		setupContext   func(c *fiber.Ctx)
+		wantStatusCode int
+		wantStore      bool
+		wantStored     string
+		wantEffective  string
+	}{
+		{
+			name: "success - sets per-certificate host",
+			body: `{"verify_host": "https://verify.org.example.com/"}`,
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "owner@example.com")
+			},
+			wantStatusCode: fiber.StatusOK,
+			wantStore:      true,
+			wantStored:     "https://verify.org.example.com",
+			wantEffective:  "https://verify.org.example.com",
+		},
+		{
+			name: "success - empty host falls back to global default",
+			body: `{"verify_host": ""}`,
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "owner@example.com")
+			},
+			wantStatusCode: fiber.StatusOK,
+			wantStore:      true,
+			wantStored:     "",
+			wantEffective:  defaultHost,
+		},
+		{
+			name: "failed - invalid url",
+			body: `{"verify_host": "not a url"}`,
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "owner@example.com")
+			},
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name: "failed - not the owner",
+			body: `{"verify_host": "https://verify.org.example.com"}`,
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "other@example.com")
+			},
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var stored *string
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+			mockCertRepo.SetVerifyHostFunc = func(certificateId string, verifyHost string) error {
+				stored = &verifyHost
+				return nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+			app.Put("/certificate/:certId/verify-host", func(c *fiber.Ctx) error {
+				if tt.setupContext != nil {
+					tt.setupContext(c)
+				}
+				return ctrl.SetVerifyHost(c)
+			})
+
+			req := httptest.NewRequest("PUT", "/certificate/cert123/verify-host", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			if !tt.wantStore {
+				if stored != nil {
+					t.Errorf("Expected verify host not to be stored, got %q", *stored)
+				}
+				return
+			}
+
+			if stored == nil || *stored != tt.wantStored {
+				t.Errorf("Expected stored verify host %q, got %v", tt.wantStored, stored)
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			var response map[string]any
+			if err := json.Unmarshal(body, &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			data, _ := response["data"].(map[string]any)
+			if data["effective_verify_host"] != tt.wantEffective {
+				t.Errorf("Expected effective_verify_host %q, got %v", tt.wantEffective, data["effective_verify_host"])
+			}
+		})
+	}
+}
+
+func TestCertificateController_RegenerateQRCodes(t *testing.T) {
+	tests := []struct {
+		name           string
+		userId         string
+		participants   []*participantmodel.CombinedParticipant
+		wantStatusCode int
+	}{
+		{
+			name:           "failed - not the owner",
+			userId:         "other@example.com",
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:   "failed - nothing generated yet",
+			userId: "owner@example.com",
+			participants: []*participantmodel.CombinedParticipant{
+				{ID: "p1"},
+			},
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.GetParticipantsByCertIdFunc = func(certId string) ([]*participantmodel.CombinedParticipant, error) {
+				return tt.participants, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+
+			app.Post("/certificate/:certId/regenerate-qr", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.RegenerateQRCodes(c)
+			})
+
+			req := httptest.NewRequest("POST", "/certificate/cert123/regenerate-qr", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCertificateController_GetAnchorDetails(t *testing.T) {
+	design := `{
+		"anchorOrder": ["name", "course"],
+		"objects": [
+			{"id": "PLACEHOLDER-name", "type": "Textbox", "text": "John Doe"},
+			{"id": "PLACEHOLDER-course", "type": "Group", "optional": true, "objects": [
+				{"type": "Rect"},
+				{"type": "Textbox", "text": "Course Name"}
+			]},
+			{"id": "SIGNATURE-signer1", "type": "image"}
+		]
+	}`
+
+	app := fiber.New()
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+		return &model.Certificate{ID: certId, UserID: "user123", Design: design}, nil
+	}
+	ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+	app.Get("/certificate/anchor/:certId/details", ctrl.GetAnchorDetails)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/certificate/anchor/cert123/details", nil))
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var response struct {
+		Data []certificate_controller.AnchorDetail `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	want := []certificate_controller.AnchorDetail{
+		{Name: "name", Type: "textbox", Sample: "John Doe"},
+		{Name: "course", Type: "group", Sample: "Course Name", Optional: true},
+	}
+	if len(response.Data) != len(want) {
+		t.Fatalf("Expected %d anchors, got %d", len(want), len(response.Data))
+	}
+	for i := range want {
+		if response.Data[i] != want[i] {
+			t.Errorf("Anchor %d: expected %+v, got %+v", i, want[i], response.Data[i])
+		}
+	}
+}
+
+func TestCertificateController_BulkRevoke(t *testing.T) {
+	tests := []struct {
+		name           string
+		userId         string
+		body           string
+		wantStatusCode int
+		wantRevoked    float64
+	}{
+		{
+			name:           "success - per-ID results",
+			userId:         "owner@example.com",
+			body:           `{"participantIds": ["p1", "p2", "p3"]}`,
+			wantStatusCode: fiber.StatusOK,
+			wantRevoked:    1,
+		},
+		{
+			name:           "failed - empty participant list",
+			userId:         "owner@example.com",
+			body:           `{"participantIds": []}`,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:           "failed - not the owner",
+			userId:         "other@example.com",
+			body:           `{"participantIds": ["p1"]}`,
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.BulkRevokeFunc = func(certId string, participantIds []string) (*participantmodel.BulkRevokeResult, error) {
+				return &participantmodel.BulkRevokeResult{
+					Revoked:        []string{"p1"},
+					AlreadyRevoked: []string{"p2"},
+					NotFound:       []string{"p3"},
+				}, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+
+			app.Post("/certificate/:certId/revoke", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.BulkRevoke(c)
+			})
+
+			req := httptest.NewRequest("POST", "/certificate/cert123/revoke", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			if tt.wantStatusCode != fiber.StatusOK {
+				return
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			var response map[string]any
+			if err := json.Unmarshal(body, &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			data := response["data"].(map[string]any)
+			if data["revoked_count"] != tt.wantRevoked {
+				t.Errorf("Expected revoked_count=%v, got %v", tt.wantRevoked, data["revoked_count"])
+			}
+			if results, _ := data["results"].([]any); len(results) != 3 {
+				t.Errorf("Expected 3 per-ID results, got %d", len(results))
+			}
+		})
+	}
+}
+
+func TestCertificateController_GetByUser_StatusFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		wantStatusCode int
+		wantStatus     string
+		wantAll        bool
+	}{
+		{
+			name:           "no filter lists everything",
+			query:          "",
+			wantStatusCode: fiber.StatusOK,
+			wantAll:        true,
+		},
+		{
+			name:           "awaiting signatures",
+			query:          "?status=awaiting_signatures",
+			wantStatusCode: fiber.StatusOK,
+			wantStatus:     "awaiting_signatures",
+		},
+		{
+			name:           "ready to distribute",
+			query:          "?status=ready_to_distribute",
+			wantStatusCode: fiber.StatusOK,
+			wantStatus:     "ready_to_distribute",
+		},
+		{
+			name:           "invalid filter",
+			query:          "?status=archived",
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var gotStatus string
+			calledAll := false
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByUserFunc = func(userId string) ([]*model.Certificate, error) {
+				calledAll = true
+				return []*model.Certificate{}, nil
+			}
+			mockCertRepo.GetByUserAndStatusFunc = func(userId string, status string) ([]*model.Certificate, error) {
+				gotStatus = status
+				return []*model.Certificate{}, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+			app.Get("/certificate", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user123@example.com")
+				return ctrl.GetByUser(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate"+tt.query, nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if calledAll != tt.wantAll {
+				t.Errorf("Expected unfiltered query=%v, got %v", tt.wantAll, calledAll)
+			}
+			if gotStatus != tt.wantStatus {
+				t.Errorf("Expected status filter %q, got %q", tt.wantStatus, gotStatus)
+			}
+		})
+	}
+}
+
+func TestCertificateController_SetArchiveFilenameTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantStatusCode int
+		wantStored     bool
+	}{
+		{
+			name:           "success - template stored",
+			body:           `{"template": "{{name}}-certificate.pdf"}`,
+			wantStatusCode: fiber.StatusOK,
+			wantStored:     true,
+		},
+		{
+			name:           "success - empty restores default",
+			body:           `{"template": ""}`,
+			wantStatusCode: fiber.StatusOK,
+			wantStored:     true,
+		},
+		{
+			name:           "failed - unbalanced placeholder",
+			body:           `{"template": "{{name}.pdf"}`,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			stored := false
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+			mockCertRepo.SetArchiveFilenameTemplateFunc = func(certificateId string, template string) error {
+				stored = true
+				return nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+			app.Put("/certificate/:certId/archive-filename", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.SetArchiveFilenameTemplate(c)
+			})
+
+			req := httptest.NewRequest("PUT", "/certificate/cert123/archive-filename", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if stored != tt.wantStored {
+				t.Errorf("Expected stored=%v, got %v", tt.wantStored, stored)
+			}
+		})
+	}
+}
+
+func TestCertificateController_SetPdfLayout(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantStatusCode int
+		wantMargin     *float64
+		wantFitMode    string
+	}{
+		{
+			name:           "success - margin and contain",
+			body:           `{"margin_mm": 12.5, "fit_mode": "contain"}`,
+			wantStatusCode: fiber.StatusOK,
+			wantMargin:     func() *float64 { m := 12.5; return &m }(),
+			wantFitMode:    "contain",
+		},
+		{
+			name:           "success - reset to defaults",
+			body:           `{}`,
+			wantStatusCode: fiber.StatusOK,
+		},
+		{
+			name:           "failed - unknown fit mode",
+			body:           `{"fit_mode": "stretch"}`,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:           "failed - margin too large",
+			body:           `{"margin_mm": 80}`,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var gotMargin *float64
+			var gotFitMode string
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+			mockCertRepo.SetPdfLayoutFunc = func(certificateId string, marginMm *float64, fitMode string) error {
+				gotMargin = marginMm
+				gotFitMode = fitMode
+				return nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+			app.Put("/certificate/:certId/pdf-layout", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.SetPdfLayout(c)
+			})
+
+			req := httptest.NewRequest("PUT", "/certificate/cert123/pdf-layout", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if (gotMargin == nil) != (tt.wantMargin == nil) || (gotMargin != nil && *gotMargin != *tt.wantMargin) {
+				t.Errorf("Expected margin %v, got %v", tt.wantMargin, gotMargin)
+			}
+			if gotFitMode != tt.wantFitMode {
+				t.Errorf("Expected fit mode %q, got %q", tt.wantFitMode, gotFitMode)
+			}
+		})
+	}
+}
+
+func TestCertificateController_BulkRename(t *testing.T) {
+	ownedId := "11111111-1111-1111-1111-111111111111"
+	otherId := "22222222-2222-2222-2222-222222222222"
+	missingId := "33333333-3333-3333-3333-333333333333"
+
+	app := fiber.New()
+
+	var gotNames map[string]string
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.GetByIdsFunc = func(certIds []string) ([]*model.Certificate, error) {
+		if len(certIds) != 4 {
+			t.Errorf("Expected every item to be looked up, got %v", certIds)
+		}
+		return []*model.Certificate{
+			{ID: ownedId, UserID: "owner@example.com"},
+			{ID: otherId, UserID: "someone-else@example.com"},
+		}, nil
+	}
+	mockCertRepo.BulkRenameFunc = func(names map[string]string) ([]*model.Certificate, error) {
+		gotNames = names
+		return []*model.Certificate{}, nil
+	}
+
+	ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+	app.Patch("/certificate/bulk-rename", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.BulkRename(c)
+	})
+
+	body := `{"items": [
+		{"id": "` + ownedId + `", "name": " 2026 Workshop "},
+		{"id": "` + otherId + `", "name": "2026 Other"},
+		{"id": "` + missingId + `", "name": "2026 Missing"},
+		{"id": "` + ownedId + `", "name": "2026 Again"}
+	]}`
+	req := httptest.NewRequest("PATCH", "/certificate/bulk-rename", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	if len(gotNames) != 1 || gotNames[ownedId] != "2026 Workshop" {
+		t.Errorf("Expected only the owned certificate to be renamed, got %v", gotNames)
+	}
+
+	var response map[string]any
+	respBody, _ := io.ReadAll(resp.Body)
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	data := response["data"].(map[string]any)
+	if data["renamed_count"] != float64(1) || data["failed_count"] != float64(3) {
+		t.Errorf("Unexpected counts: %v", data)
+	}
+
+	wantStatuses := []string{"renamed", "not_owned", "not_found", "duplicate"}
+	results := data["results"].([]any)
+	for i, want := range wantStatuses {
+		if got := results[i].(map[string]any)["status"]; got != want {
+			t.Errorf("Item %d: expected status %q, got %v", i, want, got)
+		}
+	}
+}
+
+func TestCertificateController_BulkRename_EmptyItems(t *testing.T) {
+	app := fiber.New()
+	ctrl := certificate_controller.NewCertificateController(certificatemodel.NewMockCertificateRepository(), signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+	app.Patch("/certificate/bulk-rename", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.BulkRename(c)
+	})
+
+	req := httptest.NewRequest("PATCH", "/certificate/bulk-rename", bytes.NewBufferString(`{"items": []}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestCertificateController_BulkRename_InvalidId(t *testing.T) {
+	app := fiber.New()
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.GetByIdsFunc = func(certIds []string) ([]*model.Certificate, error) {
+		t.Errorf("Expected no lookup for an invalid id, got %v", certIds)
+		return nil, nil
+	}
+	ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+	app.Patch("/certificate/bulk-rename", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.BulkRename(c)
+	})
+
+	req := httptest.NewRequest("PATCH", "/certificate/bulk-rename", bytes.NewBufferString(`{"items": [{"id": "unknown-id", "name": "2026 Unknown"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestCertificateController_GetThumbnail(t *testing.T) {
+	tests := []struct {
+		name           string
+		cert           *model.Certificate
+		wantStatusCode int
+		wantURL        string
+	}{
+		{
+			name:           "success - stored thumbnail",
+			cert:           &model.Certificate{ID: "cert123", UserID: "owner@example.com", Design: "{}", ThumbnailURL: "https://minio.example.com/thumb.png"},
+			wantStatusCode: fiber.StatusOK,
+			wantURL:        "https://minio.example.com/thumb.png",
+		},
+		{
+			name:           "failed - no design to render",
+			cert:           &model.Certificate{ID: "cert123", UserID: "owner@example.com"},
+			wantStatusCode: fiber.StatusNotFound,
+		},
+		{
+			name:           "failed - certificate not found",
+			cert:           nil,
+			wantStatusCode: fiber.StatusNotFound,
+		},
+		{
+			name:           "failed - wrong owner",
+			cert:           &model.Certificate{ID: "cert123", UserID: "someone-else@example.com", ThumbnailURL: "https://minio.example.com/thumb.png"},
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return tt.cert, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+			app.Get("/certificate/:certId/thumbnail", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.GetThumbnail(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate/cert123/thumbnail", nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			if tt.wantURL != "" {
+				var response map[string]any
+				body, _ := io.ReadAll(resp.Body)
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				data := response["data"].(map[string]any)
+				if data["thumbnail_url"] != tt.wantURL || data["generated"] != false {
+					t.Errorf("Unexpected thumbnail response: %v", data)
+				}
+			}
+		})
+	}
+}
+
+func TestCertificateController_SetSigningOrder(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantStatusCode int
+		wantOrder      []string
+	}{
+		{
+			name:           "success - enable with order",
+			body:           `{"sequential": true, "signer_ids": ["signer-2", "signer-1"]}`,
+			wantStatusCode: fiber.StatusOK,
+			wantOrder:      []string{"signer-2", "signer-1"},
+		},
+		{
+			name:           "success - disable keeps order",
+			body:           `{"sequential": false}`,
+			wantStatusCode: fiber.StatusOK,
+		},
+		{
+			name:           "failed - missing sequential",
+			body:           `{"signer_ids": ["signer-1"]}`,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:           "failed - unknown signer",
+			body:           `{"sequential": true, "signer_ids": ["signer-9"]}`,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var gotOrder []string
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+
+			mockSignatureRepo := signaturemodel.NewMockSignatureRepository()
+			mockSignatureRepo.GetSignaturesByCertificateFunc = func(certId string) ([]*model.Signature, error) {
+				return []*model.Signature{
+					{ID: "sig-1", SignerID: "signer-1", CertificateID: certId, SignOrder: 1},
+					{ID: "sig-2", SignerID: "signer-2", CertificateID: certId, SignOrder: 2},
+				}, nil
+			}
+			mockSignatureRepo.SetSignOrderFunc = func(certificateId string, signerIds []string) error {
+				gotOrder = signerIds
+				return nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, mockSignatureRepo, participantmodel.NewMockParticipantRepository())
+
+			app.Put("/certificate/:certId/signing-order", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.SetSigningOrder(c)
+			})
+
+			req := httptest.NewRequest("PUT", "/certificate/cert123/signing-order", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if len(gotOrder) != len(tt.wantOrder) {
+				t.Fatalf("Expected order %v, got %v", tt.wantOrder, gotOrder)
+			}
+			for i := range gotOrder {
+				if gotOrder[i] != tt.wantOrder[i] {
+					t.Errorf("Expected order %v, got %v", tt.wantOrder, gotOrder)
+				}
+			}
+		})
+	}
+}
+
+func TestCertificateController_Update_AnchorNameValidation(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	enabled := true
+	hyphenPattern := `^[a-z-]+$`
+	design := `{"objects":[{"id":"PLACEHOLDER-first_name"},{"id":"PLACEHOLDER-last-name"}]}`
+
+	tests := []struct {
+		name           string
+		config         *shared.Config
+		design         string
+		wantStatusCode int
+	}{
+		{
+			name:           "validation disabled",
+			config:         &shared.Config{},
+			design:         design,
+			wantStatusCode: fiber.StatusOK,
+		},
+		{
+			name:           "default pattern rejects hyphen",
+			config:         &shared.Config{AnchorNameValidation: &enabled},
+			design:         design,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:           "default pattern accepts alphanumerics and underscores",
+			config:         &shared.Config{AnchorNameValidation: &enabled},
+			design:         `{"objects":[{"id":"PLACEHOLDER-first_name"},{"id":"PLACEHOLDER-score2"}]}`,
+			wantStatusCode: fiber.StatusOK,
+		},
+		{
+			name:           "custom pattern rejects underscore",
+			config:         &shared.Config{AnchorNameValidation: &enabled, AnchorNamePattern: &hyphenPattern},
+			design:         design,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common.Config = tt.config
+			app := fiber.New()
+
+			updated := false
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.UpdateFunc = func(id string, name string, design string, autosave bool) (*model.Certificate, error) {
+				updated = true
+				return &model.Certificate{ID: id, Design: design, UserID: "owner@example.com"}, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+			app.Put("/certificate/:id", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.Update(c)
+			})
+
+			bodyBytes, _ := json.Marshal(map[string]string{"design": tt.design})
+			req := httptest.NewRequest("PUT", "/certificate/cert123?autosave=true", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if updated != (tt.wantStatusCode == fiber.StatusOK) {
+				t.Errorf("Expected update called=%v, got %v", tt.wantStatusCode == fiber.StatusOK, updated)
+			}
+		})
+	}
+}
+
+func TestCertificateController_MergeFrom(t *testing.T) {
+	sharedDesign := `{"objects":[{"id":"PLACEHOLDER-name"},{"id":"PLACEHOLDER-email"}]}`
+
+	tests := []struct {
+		name              string
+		url               string
+		sourceDesign      string
+		sourceOwner       string
+		failedIds         []string
+		wantStatusCode    int
+		wantAdded         int
+		wantSourceDeleted bool
+	}{
+		{
+			name:           "success - merge without deleting source",
+			url:            "/certificate/target/merge-from/source",
+			sourceDesign:   sharedDesign,
+			sourceOwner:    "owner@example.com",
+			wantStatusCode: fiber.StatusOK,
+			wantAdded:      2,
+		},
+		{
+			name:              "success - merge and delete source",
+			url:               "/certificate/target/merge-from/source?delete_source=true",
+			sourceDesign:      sharedDesign,
+			sourceOwner:       "owner@example.com",
+			wantStatusCode:    fiber.StatusOK,
+			wantAdded:         2,
+			wantSourceDeleted: true,
+		},
+		{
+			name:           "partial failure keeps source",
+			url:            "/certificate/target/merge-from/source?delete_source=true",
+			sourceDesign:   sharedDesign,
+			sourceOwner:    "owner@example.com",
+			failedIds:      []string{"new-2"},
+			wantStatusCode: fiber.StatusOK,
+			wantAdded:      2,
+		},
+		{
+			name:           "failed - anchors differ",
+			url:            "/certificate/target/merge-from/source",
+			sourceDesign:   `{"objects":[{"id":"PLACEHOLDER-name"},{"id":"PLACEHOLDER-score"}]}`,
+			sourceOwner:    "owner@example.com",
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:           "failed - source owned by someone else",
+			url:            "/certificate/target/merge-from/source",
+			sourceDesign:   sharedDesign,
+			sourceOwner:    "other@example.com",
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "failed - merge into itself",
+			url:            "/certificate/target/merge-from/target",
+			sourceDesign:   sharedDesign,
+			sourceOwner:    "owner@example.com",
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				if certId == "source" {
+					return &model.Certificate{ID: certId, UserID: tt.sourceOwner, Design: tt.sourceDesign}, nil
+				}
+				return &model.Certificate{ID: certId, UserID: "owner@example.com", Design: sharedDesign}, nil
+			}
+			sourceDeleted := false
+			mockCertRepo.DeleteFunc = func(id string) (*model.Certificate, error) {
+				sourceDeleted = id == "source"
+				return &model.Certificate{ID: id}, nil
+			}
+
+			var added []map[string]any
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.GetCopyableParticipantsFunc = func(certId string) ([]map[string]any, error) {
+				if certId != "source" {
+					t.Errorf("Expected participants read from source, got %s", certId)
+				}
+				return []map[string]any{
+					{"name": "Alice", "email": "alice@example.com", "tags": []string{"vip"}},
+					{"name": "Bob", "email": "bob@example.com"},
+				}, nil
+			}
+			mockParticipantRepo.AddParticipantsFunc = func(certId string, participants []map[string]any) (*participantmodel.ParticipantCreateResult, error) {
+				if certId != "target" {
+					t.Errorf("Expected participants added to target, got %s", certId)
+				}
+				added = participants
+				failed := tt.failedIds
+				if failed == nil {
+					failed = []string{}
+				}
+				return &participantmodel.ParticipantCreateResult{CreatedIDs: []string{"new-1", "new-2"}, FailedPostgresIDs: failed}, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+
+			app.Post("/certificate/:targetId/merge-from/:sourceId", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.MergeFrom(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("POST", tt.url, nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if len(added) != tt.wantAdded {
+				t.Errorf("Expected %d participants added, got %d", tt.wantAdded, len(added))
+			}
+			if tt.wantAdded > 0 {
+				if added[0]["name"] != "Alice" || added[0]["tags"] == nil {
+					t.Errorf("Expected participant data and tags to be copied, got %v", added[0])
+				}
+				if _, hasTags := added[1]["tags"]; hasTags {
+					t.Errorf("Expected no tags for untagged participant, got %v", added[1])
+				}
+			}
+			if sourceDeleted != tt.wantSourceDeleted {
+				t.Errorf("Expected source deleted=%v, got %v", tt.wantSourceDeleted, sourceDeleted)
+			}
+		})
+	}
+}
+
+func TestCertificateController_BatchStatus(t *testing.T) {
+	app := fiber.New()
+
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.GetByIdsFunc = func(certIds []string) ([]*model.Certificate, error) {
+		return []*model.Certificate{
+			{ID: "unsigned", UserID: "owner@example.com"},
+			{ID: "signed", UserID: "owner@example.com"},
+			{ID: "partial", UserID: "owner@example.com", IsSigned: true, IsDistributed: true},
+			{ID: "foreign", UserID: "other@example.com", IsSigned: true},
+		}, nil
+	}
+
+	var signatureQueryIds, participantQueryIds []string
+	mockSignatureRepo := signaturemodel.NewMockSignatureRepository()
+	mockSignatureRepo.GetSignatureProgressByCertificatesFunc = func(certIds []string) (map[string]signaturemodel.SignatureProgress, error) {
+		signatureQueryIds = certIds
+		return map[string]signaturemodel.SignatureProgress{
+			"unsigned": {Total: 2, Signed: 1},
+			"signed":   {Total: 2, Signed: 2},
+		}, nil
+	}
+	mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+	mockParticipantRepo.CountGenerationByCertificatesFunc = func(certIds []string) (map[string]participantmodel.GenerationCounts, error) {
+		participantQueryIds = certIds
+		return map[string]participantmodel.GenerationCounts{
+			"partial": {Total: 4, Pending: 1, PdfSigned: 2, PdfUnknown: 1},
+		}, nil
+	}
+
+	ctrl := certificate_controller.NewCertificateController(mockCertRepo, mockSignatureRepo, mockParticipantRepo)
+
+	app.Post("/certificate/batch-status", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.BatchStatus(c)
+	})
+
+	bodyBytes, _ := json.Marshal(payload.BatchGetCertificatePayload{Ids: []string{"unsigned", "signed", "partial", "foreign", "missing", "signed"}})
+	req := httptest.NewRequest("POST", "/certificate/batch-status", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	if len(signatureQueryIds) != 3 || len(participantQueryIds) != 3 {
+		t.Errorf("Expected one batched query per repository for the 3 owned certificates, got %v and %v", signatureQueryIds, participantQueryIds)
+	}
+
+	var result struct {
+		Data struct {
+			Statuses []struct {
+				CertificateID      string `json:"certificate_id"`
+				IsSigned           bool   `json:"is_signed"`
+				IsGenerated        bool   `json:"is_generated"`
+				IsPartialGenerated bool   `json:"is_partial_generated"`
+				PdfSignedCount     int    `json:"pdf_signed_count"`
+				PdfUnknownCount    int    `json:"pdf_unknown_count"`
+			} `json:"statuses"`
+			UnavailableIds []string `json:"unavailable_ids"`
+		} `json:"data"`
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	statuses := result.Data.Statuses
+	if len(statuses) != 3 {
+		t.Fatalf("Expected 3 statuses, got %d", len(statuses))
+	}
+	if statuses[0].CertificateID != "unsigned" || statuses[0].IsSigned {
+		t.Errorf("Expected unsigned certificate to be reported as not signed, got %+v", statuses[0])
+	}
+	if statuses[1].CertificateID != "signed" || !statuses[1].IsSigned || statuses[1].IsGenerated {
+		t.Errorf("Expected fully signed certificate to be signed but not generated, got %+v", statuses[1])
+	}
+	if !statuses[2].IsGenerated || !statuses[2].IsPartialGenerated || statuses[2].PdfSignedCount != 2 || statuses[2].PdfUnknownCount != 1 {
+		t.Errorf("Expected partially generated certificate with PDF counts, got %+v", statuses[2])
+	}
+	if len(result.Data.UnavailableIds) != 2 {
+		t.Errorf("Expected 2 unavailable ids, got %v", result.Data.UnavailableIds)
+	}
+}
+
+func TestCertificateController_DownloadCombinedPDF(t *testing.T) {
+	tests := []struct {
+		name           string
+		owner          string
+		participants   []*participantmodel.CombinedParticipant
+		wantStatusCode int
+	}{
+		{
+			name:           "failed - not the owner",
+			owner:          "other@example.com",
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:  "failed - no generated certificates",
+			owner: "owner@example.com",
+			participants: []*participantmodel.CombinedParticipant{
+				{ID: "p1"},
+				{ID: "p2", CertificateURL: "http://minio/certs/p2.pdf", IsRevoke: true},
+			},
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: tt.owner}, nil
+			}
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.GetParticipantsByCertIdFunc = func(certId string) ([]*participantmodel.CombinedParticipant, error) {
+				return tt.participants, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+
+			app.Get("/certificate/:certId/combined-pdf", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.DownloadCombinedPDF(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate/cert1/combined-pdf", nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCertificateController_StoredAnchors(t *testing.T) {
+	app := fiber.New()
+
+	var stored []string
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+		return &model.Certificate{
+			ID:      certId,
+			UserID:  "owner@example.com",
+			Design:  `{"anchorOrder":["name"],"objects":[{"id":"PLACEHOLDER-name"},{"id":"PLACEHOLDER-course"}]}`,
+			Anchors: []string{"stored"},
+		}, nil
+	}
+	mockCertRepo.SetAnchorsFunc = func(certificateId string, anchors []string) error {
+		stored = anchors
+		return nil
+	}
+
+	ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+	app.Get("/certificate/anchor/:certId", ctrl.GetAnchorList)
+	app.Post("/certificate/anchor/:certId/refresh", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.RefreshAnchors(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/certificate/anchor/cert1", nil))
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	var listed struct {
+		Data []string `json:"data"`
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if err := json.Unmarshal(body, &listed); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(listed.Data) != 1 || listed.Data[0] != "stored" {
+		t.Errorf("Expected the stored anchor list, got %v", listed.Data)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/certificate/anchor/cert1/refresh", nil))
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	if len(stored) != 2 || stored[0] != "name" || stored[1] != "course" {
+		t.Errorf("Expected anchors re-extracted from the design, got %v", stored)
+	}
+}
+
+func TestCertificateController_DistributeSelected(t *testing.T) {
+	participants := []*participantmodel.CombinedParticipant{
+		{ID: "p1", CertificateURL: "https://minio.example.com/certificate/p1.pdf", DynamicData: map[string]any{"name": "Alice"}},
+		{ID: "p2", CertificateURL: "https://minio.example.com/certificate/p2.pdf", IsRevoke: true},
+		{ID: "p3"},
+	}
+
+	tests := []struct {
+		name           string
+		userId         string
+		body           string
+		wantStatusCode int
+		wantUpdated    []string
+		checkResponse  func(t *testing.T, data map[string]any)
+	}{
+		{
+			name:           "rejects participants that cannot receive mail",
+			userId:         "owner@example.com",
+			body:           `{"participant_ids":["p1","p2","p3","missing"]}`,
+			wantStatusCode: fiber.StatusBadRequest,
+			checkResponse: func(t *testing.T, data map[string]any) {
+				errs, _ := data["errors"].(map[string]any)
+				for key, want := range map[string]string{"unknown_ids": "missing", "revoked_ids": "p2", "not_generated_ids": "p3"} {
+					ids, _ := errs[key].([]any)
+					if len(ids) != 1 || ids[0] != want {
+						t.Errorf("Expected %s=[%s], got %v", key, want, errs[key])
+					}
+				}
+			},
+		},
+		{
+			name:           "marks participants without an address as failed",
+			userId:         "owner@example.com",
+			body:           `{"participant_ids":["p1"],"email_field":"contact"}`,
+			wantStatusCode: fiber.StatusOK,
+			wantUpdated:    []string{"p1"},
+			checkResponse: func(t *testing.T, data map[string]any) {
+				if data["failed_count"] != float64(1) || data["success_count"] != float64(0) {
+					t.Errorf("Expected one failed send, got %v", data)
+				}
+			},
+		},
+		{
+			name:           "failed - empty participant list",
+			userId:         "owner@example.com",
+			body:           `{"participant_ids":[]}`,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:           "failed - not the owner",
+			userId:         "other@example.com",
+			body:           `{"participant_ids":["p1"]}`,
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+
+			var updated []string
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.GetParticipantsByCertIdFunc = func(certId string) ([]*participantmodel.CombinedParticipant, error) {
+				return participants, nil
+			}
+			mockParticipantRepo.BulkUpdateEmailStatusFunc = func(participantIds []string, status string) error {
+				if status != "failed" {
+					t.Errorf("Expected failed status, got %s", status)
+				}
+				updated = append(updated, participantIds...)
+				return nil
+			}
+
+			app := fiber.New()
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+			app.Post("/certificate/:certId/distribute", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.DistributeSelected(c)
+			})
+
+			req := httptest.NewRequest("POST", "/certificate/cert123/distribute", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if len(updated) != len(tt.wantUpdated) {
+				t.Errorf("Expected email status updates for %v, got %v", tt.wantUpdated, updated)
+			}
+
+			if tt.checkResponse != nil {
+				var body struct {
+					Data map[string]any `json:"data"`
+				}
+				respBody, _ := io.ReadAll(resp.Body)
+				if err := json.Unmarshal(respBody, &body); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				tt.checkResponse(t, body.Data)
+			}
+		})
+	}
+}
+
+func TestCertificateController_GetActivity(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		userId         string
+		wantStatusCode int
+		wantLimit      int
+	}{
+		{name: "default limit", userId: "owner@example.com", wantStatusCode: fiber.StatusOK, wantLimit: certificatemodel.DefaultActivityLimit},
+		{name: "custom limit", query: "?limit=5", userId: "owner@example.com", wantStatusCode: fiber.StatusOK, wantLimit: 5},
+		{name: "failed - limit too large", query: "?limit=1000", userId: "owner@example.com", wantStatusCode: fiber.StatusBadRequest},
+		{name: "failed - limit not a number", query: "?limit=abc", userId: "owner@example.com", wantStatusCode: fiber.StatusBadRequest},
+		{name: "failed - not the owner", userId: "other@example.com", wantStatusCode: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotLimit := 0
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+			mockCertRepo.GetRecentActivityFunc = func(certificateId string, limit int) ([]*model.CertificateActivity, error) {
+				gotLimit = limit
+				return []*model.CertificateActivity{
+					{CertificateID: certificateId, Actor: "owner@example.com", Action: certificatemodel.ActivityDistributed, Detail: "3 sent, 0 failed"},
+					{CertificateID: certificateId, Actor: "owner@example.com", Action: certificatemodel.ActivityEdited, Detail: "design"},
+				}, nil
+			}
+
+			app := fiber.New()
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+			app.Get("/certificate/:certId/activity", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.GetActivity(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate/cert123/activity"+tt.query, nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if gotLimit != tt.wantLimit {
+				t.Errorf("Expected limit %d, got %d", tt.wantLimit, gotLimit)
+			}
+
+			if tt.wantStatusCode == fiber.StatusOK {
+				var body struct {
+					Data []model.CertificateActivity `json:"data"`
+				}
+				respBody, _ := io.ReadAll(resp.Body)
+				if err := json.Unmarshal(respBody, &body); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if len(body.Data) != 2 || body.Data[0].Action != certificatemodel.ActivityDistributed {
+					t.Errorf("Expected activity newest first, got %+v", body.Data)
+				}
+			}
+		})
+	}
+}
+
+func TestCertificateController_RecordsActivity(t *testing.T) {
+	var recorded []string
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+		return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+	}
+	mockCertRepo.RecordActivityFunc = func(certificateId, actor, action, detail string) error {
+		if actor != "owner@example.com" {
+			t.Errorf("Expected the owner as actor, got %s", actor)
+		}
+		recorded = append(recorded, action+": "+detail)
+		return nil
+	}
+	mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+	mockParticipantRepo.BulkRevokeFunc = func(certId string, participantIds []string) (*participantmodel.BulkRevokeResult, error) {
+		return &participantmodel.BulkRevokeResult{Revoked: participantIds}, nil
+	}
+
+	app := fiber.New()
+	ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+	app.Post("/certificate/:certId/revoke", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.BulkRevoke(c)
+	})
+
+	req := httptest.NewRequest("POST", "/certificate/cert123/revoke", bytes.NewBufferString(`{"participantIds":["p1","p2"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+
+	if len(recorded) != 1 || recorded[0] != "revoked: 2 participants" {
+		t.Errorf("Expected one revoke activity, got %v", recorded)
+	}
+}
+
+func TestCertificateController_GetPendingSignatures(t *testing.T) {
+	tests := []struct {
+		name           string
+		userId         string
+		repoErr        error
+		wantStatusCode int
+	}{
+		{name: "successful list", userId: "owner@example.com", wantStatusCode: fiber.StatusOK},
+		{name: "failed - no user in context", wantStatusCode: fiber.StatusUnauthorized},
+		{name: "failed - repository error", userId: "owner@example.com", repoErr: errors.New("database error"), wantStatusCode: fiber.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSignatureRepo := signaturemodel.NewMockSignatureRepository()
+			mockSignatureRepo.GetPendingSignaturesByOwnerFunc = func(userId string) ([]signaturemodel.PendingSignatureCertificate, error) {
+				if userId != tt.userId {
+					t.Errorf("Expected pending signatures for %s, got %s", tt.userId, userId)
+				}
+				if tt.repoErr != nil {
+					return nil, tt.repoErr
+				}
+				return []signaturemodel.PendingSignatureCertificate{
+					{CertificateID: "cert1", CertificateName: "Workshop", PendingCount: 2},
+				}, nil
+			}
+
+			app := fiber.New()
+			ctrl := certificate_controller.NewCertificateController(certificatemodel.NewMockCertificateRepository(), mockSignatureRepo, participantmodel.NewMockParticipantRepository())
+			app.Get("/certificate/pending-signatures", func(c *fiber.Ctx) error {
+				if tt.userId != "" {
+					c.Locals("user_id", tt.userId)
+				}
+				return ctrl.GetPendingSignatures(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate/pending-signatures", nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			if tt.wantStatusCode == fiber.StatusOK {
+				var body struct {
+					Data []signaturemodel.PendingSignatureCertificate `json:"data"`
+				}
+				respBody, _ := io.ReadAll(resp.Body)
+				if err := json.Unmarshal(respBody, &body); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if len(body.Data) != 1 || body.Data[0].PendingCount != 2 {
+					t.Errorf("Expected one certificate with 2 pending signatures, got %+v", body.Data)
+				}
+			}
+		})
+	}
+}
+
+type notifyRecordingMailer struct {
+	messages []*util.MailMessage
+}
+
+func (m *notifyRecordingMailer) Send(message *util.MailMessage) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func TestCertificateController_NotifyComplete(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	defer util.SetMailer(nil)
+
+	sender := "noreply@example.com"
+	verifyHost := "https://verify.example.com"
+	common.Config = &shared.Config{MailUser: &sender, VerifyHost: &verifyHost}
+
+	tests := []struct {
+		name           string
+		userId         string
+		body           string
+		allComplete    bool
+		wantStatusCode int
+		wantNote       string
+	}{
+		{
+			name:           "success - escaped custom message",
+			userId:         "owner@example.com",
+			body:           `{"message":"Ready <now>"}`,
+			allComplete:    true,
+			wantStatusCode: fiber.StatusOK,
+			wantNote:       "Ready &lt;now&gt;",
+		},
+		{
+			name:           "success - without body",
+			userId:         "owner@example.com",
+			allComplete:    true,
+			wantStatusCode: fiber.StatusOK,
+		},
+		{
+			name:           "failed - signatures incomplete",
+			userId:         "owner@example.com",
+			body:           `{"message":"hi"}`,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:           "failed - not the owner",
+			userId:         "other@example.com",
+			allComplete:    true,
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, Name: "Award", UserID: "owner@example.com"}, nil
+			}
+			mockSignatureRepo := signaturemodel.NewMockSignatureRepository()
+			mockSignatureRepo.AreAllSignaturesCompleteFunc = func(certificateId string) (bool, error) {
+				return tt.allComplete, nil
+			}
+
+			mailer := &notifyRecordingMailer{}
+			util.SetMailer(mailer)
+
+			app := fiber.New()
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, mockSignatureRepo, participantmodel.NewMockParticipantRepository())
+			app.Post("/certificate/:certId/notify-complete", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.NotifyComplete(c)
+			})
+
+			req := httptest.NewRequest("POST", "/certificate/cert123/notify-complete", bytes.NewBufferString(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			wantSent := tt.wantStatusCode == fiber.StatusOK
+			if wantSent != (len(mailer.messages) == 1) {
+				t.Fatalf("Expected mail sent=%v, got %d messages", wantSent, len(mailer.messages))
+			}
+			if tt.wantNote != "" && !strings.Contains(mailer.messages[0].HTMLBody, tt.wantNote) {
+				t.Errorf("Expected email body to contain %q", tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestCertificateController_DownloadVerificationReport(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	verifyHost := "https://verify.example.com"
+	common.Config = &shared.Config{VerifyHost: &verifyHost}
+
+	tests := []struct {
+		name           string
+		owner          string
+		wantStatusCode int
+	}{
+		{
+			name:           "success",
+			owner:          "owner@example.com",
+			wantStatusCode: fiber.StatusOK,
+		},
+		{
+			name:           "failed - not the owner",
+			owner:          "other@example.com",
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, Name: "Award", UserID: tt.owner}, nil
+			}
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.GetParticipantsByCertIdFunc = func(certId string) ([]*participantmodel.CombinedParticipant, error) {
+				return []*participantmodel.CombinedParticipant{
+					{ID: "p1", CertificateURL: "http://minio/certs/p1.pdf", DynamicData: map[string]any{"name": "Alice"}},
+					{ID: "p2", IsRevoke: true},
+				}, nil
+			}
+			mockSignatureRepo := signaturemodel.NewMockSignatureRepository()
+			mockSignatureRepo.GetSignerStatusesByCertificateFunc = func(certificateId string) ([]signaturemodel.CertificateSignerStatus, error) {
+				return []signaturemodel.CertificateSignerStatus{{SignerID: "s1", DisplayName: "Dean", Email: "dean@example.com", IsSigned: true}}, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, mockSignatureRepo, mockParticipantRepo)
+
+			app.Get("/certificate/:certId/verification-report", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.DownloadVerificationReport(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate/cert1/verification-report", nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			if tt.wantStatusCode == fiber.StatusOK {
+				if resp.Header.Get("Content-Type") != "application/pdf" {
+					t.Errorf("Expected application/pdf, got %s", resp.Header.Get("Content-Type"))
+				}
+				body, _ := io.ReadAll(resp.Body)
+				if !bytes.HasPrefix(body, []byte("%PDF")) {
+					t.Error("Expected a PDF document")
+				}
+			}
+		})
+	}
+}
+
+func TestCertificateController_GetDistributionPreview(t *testing.T) {
+	participants := []*participantmodel.CombinedParticipant{
+		{ID: "p1", EmailStatus: "success", CertificateURL: "u", DynamicData: map[string]any{"email": "a@example.com"}},
+		{ID: "p2", IsRevoke: true, CertificateURL: "u", DynamicData: map[string]any{"email": "b@example.com"}},
+		{ID: "p3", DynamicData: map[string]any{"email": "c@example.com"}},
+		{ID: "p4", CertificateURL: "u", DynamicData: map[string]any{}},
+		{ID: "p5", CertificateURL: "u", DynamicData: map[string]any{"email": "not-an-email"}},
+		{ID: "p6", CertificateURL: "u", DynamicData: map[string]any{"email": "f@example.com"}},
+		{ID: "p7", CertificateURL: "u", EmailStatus: "failed", DynamicData: map[string]any{"email": "g@example.com"}},
+	}
+
+	tests := []struct {
+		name           string
+		userId         string
+		wantStatusCode int
+		want           map[string]float64
+	}{
+		{
+			name:           "success",
+			userId:         "owner@example.com",
+			wantStatusCode: fiber.StatusOK,
+			want: map[string]float64{
+				"total_participants": 7,
+				"already_sent":       1,
+				"revoked":            1,
+				"not_generated":      1,
+				"missing_email":      1,
+				"invalid_email":      1,
+				"will_send":          2,
+			},
+		},
+		{
+			name:           "failed - not the owner",
+			userId:         "other@example.com",
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.GetParticipantsByCertIdFunc = func(certId string) ([]*participantmodel.CombinedParticipant, error) {
+				return participants, nil
+			}
+
+			app := fiber.New()
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+			app.Get("/certificate/:certId/distribution-preview", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.GetDistributionPreview(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate/cert123/distribution-preview", nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			if tt.want != nil {
+				var body struct {
+					Data map[string]float64 `json:"data"`
+				}
+				respBody, _ := io.ReadAll(resp.Body)
+				if err := json.Unmarshal(respBody, &body); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				for key, want := range tt.want {
+					if body.Data[key] != want {
+						t.Errorf("Expected %s=%v, got %v", key, want, body.Data[key])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCertificateController_SetSignatureBackground(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantStatusCode int
+		wantStored     string
+	}{
+		{
+			name:           "success - background normalized to lowercase",
+			body:           `{"background": "#FFFFFF"}`,
+			wantStatusCode: fiber.StatusOK,
+			wantStored:     "#ffffff",
+		},
+		{
+			name:           "success - empty keeps transparency",
+			body:           `{"background": ""}`,
+			wantStatusCode: fiber.StatusOK,
+			wantStored:     "",
+		},
+		{
+			name:           "failed - not a hex color",
+			body:           `{"background": "white"}`,
+			wantStatusCode: fiber.StatusBadRequest,
+			wantStored:     "unchanged",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			stored := "unchanged"
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+			mockCertRepo.SetSignatureBackgroundFunc = func(certificateId string, background string) error {
+				stored = background
+				return nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+			app.Put("/certificate/:certId/signature-background", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.SetSignatureBackground(c)
+			})
+
+			req := httptest.NewRequest("PUT", "/certificate/cert123/signature-background", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if stored != tt.wantStored {
+				t.Errorf("Expected stored=%q, got %q", tt.wantStored, stored)
+			}
+		})
+	}
+}
+
+func TestCertificateController_GetByTemplate(t *testing.T) {
+	app := fiber.New()
+
+	var gotUser, gotTemplate string
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.GetByUserAndTemplateFunc = func(userId string, templateId string) ([]*model.Certificate, error) {
+		gotUser, gotTemplate = userId, templateId
+		return []*model.Certificate{{ID: "cert1", UserID: userId, SourceTemplateID: &templateId}}, nil
+	}
+
+	ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+	app.Get("/template/:templateId/certificates", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.GetByTemplate(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/template/tpl-1/certificates", nil))
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	if gotUser != "owner@example.com" || gotTemplate != "tpl-1" {
+		t.Errorf("Expected lookup for owner@example.com/tpl-1, got %s/%s", gotUser, gotTemplate)
+	}
+
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	certs, _ := body["data"].([]any)
+	if len(certs) != 1 {
+		t.Fatalf("Expected 1 certificate, got %v", body["data"])
+	}
+	if cert := certs[0].(map[string]any); cert["source_template_id"] != "tpl-1" {
+		t.Errorf("Expected source_template_id tpl-1, got %v", cert["source_template_id"])
+	}
+}
+
+func TestCertificateController_Update_ThumbnailOnDesignChange(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	enabled := true
+	design := `{"objects":[]}`
+
+	tests := []struct {
+		name             string
+		config           *shared.Config
+		body             map[string]string
+		wantDesignLookup bool
+	}{
+		{
+			name:             "disabled by default",
+			config:           &shared.Config{},
+			body:             map[string]string{"design": design},
+			wantDesignLookup: false,
+		},
+		{
+			name:             "enabled - stored design compared",
+			config:           &shared.Config{ThumbnailOnDesignChange: &enabled},
+			body:             map[string]string{"design": design},
+			wantDesignLookup: true,
+		},
+		{
+			name:             "enabled - name only autosave skipped",
+			config:           &shared.Config{ThumbnailOnDesignChange: &enabled},
+			body:             map[string]string{"name": "Renamed"},
+			wantDesignLookup: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common.Config = tt.config
+			app := fiber.New()
+
+			lookedUp := false
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				lookedUp = true
+				// Same design as the update, so no background render is started
+				return &model.Certificate{ID: certId, Design: design}, nil
+			}
+			mockCertRepo.UpdateFunc = func(id string, name string, newDesign string, autosave bool) (*model.Certificate, error) {
+				return &model.Certificate{ID: id, Name: name, Design: design, UserID: "owner@example.com"}, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+			app.Put("/certificate/:id", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.Update(c)
+			})
+
+			bodyBytes, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PUT", "/certificate/cert123?autosave=true", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != fiber.StatusOK {
+				t.Fatalf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+			}
+			if lookedUp != tt.wantDesignLookup {
+				t.Errorf("Expected stored design lookup=%v, got %v", tt.wantDesignLookup, lookedUp)
+			}
+		})
+	}
+}
+
+func TestCertificateController_GetEmailStatusSummary(t *testing.T) {
+	tests := []struct {
+		name           string
+		userId         string
+		wantStatusCode int
+		wantTotal      float64
+	}{
+		{name: "success - counts and total", userId: "owner@example.com", wantStatusCode: fiber.StatusOK, wantTotal: 10},
+		{name: "failed - not the owner", userId: "other@example.com", wantStatusCode: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.CountEmailStatusesByCertificateFunc = func(certId string) (map[string]int64, error) {
+				return map[string]int64{"pending": 3, "success": 5, "failed": 1, "bounced": 1}, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+
+			app.Get("/certificate/:certId/email-status-summary", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.GetEmailStatusSummary(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate/cert123/email-status-summary", nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if tt.wantStatusCode != fiber.StatusOK {
+				return
+			}
+
+			var body map[string]any
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			data := body["data"].(map[string]any)
+			if data["total"] != tt.wantTotal {
+				t.Errorf("Expected total %v, got %v", tt.wantTotal, data["total"])
+			}
+			if counts := data["counts"].(map[string]any); counts["success"] != float64(5) {
+				t.Errorf("Expected 5 successful emails, got %v", counts["success"])
+			}
+		})
+	}
+}
+
+func TestCertificateController_GetStorageUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		certificate    *model.Certificate
+		userId         string
+		wantStatusCode int
+	}{
+		{name: "failed - certificate not found", userId: "owner@example.com", wantStatusCode: fiber.StatusBadRequest},
+		{name: "failed - not the owner", certificate: &model.Certificate{ID: "cert123", UserID: "owner@example.com"}, userId: "other@example.com", wantStatusCode: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return tt.certificate, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), participantmodel.NewMockParticipantRepository())
+
+			app.Get("/certificate/:certId/storage", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.GetStorageUsage(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate/cert123/storage", nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCertificateController_Search(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		wantStatusCode int
+		wantTotal      float64
+		wantTypes      []string
+	}{
+		{name: "success - certificates before participants", url: "/search?q=workshop", wantStatusCode: fiber.StatusOK, wantTotal: 3, wantTypes: []string{"certificate", "participant", "participant"}},
+		{name: "success - second page", url: "/search?q=workshop&page=2&page_size=2", wantStatusCode: fiber.StatusOK, wantTotal: 3, wantTypes: []string{"participant"}},
+		{name: "success - page past the end", url: "/search?q=workshop&page=5", wantStatusCode: fiber.StatusOK, wantTotal: 3, wantTypes: []string{}},
+		{name: "failed - missing query", url: "/search?q=%20", wantStatusCode: fiber.StatusBadRequest},
+		{name: "failed - page size too large", url: "/search?q=workshop&page_size=1000", wantStatusCode: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			now := time.Now()
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByUserFunc = func(userId string) ([]*model.Certificate, error) {
+				return []*model.Certificate{
+					{ID: "cert-old", Name: "Annual Workshop", UserID: userId, CreatedAt: now.Add(-time.Hour)},
+					{ID: "cert-new", Name: "Hackathon", UserID: userId, CreatedAt: now},
+				}, nil
+			}
+			var searchedCertIds []string
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.SearchParticipantsFunc = func(certIds []string, term string, limit int) ([]*participantmodel.ParticipantSearchMatch, bool, error) {
+				searchedCertIds = certIds
+				return []*participantmodel.ParticipantSearchMatch{
+					{ParticipantID: "p1", CertificateID: "cert-new", Email: "a@example.com", Field: "team", Value: "Workshop crew"},
+					{ParticipantID: "p2", CertificateID: "cert-old", Email: "b@example.com", Field: "email", Value: "b@workshop.com"},
+				}, false, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+
+			app.Get("/search", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.Search(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", tt.url, nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if tt.wantStatusCode != fiber.StatusOK {
+				return
+			}
+
+			if strings.Join(searchedCertIds, ",") != "cert-new,cert-old" {
+				t.Errorf("Expected participants searched newest certificate first, got %v", searchedCertIds)
+			}
+
+			var body map[string]any
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			data := body["data"].(map[string]any)
+			if data["total"] != tt.wantTotal {
+				t.Errorf("Expected total %v, got %v", tt.wantTotal, data["total"])
+			}
+
+			results := data["results"].([]any)
+			if len(results) != len(tt.wantTypes) {
+				t.Fatalf("Expected %d results, got %d", len(tt.wantTypes), len(results))
+			}
+			for i, wantType := range tt.wantTypes {
+				result := results[i].(map[string]any)
+				if result["type"] != wantType {
+					t.Errorf("Expected result %d to be a %s, got %v", i, wantType, result["type"])
+				}
+				if result["certificate_name"] == "" {
+					t.Errorf("Expected result %d to carry its certificate name", i)
+				}
+			}
+		})
+	}
+}
+
+func TestCertificateController_GetArchiveRecovery(t *testing.T) {
+	tests := []struct {
+		name           string
+		certificate    *model.Certificate
+		userId         string
+		wantStatusCode int
+		wantCounts     map[string]float64
+	}{
+		{
+			name:           "success - no archive",
+			certificate:    &model.Certificate{ID: "cert123", UserID: "owner@example.com"},
+			userId:         "owner@example.com",
+			wantStatusCode: fiber.StatusOK,
+			wantCounts:     map[string]float64{"intact": 1, "recoverable": 1, "lost": 1},
+		},
+		{name: "failed - certificate not found", userId: "owner@example.com", wantStatusCode: fiber.StatusBadRequest},
+		{name: "failed - not the owner", certificate: &model.Certificate{ID: "cert123", UserID: "owner@example.com"}, userId: "other@example.com", wantStatusCode: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return tt.certificate, nil
+			}
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.GetRecoveryStatusByCertIdFunc = func(certId string) ([]*participantmodel.ParticipantRecoveryStatus, error) {
+				return []*participantmodel.ParticipantRecoveryStatus{
+					{ID: "p1", HasData: true},
+					{ID: "p2", CertificateURL: "http://minio/cert123/certificate_p2.pdf"},
+					{ID: "p3"},
+				}, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+
+			app.Get("/certificate/:certId/archive-recovery", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.GetArchiveRecovery(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate/cert123/archive-recovery", nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if tt.wantCounts == nil {
+				return
+			}
+
+			var body struct {
+				Data struct {
+					ArchiveAvailable bool               `json:"archive_available"`
+					Counts           map[string]float64 `json:"counts"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if body.Data.ArchiveAvailable {
+				t.Error("Expected archive_available to be false")
+			}
+			for state, want := range tt.wantCounts {
+				if body.Data.Counts[state] != want {
+					t.Errorf("Expected %d %s participants, got %v", int(want), state, body.Data.Counts[state])
+				}
+			}
+		})
+	}
+}
+
+func TestCertificateController_GetDesignDiff(t *testing.T) {
+	tests := []struct {
+		name           string
+		certificate    *model.Certificate
+		userId         string
+		wantStatusCode int
+	}{
+		{
+			name: "success - anchor added",
+			certificate: &model.Certificate{
+				ID:             "cert123",
+				UserID:         "owner@example.com",
+				Design:         `{"objects":[{"id":"PLACEHOLDER-name"},{"id":"PLACEHOLDER-score"}]}`,
+				PreviousDesign: `{"objects":[{"id":"PLACEHOLDER-name"}]}`,
+			},
+			userId:         "owner@example.com",
+			wantStatusCode: fiber.StatusOK,
+		},
+		{
+			name:           "failed - no previous design",
+			certificate:    &model.Certificate{ID: "cert123", UserID: "owner@example.com", Design: `{"objects":[]}`},
+			userId:         "owner@example.com",
+			wantStatusCode: fiber.StatusNotFound,
+		},
+		{name: "failed - certificate not found", userId: "owner@example.com", wantStatusCode: fiber.StatusBadRequest},
+		{name: "failed - not the owner", certificate: &model.Certificate{ID: "cert123", UserID: "owner@example.com"}, userId: "other@example.com", wantStatusCode: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return tt.certificate, nil
+			}
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.CountGenerationByCertificatesFunc = func(certIds []string) (map[string]participantmodel.GenerationCounts, error) {
+				return map[string]participantmodel.GenerationCounts{"cert123": {Total: 4}}, nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+
+			app.Get("/certificate/:certId/design-diff", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.GetDesignDiff(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/certificate/cert123/design-diff", nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if resp.StatusCode != fiber.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data struct {
+					AnchorsChanged   bool    `json:"anchors_changed"`
+					ParticipantCount float64 `json:"participant_count"`
+					Diff             struct {
+						AddedAnchors []string `json:"added_anchors"`
+					} `json:"diff"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !body.Data.AnchorsChanged || body.Data.ParticipantCount != 4 {
+				t.Errorf("Expected anchors_changed with 4 participants, got %+v", body.Data)
+			}
+			if len(body.Data.Diff.AddedAnchors) != 1 || body.Data.Diff.AddedAnchors[0] != "score" {
+				t.Errorf("Expected added anchor score, got %v", body.Data.Diff.AddedAnchors)
+			}
+		})
+	}
+}
+
+func TestCertificateController_ResendParticipantMail(t *testing.T) {
+	tests := []struct {
+		name            string
+		participant     *participantmodel.CombinedParticipant
+		userId          string
+		url             string
+		wantStatusCode  int
+		wantEmailStatus string
+	}{
+		{
+			name:           "failed - participant not found",
+			userId:         "owner@example.com",
+			url:            "/participant/p1/resend",
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:           "failed - not the owner",
+			participant:    &participantmodel.CombinedParticipant{ID: "p1", CertificateID: "cert123", CertificateURL: "http://minio/cert.pdf"},
+			userId:         "other@example.com",
+			url:            "/participant/p1/resend",
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "failed - revoked participant",
+			participant:    &participantmodel.CombinedParticipant{ID: "p1", CertificateID: "cert123", CertificateURL: "http://minio/cert.pdf", IsRevoke: true},
+			userId:         "owner@example.com",
+			url:            "/participant/p1/resend",
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name: "failed - email field missing",
+			participant: &participantmodel.CombinedParticipant{
+				ID:             "p1",
+				CertificateID:  "cert123",
+				CertificateURL: "http://minio/cert.pdf",
+				DynamicData:    map[string]any{"email": "a@example.com"},
+			},
+			userId:          "owner@example.com",
+			url:             "/participant/p1/resend?email=contact",
+			wantStatusCode:  fiber.StatusBadRequest,
+			wantEmailStatus: "failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+			emailStatus := ""
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.GetParticipantsByIdFunc = func(participantId string) (*participantmodel.CombinedParticipant, error) {
+				return tt.participant, nil
+			}
+			mockParticipantRepo.UpdateEmailStatusFunc = func(participantId string, status string) error {
+				emailStatus = status
+				return nil
+			}
+
+			ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+
+			app.Post("/participant/:participantId/resend", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.ResendParticipantMail(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("POST", tt.url, nil))
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if emailStatus != tt.wantEmailStatus {
+				t.Errorf("Expected email status %q, got %q", tt.wantEmailStatus, emailStatus)
+			}
+		})
+	}
+}
+
+func TestCertificateController_Render_RenderingDisabled(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	disabled := true
+	common.Config = &shared.Config{RenderingDisabled: &disabled}
+
+	app := fiber.New()
+
+	mockCertRepo := certificatemodel.NewMockCertificateRepository()
+	mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+		return &model.Certificate{ID: certId, UserID: "owner@example.com", IsDistributed: true}, nil
+	}
+	participantsFetched := false
+	mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+	mockParticipantRepo.GetParticipantsByCertIdFunc = func(certId string) ([]*participantmodel.CombinedParticipant, error) {
+		participantsFetched = true
+		return nil, nil
+	}
+
+	ctrl := certificate_controller.NewCertificateController(mockCertRepo, signaturemodel.NewMockSignatureRepository(), mockParticipantRepo)
+
+	app.Post("/certificate/:certId/render", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "owner@example.com")
+		return ctrl.Render(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/certificate/cert123/render", nil))
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusServiceUnavailable, resp.StatusCode)
+	}
+	if participantsFetched {
+		t.Error("Expected generation to stop before loading participants")
+	}
+}
@@ -4,13 +4,22 @@ import (
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
 	"github.com/sunthewhat/easy-cert-api/type/response"
 )
 
 type responseStruct struct {
-	IsSigned           bool `json:"is_signed"`
-	IsGenerated        bool `json:"is_generated"`
-	IsPartialGenerated bool `json:"is_partial_generated"`
+	IsSigned           bool   `json:"is_signed"`
+	IsGenerated        bool   `json:"is_generated"`
+	IsPartialGenerated bool   `json:"is_partial_generated"`
+	GenerationState    string `json:"generation_state"`
+	QueuePosition      int    `json:"queue_position,omitempty"`
+
+	// PDF digital signature state; certificates generated before it was tracked count as unknown
+	PdfSigningEnabled bool `json:"pdf_signing_enabled"`
+	PdfSignedCount    int  `json:"pdf_signed_count"`
+	PdfUnsignedCount  int  `json:"pdf_unsigned_count"`
+	PdfUnknownCount   int  `json:"pdf_unknown_count"`
 }
 
 func (ctrl *CertificateController) CheckGenerateStatus(c *fiber.Ctx) error {
@@ -29,6 +38,8 @@ func (ctrl *CertificateController) CheckGenerateStatus(c *fiber.Ctx) error {
 	}
 
 	returnResponse := new(responseStruct)
+	generationState, queuePosition := renderer.Generations().State(certificateId)
+	pdfSigningEnabled := renderer.GetSigningCertificateInfo().Enabled
 
 	if !cert.IsSigned {
 		notHaveSignature, err := ctrl.signatureRepo.AreAllSignaturesComplete(certificateId)
@@ -41,6 +52,9 @@ func (ctrl *CertificateController) CheckGenerateStatus(c *fiber.Ctx) error {
 				IsSigned:           false,
 				IsGenerated:        false,
 				IsPartialGenerated: false,
+				GenerationState:    generationState,
+				QueuePosition:      queuePosition,
+				PdfSigningEnabled:  pdfSigningEnabled,
 			}
 
 			return response.SendSuccess(c, "Certificate is not signed", returnResponse)
@@ -52,6 +66,9 @@ func (ctrl *CertificateController) CheckGenerateStatus(c *fiber.Ctx) error {
 			IsSigned:           true,
 			IsGenerated:        false,
 			IsPartialGenerated: false,
+			GenerationState:    generationState,
+			QueuePosition:      queuePosition,
+			PdfSigningEnabled:  pdfSigningEnabled,
 		}
 		return response.SendSuccess(c, "Certificate is not distributed", returnResponse)
 	}
@@ -64,11 +81,21 @@ func (ctrl *CertificateController) CheckGenerateStatus(c *fiber.Ctx) error {
 	}
 
 	isPartialGenerated := false
+	signedCount, unsignedCount, unknownCount := 0, 0, 0
 
 	for _, p := range participants {
 		if p.CertificateURL == "" {
 			isPartialGenerated = true
-			break
+			continue
+		}
+
+		switch {
+		case p.PdfSigned == nil:
+			unknownCount++
+		case *p.PdfSigned:
+			signedCount++
+		default:
+			unsignedCount++
 		}
 	}
 
@@ -76,6 +103,12 @@ func (ctrl *CertificateController) CheckGenerateStatus(c *fiber.Ctx) error {
 		IsSigned:           true,
 		IsGenerated:        true,
 		IsPartialGenerated: isPartialGenerated,
+		GenerationState:    generationState,
+		QueuePosition:      queuePosition,
+		PdfSigningEnabled:  pdfSigningEnabled,
+		PdfSignedCount:     signedCount,
+		PdfUnsignedCount:   unsignedCount,
+		PdfUnknownCount:    unknownCount,
 	}
 
 	return response.SendSuccess(c, "Certificate is distributed", returnResponse)
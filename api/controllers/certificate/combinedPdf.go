@@ -0,0 +1,128 @@
+package certificate_controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// DownloadCombinedPDF streams one multi-page PDF holding the generated certificate of every non-revoked
+// participant, as an alternative to the ZIP archive. Participants whose PDF cannot be fetched or read
+// are skipped and logged, since the response has already started by then.
+func (ctrl *CertificateController) DownloadCombinedPDF(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate DownloadCombinedPDF GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate DownloadCombinedPDF UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request DownloadCombinedPDF", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	participants, err := ctrl.participantRepo.GetParticipantsByCertId(certId)
+	if err != nil {
+		slog.Error("Certificate DownloadCombinedPDF GetParticipantsByCertId failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	var generated []*participantmodel.CombinedParticipant
+	for _, p := range participants {
+		if !p.IsRevoke && p.CertificateURL != "" {
+			generated = append(generated, p)
+		}
+	}
+
+	if len(generated) == 0 {
+		return response.SendFailed(c, "No generated certificates to combine")
+	}
+
+	c.Set("Content-Type", "application/pdf")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"certificates_%s.pdf\"", certId))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		merger, err := renderer.NewPDFMerger(w)
+		if err != nil {
+			slog.Error("Certificate DownloadCombinedPDF failed to start PDF", "error", err, "cert_id", certId)
+			return
+		}
+
+		skipped := 0
+		for _, p := range generated {
+			data, err := fetchCertificatePDF(p.CertificateURL)
+			if err == nil {
+				_, err = merger.AddDocument(data)
+			}
+			if err != nil {
+				slog.Warn("Certificate DownloadCombinedPDF skipping participant", "error", err, "cert_id", certId, "participant_id", p.ID)
+				skipped++
+				continue
+			}
+
+			if err := w.Flush(); err != nil {
+				slog.Warn("Certificate DownloadCombinedPDF client disconnected", "error", err, "cert_id", certId)
+				return
+			}
+		}
+
+		if err := merger.Close(); err != nil {
+			slog.Error("Certificate DownloadCombinedPDF failed to finish PDF", "error", err, "cert_id", certId)
+			return
+		}
+		if err := w.Flush(); err != nil {
+			slog.Warn("Certificate DownloadCombinedPDF client disconnected", "error", err, "cert_id", certId)
+			return
+		}
+
+		slog.Info("Certificate DownloadCombinedPDF completed",
+			"cert_id", certId,
+			"participants", len(generated),
+			"pages", merger.PageCount(),
+			"skipped", skipped)
+	})
+
+	return nil
+}
+
+// fetchCertificatePDF downloads a participant's generated certificate from MinIO
+func fetchCertificatePDF(certificateURL string) ([]byte, error) {
+	objectName, err := util.ExtractObjectNameFromURL(certificateURL, *common.Config.BucketCertificate)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := util.DownloadFile(context.Background(), *common.Config.BucketCertificate, objectName)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	return io.ReadAll(object)
+}
@@ -1,10 +1,12 @@
 package certificate_controller
 
 import (
+	"errors"
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
 	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/type/payload"
 	"github.com/sunthewhat/easy-cert-api/type/response"
@@ -19,7 +21,11 @@ func (ctrl *CertificateController) Create(c *fiber.Ctx) error {
 
 	if err := util.ValidateStruct(body); err != nil {
 		errors := util.GetValidationErrors(err)
-		return response.SendFailed(c, errors[0])
+		return response.SendValidationFailed(c, errors[0], util.GetFieldValidationErrors(err))
+	}
+
+	if err := validateDesignAnchorNames(body.Design); err != nil {
+		return response.SendFailed(c, "Invalid certificate design: "+err.Error())
 	}
 
 	userId, status := middleware.GetUserFromContext(c)
@@ -31,6 +37,9 @@ func (ctrl *CertificateController) Create(c *fiber.Ctx) error {
 
 	newCert, err := ctrl.certRepo.Create(*body, userId)
 
+	if errors.Is(err, certificatemodel.ErrCertificateQuotaExceeded) {
+		return response.SendForbidden(c, err.Error())
+	}
 	if err != nil {
 		return response.SendInternalError(c, err)
 	}
@@ -0,0 +1,132 @@
+package certificate_controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// certificateDefinitionVersion is bumped when the export format changes incompatibly
+const certificateDefinitionVersion = 1
+
+// ExportDefinition returns a certificate's design and settings as a portable definition.
+// Participants and signatures are intentionally excluded.
+func (ctrl *CertificateController) ExportDefinition(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		slog.Warn("Certificate ExportDefinition attempt with empty certificate ID")
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate ExportDefinition GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		slog.Warn("Certificate ExportDefinition certificate not found", "cert_id", certId)
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate ExportDefinition UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request ExportDefinition", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	anchors, err := certificatemodel.ExtractDesignAnchors(cert.Design)
+	if err != nil {
+		slog.Warn("Certificate ExportDefinition invalid stored design", "error", err, "cert_id", certId)
+		anchors = []string{}
+	}
+
+	signerIds, err := extractSignerIdsFromDesign(cert.Design)
+	if err != nil {
+		signerIds = []string{}
+	}
+
+	definition := payload.CertificateDefinition{
+		Version:    certificateDefinitionVersion,
+		Name:       cert.Name,
+		Design:     cert.Design,
+		Anchors:    anchors,
+		SignerIds:  signerIds,
+		ExportedAt: time.Now(),
+	}
+
+	slog.Info("Certificate ExportDefinition successful", "cert_id", certId)
+	return response.SendSuccess(c, "Certificate definition exported", definition)
+}
+
+// ImportDefinition recreates a certificate for the caller from an exported definition
+func (ctrl *CertificateController) ImportDefinition(c *fiber.Ctx) error {
+	body := new(payload.CertificateDefinition)
+
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Failed to parse body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	if body.Version > certificateDefinitionVersion {
+		return response.SendFailed(c, fmt.Sprintf("Unsupported definition version %d", body.Version))
+	}
+
+	if _, err := certificatemodel.ExtractDesignAnchors(body.Design); err != nil {
+		slog.Warn("Certificate ImportDefinition invalid design", "error", err)
+		return response.SendFailed(c, "Invalid certificate design: "+err.Error())
+	}
+
+	if err := validateDesignAnchorNames(body.Design); err != nil {
+		return response.SendFailed(c, "Invalid certificate design: "+err.Error())
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate ImportDefinition GetUserId failed")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	newCert, err := ctrl.certRepo.Create(payload.CreateCertificatePayload{
+		Name:   body.Name,
+		Design: body.Design,
+	}, userId)
+	if errors.Is(err, certificatemodel.ErrCertificateQuotaExceeded) {
+		return response.SendForbidden(c, err.Error())
+	}
+	if err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	util.RenderCertificateThumbnailAsync(newCert)
+
+	// Signers belong to the source deployment, so signature slots must be reassigned by the user
+	signerIds, err := extractSignerIdsFromDesign(body.Design)
+	if err != nil {
+		signerIds = []string{}
+	}
+
+	slog.Info("Certificate ImportDefinition successful", "cert_id", newCert.ID, "unresolved_signers", len(signerIds))
+	return response.SendSuccess(c, "Certificate definition imported", fiber.Map{
+		"certificate":           newCert,
+		"unresolved_signer_ids": signerIds,
+	})
+}
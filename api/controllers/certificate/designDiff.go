@@ -0,0 +1,65 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetDesignDiff compares a certificate's current design with the one its last design edit replaced, listing
+// added, removed and unchanged anchors and added, removed and changed objects. The participant count shows how
+// many participants an anchor change affects, since their data fields follow the anchors.
+func (ctrl *CertificateController) GetDesignDiff(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate GetDesignDiff GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate GetDesignDiff UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request GetDesignDiff", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	if cert.PreviousDesign == "" {
+		return response.SendNotFound(c, "No previous design version stored for this certificate")
+	}
+
+	diff, err := certificatemodel.DiffDesigns(cert.PreviousDesign, cert.Design)
+	if err != nil {
+		slog.Warn("Certificate GetDesignDiff failed to compare designs", "error", err, "cert_id", certId)
+		return response.SendFailed(c, "Failed to compare designs: "+err.Error())
+	}
+
+	counts, err := ctrl.participantRepo.CountGenerationByCertificates([]string{certId})
+	if err != nil {
+		slog.Error("Certificate GetDesignDiff CountGenerationByCertificates failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Certificate design diff fetched", fiber.Map{
+		"certificate_id":    certId,
+		"anchors_changed":   diff.AnchorsChanged(),
+		"participant_count": counts[certId].Total,
+		"diff":              diff,
+	})
+}
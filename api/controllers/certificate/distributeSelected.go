@@ -0,0 +1,138 @@
+package certificate_controller
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+const defaultDistributeEmailField = "email"
+
+// DistributeSelected emails only the given participants and updates their email status. Unlike DistributeByMail
+// the selection is explicit, so participants are mailed again even if an earlier send succeeded. Every ID must
+// belong to the certificate, be non-revoked and have a generated certificate, otherwise nothing is sent.
+func (ctrl *CertificateController) DistributeSelected(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	body := new(payload.DistributeSelectedPayload)
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	emailField := body.EmailField
+	if emailField == "" {
+		emailField = defaultDistributeEmailField
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate DistributeSelected GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate DistributeSelected UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request DistributeSelected", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	participants, err := ctrl.participantRepo.GetParticipantsByCertId(certId)
+	if err != nil {
+		slog.Error("Certificate DistributeSelected GetParticipantsByCertId failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	byId := make(map[string]*participantmodel.CombinedParticipant, len(participants))
+	for _, p := range participants {
+		byId[p.ID] = p
+	}
+
+	selected := make([]*participantmodel.CombinedParticipant, 0, len(body.ParticipantIds))
+	unknownIds := []string{}
+	revokedIds := []string{}
+	notGeneratedIds := []string{}
+	for _, id := range body.ParticipantIds {
+		p, ok := byId[id]
+		switch {
+		case !ok:
+			unknownIds = append(unknownIds, id)
+		case p.IsRevoke:
+			revokedIds = append(revokedIds, id)
+		case p.CertificateURL == "":
+			notGeneratedIds = append(notGeneratedIds, id)
+		default:
+			selected = append(selected, p)
+		}
+	}
+
+	if len(selected) != len(body.ParticipantIds) {
+		return response.SendValidationFailed(c, "Some participants cannot be distributed to", fiber.Map{
+			"unknown_ids":       unknownIds,
+			"revoked_ids":       revokedIds,
+			"not_generated_ids": notGeneratedIds,
+		})
+	}
+
+	successResults := []map[string]string{}
+	failedResults := []map[string]string{}
+	statusBatch := participantmodel.NewEmailStatusBatch()
+
+	for _, p := range selected {
+		participantInfo, sent := ctrl.mailParticipantCertificate(certId, p, emailField)
+		if sent {
+			statusBatch.Add(p.ID, "success")
+			successResults = append(successResults, participantInfo)
+		} else {
+			statusBatch.Add(p.ID, "failed")
+			failedResults = append(failedResults, participantInfo)
+		}
+	}
+
+	if err := statusBatch.Flush(ctrl.participantRepo); err != nil {
+		slog.Warn("Certificate DistributeSelected failed to update email statuses", "error", err, "cert_id", certId)
+	}
+
+	if len(successResults) > 0 {
+		ctrl.recordDistributionEmailField(certId, emailField)
+		ctrl.recordActivity(c, certId, certificatemodel.ActivityDistributed, fmt.Sprintf("%d selected sent, %d failed", len(successResults), len(failedResults)))
+	}
+
+	slog.Info("Certificate DistributeSelected completed",
+		"cert_id", certId,
+		"requested", len(selected),
+		"success", len(successResults),
+		"failed", len(failedResults))
+
+	return response.SendSuccess(c, "Mail distribution completed", fiber.Map{
+		"total_participants": len(selected),
+		"success_count":      len(successResults),
+		"failed_count":       len(failedResults),
+		"success_results":    successResults,
+		"failed_results":     failedResults,
+	})
+}
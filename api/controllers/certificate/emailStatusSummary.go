@@ -0,0 +1,57 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetEmailStatusSummary returns how many participants are in each email status (pending, success, failed,
+// bounced) for a delivery status chart, counted in the database instead of loading every participant
+func (ctrl *CertificateController) GetEmailStatusSummary(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate GetEmailStatusSummary GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate GetEmailStatusSummary UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request GetEmailStatusSummary", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	counts, err := ctrl.participantRepo.CountEmailStatusesByCertificate(certId)
+	if err != nil {
+		slog.Error("Certificate GetEmailStatusSummary count failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+
+	return response.SendSuccess(c, "Email status summary fetched", map[string]any{
+		"certificate_id": certId,
+		"counts":         counts,
+		"total":          total,
+	})
+}
@@ -0,0 +1,47 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetGenerationErrors returns the participants that failed in the certificate's last generation run and why
+func (ctrl *CertificateController) GetGenerationErrors(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate GetGenerationErrors GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate GetGenerationErrors UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request GetGenerationErrors", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	run, ok := renderer.LastGenerationRun(certId)
+	if !ok {
+		return response.SendSuccess(c, "No generation run recorded for this certificate", nil)
+	}
+
+	return response.SendSuccess(c, "Generation errors retrieved", run)
+}
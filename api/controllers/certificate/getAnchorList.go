@@ -1,11 +1,12 @@
 package certificate_controller
 
 import (
-	"encoding/json"
+	"errors"
 	"log/slog"
-	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
 	"github.com/sunthewhat/easy-cert-api/type/response"
 )
 
@@ -29,35 +30,63 @@ func (ctrl *CertificateController) GetAnchorList(c *fiber.Ctx) error {
 		return response.SendFailed(c, "Certificate not found")
 	}
 
-	// Parse the certificate design JSON
-	var design map[string]any
-	if err := json.Unmarshal([]byte(cert.Design), &design); err != nil {
-		slog.Error("Error parsing certificate design", "certId", certId, "error", err)
-		return response.SendInternalError(c, err)
+	// Anchors are stored when the design is saved; older certificates fall back to parsing the design
+	if cert.Anchors != nil {
+		return response.SendSuccess(c, "Anchor list retrieved successfully", cert.Anchors)
 	}
 
-	// Extract objects array
-	objects, ok := design["objects"].([]any)
-	if !ok {
+	anchorNames, err := certificatemodel.ExtractDesignAnchors(cert.Design)
+	if errors.Is(err, certificatemodel.ErrInvalidDesignFormat) {
 		slog.Warn("Invalid design format - objects array not found", "certId", certId)
 		return response.SendFailed(c, "Invalid certificate design format")
 	}
+	if err != nil {
+		slog.Error("Error parsing certificate design", "certId", certId, "error", err)
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Anchor list retrieved successfully", anchorNames)
+}
 
-	// Find all placeholder objects and extract anchor names
-	var anchorNames []string
-	for _, obj := range objects {
-		objMap, ok := obj.(map[string]any)
-		if !ok {
-			continue
-		}
+// RefreshAnchors re-extracts the anchor list from the certificate's design and stores it,
+// e.g. for certificates saved before anchors were stored
+func (ctrl *CertificateController) RefreshAnchors(c *fiber.Ctx) error {
+	certId := c.Params("certId")
 
-		id, exists := objMap["id"].(string)
-		if exists && strings.HasPrefix(id, "PLACEHOLDER-") {
-			// Extract the anchor name after "PLACEHOLDER-"
-			anchorName := strings.TrimPrefix(id, "PLACEHOLDER-")
-			anchorNames = append(anchorNames, anchorName)
-		}
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
 	}
 
-	return response.SendSuccess(c, "Anchor list retrieved successfully", anchorNames)
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate RefreshAnchors GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate RefreshAnchors UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request RefreshAnchors", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	anchors, err := certificatemodel.ExtractDesignAnchors(cert.Design)
+	if err != nil {
+		return response.SendFailed(c, "Invalid certificate design: "+err.Error())
+	}
+
+	if err := ctrl.certRepo.SetAnchors(certId, anchors); err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	slog.Info("Certificate RefreshAnchors successful", "cert_id", certId, "anchor_count", len(anchors))
+	return response.SendSuccess(c, "Anchor list refreshed", anchors)
 }
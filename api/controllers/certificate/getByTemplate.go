@@ -0,0 +1,33 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetByTemplate lists the user's certificates created from a template, so a design fix to the template
+// can be propagated to them. Certificates owned by other users are never included.
+func (ctrl *CertificateController) GetByTemplate(c *fiber.Ctx) error {
+	templateId := c.Params("templateId")
+
+	if templateId == "" {
+		return response.SendFailed(c, "Template ID is required")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate GetByTemplate UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	certificates, err := ctrl.certRepo.GetByUserAndTemplate(userId, templateId)
+	if err != nil {
+		slog.Error("Certificate GetByTemplate failed", "error", err, "template_id", templateId)
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Template certificates fetched", certificates)
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
 	"github.com/sunthewhat/easy-cert-api/type/response"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
 )
 
 func (ctrl *CertificateController) GetByUser(c *fiber.Ctx) error {
@@ -16,7 +18,18 @@ func (ctrl *CertificateController) GetByUser(c *fiber.Ctx) error {
 		return response.SendUnauthorized(c, "User token not found")
 	}
 
-	certificates, err := ctrl.certRepo.GetByUser(userId)
+	var certificates []*model.Certificate
+	var err error
+
+	// Optional ?status= narrows the list to awaiting_signatures, ready_to_distribute or distributed
+	status := c.Query("status")
+	if status == "" {
+		certificates, err = ctrl.certRepo.GetByUser(userId)
+	} else if certificatemodel.IsValidStatus(status) {
+		certificates, err = ctrl.certRepo.GetByUserAndStatus(userId, status)
+	} else {
+		return response.SendFailed(c, "Invalid status filter, expected one of: awaiting_signatures, ready_to_distribute, distributed")
+	}
 
 	if err != nil {
 		slog.Error("Certificate GetAll controller failed", "error", err)
@@ -0,0 +1,157 @@
+package certificate_controller
+
+import (
+	"errors"
+	"log/slog"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// anchorMismatch lists the anchors only one of two designs defines
+func anchorMismatch(targetAnchors, sourceAnchors []string) (missingInTarget []string, missingInSource []string) {
+	missingInTarget = uniqueSorted(stringSliceDifference(sourceAnchors, targetAnchors))
+	missingInSource = uniqueSorted(stringSliceDifference(targetAnchors, sourceAnchors))
+	return missingInTarget, missingInSource
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := []string{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// MergeFrom copies every participant of the source certificate into the target certificate under new IDs.
+// Both certificates must belong to the caller and their designs must define the same anchors.
+// With ?delete_source=true the source certificate is deleted once all participants were copied.
+func (ctrl *CertificateController) MergeFrom(c *fiber.Ctx) error {
+	targetId := c.Params("targetId")
+	sourceId := c.Params("sourceId")
+
+	if targetId == "" || sourceId == "" {
+		return response.SendFailed(c, "Target and source certificate IDs are required")
+	}
+
+	if targetId == sourceId {
+		return response.SendFailed(c, "Cannot merge a certificate into itself")
+	}
+
+	deleteSource := c.Query("delete_source") == "true"
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate MergeFrom UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	target, err := ctrl.certRepo.GetById(targetId)
+	if err != nil {
+		slog.Error("Certificate MergeFrom GetById target failed", "error", err, "cert_id", targetId)
+		return response.SendInternalError(c, err)
+	}
+	if target == nil {
+		return response.SendFailed(c, "Target certificate not found")
+	}
+
+	source, err := ctrl.certRepo.GetById(sourceId)
+	if err != nil {
+		slog.Error("Certificate MergeFrom GetById source failed", "error", err, "cert_id", sourceId)
+		return response.SendInternalError(c, err)
+	}
+	if source == nil {
+		return response.SendFailed(c, "Source certificate not found")
+	}
+
+	if userId != target.UserID || userId != source.UserID {
+		slog.Warn("Wrong Owner Request MergeFrom", "user", userId, "target-owner", target.UserID, "source-owner", source.UserID)
+		return response.SendUnauthorized(c, "User did not own both certificates")
+	}
+
+	targetAnchors, err := certificatemodel.ExtractDesignAnchors(target.Design)
+	if err != nil {
+		return response.SendFailed(c, "Invalid target certificate design: "+err.Error())
+	}
+	sourceAnchors, err := certificatemodel.ExtractDesignAnchors(source.Design)
+	if err != nil {
+		return response.SendFailed(c, "Invalid source certificate design: "+err.Error())
+	}
+
+	missingInTarget, missingInSource := anchorMismatch(targetAnchors, sourceAnchors)
+	if len(missingInTarget) > 0 || len(missingInSource) > 0 {
+		return response.SendValidationFailed(c, "Certificates do not share the same anchors", fiber.Map{
+			"missing_in_target": missingInTarget,
+			"missing_in_source": missingInSource,
+		})
+	}
+
+	participants, err := ctrl.participantRepo.GetCopyableParticipants(sourceId)
+	if err != nil {
+		slog.Error("Certificate MergeFrom reading source participants failed", "error", err, "cert_id", sourceId)
+		return response.SendInternalError(c, err)
+	}
+
+	if len(participants) == 0 {
+		return response.SendFailed(c, "Source certificate has no participants to merge")
+	}
+
+	result, err := ctrl.participantRepo.AddParticipants(targetId, participants)
+	if errors.Is(err, participantmodel.ErrParticipantLimitExceeded) {
+		return response.SendFailed(c, err.Error())
+	}
+	if errors.Is(err, participantmodel.ErrParticipantQuotaExceeded) {
+		return response.SendForbidden(c, err.Error())
+	}
+	if err != nil {
+		slog.Error("Certificate MergeFrom AddParticipants failed", "error", err, "target_id", targetId, "source_id", sourceId)
+		return response.SendInternalError(c, err)
+	}
+
+	sourceDeleted := false
+	if deleteSource {
+		if len(result.FailedPostgresIDs) > 0 {
+			slog.Warn("Certificate MergeFrom keeping source, some participants were not merged", "source_id", sourceId, "failed_count", len(result.FailedPostgresIDs))
+		} else if err := ctrl.deleteMergedSource(sourceId); err != nil {
+			slog.Error("Certificate MergeFrom deleting source failed", "error", err, "source_id", sourceId)
+		} else {
+			sourceDeleted = true
+		}
+	}
+
+	slog.Info("Certificate MergeFrom successful",
+		"target_id", targetId,
+		"source_id", sourceId,
+		"merged_count", len(result.CreatedIDs),
+		"failed_count", len(result.FailedPostgresIDs),
+		"source_deleted", sourceDeleted)
+
+	return response.SendSuccess(c, "Participants merged", fiber.Map{
+		"merged_count":    len(result.CreatedIDs),
+		"failed_count":    len(result.FailedPostgresIDs),
+		"failed_ids":      result.FailedPostgresIDs,
+		"participant_ids": result.CreatedIDs,
+		"source_deleted":  sourceDeleted,
+	})
+}
+
+// deleteMergedSource removes the source certificate of a merge along with its participants and signatures
+func (ctrl *CertificateController) deleteMergedSource(sourceId string) error {
+	if _, err := ctrl.participantRepo.DeleteByCertId(sourceId); err != nil {
+		return err
+	}
+	if _, err := ctrl.signatureRepo.DeleteSignaturesByCertificate(sourceId); err != nil {
+		return err
+	}
+	_, err := ctrl.certRepo.Delete(sourceId)
+	return err
+}
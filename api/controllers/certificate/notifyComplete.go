@@ -0,0 +1,79 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// NotifyComplete re-sends the "all signatures complete" email to the owner, optionally with a custom message
+// in the body. The certificate must have all of its signatures collected.
+func (ctrl *CertificateController) NotifyComplete(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	// The body is optional; without one the standard notification is sent
+	body := new(payload.NotifyCompletePayload)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(body); err != nil {
+			return response.SendFailed(c, "Invalid request body")
+		}
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate NotifyComplete GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate NotifyComplete UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request NotifyComplete", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	allComplete, err := ctrl.signatureRepo.AreAllSignaturesComplete(certId)
+	if err != nil {
+		slog.Error("Certificate NotifyComplete AreAllSignaturesComplete failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if !allComplete {
+		return response.SendFailed(c, "Certificate signatures are not complete")
+	}
+
+	ownerEmail, err := util.ResolveUserEmail(cert.UserID)
+	if err != nil {
+		slog.Error("Certificate NotifyComplete failed to resolve owner email", "error", err, "cert_id", certId, "owner", cert.UserID)
+		return response.SendError(c, "Failed to send completion notification")
+	}
+
+	if err := util.SendAllSignaturesCompleteMailWithNote(ownerEmail, cert.Name, cert.ID, "", cert.VerifyHost, body.Message); err != nil {
+		slog.Error("Certificate NotifyComplete failed to send notification", "error", err, "cert_id", certId, "owner", cert.UserID)
+		return response.SendError(c, "Failed to send completion notification")
+	}
+
+	slog.Info("Certificate NotifyComplete owner notified", "cert_id", certId, "owner", cert.UserID, "with_message", body.Message != "")
+	return response.SendSuccess(c, "Completion notification sent", nil)
+}
@@ -0,0 +1,65 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// SetPdfLayout overrides how the design is placed on PDFs generated from now on: the white page
+// margin in mm and the fit mode (fill, contain or cover). Omitted values fall back to the config defaults.
+func (ctrl *CertificateController) SetPdfLayout(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	body := new(payload.SetPdfLayoutPayload)
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate SetPdfLayout GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate SetPdfLayout UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request SetPdfLayout", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	if err := ctrl.certRepo.SetPdfLayout(certId, body.MarginMm, body.FitMode); err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	effective := renderer.ResolvePdfLayout(body.MarginMm, body.FitMode)
+	return response.SendSuccess(c, "PDF layout updated", map[string]any{
+		"pdf_margin_mm":       body.MarginMm,
+		"pdf_fit_mode":        body.FitMode,
+		"effective_margin_mm": effective.MarginMm,
+		"effective_fit_mode":  effective.FitMode,
+	})
+}
@@ -0,0 +1,27 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetPendingSignatures lists the user's certificates that are still waiting on signatures, with the number
+// of outstanding signatures each, longest waiting first
+func (ctrl *CertificateController) GetPendingSignatures(c *fiber.Ctx) error {
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate GetPendingSignatures UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	pending, err := ctrl.signatureRepo.GetPendingSignaturesByOwner(userId)
+	if err != nil {
+		slog.Error("Certificate GetPendingSignatures failed", "error", err, "user_id", userId)
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Certificates with pending signatures fetched", pending)
+}
@@ -0,0 +1,78 @@
+package certificate_controller
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// activeParticipants returns the non-revoked participants, whose QR codes still verify, for the renderer
+func activeParticipants(participants []*participantmodel.CombinedParticipant) []any {
+	active := make([]any, 0, len(participants))
+	for _, p := range participants {
+		if !p.IsRevoke {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// DownloadQRSheet returns a printable PDF grid of every non-revoked participant's verification QR code, for
+// handing out codes at a physical ceremony. Each code is labelled with the ?name_field= data field
+// (default "name"), falling back to the participant ID.
+func (ctrl *CertificateController) DownloadQRSheet(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	nameField := c.Query("name_field", renderer.DefaultQRSheetLabelField)
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate DownloadQRSheet GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate DownloadQRSheet UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request DownloadQRSheet", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	participants, err := ctrl.participantRepo.GetParticipantsByCertId(certId)
+	if err != nil {
+		slog.Error("Certificate DownloadQRSheet GetParticipantsByCertId failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	entries := renderer.ParticipantQRSheetEntries(activeParticipants(participants), cert.ID, util.CertificateVerifyHost(cert), nameField)
+
+	pdfBytes, err := renderer.BuildQRCodeSheet(cert.Name, entries)
+	if err != nil {
+		slog.Error("Certificate DownloadQRSheet failed to build PDF", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	slog.Info("Certificate DownloadQRSheet generated", "cert_id", certId, "qr_codes", len(entries))
+
+	c.Set("Content-Type", "application/pdf")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"qr_codes_%s.pdf\"", certId))
+	return c.Send(pdfBytes)
+}
@@ -0,0 +1,193 @@
+package certificate_controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// RegenerateQRCodes re-renders every already generated certificate so its embedded QR code points at
+// the certificate's current verify host. Distribution status is kept since only the QR link changes.
+func (ctrl *CertificateController) RegenerateQRCodes(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate RegenerateQRCodes GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate RegenerateQRCodes UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request RegenerateQRCodes", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	allParticipants, err := ctrl.participantRepo.GetParticipantsByCertId(certId)
+	if err != nil {
+		slog.Error("Certificate RegenerateQRCodes GetParticipantsByCertId failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	// Only certificates that were generated before carry a QR code that can be stale
+	var participants []*participantmodel.CombinedParticipant
+	for _, p := range allParticipants {
+		if p.CertificateURL != "" {
+			participants = append(participants, p)
+		}
+	}
+
+	if len(participants) == 0 {
+		return response.SendFailed(c, "Certificate has no generated certificates to regenerate")
+	}
+
+	if err := renderer.RenderingAvailable(); err != nil {
+		slog.Warn("Certificate RegenerateQRCodes rejected, rendering unavailable", "error", err, "cert_id", certId)
+		return response.SendServiceUnavailable(c, err.Error())
+	}
+
+	release, err := renderer.Generations().Acquire(c.Context(), certId)
+	if errors.Is(err, renderer.ErrGenerationInProgress) {
+		return response.SendFailed(c, "Certificate generation is already in progress")
+	}
+	if errors.Is(err, renderer.ErrGenerationQueueFull) {
+		return response.SendTooManyRequests(c, "Too many certificate generations in progress, please retry later", map[string]any{
+			"queue_position": renderer.Generations().QueueLength() + 1,
+		})
+	}
+	if err != nil {
+		slog.Error("Certificate RegenerateQRCodes waiting for generation slot failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+	defer release()
+
+	signatures, err := ctrl.signatureRepo.GetSignaturesByCertificate(certId)
+	if err != nil {
+		slog.Error("Certificate RegenerateQRCodes GetSignaturesByCertificate failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	verifyHost := util.CertificateVerifyHost(cert)
+	slog.Info("Certificate RegenerateQRCodes starting",
+		"cert_id", certId,
+		"participant_count", len(participants),
+		"verify_host", verifyHost)
+
+	embeddedRenderer, err := renderer.NewEmbeddedRenderer()
+	if err != nil {
+		slog.Error("Failed to initialize embedded renderer", "error", err, "cert_id", certId)
+		if renderer.IsRendererUnavailable(err) {
+			return response.SendServiceUnavailable(c, err.Error())
+		}
+		return response.SendError(c, "Failed to initialize renderer")
+	}
+	defer embeddedRenderer.Close()
+
+	ctx, cancel := renderer.NewGenerationContext(context.Background())
+	defer cancel()
+
+	participantInterfaces := make([]any, len(participants))
+	previousURLs := make(map[string]string, len(participants))
+	for i, p := range participants {
+		participantInterfaces[i] = p
+		previousURLs[p.ID] = p.CertificateURL
+	}
+
+	results, zipFilePath, err := embeddedRenderer.ProcessCertificates(ctx, util.RendererCertificateMap(cert), participantInterfaces, util.DecryptSignatureImages(signatures))
+	deadlineExceeded := errors.Is(err, renderer.ErrGenerationDeadlineExceeded) && len(results) > 0
+	if deadlineExceeded {
+		slog.Warn("Certificate RegenerateQRCodes stopped at the generation deadline", "error", err, "cert_id", certId)
+	} else if err != nil {
+		slog.Error("Certificate RegenerateQRCodes rendering failed", "error", err, "cert_id", certId)
+		return response.SendError(c, fmt.Sprintf("Renderer processing failed: %v", err))
+	}
+
+	var failedResults []map[string]string
+	var signedIds, unsignedIds []string
+	successCount := 0
+	for _, result := range results {
+		if result.Status != "success" || result.FilePath == "" {
+			failedResults = append(failedResults, map[string]string{
+				"participant_id": result.ParticipantID,
+				"error":          result.Error,
+			})
+			continue
+		}
+
+		certificateURL := util.GenerateProxyURL(*common.Config.BucketCertificate, result.FilePath)
+		if err := ctrl.participantRepo.UpdateParticipantCertificateUrl(result.ParticipantID, certificateURL); err != nil {
+			slog.Warn("Certificate RegenerateQRCodes failed to update participant certificate URL",
+				"error", err,
+				"participant_id", result.ParticipantID)
+			failedResults = append(failedResults, map[string]string{
+				"participant_id": result.ParticipantID,
+				"error":          err.Error(),
+			})
+			continue
+		}
+
+		if result.Signed {
+			signedIds = append(signedIds, result.ParticipantID)
+		} else {
+			unsignedIds = append(unsignedIds, result.ParticipantID)
+		}
+
+		if oldURL := previousURLs[result.ParticipantID]; oldURL != "" {
+			if err := util.DeleteFileByURL(context.Background(), *common.Config.BucketCertificate, oldURL); err != nil {
+				slog.Warn("Certificate RegenerateQRCodes failed to delete old certificate file",
+					"error", err,
+					"participant_id", result.ParticipantID,
+					"certificate_url", oldURL)
+			}
+		}
+		successCount++
+	}
+
+	recordPdfSigned(ctrl.participantRepo, certId, signedIds, unsignedIds)
+
+	if zipFilePath != "" {
+		if cert.ArchiveURL != "" {
+			if err := util.DeleteFileByURL(context.Background(), *common.Config.BucketCertificate, cert.ArchiveURL); err != nil {
+				slog.Warn("Certificate RegenerateQRCodes failed to delete old zip archive", "error", err, "cert_id", certId)
+			}
+		}
+		ctrl.certRepo.EditArchiveUrl(certId, util.GenerateProxyURL(*common.Config.BucketCertificate, zipFilePath))
+	}
+
+	slog.Info("Certificate RegenerateQRCodes completed",
+		"cert_id", certId,
+		"success_count", successCount,
+		"failed_count", len(failedResults))
+
+	return response.SendSuccess(c, "Certificate QR codes regenerated", map[string]any{
+		"verify_host":        verifyHost,
+		"total_participants": len(participants),
+		"success_count":      successCount,
+		"failed_count":       len(failedResults),
+		"failed_results":     failedResults,
+		"zipFilePath":        zipFilePath,
+		"deadline_exceeded":  deadlineExceeded,
+	})
+}
@@ -0,0 +1,98 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// RemindDownloads emails a reminder with their download link to every participant
+// who received the certificate but has not downloaded it yet
+func (ctrl *CertificateController) RemindDownloads(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+	emailField := c.Query("email", "email")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate RemindDownloads GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		slog.Warn("Certificate RemindDownloads certificate not found", "cert_id", certId)
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate RemindDownloads UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request RemindDownloads", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	participants, err := ctrl.participantRepo.GetNotDownloadedByCertId(certId)
+	if err != nil {
+		slog.Error("Certificate RemindDownloads GetNotDownloadedByCertId failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	var successResults []map[string]string
+	var failedResults []map[string]string
+
+	for _, participant := range participants {
+		participantInfo := map[string]string{
+			"participant_id": participant.ID,
+		}
+
+		email, ok := participant.DynamicData[emailField].(string)
+		if !ok || email == "" {
+			participantInfo["error"] = "Email field not found in participant data"
+			failedResults = append(failedResults, participantInfo)
+			slog.Warn("RemindDownloads email field missing",
+				"certId", certId,
+				"participantId", participant.ID,
+				"emailField", emailField)
+			continue
+		}
+
+		participantInfo["email"] = email
+
+		if err := util.SendDownloadReminderMail(email, cert.Name, util.BuildCertificateDownloadURL(participant.ID)); err != nil {
+			participantInfo["error"] = err.Error()
+			failedResults = append(failedResults, participantInfo)
+			slog.Error("RemindDownloads failed to send reminder",
+				"error", err,
+				"certId", certId,
+				"participantId", participant.ID,
+				"email", email)
+			continue
+		}
+
+		successResults = append(successResults, participantInfo)
+	}
+
+	slog.Info("Certificate RemindDownloads completed",
+		"cert_id", certId,
+		"total", len(participants),
+		"success_count", len(successResults),
+		"failed_count", len(failedResults))
+
+	return response.SendSuccess(c, "Download reminders sent", map[string]any{
+		"total_participants": len(participants),
+		"success_count":      len(successResults),
+		"failed_count":       len(failedResults),
+		"success_results":    successResults,
+		"failed_results":     failedResults,
+	})
+}
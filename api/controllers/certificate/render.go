@@ -2,11 +2,10 @@ package certificate_controller
 
 import (
 	"context"
-	"encoding/base64"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sunthewhat/easy-cert-api/api/middleware"
@@ -15,6 +14,7 @@ import (
 	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/internal/renderer"
 	"github.com/sunthewhat/easy-cert-api/type/response"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
 )
 
 func (ctrl *CertificateController) Render(c *fiber.Ctx) error {
@@ -58,6 +58,29 @@ func (ctrl *CertificateController) Render(c *fiber.Ctx) error {
 		return response.SendUnauthorized(c, "User did not own this certificate")
 	}
 
+	if err := renderer.RenderingAvailable(); err != nil {
+		slog.Warn("Certificate Render rejected, rendering unavailable", "error", err, "cert_id", certId)
+		return response.SendServiceUnavailable(c, err.Error())
+	}
+
+	// Wait for a generation slot so concurrent full-cohort runs don't swamp the renderer and MinIO
+	release, err := renderer.Generations().Acquire(c.Context(), certId)
+	if errors.Is(err, renderer.ErrGenerationInProgress) {
+		slog.Warn("Certificate Render already in progress", "cert_id", certId)
+		return response.SendFailed(c, "Certificate generation is already in progress")
+	}
+	if errors.Is(err, renderer.ErrGenerationQueueFull) {
+		slog.Warn("Certificate Render rejected, generation queue full", "cert_id", certId)
+		return response.SendTooManyRequests(c, "Too many certificate generations in progress, please retry later", map[string]any{
+			"queue_position": renderer.Generations().QueueLength() + 1,
+		})
+	}
+	if err != nil {
+		slog.Error("Certificate Render waiting for generation slot failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+	defer release()
+
 	// Get participants data
 	allParticipants, err := ctrl.participantRepo.GetParticipantsByCertId(certId)
 	if err != nil {
@@ -73,21 +96,7 @@ func (ctrl *CertificateController) Render(c *fiber.Ctx) error {
 	}
 
 	// Decrypt signature images and create a map of signerId -> base64 image
-	decryptedSignatures := make(map[string]string)
-	for _, sig := range signatures {
-		if sig.IsSigned && sig.Signature != "" {
-			decryptedImage, decryptErr := util.DecryptData(sig.Signature, *common.Config.EncryptionKey)
-			if decryptErr != nil {
-				slog.Warn("Certificate Render: Failed to decrypt signature",
-					"error", decryptErr,
-					"cert_id", certId,
-					"signer_id", sig.SignerID)
-				continue
-			}
-			// Convert to base64 for rendering
-			decryptedSignatures[sig.SignerID] = base64.StdEncoding.EncodeToString(decryptedImage)
-		}
-	}
+	decryptedSignatures := util.DecryptSignatureImages(signatures)
 
 	slog.Info("Certificate Render: Decrypted signatures",
 		"cert_id", certId,
@@ -196,12 +205,15 @@ func (ctrl *CertificateController) Render(c *fiber.Ctx) error {
 	embeddedRenderer, err := renderer.NewEmbeddedRenderer()
 	if err != nil {
 		slog.Error("Failed to initialize embedded renderer", "error", err, "cert_id", certId)
+		if renderer.IsRendererUnavailable(err) {
+			return response.SendServiceUnavailable(c, err.Error())
+		}
 		return response.SendError(c, "Failed to initialize renderer")
 	}
 	defer embeddedRenderer.Close()
 
-	// Create context with timeout (reduced from 5min to 2min for embedded renderer)
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	// The whole generation runs under one overall budget (generation_deadline_seconds)
+	ctx, cancel := renderer.NewGenerationContext(context.Background())
 	defer cancel()
 
 	// Convert participants to interface{} slice
@@ -220,18 +232,35 @@ func (ctrl *CertificateController) Render(c *fiber.Ctx) error {
 
 	// Convert certificate struct to map for renderer compatibility
 	certMap := map[string]any{
-		"id":     cert.ID,
-		"name":   cert.Name,
-		"design": cert.Design,
+		"id":          cert.ID,
+		"name":        cert.Name,
+		"design":      cert.Design,
+		"verify_host": util.CertificateVerifyHost(cert),
+		"pdf_footer":  cert.PdfFooter,
+
+		"archive_filename_template": cert.ArchiveFilenameTemplate,
+		"pdf_margin_mm":             cert.PdfMarginMm,
+		"pdf_fit_mode":              cert.PdfFitMode,
 		// Add other fields as needed
 	}
 
 	// Process certificates with embedded renderer, passing decrypted signatures
 	results, zipFilePath, err := embeddedRenderer.ProcessCertificates(ctx, certMap, participantInterfaces, decryptedSignatures)
-	if err != nil {
+	deadlineExceeded := errors.Is(err, renderer.ErrGenerationDeadlineExceeded) && len(results) > 0
+	if err != nil && !deadlineExceeded {
 		slog.Error("Embedded renderer processing failed", "error", err, "cert_id", certId)
+		ctrl.clearUnfinishedCertificates(cert, "", participants, results)
 		return response.SendError(c, fmt.Sprintf("Renderer processing failed: %v", err))
 	}
+	if deadlineExceeded {
+		// Keep the certificates finished within the budget; the rest can be generated on the next run
+		slog.Warn("Certificate Render stopped at the generation deadline",
+			"error", err,
+			"cert_id", certId,
+			"deadline", renderer.GenerationDeadline())
+	}
+	// The old files were deleted above, so nothing may keep pointing at them
+	notGeneratedIds := ctrl.clearUnfinishedCertificates(cert, zipFilePath, participants, results)
 
 	// Update certificate archive URL with proxy URL
 	if zipFilePath != "" {
@@ -242,8 +271,15 @@ func (ctrl *CertificateController) Render(c *fiber.Ctx) error {
 	}
 
 	// Update participant certificate URLs with proxy URLs
+	var signedIds, unsignedIds []string
 	for _, result := range results {
 		if result.Status == "success" && result.FilePath != "" {
+			if result.Signed {
+				signedIds = append(signedIds, result.ParticipantID)
+			} else {
+				unsignedIds = append(unsignedIds, result.ParticipantID)
+			}
+
 			// Use backend proxy URL instead of direct MinIO URL for security
 			certificateURL := util.GenerateProxyURL(*common.Config.BucketCertificate, result.FilePath)
 			err := ctrl.participantRepo.UpdateParticipantCertificateUrl(result.ParticipantID, certificateURL)
@@ -261,14 +297,18 @@ func (ctrl *CertificateController) Render(c *fiber.Ctx) error {
 		}
 	}
 
+	recordPdfSigned(ctrl.participantRepo, certId, signedIds, unsignedIds)
+
 	// Get updated participants data
 	updatedParticipants, err := ctrl.participantRepo.GetParticipantsByCertId(certId)
 	if err != nil {
 		slog.Error("Certificate Render failed to get updated participants", "error", err, "cert_id", certId)
 		// Fallback to results if getting updated participants fails
 		return response.SendSuccess(c, "Certificate rendered successfully", map[string]any{
-			"results":     results,
-			"zipFilePath": zipFilePath,
+			"results":           results,
+			"zipFilePath":       zipFilePath,
+			"deadline_exceeded": deadlineExceeded,
+			"not_generated":     notGeneratedIds,
 		})
 	}
 
@@ -277,9 +317,69 @@ func (ctrl *CertificateController) Render(c *fiber.Ctx) error {
 		"successful_renders", len(results),
 		"zip_file", zipFilePath)
 
+	message := "Certificate rendered successfully"
+	if deadlineExceeded {
+		message = fmt.Sprintf("Certificate generation stopped at the deadline, %d certificates were not generated and no archive was created", len(notGeneratedIds))
+	}
+
 	// Return updated participants with zipFilePath
-	return response.SendSuccess(c, "Certificate rendered successfully", map[string]any{
-		"participants": updatedParticipants,
-		"zipFilePath":  zipFilePath,
+	return response.SendSuccess(c, message, map[string]any{
+		"participants":      updatedParticipants,
+		"zipFilePath":       zipFilePath,
+		"results":           results,
+		"deadline_exceeded": deadlineExceeded,
+		"not_generated":     notGeneratedIds,
 	})
 }
+
+// clearUnfinishedCertificates clears the certificate URL of every participant without a new file, and the
+// archive URL when no new archive was created, since the old files were deleted before rendering.
+// They show as not generated until the next run. It returns the participants left without a certificate.
+func (ctrl *CertificateController) clearUnfinishedCertificates(cert *model.Certificate, zipFilePath string, participants []*participantmodel.CombinedParticipant, results []renderer.CertificateResult) []string {
+	if zipFilePath == "" && cert.ArchiveURL != "" {
+		if err := ctrl.certRepo.EditArchiveUrl(cert.ID, ""); err != nil {
+			slog.Warn("Certificate Render failed to clear the deleted archive URL", "error", err, "cert_id", cert.ID)
+		}
+	}
+
+	generated := make(map[string]bool, len(results))
+	for _, result := range results {
+		if result.Status == "success" && result.FilePath != "" {
+			generated[result.ParticipantID] = true
+		}
+	}
+
+	var unfinishedIds, clearIds []string
+	for _, p := range participants {
+		if generated[p.ID] {
+			continue
+		}
+		unfinishedIds = append(unfinishedIds, p.ID)
+		if p.CertificateURL != "" {
+			clearIds = append(clearIds, p.ID)
+		}
+	}
+	if err := ctrl.participantRepo.ClearParticipantCertificateUrls(clearIds); err != nil {
+		slog.Warn("Certificate Render failed to clear deleted certificate URLs", "error", err, "cert_id", cert.ID, "count", len(clearIds))
+	} else if len(clearIds) > 0 {
+		slog.Info("Certificate Render cleared certificate URLs of unfinished participants", "cert_id", cert.ID, "count", len(clearIds))
+	}
+	return unfinishedIds
+}
+
+// recordPdfSigned stores which freshly generated PDFs carry a digital signature. Failures are only logged
+// since the certificates themselves were generated successfully.
+func recordPdfSigned(participantRepo participantmodel.IParticipantRepository, certId string, signedIds []string, unsignedIds []string) {
+	if err := participantRepo.SetParticipantsPdfSigned(signedIds, true); err != nil {
+		slog.Warn("Failed to record signed certificate PDFs", "error", err, "cert_id", certId, "count", len(signedIds))
+	}
+	if err := participantRepo.SetParticipantsPdfSigned(unsignedIds, false); err != nil {
+		slog.Warn("Failed to record unsigned certificate PDFs", "error", err, "cert_id", certId, "count", len(unsignedIds))
+	}
+	if len(unsignedIds) > 0 {
+		slog.Warn("Certificates generated without a digital signature",
+			"cert_id", certId,
+			"unsigned_count", len(unsignedIds),
+			"signing", renderer.GetSigningCertificateInfo())
+	}
+}
@@ -0,0 +1,68 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// ResetStatus resets email and download tracking for every participant of a certificate
+// so the owner can re-distribute it. Requires ?confirm=true since tracking data is lost.
+func (ctrl *CertificateController) ResetStatus(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		slog.Warn("Certificate ResetStatus attempt with empty certificate ID")
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	if c.Query("confirm") != "true" {
+		slog.Warn("Certificate ResetStatus attempt without confirmation", "cert_id", certId)
+		return response.SendFailed(c, "Resetting statuses requires confirm=true")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate ResetStatus GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		slog.Warn("Certificate ResetStatus certificate not found", "cert_id", certId)
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate ResetStatus UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request ResetStatus", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	participants, err := ctrl.participantRepo.GetParticipantsByCertId(certId)
+	if err != nil {
+		slog.Error("Certificate ResetStatus GetParticipantsByCertId failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	participantIds := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		participantIds = append(participantIds, participant.ID)
+	}
+
+	if err := ctrl.participantRepo.ResetParticipantStatuses(participantIds); err != nil {
+		slog.Error("Certificate ResetStatus ResetParticipantStatuses failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	slog.Info("Certificate ResetStatus successful", "cert_id", certId, "reset_count", len(participantIds))
+	return response.SendSuccess(c, "Participant statuses reset", fiber.Map{
+		"reset_count": len(participantIds),
+	})
+}
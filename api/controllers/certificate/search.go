@@ -0,0 +1,132 @@
+package certificate_controller
+
+import (
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// Search result types
+const (
+	SearchResultCertificate = "certificate"
+	SearchResultParticipant = "participant"
+)
+
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+	maxSearchTermLength   = 100
+)
+
+type searchResult struct {
+	Type            string `json:"type"`
+	CertificateID   string `json:"certificate_id"`
+	CertificateName string `json:"certificate_name"`
+	ParticipantID   string `json:"participant_id,omitempty"`
+	Email           string `json:"email,omitempty"`
+	MatchedField    string `json:"matched_field,omitempty"`
+	MatchedValue    string `json:"matched_value,omitempty"`
+}
+
+// searchPageParam parses an optional positive integer query parameter no greater than max (0 = no maximum)
+func searchPageParam(c *fiber.Ctx, name string, fallback int, max int) (int, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return fallback, true
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 1 || (max > 0 && value > max) {
+		return 0, false
+	}
+	return value, true
+}
+
+// Search finds the user's certificates whose name contains ?q= and the participants of those certificates with
+// a field value containing it, newest certificate first. Certificate matches come before participant matches
+// and the combined list is paginated with ?page= and ?page_size=. Participant matches are capped at
+// participantmodel.MaxSearchMatches; truncated reports when more exist.
+func (ctrl *CertificateController) Search(c *fiber.Ctx) error {
+	term := strings.TrimSpace(c.Query("q"))
+	if term == "" {
+		return response.SendFailed(c, "Search query is required")
+	}
+	if len(term) > maxSearchTermLength {
+		return response.SendFailed(c, "Search query must be at most "+strconv.Itoa(maxSearchTermLength)+" characters")
+	}
+
+	page, ok := searchPageParam(c, "page", 1, 0)
+	if !ok {
+		return response.SendFailed(c, "page must be a positive number")
+	}
+	pageSize, ok := searchPageParam(c, "page_size", defaultSearchPageSize, maxSearchPageSize)
+	if !ok {
+		return response.SendFailed(c, "page_size must be between 1 and "+strconv.Itoa(maxSearchPageSize))
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate Search UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	certs, err := ctrl.certRepo.GetByUser(userId)
+	if err != nil {
+		slog.Error("Certificate Search GetByUser failed", "error", err, "user_id", userId)
+		return response.SendInternalError(c, err)
+	}
+
+	sort.SliceStable(certs, func(i, j int) bool { return certs[i].CreatedAt.After(certs[j].CreatedAt) })
+
+	lowerTerm := strings.ToLower(term)
+	results := []searchResult{}
+	certIds := make([]string, len(certs))
+	certNames := make(map[string]string, len(certs))
+	for i, cert := range certs {
+		certIds[i] = cert.ID
+		certNames[cert.ID] = cert.Name
+		if strings.Contains(strings.ToLower(cert.Name), lowerTerm) {
+			results = append(results, searchResult{
+				Type:            SearchResultCertificate,
+				CertificateID:   cert.ID,
+				CertificateName: cert.Name,
+			})
+		}
+	}
+
+	matches, truncated, err := ctrl.participantRepo.SearchParticipants(certIds, term, participantmodel.MaxSearchMatches)
+	if err != nil {
+		slog.Error("Certificate Search SearchParticipants failed", "error", err, "user_id", userId)
+		return response.SendInternalError(c, err)
+	}
+
+	for _, match := range matches {
+		results = append(results, searchResult{
+			Type:            SearchResultParticipant,
+			CertificateID:   match.CertificateID,
+			CertificateName: certNames[match.CertificateID],
+			ParticipantID:   match.ParticipantID,
+			Email:           match.Email,
+			MatchedField:    match.Field,
+			MatchedValue:    match.Value,
+		})
+	}
+
+	total := len(results)
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+
+	return response.SendSuccess(c, "Search results fetched", map[string]any{
+		"query":     term,
+		"page":      page,
+		"page_size": pageSize,
+		"total":     total,
+		"truncated": truncated,
+		"results":   results[start:end],
+	})
+}
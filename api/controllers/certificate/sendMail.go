@@ -1,9 +1,13 @@
 package certificate_controller
 
 import (
+	"fmt"
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
 	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/type/response"
 )
@@ -11,6 +15,7 @@ import (
 func (ctrl *CertificateController) DistributeByMail(c *fiber.Ctx) error {
 	certId := c.Params("certId")
 	emailField := c.Query("email")
+	tag := c.Query("tag")
 
 	if emailField == "" {
 		return response.SendFailed(c, "Missing email field")
@@ -33,19 +38,24 @@ func (ctrl *CertificateController) DistributeByMail(c *fiber.Ctx) error {
 		return response.SendInternalError(c, err)
 	}
 
+	// Optionally restrict distribution to a tagged sub-group
+	participants = participantmodel.FilterByTag(participants, tag)
+
 	var successResults []map[string]string
 	var failedResults []map[string]string
 	var skippedResults []map[string]string
 
-	for _, participant := range participants {
-		participantInfo := map[string]string{
-			"participant_id": participant.ID,
-		}
+	// Accumulate status results and write them in bulk once the distribution finishes
+	statusBatch := participantmodel.NewEmailStatusBatch()
 
+	for _, participant := range participants {
 		// Skip if email was already sent successfully
 		if participant.EmailStatus == "success" {
-			participantInfo["status"] = "skipped"
-			participantInfo["reason"] = "Email already sent successfully"
+			participantInfo := map[string]string{
+				"participant_id": participant.ID,
+				"status":         "skipped",
+				"reason":         "Email already sent successfully",
+			}
 			skippedResults = append(skippedResults, participantInfo)
 			slog.Info("Skipping participant - email already sent",
 				"certId", certId,
@@ -54,83 +64,25 @@ func (ctrl *CertificateController) DistributeByMail(c *fiber.Ctx) error {
 			continue
 		}
 
-		if participant.CertificateURL == "" {
-			participantInfo["error"] = "Certificate URL not found"
-			failedResults = append(failedResults, participantInfo)
-			slog.Error("Attempt to send mail without certificate url", "certId", certId, "participantId", participant.ID)
-			// Update email status to failed
-			ctrl.participantRepo.UpdateEmailStatus(participant.ID, "failed")
-			continue
-		}
-
-		// Extract email from DynamicData using the emailField parameter
-		emailValue, exists := participant.DynamicData[emailField]
-		if !exists {
-			participantInfo["error"] = "Email field not found in participant data"
-			failedResults = append(failedResults, participantInfo)
-			slog.Warn("Email field not found in participant data",
-				"certId", certId,
-				"participantId", participant.ID,
-				"emailField", emailField)
-			// Update email status to failed
-			ctrl.participantRepo.UpdateEmailStatus(participant.ID, "failed")
-			continue
-		}
-
-		// Convert to string
-		email, ok := emailValue.(string)
-		if !ok {
-			participantInfo["error"] = "Email field is not a string"
-			failedResults = append(failedResults, participantInfo)
-			slog.Warn("Email field is not a string",
-				"certId", certId,
-				"participantId", participant.ID,
-				"emailField", emailField,
-				"emailValue", emailValue)
-			// Update email status to failed
-			ctrl.participantRepo.UpdateEmailStatus(participant.ID, "failed")
-			continue
-		}
-
-		if email == "" {
-			participantInfo["error"] = "Empty email address"
+		participantInfo, sent := ctrl.mailParticipantCertificate(certId, participant, emailField)
+		if sent {
+			statusBatch.Add(participant.ID, "success")
+			successResults = append(successResults, participantInfo)
+		} else {
+			statusBatch.Add(participant.ID, "failed")
 			failedResults = append(failedResults, participantInfo)
-			slog.Warn("Empty email address",
-				"certId", certId,
-				"participantId", participant.ID)
-			// Update email status to failed
-			ctrl.participantRepo.UpdateEmailStatus(participant.ID, "failed")
-			continue
 		}
+	}
 
-		participantInfo["email"] = email
-
-		err := util.SendMail(email, participant.CertificateURL)
-		if err != nil {
-			participantInfo["error"] = err.Error()
-			failedResults = append(failedResults, participantInfo)
-			slog.Error("Failed to send mail to participant",
-				"error", err,
-				"certId", certId,
-				"participantId", participant.ID,
-				"email", email)
-			// Update email status to failed
-			ctrl.participantRepo.UpdateEmailStatus(participant.ID, "failed")
-		} else {
-			// Update email status to success
-			err := ctrl.participantRepo.UpdateEmailStatus(participant.ID, "success")
-			if err != nil {
-				slog.Warn("Failed to update email status to success",
-					"error", err,
-					"participantId", participant.ID)
-			}
+	if err := statusBatch.Flush(ctrl.participantRepo); err != nil {
+		slog.Warn("Failed to update email statuses after distribution",
+			"error", err,
+			"certId", certId)
+	}
 
-			successResults = append(successResults, participantInfo)
-			slog.Info("Mail sent successfully",
-				"certId", certId,
-				"participantId", participant.ID,
-				"email", email)
-		}
+	if len(successResults) > 0 {
+		ctrl.recordDistributionEmailField(certId, emailField)
+		ctrl.recordActivity(c, certId, certificatemodel.ActivityDistributed, fmt.Sprintf("%d sent, %d failed", len(successResults), len(failedResults)))
 	}
 
 	// Prepare response data
@@ -147,7 +99,74 @@ func (ctrl *CertificateController) DistributeByMail(c *fiber.Ctx) error {
 	return response.SendSuccess(c, "Mail distribution completed", responseData)
 }
 
-// ResendParticipantMail resends certificate email to a specific participant by their ID
+// mailParticipantCertificate emails a participant their generated certificate, reading the address from
+// the emailField of their data. It returns the participant's result entry and whether the mail was sent.
+// Delivery failures are queued for retry.
+func (ctrl *CertificateController) mailParticipantCertificate(certId string, participant *participantmodel.CombinedParticipant, emailField string) (map[string]string, bool) {
+	participantInfo := map[string]string{
+		"participant_id": participant.ID,
+	}
+
+	if participant.CertificateURL == "" {
+		participantInfo["error"] = "Certificate URL not found"
+		slog.Error("Attempt to send mail without certificate url", "certId", certId, "participantId", participant.ID)
+		return participantInfo, false
+	}
+
+	// Extract email from DynamicData using the emailField parameter
+	emailValue, exists := participant.DynamicData[emailField]
+	if !exists {
+		participantInfo["error"] = "Email field not found in participant data"
+		slog.Warn("Email field not found in participant data",
+			"certId", certId,
+			"participantId", participant.ID,
+			"emailField", emailField)
+		return participantInfo, false
+	}
+
+	// Convert to string
+	email, ok := emailValue.(string)
+	if !ok {
+		participantInfo["error"] = "Email field is not a string"
+		slog.Warn("Email field is not a string",
+			"certId", certId,
+			"participantId", participant.ID,
+			"emailField", emailField,
+			"emailValue", emailValue)
+		return participantInfo, false
+	}
+
+	if email == "" {
+		participantInfo["error"] = "Empty email address"
+		slog.Warn("Empty email address",
+			"certId", certId,
+			"participantId", participant.ID)
+		return participantInfo, false
+	}
+
+	participantInfo["email"] = email
+
+	if err := util.SendMail(email, participant.CertificateURL); err != nil {
+		participantInfo["error"] = err.Error()
+		slog.Error("Failed to send mail to participant",
+			"error", err,
+			"certId", certId,
+			"participantId", participant.ID,
+			"email", email)
+		ctrl.recordFailedEmail(certId, participant.ID, email, emailField, err)
+		return participantInfo, false
+	}
+
+	slog.Info("Mail sent successfully",
+		"certId", certId,
+		"participantId", participant.ID,
+		"email", email)
+	return participantInfo, true
+}
+
+// ResendParticipantMail resends certificate email to a specific participant by their ID, for a participant who
+// lost the original. The address is read from the ?email= data field (default "email") and the participant's
+// email status is updated with the outcome. Only the owner of the participant's certificate may resend.
 func (ctrl *CertificateController) ResendParticipantMail(c *fiber.Ctx) error {
 	participantId := c.Params("participantId")
 
@@ -155,6 +174,8 @@ func (ctrl *CertificateController) ResendParticipantMail(c *fiber.Ctx) error {
 		return response.SendFailed(c, "Participant ID is required")
 	}
 
+	emailField := c.Query("email", defaultDistributeEmailField)
+
 	// Get participant by ID
 	participant, err := ctrl.participantRepo.GetParticipantsById(participantId)
 	if err != nil {
@@ -167,6 +188,31 @@ func (ctrl *CertificateController) ResendParticipantMail(c *fiber.Ctx) error {
 		return response.SendFailed(c, "Participant not found")
 	}
 
+	cert, err := ctrl.certRepo.GetById(participant.CertificateID)
+	if err != nil {
+		slog.Error("Resend Participant Mail: Error getting certificate", "error", err, "participantId", participantId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Resend Participant Mail: UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request ResendParticipantMail", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	if participant.IsRevoke {
+		return response.SendFailed(c, "Participant certificate has been revoked")
+	}
+
 	// Check if certificate URL exists
 	if participant.CertificateURL == "" {
 		slog.Error("Resend Participant Mail: Certificate URL not found", "participantId", participantId)
@@ -175,10 +221,11 @@ func (ctrl *CertificateController) ResendParticipantMail(c *fiber.Ctx) error {
 	}
 
 	// Extract email from DynamicData using the emailField parameter
-	emailValue, exists := participant.DynamicData["email"]
+	emailValue, exists := participant.DynamicData[emailField]
 	if !exists {
 		slog.Warn("Resend Participant Mail: Email field not found in participant data",
-			"participantId", participantId)
+			"participantId", participantId,
+			"emailField", emailField)
 		ctrl.participantRepo.UpdateEmailStatus(participantId, "failed")
 		return response.SendFailed(c, "Email field not found in participant data")
 	}
@@ -207,6 +254,7 @@ func (ctrl *CertificateController) ResendParticipantMail(c *fiber.Ctx) error {
 			"participantId", participantId,
 			"email", email)
 		ctrl.participantRepo.UpdateEmailStatus(participantId, "failed")
+		ctrl.recordFailedEmail(participant.CertificateID, participantId, email, emailField, err)
 		return response.SendError(c, "Failed to send email: "+err.Error())
 	}
 
@@ -219,6 +267,9 @@ func (ctrl *CertificateController) ResendParticipantMail(c *fiber.Ctx) error {
 		// Don't fail the request - email was sent successfully
 	}
 
+	ctrl.recordDistributionEmailField(participant.CertificateID, emailField)
+	ctrl.recordActivity(c, participant.CertificateID, certificatemodel.ActivityDistributed, "resent to "+email)
+
 	slog.Info("Resend Participant Mail: Email sent successfully",
 		"participantId", participantId,
 		"email", email)
@@ -234,3 +285,19 @@ func (ctrl *CertificateController) ResendParticipantMail(c *fiber.Ctx) error {
 
 	return response.SendSuccess(c, "Email sent successfully", responseData)
 }
+
+// recordDistributionEmailField remembers which participant data field certificate mail was sent to, so bounce
+// notifications are matched on the same field. Failing to store it is only logged since the mail was sent.
+func (ctrl *CertificateController) recordDistributionEmailField(certId, emailField string) {
+	if err := ctrl.certRepo.SetDistributionEmailField(certId, emailField); err != nil {
+		slog.Warn("Failed to store distribution email field", "error", err, "certId", certId, "emailField", emailField)
+	}
+}
+
+// recordFailedEmail queues a participant certificate email that could not be sent for the retry job. Failing to
+// queue it is only logged since the send failure itself is already reported.
+func (ctrl *CertificateController) recordFailedEmail(certId, participantId, email, emailField string, sendErr error) {
+	if err := ctrl.certRepo.RecordFailedEmail(certId, certificatemodel.FailedEmailParticipantCertificate, participantId, email, emailField, sendErr); err != nil {
+		slog.Warn("Failed to queue failed email for retry", "error", err, "certId", certId, "participantId", participantId)
+	}
+}
@@ -0,0 +1,48 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetSignatureInfo returns the public metadata of the certificate used to sign generated PDFs
+// so recipients can compare it against the signature embedded in their PDF
+func (ctrl *CertificateController) GetSignatureInfo(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		slog.Warn("Certificate GetSignatureInfo attempt with empty certificate ID")
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate GetSignatureInfo GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		slog.Warn("Certificate GetSignatureInfo certificate not found", "cert_id", certId)
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	// Read what the signer loaded at startup or on the last generation instead of reloading the key here
+	info := renderer.GetSigningCertificateInfo()
+	if info.Configured && !info.Enabled {
+		slog.Error("Certificate GetSignatureInfo signer failed to load", "error", info.Error, "cert_id", certId)
+		return response.SendError(c, "Failed to load signing certificate")
+	}
+
+	details := info.Details
+	if details == nil {
+		return response.SendFailed(c, "PDF signing is not enabled")
+	}
+
+	return response.SendSuccess(c, "Signature info fetched", fiber.Map{
+		"certificate_id": certId,
+		"signer":         details,
+	})
+}
@@ -0,0 +1,99 @@
+package certificate_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// SetSigningOrder enables or disables sequential signing. When signer_ids is given the certificate's
+// signatures are reordered to follow it; signers left out keep their order after the listed ones.
+func (ctrl *CertificateController) SetSigningOrder(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	body := new(payload.SetSigningOrderPayload)
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate SetSigningOrder GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate SetSigningOrder UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request SetSigningOrder", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	if len(body.SignerIds) > 0 {
+		signatures, err := ctrl.signatureRepo.GetSignaturesByCertificate(certId)
+		if err != nil {
+			slog.Error("Certificate SetSigningOrder GetSignaturesByCertificate failed", "error", err, "cert_id", certId)
+			return response.SendInternalError(c, err)
+		}
+
+		known := make(map[string]bool, len(signatures))
+		for _, signature := range signatures {
+			known[signature.SignerID] = true
+		}
+		for _, signerId := range body.SignerIds {
+			if !known[signerId] {
+				return response.SendFailed(c, "Signer "+signerId+" is not a signer of this certificate")
+			}
+		}
+
+		if err := ctrl.signatureRepo.SetSignOrder(certId, body.SignerIds); err != nil {
+			return response.SendInternalError(c, err)
+		}
+	}
+
+	if err := ctrl.certRepo.SetSequentialSigning(certId, *body.Sequential); err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	signatures, err := ctrl.signatureRepo.GetSignaturesByCertificate(certId)
+	if err != nil {
+		slog.Error("Certificate SetSigningOrder reloading signatures failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	order := make([]map[string]any, 0, len(signatures))
+	for _, signature := range signaturemodel.SortBySignOrder(signatures) {
+		order = append(order, map[string]any{
+			"signer_id":  signature.SignerID,
+			"sign_order": signature.SignOrder,
+			"is_signed":  signature.IsSigned,
+		})
+	}
+
+	return response.SendSuccess(c, "Signing order updated", map[string]any{
+		"sequential_signing": *body.Sequential,
+		"signing_order":      order,
+	})
+}
@@ -0,0 +1,123 @@
+package certificate_controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/storage"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// Storage usage categories reported by GetStorageUsage
+const (
+	storageCategoryPDFs       = "pdfs"
+	storageCategoryThumbnails = "thumbnails"
+	storageCategoryPreviews   = "previews"
+	storageCategoryArchive    = "archive"
+	storageCategoryOther      = "other"
+)
+
+type storageCategoryUsage struct {
+	Bytes   int64 `json:"bytes"`
+	Objects int   `json:"objects"`
+}
+
+// storageCategory classifies an object key by the naming the renderer uploads artifacts with
+func storageCategory(key string) string {
+	name := key[strings.LastIndex(key, "/")+1:]
+	switch {
+	case strings.HasPrefix(key, "previews/"):
+		return storageCategoryPreviews
+	case strings.HasPrefix(name, "thumbnail_"):
+		return storageCategoryThumbnails
+	case strings.HasSuffix(name, ".zip"):
+		return storageCategoryArchive
+	case strings.HasSuffix(name, ".pdf"):
+		return storageCategoryPDFs
+	default:
+		return storageCategoryOther
+	}
+}
+
+// GetStorageUsage sums the size of every MinIO object belonging to a certificate, broken down into generated
+// PDFs, thumbnails, previews and the ZIP archive, so owners can see their storage footprint
+func (ctrl *CertificateController) GetStorageUsage(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate GetStorageUsage GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate GetStorageUsage UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request GetStorageUsage", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	// Thumbnails live in the preview bucket, which may be the certificate bucket itself
+	certificateBucket := *common.Config.BucketCertificate
+	previewBucket := storage.PreviewBucket()
+	prefixes := map[string][]string{
+		certificateBucket: {certId + "/"},
+	}
+	if previewBucket != certificateBucket {
+		prefixes[previewBucket] = append(prefixes[previewBucket], certId+"/")
+	}
+	prefixes[previewBucket] = append(prefixes[previewBucket], fmt.Sprintf("previews/%s/", certId))
+
+	breakdown := map[string]*storageCategoryUsage{
+		storageCategoryPDFs:       {},
+		storageCategoryThumbnails: {},
+		storageCategoryPreviews:   {},
+		storageCategoryArchive:    {},
+		storageCategoryOther:      {},
+	}
+	var totalBytes int64
+	totalObjects := 0
+
+	for bucket, bucketPrefixes := range prefixes {
+		for _, prefix := range bucketPrefixes {
+			objects, err := util.ListObjectsByPrefix(context.Background(), bucket, prefix)
+			if err != nil {
+				slog.Error("Certificate GetStorageUsage list objects failed", "error", err, "cert_id", certId, "bucket", bucket, "prefix", prefix)
+				return response.SendInternalError(c, err)
+			}
+
+			for _, object := range objects {
+				usage := breakdown[storageCategory(object.Key)]
+				usage.Bytes += object.Size
+				usage.Objects++
+				totalBytes += object.Size
+				totalObjects++
+			}
+		}
+	}
+
+	return response.SendSuccess(c, "Certificate storage usage fetched", map[string]any{
+		"certificate_id": certId,
+		"total_bytes":    totalBytes,
+		"total_objects":  totalObjects,
+		"breakdown":      breakdown,
+	})
+}
@@ -0,0 +1,71 @@
+package certificate_controller
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetThumbnail returns only the certificate's thumbnail URL for gallery views, rendering the thumbnail
+// on demand when none has been stored yet
+func (ctrl *CertificateController) GetThumbnail(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate GetThumbnail GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendNotFound(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate GetThumbnail UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request GetThumbnail", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	generated := false
+	if cert.ThumbnailURL == "" {
+		if strings.TrimSpace(cert.Design) == "" {
+			return response.SendNotFound(c, "Certificate has no design to render a thumbnail from")
+		}
+
+		if err := util.RenderCertificateThumbnail(cert); err != nil {
+			slog.Error("Certificate GetThumbnail on-demand rendering failed", "error", err, "cert_id", certId)
+			if renderer.IsRendererUnavailable(err) {
+				return response.SendServiceUnavailable(c, err.Error())
+			}
+			return response.SendInternalError(c, err)
+		}
+
+		cert, err = ctrl.certRepo.GetById(certId)
+		if err != nil || cert == nil {
+			slog.Error("Certificate GetThumbnail reload failed", "error", err, "cert_id", certId)
+			return response.SendError(c, "Failed to load generated thumbnail")
+		}
+		generated = true
+	}
+
+	return response.SendSuccess(c, "Certificate thumbnail fetched", fiber.Map{
+		"certificate_id": cert.ID,
+		"thumbnail_url":  cert.ThumbnailURL,
+		"generated":      generated,
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
 	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/type/payload"
 	"github.com/sunthewhat/easy-cert-api/type/response"
@@ -63,6 +64,18 @@ func stringSliceDifference(a, b []string) []string {
 	return diff
 }
 
+// updatedFieldsDetail describes which certificate fields an update changed, for the activity log
+func updatedFieldsDetail(body *payload.UpdateCertificatePayload) string {
+	switch {
+	case body.Name != "" && body.Design != "":
+		return "name and design"
+	case body.Name != "":
+		return "name"
+	default:
+		return "design"
+	}
+}
+
 func (ctrl *CertificateController) Update(c *fiber.Ctx) error {
 	// Get certificate ID from URL parameter
 	id := c.Params("id")
@@ -83,7 +96,7 @@ func (ctrl *CertificateController) Update(c *fiber.Ctx) error {
 	// Validate request body using validator
 	if err := util.ValidateStruct(body); err != nil {
 		errors := util.GetValidationErrors(err)
-		return response.SendFailed(c, errors[0])
+		return response.SendValidationFailed(c, errors[0], util.GetFieldValidationErrors(err))
 	}
 
 	// Validate at least one field is provided for update
@@ -91,8 +104,23 @@ func (ctrl *CertificateController) Update(c *fiber.Ctx) error {
 		return response.SendFailed(c, "At least one field (name or design) must be provided")
 	}
 
+	if err := validateDesignAnchorNames(body.Design); err != nil {
+		return response.SendFailed(c, "Invalid certificate design: "+err.Error())
+	}
+
+	// Autosaves only refresh the thumbnail when enabled and the design actually changed, so look up the stored design first
+	refreshOnAutoSave := isAutoSave && body.Design != "" && util.ThumbnailOnDesignChange()
+	previousDesign := ""
+	if refreshOnAutoSave {
+		if existing, getErr := ctrl.certRepo.GetById(id); getErr != nil {
+			slog.Warn("Certificate Update: Failed to get stored design for thumbnail refresh", "error", getErr, "cert_id", id)
+		} else if existing != nil {
+			previousDesign = existing.Design
+		}
+	}
+
 	// Update certificate
-	updatedCert, updateErr := ctrl.certRepo.Update(id, body.Name, body.Design)
+	updatedCert, updateErr := ctrl.certRepo.Update(id, body.Name, body.Design, isAutoSave)
 	if updateErr != nil {
 		if updateErr.Error() == "certificate not found" {
 			slog.Warn("Certificate Update attempt with non-existent ID", "cert_id", id)
@@ -154,7 +182,7 @@ func (ctrl *CertificateController) Update(c *fiber.Ctx) error {
 						}
 
 						// Send signature request emails for newly added signatures
-						emailErr := util.BulkSendSignatureRequests(id, updatedCert.Name, addedSignerIds)
+						emailErr := util.BulkSendSignatureRequests(id, updatedCert.Name, updatedCert.VerifyHost, addedSignerIds)
 						if emailErr != nil {
 							slog.Warn("Certificate Update: Failed to send signature request emails", "error", emailErr, "cert_id", id)
 						}
@@ -184,7 +212,10 @@ func (ctrl *CertificateController) Update(c *fiber.Ctx) error {
 							slog.Warn("Certificate Update: Failed to mark certificate as signed", "error", markErr, "cert_id", id)
 						}
 
-						notifyErr := util.SendAllSignaturesCompleteMail(updatedCert.UserID, updatedCert.Name, updatedCert.ID, "")
+						ownerEmail, notifyErr := util.ResolveUserEmail(updatedCert.UserID)
+						if notifyErr == nil {
+							notifyErr = util.SendAllSignaturesCompleteMail(ownerEmail, updatedCert.Name, updatedCert.ID, "", updatedCert.VerifyHost)
+						}
 						if notifyErr != nil {
 							slog.Warn("Certificate Update: Failed to send completion notification", "error", notifyErr, "cert_id", id, "owner", updatedCert.UserID)
 						} else {
@@ -209,8 +240,12 @@ func (ctrl *CertificateController) Update(c *fiber.Ctx) error {
 	slog.Info("Certificate Update successful", "cert_id", id, "cert_name", updatedCert.Name)
 
 	if !isAutoSave {
+		ctrl.recordActivity(c, id, certificatemodel.ActivityEdited, updatedFieldsDetail(body))
+
 		// Start thumbnail rendering in background - don't block the response
 		util.RenderCertificateThumbnailAsync(updatedCert)
+	} else if refreshOnAutoSave && updatedCert.Design != previousDesign {
+		util.RenderCertificateThumbnailAsync(updatedCert)
 	}
 
 	return response.SendSuccess(c, "Certificate updated successfully", updatedCert)
@@ -0,0 +1,110 @@
+package certificate_controller
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+const defaultReportNameField = "name"
+
+// DownloadVerificationReport returns an archival PDF listing every participant with their verification URL
+// and revoke status, together with the certificate's signers and their signing status. The participant
+// label is read from the ?name_field= data field (default "name"), falling back to the participant ID.
+// ?include_qr=true appends a printable sheet of the non-revoked participants' QR codes.
+func (ctrl *CertificateController) DownloadVerificationReport(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	nameField := c.Query("name_field", defaultReportNameField)
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate DownloadVerificationReport GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate DownloadVerificationReport UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request DownloadVerificationReport", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	participants, err := ctrl.participantRepo.GetParticipantsByCertId(certId)
+	if err != nil {
+		slog.Error("Certificate DownloadVerificationReport GetParticipantsByCertId failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	signers, err := ctrl.signatureRepo.GetSignerStatusesByCertificate(certId)
+	if err != nil {
+		slog.Error("Certificate DownloadVerificationReport GetSignerStatusesByCertificate failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	verifyHost := util.CertificateVerifyHost(cert)
+	report := &renderer.VerificationReport{
+		CertificateID:   cert.ID,
+		CertificateName: cert.Name,
+		GeneratedAt:     time.Now(),
+		Signers:         make([]renderer.VerificationReportSigner, 0, len(signers)),
+		Participants:    make([]renderer.VerificationReportParticipant, 0, len(participants)),
+	}
+
+	for _, s := range signers {
+		report.Signers = append(report.Signers, renderer.VerificationReportSigner{
+			Name:      s.DisplayName,
+			Email:     s.Email,
+			SignOrder: s.SignOrder,
+			Signed:    s.IsSigned,
+		})
+	}
+
+	for _, p := range participants {
+		name := ""
+		if value, ok := p.DynamicData[nameField]; ok && value != nil {
+			name = fmt.Sprint(value)
+		}
+		report.Participants = append(report.Participants, renderer.VerificationReportParticipant{
+			ID:              p.ID,
+			Name:            name,
+			VerificationURL: renderer.VerificationURL(verifyHost, p.ID),
+			Revoked:         p.IsRevoke,
+			Generated:       p.CertificateURL != "",
+		})
+	}
+
+	if c.QueryBool("include_qr") {
+		report.QRCodes = renderer.ParticipantQRSheetEntries(activeParticipants(participants), cert.ID, verifyHost, nameField)
+	}
+
+	pdfBytes, err := renderer.BuildVerificationReport(report)
+	if err != nil {
+		slog.Error("Certificate DownloadVerificationReport failed to build PDF", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	slog.Info("Certificate DownloadVerificationReport generated", "cert_id", certId, "participants", len(participants), "signers", len(signers))
+
+	c.Set("Content-Type", "application/pdf")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"verification_report_%s.pdf\"", certId))
+	return c.Send(pdfBytes)
+}
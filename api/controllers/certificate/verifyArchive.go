@@ -0,0 +1,99 @@
+package certificate_controller
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// VerifyArchive checks that the stored ZIP archive opens and holds a readable PDF for every
+// non-revoked participant with a generated certificate, without regenerating anything
+func (ctrl *CertificateController) VerifyArchive(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate VerifyArchive GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate VerifyArchive UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request VerifyArchive", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	if cert.ArchiveURL == "" {
+		return response.SendFailed(c, "Certificate archive not available")
+	}
+
+	participants, err := ctrl.participantRepo.GetParticipantsByCertId(certId)
+	if err != nil {
+		slog.Error("Certificate VerifyArchive GetParticipantsByCertId failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	var expectedParticipants []any
+	for _, p := range participants {
+		if !p.IsRevoke && p.CertificateURL != "" {
+			expectedParticipants = append(expectedParticipants, p)
+		}
+	}
+	expected := renderer.ArchiveEntryNames(cert.ArchiveFilenameTemplate, expectedParticipants)
+
+	objectPath, err := util.ExtractObjectNameFromURL(cert.ArchiveURL, *common.Config.BucketCertificate)
+	if err != nil {
+		slog.Error("Certificate VerifyArchive invalid archive URL", "error", err, "cert_id", certId, "archive_url", cert.ArchiveURL)
+		return response.SendError(c, "Invalid archive URL")
+	}
+
+	object, err := util.DownloadFile(context.Background(), *common.Config.BucketCertificate, objectPath)
+	if err != nil {
+		slog.Error("Certificate VerifyArchive download failed", "error", err, "cert_id", certId, "object_path", objectPath)
+		return response.SendError(c, "Archive file not found")
+	}
+	defer object.Close()
+
+	objectInfo, err := object.Stat()
+	if err != nil {
+		slog.Warn("Certificate VerifyArchive archive missing from storage", "error", err, "cert_id", certId, "object_path", objectPath)
+		return response.SendFailed(c, "Archive file not found in storage")
+	}
+
+	verification, err := renderer.VerifyArchive(object, objectInfo.Size, expected)
+	if err != nil {
+		slog.Warn("Certificate VerifyArchive archive is corrupt", "error", err, "cert_id", certId, "object_path", objectPath)
+		return response.SendSuccess(c, "Archive is corrupt", map[string]any{
+			"valid":          false,
+			"expected_count": len(expected),
+			"error":          err.Error(),
+		})
+	}
+
+	slog.Info("Certificate VerifyArchive completed",
+		"cert_id", certId,
+		"valid", verification.Valid,
+		"missing_count", len(verification.Missing),
+		"unreadable_count", len(verification.Unreadable))
+
+	return response.SendSuccess(c, "Archive verified", verification)
+}
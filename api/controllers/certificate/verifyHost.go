@@ -0,0 +1,64 @@
+package certificate_controller
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// SetVerifyHost overrides the verification domain used in this certificate's QR codes and signing links
+func (ctrl *CertificateController) SetVerifyHost(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	body := new(payload.SetVerifyHostPayload)
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	cert, err := ctrl.certRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Certificate SetVerifyHost GetById failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Certificate SetVerifyHost UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request SetVerifyHost", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	verifyHost := strings.TrimRight(strings.TrimSpace(body.VerifyHost), "/")
+	if err := ctrl.certRepo.SetVerifyHost(certId, verifyHost); err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	slog.Info("Certificate verify host updated", "cert_id", certId, "verify_host", verifyHost)
+
+	return response.SendSuccess(c, "Verify host updated", map[string]any{
+		"verify_host":           verifyHost,
+		"effective_verify_host": util.ResolveVerifyHost(verifyHost),
+	})
+}
@@ -0,0 +1,17 @@
+package dashboard_controller
+
+import (
+	dashboardmodel "github.com/sunthewhat/easy-cert-api/api/model/dashboardModel"
+)
+
+// DashboardController handles dashboard-related HTTP requests
+type DashboardController struct {
+	dashboardRepo dashboardmodel.IDashboardRepository
+}
+
+// NewDashboardController creates a new dashboard controller with injected dependencies
+func NewDashboardController(dashboardRepo dashboardmodel.IDashboardRepository) *DashboardController {
+	return &DashboardController{
+		dashboardRepo: dashboardRepo,
+	}
+}
@@ -0,0 +1,207 @@
+package dashboard_controller_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	dashboard_controller "github.com/sunthewhat/easy-cert-api/api/controllers/dashboard"
+	dashboardmodel "github.com/sunthewhat/easy-cert-api/api/model/dashboardModel"
+)
+
+func TestDashboardController_GetSummary(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupContext   func(c *fiber.Ctx)
+		setupMock      func() *dashboardmodel.MockDashboardRepository
+		wantStatusCode int
+		checkResponse  func(t *testing.T, body []byte)
+	}{
+		{
+			name: "successful summary",
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "owner@example.com")
+			},
+			setupMock: func() *dashboardmodel.MockDashboardRepository {
+				mock := dashboardmodel.NewMockDashboardRepository()
+				mock.GetSummaryFunc = func(userId string) (*dashboardmodel.DashboardSummary, error) {
+					if userId != "owner@example.com" {
+						t.Errorf("Expected summary for owner@example.com, got %s", userId)
+					}
+					return &dashboardmodel.DashboardSummary{
+						TotalCertificates:         4,
+						UndistributedCertificates: 1,
+						TotalParticipants:         120,
+						PendingSignatures:         3,
+					}, nil
+				}
+				return mock
+			},
+			wantStatusCode: fiber.StatusOK,
+			checkResponse: func(t *testing.T, body []byte) {
+				var response map[string]any
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				data, ok := response["data"].(map[string]any)
+				if !ok {
+					t.Fatal("Expected data to be an object")
+				}
+				if data["total_certificates"] != float64(4) {
+					t.Errorf("Expected total_certificates=4, got %v", data["total_certificates"])
+				}
+				if data["undistributed_certificates"] != float64(1) {
+					t.Errorf("Expected undistributed_certificates=1, got %v", data["undistributed_certificates"])
+				}
+				if data["total_participants"] != float64(120) {
+					t.Errorf("Expected total_participants=120, got %v", data["total_participants"])
+				}
+				if data["pending_signatures"] != float64(3) {
+					t.Errorf("Expected pending_signatures=3, got %v", data["pending_signatures"])
+				}
+			},
+		},
+		{
+			name: "failed - no user in context",
+			setupMock: func() *dashboardmodel.MockDashboardRepository {
+				return dashboardmodel.NewMockDashboardRepository()
+			},
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name: "failed - repository error",
+			setupContext: func(c *fiber.Ctx) {
+				c.Locals("user_id", "owner@example.com")
+			},
+			setupMock: func() *dashboardmodel.MockDashboardRepository {
+				mock := dashboardmodel.NewMockDashboardRepository()
+				mock.GetSummaryFunc = func(userId string) (*dashboardmodel.DashboardSummary, error) {
+					return nil, errors.New("database error")
+				}
+				return mock
+			},
+			wantStatusCode: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			ctrl := dashboard_controller.NewDashboardController(tt.setupMock())
+
+			app.Get("/dashboard/summary", func(c *fiber.Ctx) error {
+				if tt.setupContext != nil {
+					tt.setupContext(c)
+				}
+				return ctrl.GetSummary(c)
+			})
+
+			req := httptest.NewRequest("GET", "/dashboard/summary", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, body)
+			}
+		})
+	}
+}
+
+func TestDashboardController_GetTrends(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		repoErr         error
+		wantStatusCode  int
+		wantGranularity string
+	}{
+		{
+			name:            "defaults to day",
+			wantStatusCode:  fiber.StatusOK,
+			wantGranularity: "day",
+		},
+		{
+			name:            "week granularity",
+			query:           "?granularity=week",
+			wantStatusCode:  fiber.StatusOK,
+			wantGranularity: "week",
+		},
+		{
+			name:           "failed - unknown granularity",
+			query:          "?granularity=year",
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:            "failed - repository error",
+			query:           "?granularity=month",
+			repoErr:         errors.New("database error"),
+			wantStatusCode:  fiber.StatusInternalServerError,
+			wantGranularity: "month",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var gotGranularity string
+			mock := dashboardmodel.NewMockDashboardRepository()
+			mock.GetCreationTrendsFunc = func(userId string, granularity string) ([]dashboardmodel.TrendBucket, error) {
+				gotGranularity = granularity
+				if tt.repoErr != nil {
+					return nil, tt.repoErr
+				}
+				return []dashboardmodel.TrendBucket{
+					{Period: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Count: 2},
+				}, nil
+			}
+			ctrl := dashboard_controller.NewDashboardController(mock)
+
+			app.Get("/dashboard/trends", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "owner@example.com")
+				return ctrl.GetTrends(c)
+			})
+
+			req := httptest.NewRequest("GET", "/dashboard/trends"+tt.query, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if gotGranularity != tt.wantGranularity {
+				t.Errorf("Expected granularity %q, got %q", tt.wantGranularity, gotGranularity)
+			}
+
+			if resp.StatusCode == fiber.StatusOK {
+				var response map[string]any
+				body, _ := io.ReadAll(resp.Body)
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				data := response["data"].(map[string]any)
+				buckets := data["buckets"].([]any)
+				if len(buckets) != 1 || buckets[0].(map[string]any)["count"] != float64(2) {
+					t.Errorf("Unexpected buckets %v", buckets)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package dashboard_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetSummary returns aggregate counts across all certificates owned by the user
+func (ctrl *DashboardController) GetSummary(c *fiber.Ctx) error {
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Dashboard GetSummary UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	summary, err := ctrl.dashboardRepo.GetSummary(userId)
+	if err != nil {
+		slog.Error("Dashboard GetSummary failed", "error", err, "user_id", userId)
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Dashboard summary fetched", summary)
+}
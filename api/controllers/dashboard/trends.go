@@ -0,0 +1,35 @@
+package dashboard_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	dashboardmodel "github.com/sunthewhat/easy-cert-api/api/model/dashboardModel"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetTrends returns how many certificates the user created per day, week or month (?granularity=, default day)
+func (ctrl *DashboardController) GetTrends(c *fiber.Ctx) error {
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Dashboard GetTrends UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	granularity := c.Query("granularity", dashboardmodel.TrendGranularityDay)
+	if !dashboardmodel.IsValidTrendGranularity(granularity) {
+		return response.SendFailed(c, "Granularity must be one of day, week or month")
+	}
+
+	buckets, err := ctrl.dashboardRepo.GetCreationTrends(userId, granularity)
+	if err != nil {
+		slog.Error("Dashboard GetTrends failed", "error", err, "user_id", userId, "granularity", granularity)
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Dashboard trends fetched", map[string]any{
+		"granularity": granularity,
+		"buckets":     buckets,
+	})
+}
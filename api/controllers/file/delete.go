@@ -14,7 +14,7 @@ import (
 func DeleteResource(c *fiber.Ctx) error {
 	resourceType := c.Params("type")
 
-	if resourceType != "background" && resourceType != "graphic" {
+	if !isResourceType(resourceType) {
 		return response.SendFailed(c, "Invalid resource type")
 	}
 
@@ -8,7 +8,7 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/storage"
 	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/type/response"
 )
@@ -25,10 +25,7 @@ func DownloadFile(c *fiber.Ctx) error {
 	}
 
 	// Validate bucket - only allow specific buckets
-	validBuckets := map[string]bool{
-		*common.Config.BucketCertificate: true,
-		*common.Config.BucketResource:    true,
-	}
+	validBuckets := storage.PublicBuckets()
 
 	if !validBuckets[bucket] {
 		slog.Warn("File download attempt with invalid bucket", "bucket", bucket)
@@ -69,7 +66,13 @@ func DownloadFile(c *fiber.Ctx) error {
 	// Set response headers
 	c.Set("Content-Type", contentType)
 	c.Set("Content-Length", fmt.Sprintf("%d", objectInfo.Size))
-	c.Set("Content-Disposition", "inline") // Display in browser instead of forcing download
+	if contentType == "image/svg+xml" {
+		// SVG can carry scripts, so never render it as a page of this origin
+		c.Set("Content-Disposition", "attachment")
+		c.Set("Content-Security-Policy", "sandbox")
+	} else {
+		c.Set("Content-Disposition", "inline") // Display in browser instead of forcing download
+	}
 
 	// Stream the file to the response
 	_, err = io.Copy(c.Response().BodyWriter(), object)
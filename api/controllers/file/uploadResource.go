@@ -2,7 +2,9 @@ package file
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"path/filepath"
 	"time"
 
@@ -14,6 +16,14 @@ import (
 	"github.com/sunthewhat/easy-cert-api/type/response"
 )
 
+// assetResourceType is the resource type design assets uploaded through UploadAsset are stored under
+const assetResourceType = "asset"
+
+// isResourceType reports whether resourceType is one of the resource folders users may manage
+func isResourceType(resourceType string) bool {
+	return resourceType == "background" || resourceType == "graphic" || resourceType == assetResourceType
+}
+
 func UploadResource(c *fiber.Ctx) error {
 	resourceType := c.Params("type")
 
@@ -21,6 +31,16 @@ func UploadResource(c *fiber.Ctx) error {
 		return response.SendFailed(c, "Invalid resource type")
 	}
 
+	return uploadResourceFile(c, resourceType)
+}
+
+// UploadAsset uploads a design asset. Only whitelisted image types (allowed_asset_types) are accepted,
+// detected from the file bytes; anything else is rejected with 415.
+func UploadAsset(c *fiber.Ctx) error {
+	return uploadResourceFile(c, assetResourceType)
+}
+
+func uploadResourceFile(c *fiber.Ctx, resourceType string) error {
 	// Get user ID from context (set by AuthMiddleware)
 	userId, ok := middleware.GetUserFromContext(c)
 	if !ok {
@@ -37,6 +57,16 @@ func UploadResource(c *fiber.Ctx) error {
 		return response.SendFailed(c, fmt.Sprintf("File size too large (%dMB out off 15MB)", file.Size/(1024*1024)))
 	}
 
+	// Trust the file bytes rather than the client supplied Content-Type
+	contentType, err := util.DetectAssetContentType(file)
+	if errors.Is(err, util.ErrAssetTypeNotAllowed) {
+		slog.Warn("File UploadResource rejected content type", "user", userId, "type", resourceType, "content_type", contentType)
+		return response.SendUnsupportedMediaType(c, fmt.Sprintf("Unsupported file type %s", contentType))
+	}
+	if err != nil {
+		return response.SendInternalError(c, err)
+	}
+
 	ext := filepath.Ext(file.Filename)
 	uniqueID := uuid.New().String()
 	timeStamp := time.Now().Unix()
@@ -46,7 +76,7 @@ func UploadResource(c *fiber.Ctx) error {
 
 	ctx := context.Background()
 
-	fileURL, err := util.UploadFile(ctx, *common.Config.BucketResource, objName, file)
+	fileURL, err := util.UploadFileWithContentType(ctx, *common.Config.BucketResource, objName, file, contentType)
 
 	if err != nil {
 		return response.SendInternalError(c, err)
@@ -60,9 +90,10 @@ func UploadResource(c *fiber.Ctx) error {
 	}
 
 	return response.SendSuccess(c, "Resource Upload Successfully", fiber.Map{
-		"filename":    file.Filename,
-		"object_name": objName,
-		"url":         proxyURL,
-		"size":        file.Size,
+		"filename":     file.Filename,
+		"object_name":  objName,
+		"url":          proxyURL,
+		"size":         file.Size,
+		"content_type": contentType,
 	})
 }
@@ -1,10 +1,12 @@
 package participant_controller
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
 	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/type/payload"
 	"github.com/sunthewhat/easy-cert-api/type/response"
@@ -43,6 +45,15 @@ func (ctrl *ParticipantController) Add(c *fiber.Ctx) error {
 		return response.SendFailed(c, errors[0])
 	}
 
+	// Normalize emails so distribution and dedup compare consistently; invalid rows are skipped and reported
+	participants, invalidRows := normalizeParticipantEmails(body.Participants)
+	if len(invalidRows) > 0 {
+		slog.Warn("Participant Add skipped rows with invalid emails", "cert_id", certId, "invalid_count", len(invalidRows))
+	}
+	if len(participants) == 0 {
+		return response.SendFailed(c, "No participants with a valid email to add")
+	}
+
 	// Note: Field validation against certificate design anchors is now handled in the model layer
 
 	// Check if collection already exists and has documents
@@ -54,19 +65,29 @@ func (ctrl *ParticipantController) Add(c *fiber.Ctx) error {
 
 	// Log collection status
 	if count > 0 {
-		slog.Info("Participant Add found existing collection", "cert_id", certId, "existing_count", count, "new_count", len(body.Participants))
+		slog.Info("Participant Add found existing collection", "cert_id", certId, "existing_count", count, "new_count", len(participants))
 	} else {
-		slog.Info("Participant Add creating new collection", "cert_id", certId, "participant_count", len(body.Participants))
+		slog.Info("Participant Add creating new collection", "cert_id", certId, "participant_count", len(participants))
+	}
+
+	if body.Upsert {
+		return ctrl.upsertParticipants(c, certId, participants, invalidRows)
 	}
 
 	// Add participants using model function
-	result, addErr := ctrl.participantRepo.AddParticipants(certId, body.Participants)
+	result, addErr := ctrl.participantRepo.AddParticipants(certId, participants)
+	if errors.Is(addErr, participantmodel.ErrParticipantLimitExceeded) {
+		return response.SendFailed(c, addErr.Error())
+	}
+	if errors.Is(addErr, participantmodel.ErrParticipantQuotaExceeded) {
+		return response.SendForbidden(c, addErr.Error())
+	}
 	if addErr != nil {
 		slog.Error("Participant Add failed", "error", addErr, "cert_id", certId)
 		return response.SendInternalError(c, addErr)
 	}
 
-	collectionName := "participant-" + certId
+	collectionName := participantmodel.ParticipantCollectionName(certId)
 	totalParticipants := count + int64(len(result.CreatedIDs))
 
 	slog.Info("Participant Add controller successful",
@@ -97,6 +118,11 @@ func (ctrl *ParticipantController) Add(c *fiber.Ctx) error {
 		},
 	}
 
+	if len(invalidRows) > 0 {
+		responseData["invalid_count"] = len(invalidRows)
+		responseData["invalid_rows"] = invalidRows
+	}
+
 	// Add warning info if there were PostgreSQL failures
 	if len(result.FailedPostgresIDs) > 0 {
 		responseData["warnings"] = []string{
@@ -112,3 +138,56 @@ func (ctrl *ParticipantController) Add(c *fiber.Ctx) error {
 
 	return response.SendSuccess(c, message, responseData)
 }
+
+// upsertParticipants imports participants keyed on email, updating existing ones instead of duplicating them
+func (ctrl *ParticipantController) upsertParticipants(c *fiber.Ctx, certId string, participants []map[string]any, invalidRows []InvalidParticipantRow) error {
+	result, err := ctrl.participantRepo.UpsertParticipantsByEmail(certId, participants)
+	if errors.Is(err, participantmodel.ErrParticipantLimitExceeded) {
+		return response.SendFailed(c, err.Error())
+	}
+	if errors.Is(err, participantmodel.ErrParticipantQuotaExceeded) {
+		return response.SendForbidden(c, err.Error())
+	}
+	if err != nil {
+		slog.Error("Participant Add upsert failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	createdIds := []string{}
+	failedPostgresIds := []string{}
+	if result.Created != nil {
+		createdIds = result.Created.CreatedIDs
+		failedPostgresIds = result.Created.FailedPostgresIDs
+	}
+
+	slog.Info("Participant Add upsert successful",
+		"cert_id", certId,
+		"requested_count", len(participants),
+		"inserted_count", len(createdIds),
+		"updated_count", len(result.UpdatedIDs),
+		"skipped_update_count", len(result.SkippedUpdates))
+
+	responseData := fiber.Map{
+		"certificate_id":  certId,
+		"requested_count": len(participants),
+		"inserted_count":  len(createdIds),
+		"updated_count":   len(result.UpdatedIDs),
+		"created_ids":     createdIds,
+		"updated_ids":     result.UpdatedIDs,
+		"skipped_updates": result.SkippedUpdates,
+	}
+
+	if len(invalidRows) > 0 {
+		responseData["invalid_count"] = len(invalidRows)
+		responseData["invalid_rows"] = invalidRows
+	}
+
+	if len(failedPostgresIds) > 0 {
+		responseData["warnings"] = []string{
+			fmt.Sprintf("%d participants were created in MongoDB but failed in PostgreSQL indexing", len(failedPostgresIds)),
+		}
+		responseData["failed_postgres_ids"] = failedPostgresIds
+	}
+
+	return response.SendSuccess(c, "Participants imported successfully", responseData)
+}
@@ -7,14 +7,14 @@ import (
 
 // ParticipantController handles participant-related HTTP requests
 type ParticipantController struct {
-	participantRepo *participantmodel.ParticipantRepository
-	certificateRepo *certificatemodel.CertificateRepository
+	participantRepo participantmodel.IParticipantRepository
+	certificateRepo certificatemodel.ICertificateRepository
 }
 
 // NewParticipantController creates a new participant controller with injected dependencies
 func NewParticipantController(
-	participantRepo *participantmodel.ParticipantRepository,
-	certificateRepo *certificatemodel.CertificateRepository,
+	participantRepo participantmodel.IParticipantRepository,
+	certificateRepo certificatemodel.ICertificateRepository,
 ) *ParticipantController {
 	return &ParticipantController{
 		participantRepo: participantRepo,
@@ -0,0 +1,102 @@
+package participant_controller
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// CopyFrom seeds a certificate's participants with the participant data of another certificate owned by
+// the same user. The copied participants get new IDs and start with fresh generation and email statuses.
+func (ctrl *ParticipantController) CopyFrom(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+	sourceId := c.Params("sourceId")
+
+	if certId == "" || sourceId == "" {
+		return response.SendFailed(c, "Target and source certificate IDs are required")
+	}
+
+	if certId == sourceId {
+		return response.SendFailed(c, "Cannot copy participants into the same certificate")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Participant CopyFrom UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	target, err := ctrl.certificateRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Participant CopyFrom target certificate lookup failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+	if target == nil {
+		return response.SendFailed(c, "Target certificate not found")
+	}
+
+	source, err := ctrl.certificateRepo.GetById(sourceId)
+	if err != nil {
+		slog.Error("Participant CopyFrom source certificate lookup failed", "error", err, "cert_id", sourceId)
+		return response.SendInternalError(c, err)
+	}
+	if source == nil {
+		return response.SendFailed(c, "Source certificate not found")
+	}
+
+	if userId != target.UserID || userId != source.UserID {
+		slog.Warn("Wrong Owner Request Participant CopyFrom", "user", userId, "target-owner", target.UserID, "source-owner", source.UserID)
+		return response.SendUnauthorized(c, "User did not own both certificates")
+	}
+
+	participants, err := ctrl.participantRepo.GetCopyableParticipants(sourceId)
+	if err != nil {
+		slog.Error("Participant CopyFrom reading source participants failed", "error", err, "source_id", sourceId)
+		return response.SendInternalError(c, err)
+	}
+
+	if len(participants) == 0 {
+		return response.SendFailed(c, "Source certificate has no participants to copy")
+	}
+
+	missingAnchors, err := ctrl.participantRepo.MissingDesignAnchors(target.Design, participants)
+	if err != nil {
+		return response.SendFailed(c, "Invalid target certificate design: "+err.Error())
+	}
+	if len(missingAnchors) > 0 {
+		slog.Warn("Participant CopyFrom anchor mismatch", "cert_id", certId, "source_id", sourceId, "missing_anchors", missingAnchors)
+		return response.SendValidationFailed(c, "Source participants do not provide every anchor of the target design", fiber.Map{
+			"missing_anchors": missingAnchors,
+		})
+	}
+
+	result, err := ctrl.participantRepo.AddParticipants(certId, participants)
+	if errors.Is(err, participantmodel.ErrParticipantLimitExceeded) {
+		return response.SendFailed(c, err.Error())
+	}
+	if errors.Is(err, participantmodel.ErrParticipantQuotaExceeded) {
+		return response.SendForbidden(c, err.Error())
+	}
+	if err != nil {
+		slog.Error("Participant CopyFrom AddParticipants failed", "error", err, "cert_id", certId, "source_id", sourceId)
+		return response.SendInternalError(c, err)
+	}
+
+	slog.Info("Participant CopyFrom successful",
+		"cert_id", certId,
+		"source_id", sourceId,
+		"copied_count", len(result.CreatedIDs),
+		"failed_count", len(result.FailedPostgresIDs))
+
+	return response.SendSuccess(c, "Participants copied", fiber.Map{
+		"certificate_id":      certId,
+		"source_id":           sourceId,
+		"copied_count":        len(result.CreatedIDs),
+		"created_ids":         result.CreatedIDs,
+		"failed_postgres_ids": result.FailedPostgresIDs,
+	})
+}
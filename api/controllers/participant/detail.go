@@ -0,0 +1,58 @@
+package participant_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// participantDetail is a participant's record together with the owner-only generation metadata
+type participantDetail struct {
+	*participantmodel.CombinedParticipant
+	Generation *GenerationMetadata `json:"generation"`
+}
+
+// GetDetail returns a participant's full record for the certificate owner: dynamic data, email, download and
+// revoke status, the generated certificate URL and the outcome of the last generation run
+func (ctrl *ParticipantController) GetDetail(c *fiber.Ctx) error {
+	participantId := c.Params("participantId")
+
+	if participantId == "" {
+		return response.SendFailed(c, "Participant ID is required")
+	}
+
+	participant, err := ctrl.participantRepo.GetParticipantsById(participantId)
+	if err != nil {
+		slog.Warn("Participant GetDetail lookup failed", "error", err, "participant_id", participantId)
+		return response.SendNotFound(c, "Participant not found")
+	}
+
+	cert, err := ctrl.certificateRepo.GetById(participant.CertificateID)
+	if err != nil {
+		slog.Error("Participant GetDetail certificate lookup failed", "error", err, "cert_id", participant.CertificateID)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendNotFound(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Participant GetDetail UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request Participant GetDetail", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	return response.SendSuccess(c, "Participant detail fetched", participantDetail{
+		CombinedParticipant: participant,
+		Generation:          generationMetadata(participant),
+	})
+}
@@ -0,0 +1,95 @@
+package participant_controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// DownloadCertificate renders a participant's certificate on demand and returns it as a signed PDF
+// (default) or, with ?format=png, as the rendered image for sharing on social media
+func (ctrl *ParticipantController) DownloadCertificate(c *fiber.Ctx) error {
+	participantId := c.Params("participantId")
+
+	if participantId == "" {
+		return response.SendFailed(c, "Participant ID is required")
+	}
+
+	format := strings.ToLower(c.Query("format", renderer.CertificateFormatPDF))
+	if !renderer.IsValidCertificateFormat(format) {
+		return response.SendFailed(c, fmt.Sprintf("Invalid format %q, expected pdf or png", format))
+	}
+
+	participant, err := ctrl.participantRepo.GetParticipantsById(participantId)
+	if err != nil {
+		slog.Warn("DownloadCertificate participant lookup failed", "error", err, "participant_id", participantId)
+		return response.SendFailed(c, "Participant not found")
+	}
+
+	if participant.IsRevoke {
+		return response.SendFailed(c, "Participant has been revoked")
+	}
+
+	cert, err := ctrl.certificateRepo.GetById(participant.CertificateID)
+	if err != nil {
+		slog.Error("DownloadCertificate certificate lookup failed", "error", err, "cert_id", participant.CertificateID)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("DownloadCertificate UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request DownloadCertificate", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	// On-demand renders share the generation slots with full runs so bursts of downloads can't spawn unbounded renderers
+	release, err := renderer.Generations().Acquire(c.Context(), "download:"+participantId)
+	if errors.Is(err, renderer.ErrGenerationInProgress) {
+		slog.Warn("DownloadCertificate render already in progress", "participant_id", participantId)
+		return response.SendFailed(c, "Certificate is already being rendered")
+	}
+	if errors.Is(err, renderer.ErrGenerationQueueFull) {
+		slog.Warn("DownloadCertificate rejected, generation queue full", "participant_id", participantId)
+		return response.SendTooManyRequests(c, "Too many certificate generations in progress, please retry later", map[string]any{
+			"queue_position": renderer.Generations().QueueLength() + 1,
+		})
+	}
+	if err != nil {
+		slog.Error("DownloadCertificate waiting for generation slot failed", "error", err, "participant_id", participantId)
+		return response.SendInternalError(c, err)
+	}
+	defer release()
+
+	file, err := util.RenderParticipantCertificateFile(cert, participant, format)
+	if err != nil {
+		slog.Error("DownloadCertificate rendering failed", "error", err, "participant_id", participantId, "format", format)
+		if renderer.IsRendererUnavailable(err) {
+			return response.SendServiceUnavailable(c, err.Error())
+		}
+		return response.SendError(c, fmt.Sprintf("Failed to render certificate: %v", err))
+	}
+
+	filename := renderer.ParticipantFilename(cert.ArchiveFilenameTemplate, participant, format)
+
+	slog.Info("DownloadCertificate rendered", "participant_id", participantId, "format", format, "size", len(file))
+
+	c.Set("Content-Type", renderer.CertificateFormatContentType(format))
+	c.Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	return c.Send(file)
+}
@@ -1,10 +1,12 @@
 package participant_controller
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
 	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/type/response"
 )
@@ -27,7 +29,15 @@ func (ctrl *ParticipantController) EditByID(c *fiber.Ctx) error {
 		return response.SendFailed(c, fmt.Sprintf("Invalid Data type %s", util.GetValidationErrors(err)[0]))
 	}
 
-	updatedParticipant, err := ctrl.participantRepo.EditParticipantByID(participantId, payload.Data)
+	// regenerate=true re-renders an already generated certificate right after the edit;
+	// otherwise the participant is flagged stale until the certificate is regenerated
+	regenerate := c.QueryBool("regenerate")
+
+	updatedParticipant, err := ctrl.participantRepo.EditParticipantByID(participantId, payload.Data, regenerate)
+	if errors.Is(err, participantmodel.ErrParticipantNotEditable) {
+		slog.Warn("EditParticipant: Participant not editable", "error", err, "participant_id", participantId)
+		return response.SendFailed(c, err.Error())
+	}
 	if err != nil {
 		slog.Error("EditParticipant: Failed to update participant", "error", err, "participant_id", participantId)
 		return response.SendInternalError(c, err)
@@ -35,6 +45,46 @@ func (ctrl *ParticipantController) EditByID(c *fiber.Ctx) error {
 
 	slog.Info("EditParticipant: Successfully updated participant", "participant_id", participantId)
 
+	if regenerate && updatedParticipant.CertificateURL != "" {
+		return ctrl.regenerateAfterEdit(c, updatedParticipant)
+	}
+
 	return response.SendSuccess(c, "Participant updated successfully", updatedParticipant)
 }
 
+// regenerateAfterEdit regenerates the participant's certificate so the PDF and QR match the edited data.
+// The edit itself has already succeeded, so a failed regeneration marks the participant stale instead of failing.
+func (ctrl *ParticipantController) regenerateAfterEdit(c *fiber.Ctx, edited *participantmodel.CombinedParticipant) error {
+	cert, err := ctrl.certificateRepo.GetById(edited.CertificateID)
+	if err == nil && cert == nil {
+		err = fmt.Errorf("certificate not found")
+	}
+
+	var participant *participantmodel.CombinedParticipant
+	if err == nil {
+		participant, err = ctrl.participantRepo.GetParticipantsById(edited.ID)
+	}
+
+	if err == nil {
+		_, err = util.RegenerateParticipantCertificate(cert, participant)
+	}
+
+	if err != nil {
+		slog.Error("EditParticipant: Regeneration after edit failed", "error", err, "participant_id", edited.ID)
+		if markErr := ctrl.participantRepo.MarkParticipantStale(edited.ID); markErr != nil {
+			slog.Warn("EditParticipant: Failed to mark participant stale", "error", markErr, "participant_id", edited.ID)
+		}
+		edited.IsStale = true
+		return response.SendSuccess(c, "Participant updated but certificate regeneration failed", edited)
+	}
+
+	refreshed, err := ctrl.participantRepo.GetParticipantsById(edited.ID)
+	if err != nil {
+		slog.Warn("EditParticipant: Failed to reload participant after regeneration", "error", err, "participant_id", edited.ID)
+		refreshed = participant
+	}
+
+	slog.Info("EditParticipant: Certificate regenerated after edit", "participant_id", edited.ID)
+	return response.SendSuccess(c, "Participant updated and certificate regenerated", refreshed)
+}
+
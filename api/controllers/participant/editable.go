@@ -0,0 +1,25 @@
+package participant_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetEditable reports whether a participant's data can currently be edited, and why not
+func (ctrl *ParticipantController) GetEditable(c *fiber.Ctx) error {
+	participantId := c.Params("participantId")
+
+	if participantId == "" {
+		return response.SendFailed(c, "Participant ID is required")
+	}
+
+	check, err := ctrl.participantRepo.GetEditability(participantId)
+	if err != nil {
+		slog.Warn("GetEditable: Failed to check participant", "error", err, "participant_id", participantId)
+		return response.SendFailed(c, "Participant not found")
+	}
+
+	return response.SendSuccess(c, "Participant editability fetched", check)
+}
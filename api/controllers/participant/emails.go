@@ -0,0 +1,49 @@
+package participant_controller
+
+import (
+	"fmt"
+
+	"github.com/sunthewhat/easy-cert-api/common/util"
+)
+
+// participantEmailField is the participant data field used for distribution and dedup-by-email
+const participantEmailField = "email"
+
+// InvalidParticipantRow reports an imported row that was skipped because of an invalid email
+type InvalidParticipantRow struct {
+	Row   int    `json:"row"`
+	Email any    `json:"email"`
+	Error string `json:"error"`
+}
+
+// normalizeParticipantEmails trims and lowercases the email field of each row in place and splits
+// out rows whose email is not valid. Rows without an email field are kept unchanged.
+func normalizeParticipantEmails(participants []map[string]any) ([]map[string]any, []InvalidParticipantRow) {
+	valid := make([]map[string]any, 0, len(participants))
+	invalid := []InvalidParticipantRow{}
+
+	for i, participant := range participants {
+		raw, exists := participant[participantEmailField]
+		if !exists {
+			valid = append(valid, participant)
+			continue
+		}
+
+		email, ok := raw.(string)
+		if !ok {
+			invalid = append(invalid, InvalidParticipantRow{Row: i + 1, Email: raw, Error: "email must be a string"})
+			continue
+		}
+
+		normalized := util.NormalizeEmail(email)
+		if err := util.ValidateEmail(normalized); err != nil {
+			invalid = append(invalid, InvalidParticipantRow{Row: i + 1, Email: email, Error: fmt.Sprintf("%q is not a valid email", email)})
+			continue
+		}
+
+		participant[participantEmailField] = normalized
+		valid = append(valid, participant)
+	}
+
+	return valid, invalid
+}
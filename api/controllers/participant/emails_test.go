@@ -0,0 +1,29 @@
+package participant_controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeParticipantEmails(t *testing.T) {
+	participants := []map[string]any{
+		{"name": "Alice", "email": "  Alice@Example.COM "},
+		{"name": "Bob", "email": "bob.example.com"},
+		{"name": "Carol"},
+		{"name": "Dave", "email": 42},
+		{"name": "Eve", "email": ""},
+	}
+
+	valid, invalid := normalizeParticipantEmails(participants)
+
+	assert.Len(t, valid, 2)
+	assert.Equal(t, "alice@example.com", valid[0]["email"])
+	assert.Equal(t, "Carol", valid[1]["name"])
+
+	assert.Len(t, invalid, 3)
+	assert.Equal(t, 2, invalid[0].Row)
+	assert.Equal(t, "bob.example.com", invalid[0].Email)
+	assert.Equal(t, 4, invalid[1].Row)
+	assert.Equal(t, 5, invalid[2].Row)
+}
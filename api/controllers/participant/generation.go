@@ -0,0 +1,48 @@
+package participant_controller
+
+import (
+	"time"
+
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+)
+
+// Generation states of a participant's certificate
+const (
+	GenerationStatusPending   = "pending"
+	GenerationStatusGenerated = "generated"
+	GenerationStatusStale     = "stale"
+	GenerationStatusFailed    = "failed"
+)
+
+// GenerationMetadata describes the state of a participant's generated certificate
+type GenerationMetadata struct {
+	Status    string     `json:"status"`
+	Error     string     `json:"error,omitempty"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+}
+
+// generationMetadata derives a participant's generation state from their record and the certificate's
+// last generation run. A failure in the last run takes precedence since the previous file was replaced.
+func generationMetadata(participant *participantmodel.CombinedParticipant) *GenerationMetadata {
+	metadata := &GenerationMetadata{Status: GenerationStatusPending}
+
+	run, failure := renderer.ParticipantFailure(participant.CertificateID, participant.ID)
+	if run != nil {
+		metadata.LastRunAt = &run.FinishedAt
+	}
+
+	switch {
+	case failure != nil:
+		metadata.Status = GenerationStatusFailed
+		metadata.Error = failure.Error
+	case participant.CertificateURL == "":
+		metadata.Status = GenerationStatusPending
+	case participant.IsStale:
+		metadata.Status = GenerationStatusStale
+	default:
+		metadata.Status = GenerationStatusGenerated
+	}
+
+	return metadata
+}
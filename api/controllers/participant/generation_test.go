@@ -0,0 +1,44 @@
+package participant_controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+)
+
+func TestGenerationMetadata(t *testing.T) {
+	certId := "cert-generation-metadata"
+	renderer.RecordGenerationRun(certId, time.Now(), 2, []renderer.CertificateResult{
+		{ParticipantID: "p-ok", Status: "success", FilePath: "cert/p-ok.pdf"},
+		{ParticipantID: "p-failed", Status: "error", Error: "render timeout"},
+	}, nil)
+
+	failed := generationMetadata(&participantmodel.CombinedParticipant{ID: "p-failed", CertificateID: certId, CertificateURL: "old-url"})
+	assert.Equal(t, GenerationStatusFailed, failed.Status)
+	assert.Equal(t, "render timeout", failed.Error)
+	assert.NotNil(t, failed.LastRunAt)
+
+	generated := generationMetadata(&participantmodel.CombinedParticipant{ID: "p-ok", CertificateID: certId, CertificateURL: "url"})
+	assert.Equal(t, GenerationStatusGenerated, generated.Status)
+	assert.Empty(t, generated.Error)
+
+	stale := generationMetadata(&participantmodel.CombinedParticipant{ID: "p-ok", CertificateID: certId, CertificateURL: "url", IsStale: true})
+	assert.Equal(t, GenerationStatusStale, stale.Status)
+
+	pending := generationMetadata(&participantmodel.CombinedParticipant{ID: "p-new", CertificateID: "cert-never-generated"})
+	assert.Equal(t, GenerationStatusPending, pending.Status)
+	assert.Nil(t, pending.LastRunAt)
+}
+
+func TestGenerationMetadata_AbortedRun(t *testing.T) {
+	certId := "cert-generation-aborted"
+	renderer.RecordGenerationRun(certId, time.Now(), 1, nil, errors.New("renderer unavailable"))
+
+	metadata := generationMetadata(&participantmodel.CombinedParticipant{ID: "p-1", CertificateID: certId})
+	assert.Equal(t, GenerationStatusPending, metadata.Status)
+	assert.NotNil(t, metadata.LastRunAt)
+}
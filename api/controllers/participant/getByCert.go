@@ -10,6 +10,7 @@ import (
 
 func (ctrl *ParticipantController) GetByCert(c *fiber.Ctx) error {
 	certId := c.Params("certId")
+	tag := c.Query("tag")
 
 	if certId == "" {
 		slog.Warn("Request get Participant with empty certificate ID")
@@ -35,6 +36,8 @@ func (ctrl *ParticipantController) GetByCert(c *fiber.Ctx) error {
 		return response.SendInternalError(c, err)
 	}
 
+	participants = participantmodel.FilterByTag(participants, tag)
+
 	// Initialize empty slice to avoid returning null
 	if participants == nil {
 		participants = make([]*participantmodel.CombinedParticipant, 0)
@@ -0,0 +1,251 @@
+package participant_controller
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// importCsvFileField is the multipart field holding the CSV of ImportUpdate
+const importCsvFileField = "file"
+
+// parseParticipantCSV reads a CSV whose header row names the participant data fields. Every row becomes one
+// participant, numbered by its CSV line (the header is line 1). Rows with the wrong number of columns are
+// returned as invalid; a malformed file or header is an error. An "Email" header is read as the email field.
+func parseParticipantCSV(r io.Reader) ([]map[string]any, []int, []InvalidParticipantRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, nil, errors.New("CSV is empty")
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	seen := make(map[string]bool, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if i == 0 {
+			// Spreadsheet exports often start with a UTF-8 byte order mark
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		if strings.EqualFold(column, participantEmailField) {
+			column = participantEmailField
+		}
+		if column == "" {
+			return nil, nil, nil, fmt.Errorf("column %d has no header", i+1)
+		}
+		if seen[column] {
+			return nil, nil, nil, fmt.Errorf("column %q appears more than once", column)
+		}
+		seen[column] = true
+		header[i] = column
+	}
+	if !seen[participantEmailField] {
+		return nil, nil, nil, fmt.Errorf("CSV must have an %s column to match participants on", participantEmailField)
+	}
+
+	rows := []map[string]any{}
+	lines := []int{}
+	invalid := []InvalidParticipantRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) != len(header) {
+			invalid = append(invalid, InvalidParticipantRow{
+				Row:   line,
+				Error: fmt.Sprintf("row has %d columns, expected %d", len(record), len(header)),
+			})
+			continue
+		}
+
+		row := make(map[string]any, len(header))
+		for i, column := range header {
+			row[column] = strings.TrimSpace(record[i])
+		}
+		rows = append(rows, row)
+		lines = append(lines, line)
+	}
+
+	return rows, lines, invalid, nil
+}
+
+// missingAnchorColumns returns the anchors a parsed row has no column for
+func missingAnchorColumns(anchors []string, row map[string]any) []string {
+	var missing []string
+	for _, anchor := range anchors {
+		if _, ok := row[anchor]; !ok {
+			missing = append(missing, anchor)
+		}
+	}
+	return missing
+}
+
+// emptyAnchorFields returns the anchors a row leaves blank
+func emptyAnchorFields(anchors []string, row map[string]any) []string {
+	var empty []string
+	for _, anchor := range anchors {
+		if value, _ := row[anchor].(string); value == "" {
+			empty = append(empty, anchor)
+		}
+	}
+	return empty
+}
+
+// ImportUpdate applies a corrected CSV to a certificate's participants: rows whose email matches an existing
+// participant update that participant, the rest are created, so the same file can be imported repeatedly.
+// The header must cover every anchor of the design; rows with an invalid email or a blank anchor value are
+// reported as failed and the remaining rows are still imported.
+func (ctrl *ParticipantController) ImportUpdate(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certificateRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Participant ImportUpdate certificate lookup failed", "error", err, "cert_id", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Participant ImportUpdate UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request Participant ImportUpdate", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	fileHeader, err := c.FormFile(importCsvFileField)
+	if err != nil {
+		return response.SendFailed(c, "A CSV file is required in the "+importCsvFileField+" field")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		slog.Error("Participant ImportUpdate failed to open CSV", "error", err, "cert_id", certId)
+		return response.SendError(c, "Failed to read CSV file")
+	}
+	defer file.Close()
+
+	rows, lines, failedRows, err := parseParticipantCSV(file)
+	if err != nil {
+		return response.SendFailed(c, "Invalid CSV: "+err.Error())
+	}
+	rowCount := len(rows) + len(failedRows)
+	if rowCount == 0 {
+		return response.SendFailed(c, "CSV has no participant rows")
+	}
+
+	anchors := cert.Anchors
+	if anchors == nil {
+		if anchors, err = certificatemodel.ExtractDesignAnchors(cert.Design); err != nil {
+			return response.SendFailed(c, "Invalid certificate design: "+err.Error())
+		}
+	}
+	if len(rows) > 0 {
+		if missing := missingAnchorColumns(anchors, rows[0]); len(missing) > 0 {
+			return response.SendFailed(c, "CSV is missing columns for the certificate anchors: "+strings.Join(missing, ", "))
+		}
+	}
+
+	participants := make([]map[string]any, 0, len(rows))
+	participantLines := make([]int, 0, len(rows))
+	for i, row := range rows {
+		if empty := emptyAnchorFields(anchors, row); len(empty) > 0 {
+			failedRows = append(failedRows, InvalidParticipantRow{
+				Row:   lines[i],
+				Email: row[participantEmailField],
+				Error: "missing values for: " + strings.Join(empty, ", "),
+			})
+			continue
+		}
+		participants = append(participants, row)
+		participantLines = append(participantLines, lines[i])
+	}
+
+	// Invalid emails are numbered within participants, so map them back to their CSV lines
+	participants, invalidEmails := normalizeParticipantEmails(participants)
+	for _, invalid := range invalidEmails {
+		invalid.Row = participantLines[invalid.Row-1]
+		failedRows = append(failedRows, invalid)
+	}
+
+	createdIds := []string{}
+	updatedIds := []string{}
+	skippedUpdates := []participantmodel.SkippedParticipantUpdate{}
+	failedPostgresIds := []string{}
+
+	if len(participants) > 0 {
+		result, err := ctrl.participantRepo.UpsertParticipantsByEmail(certId, participants)
+		if errors.Is(err, participantmodel.ErrParticipantLimitExceeded) {
+			return response.SendFailed(c, err.Error())
+		}
+		if errors.Is(err, participantmodel.ErrParticipantQuotaExceeded) {
+			return response.SendForbidden(c, err.Error())
+		}
+		if err != nil {
+			slog.Error("Participant ImportUpdate upsert failed", "error", err, "cert_id", certId)
+			return response.SendInternalError(c, err)
+		}
+
+		if result.Created != nil {
+			createdIds = result.Created.CreatedIDs
+			failedPostgresIds = result.Created.FailedPostgresIDs
+		}
+		updatedIds = result.UpdatedIDs
+		skippedUpdates = result.SkippedUpdates
+	}
+
+	failedCount := len(failedRows) + len(skippedUpdates) + len(failedPostgresIds)
+
+	slog.Info("Participant ImportUpdate completed",
+		"cert_id", certId,
+		"row_count", rowCount,
+		"created_count", len(createdIds),
+		"updated_count", len(updatedIds),
+		"failed_count", failedCount)
+
+	sort.Slice(failedRows, func(i, j int) bool { return failedRows[i].Row < failedRows[j].Row })
+
+	return response.SendSuccess(c, "Participants import-updated", fiber.Map{
+		"certificate_id":      certId,
+		"row_count":           rowCount,
+		"created_count":       len(createdIds),
+		"updated_count":       len(updatedIds),
+		"failed_count":        failedCount,
+		"created_ids":         createdIds,
+		"updated_ids":         updatedIds,
+		"failed_rows":         failedRows,
+		"skipped_updates":     skippedUpdates,
+		"failed_postgres_ids": failedPostgresIds,
+	})
+}
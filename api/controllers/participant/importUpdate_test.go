@@ -0,0 +1,56 @@
+package participant_controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseParticipantCSV(t *testing.T) {
+	csvData := "\ufeffName, Email ,score\n" +
+		"Alice,alice@example.com,90\n" +
+		"\n" +
+		"Bob,bob@example.com\n" +
+		"\"Carol, Jr.\", carol@example.com ,75\n"
+
+	rows, lines, invalid, err := parseParticipantCSV(strings.NewReader(csvData))
+	require.NoError(t, err)
+
+	assert.Equal(t, []map[string]any{
+		{"Name": "Alice", "email": "alice@example.com", "score": "90"},
+		{"Name": "Carol, Jr.", "email": "carol@example.com", "score": "75"},
+	}, rows)
+	assert.Equal(t, []int{2, 5}, lines)
+	require.Len(t, invalid, 1)
+	assert.Equal(t, 4, invalid[0].Row)
+	assert.Contains(t, invalid[0].Error, "2 columns, expected 3")
+}
+
+func TestParseParticipantCSV_InvalidHeader(t *testing.T) {
+	tests := map[string]string{
+		"empty file":       "",
+		"no email column":  "name,score\nAlice,90\n",
+		"duplicate column": "name,email,name\nAlice,a@example.com,Al\n",
+		"blank header":     "name,,email\nAlice,x,a@example.com\n",
+		"malformed quotes": "name,email\n\"Alice,a@example.com\n",
+	}
+
+	for name, csvData := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, _, err := parseParticipantCSV(strings.NewReader(csvData))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAnchorFieldChecks(t *testing.T) {
+	anchors := []string{"name", "score"}
+
+	assert.Equal(t, []string{"score"}, missingAnchorColumns(anchors, map[string]any{"name": "Alice", "email": "a@example.com"}))
+	assert.Empty(t, missingAnchorColumns(anchors, map[string]any{"name": "Alice", "score": ""}))
+
+	assert.Equal(t, []string{"score"}, emptyAnchorFields(anchors, map[string]any{"name": "Alice", "score": ""}))
+	assert.Empty(t, emptyAnchorFields(anchors, map[string]any{"name": "Alice", "score": "90"}))
+}
@@ -0,0 +1,37 @@
+package participant_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetNotDownloaded lists participants who were emailed their certificate but never downloaded it
+func (ctrl *ParticipantController) GetNotDownloaded(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		slog.Warn("Request not-downloaded Participants with empty certificate ID")
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certificateRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Get not-downloaded Participants certificate lookup failed", "error", err, "certId", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		slog.Warn("Get not-downloaded Participants with non-existing certificate", "certId", certId)
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	participants, err := ctrl.participantRepo.GetNotDownloadedByCertId(certId)
+	if err != nil {
+		slog.Error("Get not-downloaded Participants Error", "error", err, "certId", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Participant Fetched!", participants)
+}
@@ -4,6 +4,8 @@ import (
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
 	"github.com/sunthewhat/easy-cert-api/type/response"
 )
 
@@ -21,5 +23,10 @@ func (ctrl *ParticipantController) Revoke(c *fiber.Ctx) error {
 		return response.SendFailed(c, "Participant not found or already revoked")
 	}
 
+	actor, _ := middleware.GetUserFromContext(c)
+	if activityErr := ctrl.certificateRepo.RecordActivity(revokedParticipant.CertificateID, actor, certificatemodel.ActivityRevoked, "participant "+revokedParticipant.ID); activityErr != nil {
+		slog.Warn("Failed to record revoke activity", "error", activityErr, "participantId", id)
+	}
+
 	return response.SendSuccess(c, "Participant revoked successfully", revokedParticipant)
 }
\ No newline at end of file
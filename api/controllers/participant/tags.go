@@ -0,0 +1,68 @@
+package participant_controller
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// SetTags replaces the tags of a participant, used to group participants within a certificate. Tags select
+// who receives mail, so only the owner of the participant's certificate may change them.
+func (ctrl *ParticipantController) SetTags(c *fiber.Ctx) error {
+	participantId := c.Params("id")
+	if participantId == "" {
+		return response.SendFailed(c, "Participant ID is required")
+	}
+
+	body := new(payload.SetParticipantTagsPayload)
+	if err := c.BodyParser(body); err != nil {
+		slog.Warn("SetParticipantTags: Failed to parse request body", "error", err, "participant_id", participantId)
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	if err := util.ValidateStruct(*body); err != nil {
+		slog.Warn("SetParticipantTags: Validation failed", "error", err, "participant_id", participantId)
+		return response.SendFailed(c, fmt.Sprintf("Invalid Data type %s", util.GetValidationErrors(err)[0]))
+	}
+
+	participant, err := ctrl.participantRepo.GetParticipantsById(participantId)
+	if err != nil || participant == nil {
+		slog.Warn("SetParticipantTags: Participant lookup failed", "error", err, "participant_id", participantId)
+		return response.SendFailed(c, "Participant not found")
+	}
+
+	cert, err := ctrl.certificateRepo.GetById(participant.CertificateID)
+	if err != nil {
+		slog.Error("SetParticipantTags: Certificate lookup failed", "error", err, "cert_id", participant.CertificateID)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("SetParticipantTags: UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request SetParticipantTags", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	updatedParticipant, err := ctrl.participantRepo.SetParticipantTags(participantId, body.Tags)
+	if err != nil {
+		slog.Error("SetParticipantTags: Failed to update tags", "error", err, "participant_id", participantId)
+		return response.SendInternalError(c, err)
+	}
+
+	slog.Info("SetParticipantTags: Successfully updated tags", "participant_id", participantId, "tags", updatedParticipant.Tags)
+	return response.SendSuccess(c, "Participant tags updated successfully", updatedParticipant)
+}
@@ -0,0 +1,83 @@
+package participant_controller_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	participant_controller "github.com/sunthewhat/easy-cert-api/api/controllers/participant"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+func TestParticipantController_SetTags(t *testing.T) {
+	tests := []struct {
+		name           string
+		userId         string
+		participant    *participantmodel.CombinedParticipant
+		wantStatusCode int
+		wantUpdated    bool
+	}{
+		{
+			name:           "success - owner sets tags",
+			userId:         "owner@example.com",
+			participant:    &participantmodel.CombinedParticipant{ID: "p1", CertificateID: "cert1"},
+			wantStatusCode: fiber.StatusOK,
+			wantUpdated:    true,
+		},
+		{
+			name:           "failed - wrong owner",
+			userId:         "other@example.com",
+			participant:    &participantmodel.CombinedParticipant{ID: "p1", CertificateID: "cert1"},
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "failed - participant not found",
+			userId:         "owner@example.com",
+			participant:    nil,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockParticipantRepo := participantmodel.NewMockParticipantRepository()
+			mockParticipantRepo.GetParticipantsByIdFunc = func(participantId string) (*participantmodel.CombinedParticipant, error) {
+				return tt.participant, nil
+			}
+			updated := false
+			mockParticipantRepo.SetParticipantTagsFunc = func(participantId string, tags []string) (*participantmodel.CombinedParticipant, error) {
+				updated = true
+				return &participantmodel.CombinedParticipant{ID: participantId, Tags: tags}, nil
+			}
+
+			mockCertRepo := certificatemodel.NewMockCertificateRepository()
+			mockCertRepo.GetByIdFunc = func(certId string) (*model.Certificate, error) {
+				return &model.Certificate{ID: certId, UserID: "owner@example.com"}, nil
+			}
+
+			app := fiber.New()
+			ctrl := participant_controller.NewParticipantController(mockParticipantRepo, mockCertRepo)
+			app.Put("/participant/tags/:id", func(c *fiber.Ctx) error {
+				c.Locals("user_id", tt.userId)
+				return ctrl.SetTags(c)
+			})
+
+			req := httptest.NewRequest("PUT", "/participant/tags/p1", bytes.NewBufferString(`{"tags": ["vip"]}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if updated != tt.wantUpdated {
+				t.Errorf("Expected tags updated=%v, got %v", tt.wantUpdated, updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package participant_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// ValidateAll audits every stored participant against the certificate's current design anchors
+func (ctrl *ParticipantController) ValidateAll(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		slog.Warn("Validate all Participants with empty certificate ID")
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certificateRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Validate all Participants certificate lookup failed", "error", err, "certId", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		slog.Warn("Validate all Participants with non-existing certificate", "certId", certId)
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	audit, err := ctrl.participantRepo.AuditFieldConsistency(certId, cert.Design)
+	if err != nil {
+		slog.Error("Validate all Participants Error", "error", err, "certId", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	return response.SendSuccess(c, "Participants validated", audit)
+}
@@ -0,0 +1,98 @@
+package participant_controller
+
+import (
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+	"gorm.io/gorm"
+)
+
+const (
+	VerdictValid    = "valid"
+	VerdictRevoked  = "revoked"
+	VerdictNotFound = "not_found"
+)
+
+// verifyResultPath is the path segment the certificate QR codes point to
+const verifyResultPath = "/validate/result/"
+
+// Verify returns an authenticity verdict for a scanned QR payload, which is either
+// the verification URL embedded in the certificate or a bare participant ID
+func (ctrl *ParticipantController) Verify(c *fiber.Ctx) error {
+	body := new(payload.VerifyCertificatePayload)
+	if err := c.BodyParser(body); err != nil {
+		slog.Warn("Verify certificate body parsing failed", "error", err)
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	if err := util.ValidateStruct(body); err != nil {
+		return response.SendFailed(c, util.GetValidationErrors(err)[0])
+	}
+
+	participantId, ok := extractParticipantId(body.Payload)
+	if !ok {
+		return response.SendSuccess(c, "Certificate verified", fiber.Map{"verdict": VerdictNotFound})
+	}
+
+	participant, err := ctrl.participantRepo.GetParticipantsById(participantId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return response.SendSuccess(c, "Certificate verified", fiber.Map{"verdict": VerdictNotFound, "participant_id": participantId})
+	}
+	if err != nil {
+		slog.Error("Verify certificate participant lookup failed", "error", err, "participant_id", participantId)
+		return response.SendInternalError(c, err)
+	}
+
+	// Certificates that were never issued to the participant are not publicly verifiable
+	if !participant.IsDownloaded && participant.EmailStatus != "success" {
+		return response.SendSuccess(c, "Certificate verified", fiber.Map{"verdict": VerdictNotFound, "participant_id": participantId})
+	}
+
+	if participant.IsRevoke {
+		return response.SendSuccess(c, "Certificate verified", fiber.Map{"verdict": VerdictRevoked, "participant_id": participantId})
+	}
+
+	certificate, err := ctrl.certificateRepo.GetById(participant.CertificateID)
+	if err != nil {
+		slog.Error("Verify certificate lookup failed", "error", err, "participant_id", participantId)
+		return response.SendInternalError(c, err)
+	}
+	if certificate == nil {
+		return response.SendSuccess(c, "Certificate verified", fiber.Map{"verdict": VerdictNotFound, "participant_id": participantId})
+	}
+
+	return response.SendSuccess(c, "Certificate verified", fiber.Map{
+		"verdict":        VerdictValid,
+		"participant_id": participantId,
+		"certificate": fiber.Map{
+			"id":   certificate.ID,
+			"name": certificate.Name,
+		},
+		"issued_at":  participant.UpdatedAt,
+		"pdf_signed": participant.PdfSigned,
+		"data":       participant.DynamicData,
+	})
+}
+
+// extractParticipantId accepts a verification URL or a bare participant ID and returns the ID
+func extractParticipantId(raw string) (string, bool) {
+	candidate := strings.TrimSpace(raw)
+
+	if parsed, err := url.Parse(candidate); err == nil && strings.Contains(parsed.Path, verifyResultPath) {
+		candidate = parsed.Path[strings.LastIndex(parsed.Path, verifyResultPath)+len(verifyResultPath):]
+		candidate = strings.Trim(candidate, "/")
+	}
+
+	if _, err := uuid.Parse(candidate); err != nil {
+		return "", false
+	}
+
+	return candidate, true
+}
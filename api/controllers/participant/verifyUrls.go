@@ -0,0 +1,45 @@
+package participant_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// GetVerifyUrls returns every participant's verification URL, built the same way as the QR codes
+func (ctrl *ParticipantController) GetVerifyUrls(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		slog.Warn("Request participant verify URLs with empty certificate ID")
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certificateRepo.GetById(certId)
+	if err != nil {
+		slog.Error("Get participant verify URLs certificate lookup failed", "error", err, "certId", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		slog.Warn("Get participant verify URLs with non-existing certificate", "certId", certId)
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	participants, err := ctrl.participantRepo.GetParticipantsByCertId(certId)
+	if err != nil {
+		slog.Error("Get participant verify URLs Error", "error", err, "certId", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	verifyHost := util.CertificateVerifyHost(cert)
+	urls := make(map[string]string, len(participants))
+	for _, p := range participants {
+		urls[p.ID] = renderer.VerificationURL(verifyHost, p.ID)
+	}
+
+	return response.SendSuccess(c, "Participant verify URLs fetched", urls)
+}
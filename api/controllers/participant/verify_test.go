@@ -0,0 +1,44 @@
+package participant_controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+)
+
+func TestExtractParticipantId(t *testing.T) {
+	const id = "4f1c2d7e-8a9b-4c3d-9e8f-1a2b3c4d5e6f"
+
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{name: "bare participant id", input: id, want: id, wantOK: true},
+		{name: "bare id with whitespace", input: "  " + id + "\n", want: id, wantOK: true},
+		{name: "verification url", input: "https://verify.example.com/validate/result/" + id, want: id, wantOK: true},
+		{name: "verification url with trailing slash", input: "https://verify.example.com/validate/result/" + id + "/", want: id, wantOK: true},
+		{name: "unrelated url", input: "https://example.com/other/" + id, wantOK: false},
+		{name: "not a uuid", input: "hello", wantOK: false},
+		{name: "empty", input: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := extractParticipantId(tt.input)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestVerificationURLRoundTrip ensures the URLs served by GetVerifyUrls are accepted by Verify
+func TestVerificationURLRoundTrip(t *testing.T) {
+	const id = "4f1c2d7e-8a9b-4c3d-9e8f-1a2b3c4d5e6f"
+
+	got, ok := extractParticipantId(renderer.VerificationURL("https://verify.example.com", id))
+	assert.True(t, ok)
+	assert.Equal(t, id, got)
+}
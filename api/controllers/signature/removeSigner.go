@@ -0,0 +1,151 @@
+package signature_controller
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// removeSignerFromDesign drops the signer's SIGNATURE object from the design so saving the design later
+// doesn't add the signature back. It reports whether the design changed.
+func removeSignerFromDesign(designJSON string, signerId string) (string, bool, error) {
+	var design map[string]any
+	if err := json.Unmarshal([]byte(designJSON), &design); err != nil {
+		return "", false, err
+	}
+
+	objects, ok := design["objects"].([]any)
+	if !ok {
+		return designJSON, false, nil
+	}
+
+	kept := make([]any, 0, len(objects))
+	for _, obj := range objects {
+		if objMap, ok := obj.(map[string]any); ok {
+			if id, _ := objMap["id"].(string); id == "SIGNATURE-"+signerId {
+				continue
+			}
+		}
+		kept = append(kept, obj)
+	}
+
+	if len(kept) == len(objects) {
+		return designJSON, false, nil
+	}
+
+	design["objects"] = kept
+	updated, err := json.Marshal(design)
+	if err != nil {
+		return "", false, err
+	}
+	return string(updated), true, nil
+}
+
+// RemoveSigner removes one signer from a certificate's signing roster. Signers who already signed are
+// only removed with ?force=true, which also marks generated certificates stale since they carry the signature.
+// The certificate's signed state is recomputed from the remaining signatures.
+func (ctrl *SignatureController) RemoveSigner(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+	signerId := c.Params("signerId")
+
+	if certId == "" || signerId == "" {
+		return response.SendFailed(c, "Certificate ID and signer ID are required")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Signature RemoveSigner UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	certificate, err := ctrl.certificateRepo.GetById(certId)
+	if err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	if certificate == nil {
+		return response.SendNotFound(c, "Certificate not found")
+	}
+
+	if certificate.UserID != userId {
+		slog.Warn("Wrong Owner Request RemoveSigner", "user", userId, "certificate-owner", certificate.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	signature, err := ctrl.signatureRepo.GetByCertificateAndSignerId(certId, signerId)
+	if err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	if signature == nil {
+		return response.SendNotFound(c, "Signature not found")
+	}
+
+	force := c.Query("force") == "true"
+	if signature.IsSigned && !force {
+		return response.SendConflict(c, "Signer has already signed, pass force=true to remove them anyway", fiber.Map{
+			"signer_id": signerId,
+			"is_signed": true,
+		})
+	}
+
+	if err := ctrl.signatureRepo.DeleteSignature(certId, signerId); err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	if design, changed, designErr := removeSignerFromDesign(certificate.Design, signerId); designErr != nil {
+		slog.Warn("Signature RemoveSigner failed to parse design", "error", designErr, "cert_id", certId)
+	} else if changed {
+		if _, updateErr := ctrl.certificateRepo.Update(certId, "", design, false); updateErr != nil {
+			slog.Warn("Signature RemoveSigner failed to remove signer from design", "error", updateErr, "cert_id", certId, "signer_id", signerId)
+		}
+	}
+
+	staleCount := int64(0)
+	if signature.IsSigned {
+		var staleErr error
+		staleCount, staleErr = ctrl.participantRepo.MarkGeneratedParticipantsStale(certId)
+		if staleErr != nil {
+			slog.Warn("Signature RemoveSigner failed to mark generated certificates stale", "error", staleErr, "cert_id", certId)
+		}
+	}
+
+	// Removing the last pending signer completes the certificate
+	allComplete, checkErr := ctrl.signatureRepo.AreAllSignaturesComplete(certId)
+	if checkErr != nil {
+		slog.Warn("Signature RemoveSigner failed to check if all signatures complete", "error", checkErr, "cert_id", certId)
+	} else if allComplete {
+		if markErr := ctrl.certificateRepo.MarkAsSigned(certId); markErr != nil {
+			slog.Warn("Signature RemoveSigner failed to mark certificate as signed", "error", markErr, "cert_id", certId)
+		}
+		if !certificate.IsSigned {
+			ownerEmail, notifyErr := util.ResolveUserEmail(certificate.UserID)
+			if notifyErr == nil {
+				notifyErr = util.SendAllSignaturesCompleteMail(ownerEmail, certificate.Name, certificate.ID, "", certificate.VerifyHost)
+			}
+			if notifyErr != nil {
+				slog.Warn("Signature RemoveSigner failed to send completion notification", "error", notifyErr, "cert_id", certId)
+			}
+		}
+	} else if markErr := ctrl.certificateRepo.MarkAsUnsigned(certId); markErr != nil {
+		slog.Warn("Signature RemoveSigner failed to mark certificate as unsigned", "error", markErr, "cert_id", certId)
+	}
+
+	if activityErr := ctrl.certificateRepo.RecordActivity(certId, userId, certificatemodel.ActivitySignerRemoved, "signer "+signerId); activityErr != nil {
+		slog.Warn("Failed to record signer removed activity", "error", activityErr, "cert_id", certId)
+	}
+
+	slog.Info("Signer removed", "cert_id", certId, "signer_id", signerId, "was_signed", signature.IsSigned, "stale_count", staleCount)
+
+	return response.SendSuccess(c, "Signer removed", fiber.Map{
+		"signer_id":          signerId,
+		"was_signed":         signature.IsSigned,
+		"certificate_signed": allComplete,
+		"stale_count":        staleCount,
+	})
+}
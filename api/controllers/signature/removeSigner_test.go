@@ -0,0 +1,40 @@
+package signature_controller
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRemoveSignerFromDesign(t *testing.T) {
+	design := `{"version":"5.3.0","objects":[{"id":"SIGNATURE-signer-1","type":"image"},{"id":"PLACEHOLDER-name","type":"text"},{"id":"SIGNATURE-signer-2","type":"image"}]}`
+
+	updated, changed, err := removeSignerFromDesign(design, "signer-1")
+	if err != nil {
+		t.Fatalf("removeSignerFromDesign() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected the design to change")
+	}
+
+	var parsed struct {
+		Version string           `json:"version"`
+		Objects []map[string]any `json:"objects"`
+	}
+	if err := json.Unmarshal([]byte(updated), &parsed); err != nil {
+		t.Fatalf("updated design is not valid JSON: %v", err)
+	}
+	if parsed.Version != "5.3.0" {
+		t.Errorf("design properties were lost, version = %q", parsed.Version)
+	}
+	if len(parsed.Objects) != 2 || parsed.Objects[0]["id"] != "PLACEHOLDER-name" || parsed.Objects[1]["id"] != "SIGNATURE-signer-2" {
+		t.Errorf("unexpected objects after removal: %v", parsed.Objects)
+	}
+
+	if unchanged, changed, err := removeSignerFromDesign(design, "signer-3"); err != nil || changed || unchanged != design {
+		t.Errorf("removing an absent signer should keep the design, got changed=%v err=%v", changed, err)
+	}
+
+	if _, _, err := removeSignerFromDesign("not json", "signer-1"); err == nil {
+		t.Error("expected an error for an invalid design")
+	}
+}
@@ -0,0 +1,171 @@
+package signature_controller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+const maxSignatureImageSize = int64(10 * 1024 * 1024)
+
+// validateSignatureImage checks that the uploaded bytes are a decodable PNG or JPEG image
+func validateSignatureImage(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("signature image is empty")
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return errors.New("signature image must be a PNG or JPEG image")
+	}
+	if format != "png" && format != "jpeg" {
+		return fmt.Errorf("unsupported signature image format: %s", format)
+	}
+	if config.Width == 0 || config.Height == 0 {
+		return errors.New("signature image has no dimensions")
+	}
+	if err := renderer.CheckSignatureImagePixels(data); err != nil {
+		return fmt.Errorf("signature image is too large: %dx%d pixels", config.Width, config.Height)
+	}
+	return nil
+}
+
+// shrinkUploadedSignature scales an oversized signature image down to the configured maximum size.
+// Images that cannot be decoded are kept as uploaded rather than rejecting the signer.
+func shrinkUploadedSignature(data []byte, signatureId string) []byte {
+	shrunk, resized, err := renderer.ShrinkSignatureImage(data)
+	if err != nil {
+		slog.Warn("Keeping signature image at its original size", "error", err, "signatureId", signatureId)
+		return data
+	}
+	if resized {
+		slog.Info("Signature image downscaled", "signatureId", signatureId, "original_bytes", len(data), "bytes", len(shrunk))
+	}
+	return shrunk
+}
+
+// applySignatureBackground flattens a transparent signature onto the certificate's signature background.
+// Certificates without a background keep the image as uploaded; failures keep it too and are only logged.
+func (ctrl *SignatureController) applySignatureBackground(data []byte, certificateId string, signatureId string) []byte {
+	certificate, err := ctrl.certificateRepo.GetById(certificateId)
+	if err != nil || certificate == nil || certificate.SignatureBackground == "" {
+		if err != nil {
+			slog.Warn("Keeping signature image transparent, certificate lookup failed", "error", err, "signatureId", signatureId)
+		}
+		return data
+	}
+
+	flattened, err := renderer.FlattenSignatureImage(data, certificate.SignatureBackground)
+	if err != nil {
+		slog.Warn("Keeping signature image transparent", "error", err, "signatureId", signatureId, "background", certificate.SignatureBackground)
+		return data
+	}
+	return flattened
+}
+
+// ReplaceSignature swaps the image of an already signed signature without restarting the signing workflow.
+// The signature stays signed; certificates generated with the old image are marked stale for regeneration.
+func (ctrl *SignatureController) ReplaceSignature(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+	signerId := c.Params("signerId")
+
+	if certId == "" || signerId == "" {
+		return response.SendFailed(c, "Certificate ID and signer ID are required")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Signature ReplaceSignature UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	signature, err := ctrl.signatureRepo.GetByCertificateAndSignerId(certId, signerId)
+	if err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	if signature == nil {
+		return response.SendFailed(c, "Signature not found")
+	}
+
+	if signature.SignerID != userId && signature.CreatedBy != userId {
+		slog.Warn("Wrong Owner Request ReplaceSignature", "user", userId, "signer", signature.SignerID, "created-by", signature.CreatedBy)
+		return response.SendUnauthorized(c, "You do not owned this signature")
+	}
+
+	if !signature.IsSigned {
+		return response.SendFailed(c, "Signature has not been signed yet, sign it instead")
+	}
+
+	fileHeader, err := c.FormFile("signature_image")
+	if err != nil {
+		return response.SendFailed(c, "Signature image is required")
+	}
+
+	if fileHeader.Size > maxSignatureImageSize {
+		return response.SendFailed(c, "Signature image too large (max 10MB)")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		slog.Error("Failed to open signature image", "error", err)
+		return response.SendInternalError(c, err)
+	}
+	defer file.Close()
+
+	imageData, err := io.ReadAll(file)
+	if err != nil {
+		slog.Error("Failed to read signature image", "error", err)
+		return response.SendInternalError(c, err)
+	}
+
+	if err := validateSignatureImage(imageData); err != nil {
+		return response.SendFailed(c, err.Error())
+	}
+
+	imageData = shrinkUploadedSignature(imageData, signature.ID)
+	imageData = ctrl.applySignatureBackground(imageData, certId, signature.ID)
+
+	encryptedSignature, err := util.EncryptData(imageData, *common.Config.EncryptionKey)
+	if err != nil {
+		slog.Error("Failed to encrypt signature", "error", err)
+		return response.SendError(c, "Failed to encrypt signature")
+	}
+
+	updatedSignature, err := ctrl.signatureRepo.UpdateSignature(signature.ID, encryptedSignature)
+	if err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	if eventErr := ctrl.signatureRepo.RecordEvent(certId, signerId, signaturemodel.SignatureEventReplaced); eventErr != nil {
+		slog.Warn("Failed to record replaced event", "error", eventErr, "signatureId", signature.ID)
+	}
+
+	staleCount, staleErr := ctrl.participantRepo.MarkGeneratedParticipantsStale(certId)
+	if staleErr != nil {
+		slog.Warn("Signature ReplaceSignature failed to mark generated certificates stale", "error", staleErr, "cert_id", certId)
+	}
+
+	slog.Info("Signature replaced", "cert_id", certId, "signer_id", signerId, "stale_count", staleCount)
+
+	return response.SendSuccess(c, "Signature replaced successfully", fiber.Map{
+		"signature_id":   updatedSignature.ID,
+		"signer_id":      updatedSignature.SignerID,
+		"certificate_id": updatedSignature.CertificateID,
+		"is_signed":      updatedSignature.IsSigned,
+		"stale_count":    staleCount,
+	})
+}
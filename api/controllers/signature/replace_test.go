@@ -0,0 +1,35 @@
+package signature_controller
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func TestValidateSignatureImage(t *testing.T) {
+	var pngBuf bytes.Buffer
+	if err := png.Encode(&pngBuf, image.NewRGBA(image.Rect(0, 0, 4, 2))); err != nil {
+		t.Fatalf("Failed to encode test image: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{name: "valid png", data: pngBuf.Bytes(), wantErr: false},
+		{name: "empty", data: nil, wantErr: true},
+		{name: "not an image", data: []byte("%PDF-1.4 not an image"), wantErr: true},
+		{name: "truncated png header", data: pngBuf.Bytes()[:8], wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSignatureImage(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSignatureImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
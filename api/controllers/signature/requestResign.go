@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
 	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/type/response"
 )
@@ -29,7 +30,7 @@ func (ctrl *SignatureController) RequestResign(c *fiber.Ctx) error {
 		return response.SendInternalError(c, err)
 	}
 
-	err = util.SendSignatureRequestMail(signer.Email, signer.DisplayName, cert.ID, cert.Name)
+	err = util.SendSignatureRequestMail(signer.Email, signer.DisplayName, cert.ID, cert.Name, signer.ID, cert.VerifyHost)
 
 	if err != nil {
 		slog.Error("Failed to send new signature request mail", "error", err, "signatureId", signatureId)
@@ -42,5 +43,9 @@ func (ctrl *SignatureController) RequestResign(c *fiber.Ctx) error {
 		return response.SendInternalError(c, err)
 	}
 
+	if eventErr := ctrl.signatureRepo.RecordEvent(signature.CertificateID, signature.SignerID, signaturemodel.SignatureEventResignRequested); eventErr != nil {
+		slog.Warn("Failed to record resign request event", "error", eventErr, "signatureId", signatureId)
+	}
+
 	return response.SendSuccess(c, "Request resign certificate successfully")
 }
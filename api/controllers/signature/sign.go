@@ -3,12 +3,16 @@ package signature_controller
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
 	"github.com/sunthewhat/easy-cert-api/common"
 	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/internal/renderer"
@@ -22,6 +26,11 @@ func (ctrl *SignatureController) Sign(c *fiber.Ctx) error {
 		return response.SendFailed(c, "Signature ID is required")
 	}
 
+	// Enforce the signing order of sequential-signing certificates
+	if blocked, err := ctrl.checkSigningOrder(c, signatureId); blocked || err != nil {
+		return err
+	}
+
 	// 2. Receive signature image file
 	fileHeader, err := c.FormFile("signature_image")
 	if err != nil {
@@ -49,6 +58,20 @@ func (ctrl *SignatureController) Sign(c *fiber.Ctx) error {
 		return response.SendInternalError(c, err)
 	}
 
+	// Reject images whose decoded size would exhaust memory before anything decodes them
+	if err := renderer.CheckSignatureImagePixels(imageData); errors.Is(err, renderer.ErrSignatureImageTooLarge) {
+		slog.Warn("Signature image rejected", "error", err, "signatureId", signatureId)
+		return response.SendFailed(c, "Signature image dimensions are too large")
+	}
+
+	// Scale oversized signatures down so they don't bloat rendered certificates
+	imageData = shrinkUploadedSignature(imageData, signatureId)
+
+	// Flatten transparent signatures onto the certificate's signature background, if one is set
+	if signature, sigErr := ctrl.signatureRepo.GetById(signatureId); sigErr == nil && signature != nil {
+		imageData = ctrl.applySignatureBackground(imageData, signature.CertificateID, signatureId)
+	}
+
 	// 5. Encrypt the signature image
 	encryptedSignature, err := util.EncryptData(imageData, *common.Config.EncryptionKey)
 	if err != nil {
@@ -62,6 +85,13 @@ func (ctrl *SignatureController) Sign(c *fiber.Ctx) error {
 		return response.SendInternalError(c, err)
 	}
 
+	if eventErr := ctrl.signatureRepo.RecordEvent(updatedSignature.CertificateID, updatedSignature.SignerID, signaturemodel.SignatureEventSigned); eventErr != nil {
+		slog.Warn("Failed to record signed event", "error", eventErr, "signatureId", signatureId)
+	}
+	if activityErr := ctrl.certificateRepo.RecordActivity(updatedSignature.CertificateID, updatedSignature.SignerID, certificatemodel.ActivitySigned, ""); activityErr != nil {
+		slog.Warn("Failed to record signed activity", "error", activityErr, "signatureId", signatureId)
+	}
+
 	// 7. Check if all signatures are complete for this certificate
 	allComplete, checkErr := ctrl.signatureRepo.AreAllSignaturesComplete(updatedSignature.CertificateID)
 	if checkErr != nil {
@@ -141,9 +171,10 @@ func (ctrl *SignatureController) Sign(c *fiber.Ctx) error {
 
 				// Convert certificate struct to map for renderer compatibility
 				certMap := map[string]any{
-					"id":     certificate.ID,
-					"name":   certificate.Name,
-					"design": certDesign,
+					"id":          certificate.ID,
+					"name":        certificate.Name,
+					"design":      certDesign,
+					"verify_host": util.CertificateVerifyHost(certificate),
 				}
 
 				// Initialize embedded renderer
@@ -180,7 +211,10 @@ func (ctrl *SignatureController) Sign(c *fiber.Ctx) error {
 			}
 
 			// Send notification email to certificate owner with preview
-			notifyErr := util.SendAllSignaturesCompleteMail(certificate.UserID, certificate.Name, certificate.ID, previewPath)
+			ownerEmail, notifyErr := util.ResolveUserEmail(certificate.UserID)
+			if notifyErr == nil {
+				notifyErr = util.SendAllSignaturesCompleteMail(ownerEmail, certificate.Name, certificate.ID, previewPath, certificate.VerifyHost)
+			}
 			if notifyErr != nil {
 				slog.Error("Failed to send completion notification email", "error", notifyErr, "certificateId", certificate.ID, "owner", certificate.UserID)
 				// Don't fail the request - signature was uploaded successfully
@@ -200,3 +234,45 @@ func (ctrl *SignatureController) Sign(c *fiber.Ctx) error {
 		"all_complete":   allComplete,
 	})
 }
+
+// checkSigningOrder rejects signing out of order for certificates with sequential signing enabled,
+// responding 409 with the signer who has to sign first. It reports whether a response was sent.
+func (ctrl *SignatureController) checkSigningOrder(c *fiber.Ctx, signatureId string) (bool, error) {
+	signature, err := ctrl.signatureRepo.GetById(signatureId)
+	if err != nil {
+		return true, response.SendInternalError(c, err)
+	}
+	if signature == nil {
+		return true, response.SendNotFound(c, "Signature not found")
+	}
+
+	certificate, err := ctrl.certificateRepo.GetById(signature.CertificateID)
+	if err != nil {
+		return true, response.SendInternalError(c, err)
+	}
+	if certificate == nil || !certificate.SequentialSigning {
+		return false, nil
+	}
+
+	signatures, err := ctrl.signatureRepo.GetSignaturesByCertificate(certificate.ID)
+	if err != nil {
+		return true, response.SendInternalError(c, err)
+	}
+
+	pending := signaturemodel.FirstPendingBefore(signatures, signature)
+	if pending == nil {
+		return false, nil
+	}
+
+	signerName := pending.SignerID
+	if signer, signerErr := ctrl.signerRepo.GetById(pending.SignerID); signerErr == nil && signer != nil {
+		signerName = signer.DisplayName
+	}
+
+	slog.Warn("Out of order signing attempt", "signatureId", signatureId, "certificateId", certificate.ID, "waiting_for", pending.SignerID)
+	return true, response.SendConflict(c, fmt.Sprintf("%s must sign this certificate first", signerName), fiber.Map{
+		"signer_id":    pending.SignerID,
+		"display_name": signerName,
+		"sign_order":   pending.SignOrder,
+	})
+}
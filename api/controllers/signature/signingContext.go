@@ -0,0 +1,87 @@
+package signature_controller
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+// Signing page states reported by GetSigningContext
+const (
+	SigningStatePending = "pending"
+	SigningStateSigned  = "signed"
+	SigningStateExpired = "expired"
+)
+
+// signingState returns the state the signing page should render; a signed signature stays signed
+// after its link expires
+func signingState(signature *model.Signature, expired bool) string {
+	if signature.IsSigned {
+		return SigningStateSigned
+	}
+	if expired {
+		return SigningStateExpired
+	}
+	return SigningStatePending
+}
+
+// GetSigningContext resolves a per-signer signing link token to what the signing page needs to render:
+// the certificate name, the signer's display name and the signature's state. Expired links still
+// resolve so the page can tell the signer the link has expired; tampered tokens are rejected with 403.
+func (ctrl *SignatureController) GetSigningContext(c *fiber.Ctx) error {
+	token := c.Params("token")
+
+	if token == "" {
+		return response.SendFailed(c, "Signing token is required")
+	}
+
+	claims, err := util.ValidateSigningToken(token)
+	expired := errors.Is(err, util.ErrExpiredSigningToken)
+	if err != nil && !expired {
+		slog.Warn("GetSigningContext: Invalid signing token")
+		return response.SendForbidden(c, "Invalid signing link")
+	}
+
+	signature, err := ctrl.signatureRepo.GetByCertificateAndSignerId(claims.CertificateID, claims.SignerID)
+	if err != nil {
+		slog.Error("GetSigningContext: Error fetching signature", "error", err, "certificateId", claims.CertificateID, "signerId", claims.SignerID)
+		return response.SendInternalError(c, err)
+	}
+
+	if signature == nil {
+		return response.SendForbidden(c, "Invalid signing link")
+	}
+
+	certificate, err := ctrl.certificateRepo.GetById(claims.CertificateID)
+	if err != nil {
+		slog.Error("GetSigningContext: Error fetching certificate", "error", err, "certificateId", claims.CertificateID)
+		return response.SendInternalError(c, err)
+	}
+
+	if certificate == nil {
+		return response.SendForbidden(c, "Invalid signing link")
+	}
+
+	signerName := ""
+	if signer, signerErr := ctrl.signerRepo.GetById(claims.SignerID); signerErr == nil && signer != nil {
+		signerName = signer.DisplayName
+	} else if signerErr != nil {
+		slog.Warn("GetSigningContext: Error fetching signer", "error", signerErr, "signerId", claims.SignerID)
+	}
+
+	return response.SendSuccess(c, "Signing context fetched", fiber.Map{
+		"signature_id":        signature.ID,
+		"certificate_id":      certificate.ID,
+		"certificate_name":    certificate.Name,
+		"signer_id":           claims.SignerID,
+		"signer_display_name": signerName,
+		"state":               signingState(signature, expired),
+		"is_signed":           signature.IsSigned,
+		"is_expired":          expired,
+		"expires_at":          claims.ExpiresAt,
+	})
+}
@@ -0,0 +1,54 @@
+package signature_controller
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+func TestSigningState(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature *model.Signature
+		expired   bool
+		want      string
+	}{
+		{name: "pending", signature: &model.Signature{}, want: SigningStatePending},
+		{name: "signed", signature: &model.Signature{IsSigned: true}, want: SigningStateSigned},
+		{name: "expired", signature: &model.Signature{}, expired: true, want: SigningStateExpired},
+		{name: "signed before expiry", signature: &model.Signature{IsSigned: true}, expired: true, want: SigningStateSigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signingState(tt.signature, tt.expired); got != tt.want {
+				t.Errorf("signingState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSigningContext_InvalidToken(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	secret := "test-secret"
+	common.Config = &shared.Config{JWTSecret: &secret}
+
+	app := fiber.New()
+	ctrl := &SignatureController{}
+	app.Get("/signature/context/:token", ctrl.GetSigningContext)
+
+	for _, token := range []string{"not-a-token", "cGF5bG9hZA.forged"} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/signature/context/"+token, nil))
+		if err != nil {
+			t.Fatalf("Failed to execute request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusForbidden {
+			t.Errorf("token %q: expected status %d, got %d", token, fiber.StatusForbidden, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package signature_controller
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+type SignerTimelineResponse struct {
+	signaturemodel.SignerTimeline
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+}
+
+// GetTimeline returns, per signer, when each signature request and reminder was sent and when they signed
+func (ctrl *SignatureController) GetTimeline(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	cert, err := ctrl.certificateRepo.GetById(certId)
+	if err != nil {
+		slog.Error("GetTimeline: Error getting certificate", "error", err, "certId", certId)
+		return response.SendInternalError(c, err)
+	}
+
+	if cert == nil {
+		return response.SendFailed(c, "Certificate not found")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("GetTimeline UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	if userId != cert.UserID {
+		slog.Warn("Wrong Owner Request GetTimeline", "user", userId, "certificate-owner", cert.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	signatures, err := ctrl.signatureRepo.GetSignaturesByCertificate(certId)
+	if err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	events, err := ctrl.signatureRepo.GetEventsByCertificate(certId)
+	if err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	timelines := signaturemodel.BuildSignerTimelines(signatures, events)
+
+	responseData := make([]SignerTimelineResponse, 0, len(timelines))
+	for _, timeline := range timelines {
+		entry := SignerTimelineResponse{SignerTimeline: timeline}
+
+		signer, err := ctrl.signerRepo.GetById(timeline.SignerID)
+		if err != nil {
+			slog.Warn("GetTimeline: Error getting signer", "error", err, "signerId", timeline.SignerID)
+		} else if signer != nil {
+			entry.Email = signer.Email
+			entry.DisplayName = signer.DisplayName
+		}
+
+		responseData = append(responseData, entry)
+	}
+
+	return response.SendSuccess(c, "Signature timeline retrieved successfully", responseData)
+}
@@ -0,0 +1,220 @@
+package signature_controller
+
+import (
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// uploadedSignatureFieldPrefix prefixes the multipart field of each signer's image: signature_<signerId>
+const uploadedSignatureFieldPrefix = "signature_"
+
+type uploadedSignatureResult struct {
+	SignerID string `json:"signer_id"`
+	Success  bool   `json:"success"`
+	Replaced bool   `json:"replaced,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// uploadedSignerIds maps each signer ID to its uploaded image, in signer ID order. Fields without the
+// signature_ prefix or without a file are ignored.
+func uploadedSignerIds(files map[string][]*multipart.FileHeader) ([]string, map[string]*multipart.FileHeader) {
+	images := make(map[string]*multipart.FileHeader)
+	for field, headers := range files {
+		signerId := strings.TrimPrefix(field, uploadedSignatureFieldPrefix)
+		if signerId == field || signerId == "" || len(headers) == 0 {
+			continue
+		}
+		images[signerId] = headers[0]
+	}
+
+	signerIds := make([]string, 0, len(images))
+	for signerId := range images {
+		signerIds = append(signerIds, signerId)
+	}
+	sort.Strings(signerIds)
+	return signerIds, images
+}
+
+// readSignatureUpload reads and validates one uploaded signature image
+func readSignatureUpload(fileHeader *multipart.FileHeader) ([]byte, string) {
+	if fileHeader.Size > maxSignatureImageSize {
+		return nil, "Signature image too large (max 10MB)"
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		slog.Error("Failed to open signature image", "error", err)
+		return nil, "Failed to read signature image"
+	}
+	defer file.Close()
+
+	imageData, err := io.ReadAll(file)
+	if err != nil {
+		slog.Error("Failed to read signature image", "error", err)
+		return nil, "Failed to read signature image"
+	}
+
+	if err := validateSignatureImage(imageData); err != nil {
+		return nil, err.Error()
+	}
+	return imageData, ""
+}
+
+// UploadSignatures lets the certificate owner attach signatures collected offline, such as scanned paper
+// signatures, without the email round-trip. Each image is sent as the multipart file signature_<signerId>
+// and goes through the same checks as a signer's own upload. Every signer is reported separately so one bad
+// image doesn't reject the rest; signing order is not enforced since the owner uploads on the signers' behalf.
+func (ctrl *SignatureController) UploadSignatures(c *fiber.Ctx) error {
+	certId := c.Params("certId")
+
+	if certId == "" {
+		return response.SendFailed(c, "Certificate ID is required")
+	}
+
+	userId, success := middleware.GetUserFromContext(c)
+	if !success {
+		slog.Error("Signature UploadSignatures UserId not found in context")
+		return response.SendUnauthorized(c, "Unknown user request")
+	}
+
+	certificate, err := ctrl.certificateRepo.GetById(certId)
+	if err != nil {
+		return response.SendInternalError(c, err)
+	}
+
+	if certificate == nil {
+		return response.SendNotFound(c, "Certificate not found")
+	}
+
+	if certificate.UserID != userId {
+		slog.Warn("Wrong Owner Request UploadSignatures", "user", userId, "certificate-owner", certificate.UserID)
+		return response.SendUnauthorized(c, "User did not own this certificate")
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return response.SendFailed(c, "Signature images must be sent as multipart form data")
+	}
+
+	signerIds, images := uploadedSignerIds(form.File)
+	if len(signerIds) == 0 {
+		return response.SendFailed(c, "At least one signature_<signerId> image is required")
+	}
+
+	results := make([]uploadedSignatureResult, 0, len(signerIds))
+	uploadedCount := 0
+	replacedAny := false
+
+	for _, signerId := range signerIds {
+		result := uploadedSignatureResult{SignerID: signerId}
+
+		signature, err := ctrl.signatureRepo.GetByCertificateAndSignerId(certId, signerId)
+		if err != nil {
+			slog.Error("Signature UploadSignatures lookup failed", "error", err, "cert_id", certId, "signer_id", signerId)
+			result.Error = "Failed to look up signature"
+			results = append(results, result)
+			continue
+		}
+		if signature == nil {
+			result.Error = "Signer is not assigned to this certificate"
+			results = append(results, result)
+			continue
+		}
+
+		imageData, failure := readSignatureUpload(images[signerId])
+		if failure != "" {
+			result.Error = failure
+			results = append(results, result)
+			continue
+		}
+
+		imageData = shrinkUploadedSignature(imageData, signature.ID)
+		imageData = ctrl.applySignatureBackground(imageData, certId, signature.ID)
+
+		encryptedSignature, err := util.EncryptData(imageData, *common.Config.EncryptionKey)
+		if err != nil {
+			slog.Error("Failed to encrypt signature", "error", err, "signatureId", signature.ID)
+			result.Error = "Failed to encrypt signature"
+			results = append(results, result)
+			continue
+		}
+
+		if _, err := ctrl.signatureRepo.UpdateSignature(signature.ID, encryptedSignature); err != nil {
+			result.Error = "Failed to store signature"
+			results = append(results, result)
+			continue
+		}
+
+		eventType := signaturemodel.SignatureEventSigned
+		if signature.IsSigned {
+			eventType = signaturemodel.SignatureEventReplaced
+			replacedAny = true
+		}
+		if eventErr := ctrl.signatureRepo.RecordEvent(certId, signerId, eventType); eventErr != nil {
+			slog.Warn("Failed to record uploaded signature event", "error", eventErr, "signatureId", signature.ID)
+		}
+		if activityErr := ctrl.certificateRepo.RecordActivity(certId, userId, certificatemodel.ActivitySigned, "uploaded on behalf of signer "+signerId); activityErr != nil {
+			slog.Warn("Failed to record signed activity", "error", activityErr, "signatureId", signature.ID)
+		}
+
+		result.Success = true
+		result.Replaced = signature.IsSigned
+		results = append(results, result)
+		uploadedCount++
+	}
+
+	// Certificates generated with a replaced signature carry the old image
+	staleCount := int64(0)
+	if replacedAny {
+		var staleErr error
+		staleCount, staleErr = ctrl.participantRepo.MarkGeneratedParticipantsStale(certId)
+		if staleErr != nil {
+			slog.Warn("Signature UploadSignatures failed to mark generated certificates stale", "error", staleErr, "cert_id", certId)
+		}
+	}
+
+	allComplete := certificate.IsSigned
+	if uploadedCount > 0 {
+		complete, checkErr := ctrl.signatureRepo.AreAllSignaturesComplete(certId)
+		if checkErr != nil {
+			slog.Warn("Signature UploadSignatures failed to check if all signatures complete", "error", checkErr, "cert_id", certId)
+		} else {
+			allComplete = complete
+		}
+
+		if allComplete && !certificate.IsSigned {
+			if markErr := ctrl.certificateRepo.MarkAsSigned(certId); markErr != nil {
+				slog.Warn("Signature UploadSignatures failed to mark certificate as signed", "error", markErr, "cert_id", certId)
+			}
+			ownerEmail, notifyErr := util.ResolveUserEmail(certificate.UserID)
+			if notifyErr == nil {
+				notifyErr = util.SendAllSignaturesCompleteMail(ownerEmail, certificate.Name, certificate.ID, "", certificate.VerifyHost)
+			}
+			if notifyErr != nil {
+				slog.Warn("Signature UploadSignatures failed to send completion notification", "error", notifyErr, "cert_id", certId)
+			}
+		}
+	}
+
+	slog.Info("Signatures uploaded by owner", "cert_id", certId, "uploaded", uploadedCount, "failed", len(signerIds)-uploadedCount, "stale_count", staleCount)
+
+	return response.SendSuccess(c, "Signatures uploaded", fiber.Map{
+		"certificate_id": certId,
+		"uploaded_count": uploadedCount,
+		"failed_count":   len(signerIds) - uploadedCount,
+		"all_complete":   allComplete,
+		"stale_count":    staleCount,
+		"results":        results,
+	})
+}
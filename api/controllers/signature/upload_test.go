@@ -0,0 +1,25 @@
+package signature_controller
+
+import (
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+func TestUploadedSignerIds(t *testing.T) {
+	files := map[string][]*multipart.FileHeader{
+		"signature_signer-b": {{Filename: "b.png"}},
+		"signature_signer-a": {{Filename: "a.png"}, {Filename: "a-duplicate.png"}},
+		"signature_":         {{Filename: "empty.png"}},
+		"signature_signer-c": {},
+		"attachment":         {{Filename: "other.png"}},
+	}
+
+	signerIds, images := uploadedSignerIds(files)
+	if strings.Join(signerIds, ",") != "signer-a,signer-b" {
+		t.Fatalf("uploadedSignerIds() = %v, want [signer-a signer-b]", signerIds)
+	}
+	if images["signer-a"].Filename != "a.png" || images["signer-b"].Filename != "b.png" {
+		t.Errorf("unexpected images: %v", images)
+	}
+}
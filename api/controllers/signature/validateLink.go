@@ -0,0 +1,53 @@
+package signature_controller
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// ValidateSigningLink resolves a per-signer signing link token to the signature record it was issued for
+func (ctrl *SignatureController) ValidateSigningLink(c *fiber.Ctx) error {
+	token := c.Params("token")
+
+	if token == "" {
+		return response.SendFailed(c, "Signing token is required")
+	}
+
+	claims, err := util.ValidateSigningToken(token)
+	if err != nil {
+		if errors.Is(err, util.ErrExpiredSigningToken) {
+			slog.Warn("ValidateSigningLink: Expired signing token", "certificateId", claims.CertificateID, "signerId", claims.SignerID)
+			return response.SendUnauthorized(c, "Signing link has expired")
+		}
+		slog.Warn("ValidateSigningLink: Invalid signing token")
+		return response.SendUnauthorized(c, "Invalid signing link")
+	}
+
+	signature, err := ctrl.signatureRepo.GetByCertificateAndSignerId(claims.CertificateID, claims.SignerID)
+	if err != nil {
+		slog.Error("ValidateSigningLink: Error fetching signature", "error", err, "certificateId", claims.CertificateID, "signerId", claims.SignerID)
+		return response.SendInternalError(c, err)
+	}
+
+	if signature == nil {
+		return response.SendFailed(c, "Signature not found for this link")
+	}
+
+	return response.SendSuccess(c, "Signing link is valid", fiber.Map{
+		"signature": SignatureResponseDTO{
+			ID:            signature.ID,
+			SignerID:      signature.SignerID,
+			CertificateID: signature.CertificateID,
+			CreatedAt:     signature.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			IsSigned:      signature.IsSigned,
+			CreatedBy:     signature.CreatedBy,
+			IsRequested:   signature.IsRequested,
+			LastRequest:   signature.LastRequest.Format("2006-01-02T15:04:05Z07:00"),
+		},
+		"expires_at": claims.ExpiresAt,
+	})
+}
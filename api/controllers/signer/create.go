@@ -19,7 +19,7 @@ func (ctrl *SignerController) Create(c *fiber.Ctx) error {
 
 	if err := util.ValidateStruct(body); err != nil {
 		errors := util.GetValidationErrors(err)
-		return response.SendFailed(c, errors[0])
+		return response.SendValidationFailed(c, errors[0], util.GetFieldValidationErrors(err))
 	}
 
 	userId, status := middleware.GetUserFromContext(c)
@@ -5,9 +5,12 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	signermodel "github.com/sunthewhat/easy-cert-api/api/model/signerModel"
 	"github.com/sunthewhat/easy-cert-api/type/response"
 )
 
+// GetByUser lists the user's signers. With ?with_counts=true every signer also carries the number of
+// certificates they are part of, fetched with one grouped query.
 func (ctrl *SignerController) GetByUser(c *fiber.Ctx) error {
 	userId, success := middleware.GetUserFromContext(c)
 
@@ -24,5 +27,28 @@ func (ctrl *SignerController) GetByUser(c *fiber.Ctx) error {
 
 	slog.Info("Signer get by user successful", "count", len(signers))
 
-	return response.SendSuccess(c, "Signer fetched", signers)
+	if !c.QueryBool("with_counts") {
+		return response.SendSuccess(c, "Signer fetched", signers)
+	}
+
+	signerIds := make([]string, len(signers))
+	for i, signer := range signers {
+		signerIds[i] = signer.ID
+	}
+
+	counts, err := ctrl.signatureRepo.CountCertificatesBySigners(signerIds)
+	if err != nil {
+		slog.Error("Signer get by user certificate counts failed", "error", err, "user_id", userId)
+		return response.SendInternalError(c, err)
+	}
+
+	signersWithCounts := make([]signermodel.SignerWithCertificateCount, len(signers))
+	for i, signer := range signers {
+		signersWithCounts[i] = signermodel.SignerWithCertificateCount{
+			Signer:           signer,
+			CertificateCount: counts[signer.ID],
+		}
+	}
+
+	return response.SendSuccess(c, "Signer fetched", signersWithCounts)
 }
@@ -927,3 +927,62 @@ func TestSignerController_Create_DifferentCreatorsSameEmail(t *testing.T) {
 		t.Errorf("Second signer should be created by creator2, got %s", signer2.CreatedBy)
 	}
 }
+
+func TestSignerController_GetByUser_WithCounts(t *testing.T) {
+	app := fiber.New()
+	mockSignerRepo := signermodel.NewMockSignerRepository()
+	mockSignerRepo.GetByUserFunc = func(userId string) ([]*model.Signer, error) {
+		return []*model.Signer{
+			{ID: "signer1", Email: "signer1@example.com", DisplayName: "Signer One", CreatedBy: userId},
+			{ID: "signer2", Email: "signer2@example.com", DisplayName: "Signer Two", CreatedBy: userId},
+		}, nil
+	}
+
+	countCalls := 0
+	mockSignatureRepo := signaturemodel.NewMockSignatureRepository()
+	mockSignatureRepo.CountCertificatesBySignersFunc = func(signerIds []string) (map[string]int64, error) {
+		countCalls++
+		if len(signerIds) != 2 {
+			t.Errorf("Expected counts for 2 signers, got %v", signerIds)
+		}
+		return map[string]int64{"signer1": 12}, nil
+	}
+
+	ctrl := signer_controller.NewSignerController(mockSignerRepo, mockSignatureRepo, certificatemodel.NewMockCertificateRepository())
+
+	app.Get("/signer", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user123@example.com")
+		return ctrl.GetByUser(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/signer?with_counts=true", nil))
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	if countCalls != 1 {
+		t.Errorf("Expected a single grouped count query, got %d calls", countCalls)
+	}
+
+	var response map[string]any
+	body, _ := io.ReadAll(resp.Body)
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	data, ok := response["data"].([]any)
+	if !ok || len(data) != 2 {
+		t.Fatalf("Expected 2 signers, got %v", response["data"])
+	}
+
+	first := data[0].(map[string]any)
+	if first["id"] != "signer1" || first["certificate_count"] != float64(12) {
+		t.Errorf("Expected signer1 with 12 certificates, got %v", first)
+	}
+	second := data[1].(map[string]any)
+	if second["certificate_count"] != float64(0) {
+		t.Errorf("Expected signer2 with 0 certificates, got %v", second)
+	}
+}
@@ -0,0 +1,17 @@
+package webhook_controller
+
+import (
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+)
+
+// WebhookController handles callbacks from external providers
+type WebhookController struct {
+	participantRepo participantmodel.IParticipantRepository
+}
+
+// NewWebhookController creates a new webhook controller with injected dependencies
+func NewWebhookController(participantRepo participantmodel.IParticipantRepository) *WebhookController {
+	return &WebhookController{
+		participantRepo: participantRepo,
+	}
+}
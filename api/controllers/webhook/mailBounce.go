@@ -0,0 +1,62 @@
+package webhook_controller
+
+import (
+	"crypto/subtle"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/type/payload"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+// webhookSecretHeader carries the shared secret configured as mail_webhook_secret
+const webhookSecretHeader = "X-Webhook-Secret"
+
+// validWebhookSecret reports whether the request carries the configured shared secret.
+// The webhook is disabled while no secret is configured.
+func validWebhookSecret(c *fiber.Ctx) bool {
+	if common.Config == nil || common.Config.MailWebhookSecret == nil || *common.Config.MailWebhookSecret == "" {
+		return false
+	}
+	provided := c.Get(webhookSecretHeader)
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(*common.Config.MailWebhookSecret)) == 1
+}
+
+// MailBounce marks the certificate emails of the given certificate sent to a bounced address as "bounced",
+// so they show up as not delivered and are sent again on the next distribution
+func (ctrl *WebhookController) MailBounce(c *fiber.Ctx) error {
+	if !validWebhookSecret(c) {
+		slog.Warn("Mail bounce webhook rejected, invalid secret", "ip", c.IP())
+		return response.SendUnauthorized(c, "Invalid webhook secret")
+	}
+
+	body := new(payload.MailBouncePayload)
+	if err := c.BodyParser(body); err != nil {
+		return response.SendFailed(c, "Invalid request body")
+	}
+
+	body.Email = util.NormalizeEmail(body.Email)
+	if err := util.ValidateStruct(body); err != nil {
+		errors := util.GetValidationErrors(err)
+		return response.SendFailed(c, errors[0])
+	}
+
+	participantIds, err := ctrl.participantRepo.MarkBouncedByEmail(body.Email, body.CertificateId)
+	if err != nil {
+		slog.Error("Mail bounce webhook failed to mark participants", "error", err, "email", body.Email)
+		return response.SendInternalError(c, err)
+	}
+
+	slog.Info("Mail bounce recorded",
+		"email", body.Email,
+		"reason", body.Reason,
+		"cert_id", body.CertificateId,
+		"participant_count", len(participantIds))
+
+	return response.SendSuccess(c, "Bounce recorded", map[string]any{
+		"participant_ids": participantIds,
+		"bounced_count":   len(participantIds),
+	})
+}
@@ -0,0 +1,118 @@
+package webhook_controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	webhook_controller "github.com/sunthewhat/easy-cert-api/api/controllers/webhook"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestWebhookController_MailBounce(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	secret := "webhook-secret"
+
+	tests := []struct {
+		name           string
+		configSecret   *string
+		headerSecret   string
+		body           string
+		wantStatusCode int
+		wantEmail      string
+		wantCertId     string
+	}{
+		{
+			name:           "success - bounce recorded with normalized email",
+			configSecret:   &secret,
+			headerSecret:   secret,
+			body:           `{"email": " Alice@Example.com ", "reason": "mailbox full", "certificate_id": "4f1c2d7e-8a9b-4c3d-9e8f-1a2b3c4d5e6f"}`,
+			wantStatusCode: fiber.StatusOK,
+			wantEmail:      "alice@example.com",
+			wantCertId:     "4f1c2d7e-8a9b-4c3d-9e8f-1a2b3c4d5e6f",
+		},
+		{
+			name:           "failed - missing certificate",
+			configSecret:   &secret,
+			headerSecret:   secret,
+			body:           `{"email": "bob@example.com"}`,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:           "failed - wrong secret",
+			configSecret:   &secret,
+			headerSecret:   "guess",
+			body:           `{"email": "alice@example.com"}`,
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "failed - webhook disabled without secret",
+			configSecret:   nil,
+			headerSecret:   "",
+			body:           `{"email": "alice@example.com"}`,
+			wantStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "failed - invalid email",
+			configSecret:   &secret,
+			headerSecret:   secret,
+			body:           `{"email": "not-an-email", "certificate_id": "4f1c2d7e-8a9b-4c3d-9e8f-1a2b3c4d5e6f"}`,
+			wantStatusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common.Config = &shared.Config{MailWebhookSecret: tt.configSecret}
+
+			var gotEmail, gotCertId string
+			mockRepo := participantmodel.NewMockParticipantRepository()
+			mockRepo.MarkBouncedByEmailFunc = func(email string, certId string) ([]string, error) {
+				gotEmail = email
+				gotCertId = certId
+				return []string{"p1"}, nil
+			}
+
+			app := fiber.New()
+			ctrl := webhook_controller.NewWebhookController(mockRepo)
+			app.Post("/webhook/mail-bounce", ctrl.MailBounce)
+
+			req := httptest.NewRequest("POST", "/webhook/mail-bounce", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.headerSecret != "" {
+				req.Header.Set("X-Webhook-Secret", tt.headerSecret)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatusCode, resp.StatusCode)
+			}
+			if gotEmail != tt.wantEmail || gotCertId != tt.wantCertId {
+				t.Errorf("Expected lookup (%q, %q), got (%q, %q)", tt.wantEmail, tt.wantCertId, gotEmail, gotCertId)
+			}
+
+			if tt.wantStatusCode == fiber.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				var response map[string]any
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				data := response["data"].(map[string]any)
+				if data["bounced_count"] != float64(1) {
+					t.Errorf("Expected bounced_count=1, got %v", data["bounced_count"])
+				}
+			}
+		})
+	}
+}
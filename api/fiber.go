@@ -19,6 +19,7 @@ func InitFiber() {
 		Prefork:       false,
 		StrictRouting: true,
 		Network:       fiber.NetworkTCP,
+		BodyLimit:     middleware.AppBodyLimit(),
 	}
 	app := fiber.New(cfg)
 
@@ -46,13 +46,24 @@ func AuthMiddleware(ssoService util.ISSOService) fiber.Handler {
 			slog.Error("Failed to decode JWT token from refreshed token", "errror", err)
 		}
 
+		userId := util.ResolveUserId(jwtPayload)
+		if userId == "" {
+			slog.Warn("AuthMiddleware: configured user id claim missing from token",
+				"claim", util.UserIdClaim(),
+				"path", c.Path())
+			return response.SendUnauthorized(c, "Token does not contain a user identifier")
+		}
+
 		// Set user information in context for use by handlers
-		c.Locals("user_id", jwtPayload.Email)
+		c.Locals("user_id", userId)
+		if jwtPayload != nil && jwtPayload.Email != "" {
+			c.Locals("user_email", jwtPayload.Email)
+		}
 		// c.Locals("refresh_token", newToken.RefreshToken)
 		c.Set("X-Refresh-Token", newToken.RefreshToken)
 
 		slog.Info("AuthMiddleware: authentication successful",
-			"user_id", jwtPayload.Email,
+			"user_id", userId,
 			"path", c.Path(),
 			"method", c.Method(),
 			"ip", c.IP())
@@ -71,3 +82,15 @@ func GetUserFromContext(c *fiber.Ctx) (string, bool) {
 	}
 	return "", false
 }
+
+// GetUserEmailFromContext returns the signed-in user's email address from the token.
+// When the user id claim is the email itself it is used directly.
+func GetUserEmailFromContext(c *fiber.Ctx) (string, bool) {
+	if email, ok := c.Locals("user_email").(string); ok && email != "" {
+		return email, true
+	}
+	if util.UserIdClaim() == "email" {
+		return GetUserFromContext(c)
+	}
+	return "", false
+}
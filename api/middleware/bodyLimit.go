@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/response"
+)
+
+const (
+	// DefaultBodyLimit is the app-wide limit; it must stay above the 15MB resource upload limit
+	DefaultBodyLimit       = 20 * 1024 * 1024
+	DefaultImportBodyLimit = 10 * 1024 * 1024
+	DefaultDesignBodyLimit = 5 * 1024 * 1024
+)
+
+func limitOrDefault(limit *int, fallback int) int {
+	if limit == nil || *limit <= 0 {
+		return fallback
+	}
+	return *limit
+}
+
+// AppBodyLimit returns the configured app-wide request body limit in bytes
+func AppBodyLimit() int {
+	return limitOrDefault(common.Config.BodyLimitBytes, DefaultBodyLimit)
+}
+
+// BodyLimit rejects requests whose body exceeds limit bytes with 413
+func BodyLimit(limit int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		size := c.Request().Header.ContentLength()
+		if bodyLen := len(c.Body()); bodyLen > size {
+			size = bodyLen
+		}
+
+		if size > limit {
+			slog.Warn("Request body exceeds route limit",
+				"path", c.Path(),
+				"size", size,
+				"limit", limit)
+			return response.SendPayloadTooLarge(c, fmt.Sprintf("Request body too large, limit is %d bytes", limit))
+		}
+
+		return c.Next()
+	}
+}
+
+// ImportBodyLimit applies the participant import body limit
+func ImportBodyLimit() fiber.Handler {
+	return BodyLimit(limitOrDefault(common.Config.ImportBodyLimitBytes, DefaultImportBodyLimit))
+}
+
+// DesignBodyLimit applies the certificate design body limit
+func DesignBodyLimit() fiber.Handler {
+	return BodyLimit(limitOrDefault(common.Config.DesignBodyLimitBytes, DefaultDesignBodyLimit))
+}
@@ -1,28 +1,81 @@
 package middleware
 
 import (
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/sunthewhat/easy-cert-api/common"
 )
 
+// DefaultCorsMaxAge is how long, in seconds, browsers may cache a preflight response
+const DefaultCorsMaxAge = 600
+
+// Cors allows cross-origin requests with credentials from the origins listed in cors.
+// Wildcard and malformed entries are dropped, since browsers refuse credentials with a wildcard origin;
+// when nothing valid remains every cross-origin request is refused instead of falling back to "*".
 func Cors() fiber.Handler {
-	// origins is the value of allowed CORS addresses, separated by comma (,).
-	// Example: "https://www.google.com, https://www.bsthun.com, http://localhost:8080"
-	origins := ""
-
-	for i, s := range common.Config.Cors {
-		origins += *s
-		if i < len(common.Config.Cors)-1 {
-			origins += ", "
-		}
-	}
+	origins := normalizeCorsOrigins(common.Config.Cors)
 
 	config := cors.Config{
-		AllowOrigins:     origins,
+		AllowOrigins:     strings.Join(origins, ","),
+		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization",
 		AllowCredentials: true,
-		ExposeHeaders:    "X-Refresh-Token",
+		ExposeHeaders:    "X-Refresh-Token,Content-Disposition",
+		MaxAge:           limitOrDefault(common.Config.CorsMaxAgeSecs, DefaultCorsMaxAge),
+	}
+
+	if len(origins) == 0 {
+		slog.Error("No valid CORS origins configured, cross-origin requests will be refused")
+		config.AllowOriginsFunc = func(origin string) bool { return false }
+	} else {
+		slog.Info("CORS enabled", "origins", origins)
 	}
 
 	return cors.New(config)
 }
+
+// normalizeCorsOrigins returns the configured origins as lowercase scheme://host[:port] values,
+// skipping empty, wildcard and malformed entries. Subdomain wildcards like https://*.example.com are kept.
+func normalizeCorsOrigins(entries []*string) []string {
+	seen := make(map[string]bool)
+	var origins []string
+
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		origin := strings.ToLower(strings.TrimRight(strings.TrimSpace(*entry), "/"))
+		if origin == "" {
+			continue
+		}
+		if origin == "*" {
+			slog.Warn("Ignoring wildcard CORS origin, origins must be listed explicitly when credentials are allowed")
+			continue
+		}
+
+		parsed, err := url.Parse(strings.Replace(origin, "://*.", "://", 1))
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" ||
+			parsed.Path != "" || parsed.RawQuery != "" || parsed.Fragment != "" || parsed.User != nil || strings.Contains(parsed.Host, "*") {
+			slog.Warn("Ignoring malformed CORS origin", "origin", *entry)
+			continue
+		}
+		if port := parsed.Port(); port != "" {
+			if _, err := strconv.Atoi(port); err != nil {
+				slog.Warn("Ignoring malformed CORS origin", "origin", *entry)
+				continue
+			}
+		}
+
+		if !seen[origin] {
+			seen[origin] = true
+			origins = append(origins, origin)
+		}
+	}
+
+	return origins
+}
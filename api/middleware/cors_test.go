@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func corsOrigins(origins ...string) []*string {
+	entries := make([]*string, len(origins))
+	for i := range origins {
+		entries[i] = &origins[i]
+	}
+	return entries
+}
+
+// TestNormalizeCorsOrigins tests that only explicit, well-formed origins are kept
+func TestNormalizeCorsOrigins(t *testing.T) {
+	entries := append(corsOrigins(
+		" https://App.Example.com/ ",
+		"https://app.example.com",
+		"http://localhost:8080",
+		"https://*.example.org",
+		"*",
+		"",
+		"example.com",
+		"ftp://example.com",
+		"https://example.com/path",
+		"https://example.com:port",
+	), nil)
+
+	assert.Equal(t, []string{
+		"https://app.example.com",
+		"http://localhost:8080",
+		"https://*.example.org",
+	}, normalizeCorsOrigins(entries))
+}
+
+// TestCors tests simple and preflight requests against the configured origins
+func TestCors(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	tests := []struct {
+		name       string
+		origins    []*string
+		origin     string
+		wantOrigin string
+	}{
+		{name: "allowed origin", origins: corsOrigins("https://app.example.com"), origin: "https://app.example.com", wantOrigin: "https://app.example.com"},
+		{name: "subdomain wildcard", origins: corsOrigins("https://*.example.com"), origin: "https://admin.example.com", wantOrigin: "https://admin.example.com"},
+		{name: "unknown origin", origins: corsOrigins("https://app.example.com"), origin: "https://evil.example.net"},
+		{name: "wildcard only refuses everyone", origins: corsOrigins("*"), origin: "https://app.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common.Config = &shared.Config{Cors: tt.origins}
+
+			app := fiber.New()
+			app.Use(Cors())
+			app.Get("/resource", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+			req := httptest.NewRequest("GET", "/resource", nil)
+			req.Header.Set("Origin", tt.origin)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+			if tt.wantOrigin != "" {
+				assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+			}
+
+			preflight := httptest.NewRequest("OPTIONS", "/resource", nil)
+			preflight.Header.Set("Origin", tt.origin)
+			preflight.Header.Set("Access-Control-Request-Method", "PUT")
+			resp, err = app.Test(preflight)
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+			assert.Equal(t, tt.wantOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+			if tt.wantOrigin != "" {
+				assert.Contains(t, resp.Header.Get("Access-Control-Allow-Methods"), "PUT")
+				assert.Equal(t, "600", resp.Header.Get("Access-Control-Max-Age"))
+			}
+		})
+	}
+}
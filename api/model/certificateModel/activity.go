@@ -0,0 +1,57 @@
+package certificatemodel
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+	"gorm.io/gorm"
+)
+
+// Certificate activity actions recorded in the activity log
+const (
+	ActivityEdited        = "edited"
+	ActivityDistributed   = "distributed"
+	ActivityRevoked       = "revoked"
+	ActivitySigned        = "signed"
+	ActivitySignerRemoved = "signer_removed"
+)
+
+const (
+	DefaultActivityLimit = 20
+	MaxActivityLimit     = 100
+)
+
+// RecordActivity appends an entry to the certificate's activity log. actor is the user or signer who acted.
+func (r *CertificateRepository) RecordActivity(certificateId, actor, action, detail string) error {
+	activity := &model.CertificateActivity{
+		CertificateID: certificateId,
+		Actor:         actor,
+		Action:        action,
+		Detail:        detail,
+	}
+
+	if err := r.q.CertificateActivity.Create(activity); err != nil {
+		slog.Error("Certificate RecordActivity Error", "error", err, "certificate_id", certificateId, "action", action)
+		return err
+	}
+
+	return nil
+}
+
+// GetRecentActivity returns up to limit activity log entries of a certificate, newest first
+func (r *CertificateRepository) GetRecentActivity(certificateId string, limit int) ([]*model.CertificateActivity, error) {
+	activities, queryErr := r.q.CertificateActivity.Where(
+		r.q.CertificateActivity.CertificateID.Eq(certificateId),
+	).Order(r.q.CertificateActivity.CreatedAt.Desc()).Limit(limit).Find()
+
+	if queryErr != nil {
+		if errors.Is(queryErr, gorm.ErrRecordNotFound) {
+			return []*model.CertificateActivity{}, nil
+		}
+		slog.Error("Certificate GetRecentActivity Error", "error", queryErr, "certificate_id", certificateId)
+		return nil, queryErr
+	}
+
+	return activities, nil
+}
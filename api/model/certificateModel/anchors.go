@@ -0,0 +1,135 @@
+package certificatemodel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+// designAnchorOrderKey is the optional top-level design field listing anchors in the order owners want them
+// shown, e.g. ["name", "date", "score"]
+const designAnchorOrderKey = "anchorOrder"
+
+// DesignAnchorOrder returns the explicit anchor order of a parsed design, nil when the design has none
+func DesignAnchorOrder(design map[string]any) []string {
+	entries, ok := design[designAnchorOrderKey].([]any)
+	if !ok {
+		return nil
+	}
+
+	order := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if name, ok := entry.(string); ok {
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+// OrderAnchors sorts anchors by an explicit order; anchors it does not list follow alphabetically.
+// Without an explicit order all anchors are sorted alphabetically.
+func OrderAnchors(anchors []string, order []string) []string {
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		if _, seen := rank[name]; !seen {
+			rank[name] = i
+		}
+	}
+	position := func(name string) int {
+		if i, ok := rank[name]; ok {
+			return i
+		}
+		return len(order)
+	}
+
+	sorted := append([]string{}, anchors...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, pj := position(sorted[i]), position(sorted[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// ErrInvalidDesignFormat is returned for design JSON without an objects array
+var ErrInvalidDesignFormat = errors.New("invalid design format - objects array not found")
+
+// DesignAnchorObject is a placeholder object of a design, one whose id is PLACEHOLDER-<anchor name>
+type DesignAnchorObject struct {
+	Name   string
+	Object map[string]any
+}
+
+// ParseDesignAnchorObjects parses the design JSON and returns its placeholder objects in design order,
+// along with the design's explicit anchor order
+func ParseDesignAnchorObjects(designJSON string) ([]DesignAnchorObject, []string, error) {
+	var design map[string]any
+	if err := json.Unmarshal([]byte(designJSON), &design); err != nil {
+		return nil, nil, fmt.Errorf("design is not valid JSON: %w", err)
+	}
+
+	objects, ok := design["objects"].([]any)
+	if !ok {
+		return nil, nil, ErrInvalidDesignFormat
+	}
+
+	anchorObjects := []DesignAnchorObject{}
+	for _, obj := range objects {
+		objMap, ok := obj.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		id, exists := objMap["id"].(string)
+		if exists && strings.HasPrefix(id, "PLACEHOLDER-") {
+			anchorObjects = append(anchorObjects, DesignAnchorObject{Name: strings.TrimPrefix(id, "PLACEHOLDER-"), Object: objMap})
+		}
+	}
+
+	return anchorObjects, DesignAnchorOrder(design), nil
+}
+
+// ExtractDesignAnchors validates the design JSON and returns its anchor names, ordered by the design's
+// explicit anchor order or alphabetically
+func ExtractDesignAnchors(designJSON string) ([]string, error) {
+	anchorObjects, order, err := ParseDesignAnchorObjects(designJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	anchors := make([]string, len(anchorObjects))
+	for i, anchor := range anchorObjects {
+		anchors[i] = anchor.Name
+	}
+	return OrderAnchors(anchors, order), nil
+}
+
+// storedAnchors returns the anchors to store alongside a design, nil when the design cannot be parsed
+func storedAnchors(designJSON string) []string {
+	anchors, err := ExtractDesignAnchors(designJSON)
+	if err != nil {
+		slog.Warn("Certificate design anchors not stored", "error", err)
+		return nil
+	}
+	return anchors
+}
+
+// SetAnchors stores the anchor list extracted from the certificate's current design
+func (r *CertificateRepository) SetAnchors(certificateId string, anchors []string) error {
+	_, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certificateId)).
+		Select(r.q.Certificate.Anchors).
+		Updates(&model.Certificate{Anchors: anchors})
+	if queryErr != nil {
+		slog.Error("Set certificate anchors Error", "error", queryErr, "certificate_id", certificateId)
+		return queryErr
+	}
+	certificates.invalidate(certificateId)
+	return nil
+}
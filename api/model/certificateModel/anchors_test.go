@@ -0,0 +1,40 @@
+package certificatemodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractDesignAnchors(t *testing.T) {
+	anchors, err := ExtractDesignAnchors(`{"objects":[{"id":"PLACEHOLDER-name"},{"id":"logo"},{"id":"PLACEHOLDER-course"}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"course", "name"}, anchors, "anchors fall back to alphabetical order")
+
+	anchors, err = ExtractDesignAnchors(`{"anchorOrder":["name","date"],"objects":[{"id":"PLACEHOLDER-course"},{"id":"PLACEHOLDER-name"},{"id":"PLACEHOLDER-award"}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "award", "course"}, anchors, "listed anchors come first, the rest alphabetically")
+
+	anchors, err = ExtractDesignAnchors(`{"objects":[]}`)
+	require.NoError(t, err)
+	assert.NotNil(t, anchors, "a design without anchors should store an empty list")
+	assert.Empty(t, anchors)
+
+	_, err = ExtractDesignAnchors("not json")
+	assert.Error(t, err)
+
+	_, err = ExtractDesignAnchors(`{"other":"data"}`)
+	assert.Error(t, err)
+
+	assert.Nil(t, storedAnchors("not json"))
+}
+
+func TestOrderAnchors(t *testing.T) {
+	assert.Equal(t, []string{"b", "c", "a"}, OrderAnchors([]string{"a", "b", "c"}, []string{"b", "c"}))
+	assert.Equal(t, []string{"a", "b"}, OrderAnchors([]string{"b", "a"}, nil))
+	assert.Empty(t, OrderAnchors(nil, []string{"a"}))
+
+	assert.Equal(t, []string{"b", "a"}, DesignAnchorOrder(map[string]any{"anchorOrder": []any{"b", 1, "a"}}))
+	assert.Nil(t, DesignAnchorOrder(map[string]any{"anchorOrder": "a,b"}))
+}
@@ -0,0 +1,101 @@
+package certificatemodel
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+const (
+	defaultCertificateCacheTTL = 30 * time.Second
+
+	// certificateCacheSweepSize triggers a sweep of expired entries once the cache grows past it
+	certificateCacheSweepSize = 1000
+)
+
+type cachedCertificate struct {
+	certificate *model.Certificate
+	expiresAt   time.Time
+}
+
+// certificateCache is a short-lived, process-wide cache of certificate records by ID. It is shared by
+// every repository instance because validation paths create a fresh repository per call.
+type certificateCache struct {
+	mu      sync.RWMutex
+	entries map[string]cachedCertificate
+	now     func() time.Time
+}
+
+var certificates = &certificateCache{
+	entries: make(map[string]cachedCertificate),
+	now:     time.Now,
+}
+
+// certificateCacheTTL returns the cache lifetime configured through certificate_cache_ttl_seconds;
+// zero or negative disables caching
+func certificateCacheTTL() time.Duration {
+	if common.Config != nil && common.Config.CertificateCacheTTLSeconds != nil {
+		return time.Duration(*common.Config.CertificateCacheTTLSeconds) * time.Second
+	}
+	return defaultCertificateCacheTTL
+}
+
+func (c *certificateCache) get(certId string) (*model.Certificate, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[certId]
+	c.mu.RUnlock()
+
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+
+	// Hand out a copy so callers cannot mutate the cached record
+	certificate := *entry.certificate
+	return &certificate, true
+}
+
+func (c *certificateCache) set(certificate *model.Certificate, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= certificateCacheSweepSize {
+		for id, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+	}
+
+	cached := *certificate
+	c.entries[certificate.ID] = cachedCertificate{certificate: &cached, expiresAt: now.Add(ttl)}
+}
+
+func (c *certificateCache) invalidate(certId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, certId)
+}
+
+// GetByIdCached is GetById backed by a short-TTL cache, meant for hot read-only paths such as validating
+// every participant of a bulk import against the same design. Update, Delete and SetAnchors invalidate the entry.
+func (r *CertificateRepository) GetByIdCached(certId string) (*model.Certificate, error) {
+	ttl := certificateCacheTTL()
+	if ttl <= 0 {
+		return r.GetById(certId)
+	}
+
+	if certificate, ok := certificates.get(certId); ok {
+		return certificate, nil
+	}
+
+	certificate, err := r.GetById(certId)
+	if err != nil || certificate == nil {
+		return certificate, err
+	}
+
+	certificates.set(certificate, ttl)
+	return certificate, nil
+}
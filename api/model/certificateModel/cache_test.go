@@ -0,0 +1,96 @@
+package certificatemodel
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+func newTestCertificateCache(now *time.Time) *certificateCache {
+	return &certificateCache{
+		entries: make(map[string]cachedCertificate),
+		now:     func() time.Time { return *now },
+	}
+}
+
+// TestCertificateCache_GetSetExpire tests cache hits, copies and expiry
+func TestCertificateCache_GetSetExpire(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newTestCertificateCache(&now)
+
+	_, ok := cache.get("cert-1")
+	assert.False(t, ok, "empty cache should miss")
+
+	cache.set(&model.Certificate{ID: "cert-1", Design: "design-1"}, 30*time.Second)
+
+	cached, ok := cache.get("cert-1")
+	require.True(t, ok)
+	assert.Equal(t, "design-1", cached.Design)
+
+	// Mutating the returned record must not leak into the cache
+	cached.Design = "mutated"
+	again, ok := cache.get("cert-1")
+	require.True(t, ok)
+	assert.Equal(t, "design-1", again.Design)
+
+	now = now.Add(30 * time.Second)
+	_, ok = cache.get("cert-1")
+	assert.False(t, ok, "entry should expire after its TTL")
+}
+
+// TestCertificateCache_Invalidate tests that invalidated entries are dropped
+func TestCertificateCache_Invalidate(t *testing.T) {
+	now := time.Now()
+	cache := newTestCertificateCache(&now)
+
+	cache.set(&model.Certificate{ID: "cert-1"}, time.Minute)
+	cache.set(&model.Certificate{ID: "cert-2"}, time.Minute)
+	cache.invalidate("cert-1")
+
+	_, ok := cache.get("cert-1")
+	assert.False(t, ok)
+	_, ok = cache.get("cert-2")
+	assert.True(t, ok)
+}
+
+// TestCertificateCache_Concurrent exercises the cache from several goroutines; run with -race
+func TestCertificateCache_Concurrent(t *testing.T) {
+	cache := &certificateCache{entries: make(map[string]cachedCertificate), now: time.Now}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				cache.set(&model.Certificate{ID: "cert-1"}, time.Minute)
+				cache.get("cert-1")
+				cache.invalidate("cert-1")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestCertificateCacheTTL tests the configured cache lifetime
+func TestCertificateCacheTTL(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	common.Config = &shared.Config{}
+	assert.Equal(t, 30*time.Second, certificateCacheTTL())
+
+	seconds := 5
+	common.Config = &shared.Config{CertificateCacheTTLSeconds: &seconds}
+	assert.Equal(t, 5*time.Second, certificateCacheTTL())
+
+	disabled := 0
+	common.Config = &shared.Config{CertificateCacheTTLSeconds: &disabled}
+	assert.Equal(t, time.Duration(0), certificateCacheTTL())
+}
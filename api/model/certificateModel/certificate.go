@@ -2,12 +2,14 @@ package certificatemodel
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/sunthewhat/easy-cert-api/common"
 	"github.com/sunthewhat/easy-cert-api/type/payload"
 	"github.com/sunthewhat/easy-cert-api/type/shared/model"
 	"github.com/sunthewhat/easy-cert-api/type/shared/query"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 )
 
@@ -32,10 +34,25 @@ func GetDefaultRepository() *CertificateRepository {
 
 // Create creates a new certificate
 func (r *CertificateRepository) Create(certData payload.CreateCertificatePayload, userId string) (*model.Certificate, error) {
+	if maxCertificatesPerUser() > 0 {
+		existing, err := r.CountByUser(userId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count existing certificates: %w", err)
+		}
+		if err := checkCertificateQuota(existing); err != nil {
+			slog.Warn("Certificate Create quota exceeded", "error", err, "userId", userId)
+			return nil, err
+		}
+	}
+
 	cert := &model.Certificate{
-		UserID: userId,
-		Name:   certData.Name,
-		Design: certData.Design,
+		UserID:  userId,
+		Name:    certData.Name,
+		Design:  certData.Design,
+		Anchors: storedAnchors(certData.Design),
+	}
+	if certData.TemplateID != "" {
+		cert.SourceTemplateID = &certData.TemplateID
 	}
 
 	createErr := r.q.Certificate.Create(cert)
@@ -78,6 +95,65 @@ func (r *CertificateRepository) GetByUser(userId string) ([]*model.Certificate,
 	return certs, nil
 }
 
+// GetByUserAndTemplate retrieves the user's certificates created from the given template, newest first
+func (r *CertificateRepository) GetByUserAndTemplate(userId string, templateId string) ([]*model.Certificate, error) {
+	certs, queryErr := r.q.Certificate.Where(
+		r.q.Certificate.UserID.Eq(userId),
+		r.q.Certificate.SourceTemplateID.Eq(templateId),
+	).Order(r.q.Certificate.CreatedAt.Desc()).Find()
+
+	if queryErr != nil {
+		slog.Error("Certificate GetByUserAndTemplate", "error", queryErr, "template_id", templateId)
+		return nil, queryErr
+	}
+
+	return certs, nil
+}
+
+// Certificate list filters derived from the IsSigned/IsDistributed flags
+const (
+	StatusAwaitingSignatures = "awaiting_signatures"
+	StatusReadyToDistribute  = "ready_to_distribute"
+	StatusDistributed        = "distributed"
+)
+
+// IsValidStatus reports whether status is a supported certificate list filter
+func IsValidStatus(status string) bool {
+	switch status {
+	case StatusAwaitingSignatures, StatusReadyToDistribute, StatusDistributed:
+		return true
+	}
+	return false
+}
+
+// GetByUserAndStatus retrieves the user's certificates matching a status filter
+func (r *CertificateRepository) GetByUserAndStatus(userId string, status string) ([]*model.Certificate, error) {
+	do := r.q.Certificate.Where(r.q.Certificate.UserID.Eq(userId))
+
+	switch status {
+	case StatusAwaitingSignatures:
+		do = do.Where(r.q.Certificate.IsSigned.Is(false), r.q.Certificate.IsDistributed.Is(false))
+	case StatusReadyToDistribute:
+		do = do.Where(r.q.Certificate.IsSigned.Is(true), r.q.Certificate.IsDistributed.Is(false))
+	case StatusDistributed:
+		do = do.Where(r.q.Certificate.IsDistributed.Is(true))
+	default:
+		return nil, fmt.Errorf("unknown certificate status filter: %s", status)
+	}
+
+	certs, queryErr := do.Find()
+
+	if queryErr != nil {
+		if errors.Is(queryErr, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("Certificate GetByUserAndStatus", "error", queryErr, "status", status)
+		return nil, queryErr
+	}
+
+	return certs, nil
+}
+
 // GetById retrieves a certificate by ID
 func (r *CertificateRepository) GetById(certId string) (*model.Certificate, error) {
 	cert, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certId)).First()
@@ -93,6 +169,25 @@ func (r *CertificateRepository) GetById(certId string) (*model.Certificate, erro
 	return cert, nil
 }
 
+// GetByIds retrieves all certificates matching the given IDs in a single query
+func (r *CertificateRepository) GetByIds(certIds []string) ([]*model.Certificate, error) {
+	if len(certIds) == 0 {
+		return []*model.Certificate{}, nil
+	}
+
+	certs, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.In(certIds...)).Find()
+
+	if queryErr != nil {
+		if errors.Is(queryErr, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("Certificate GetByIds", "error", queryErr, "count", len(certIds))
+		return nil, queryErr
+	}
+
+	return certs, nil
+}
+
 // Delete deletes a certificate by ID
 func (r *CertificateRepository) Delete(id string) (*model.Certificate, error) {
 	cert, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(id)).First()
@@ -109,12 +204,14 @@ func (r *CertificateRepository) Delete(id string) (*model.Certificate, error) {
 		slog.Error("Certificate Delete", "error", deleteErr)
 		return nil, deleteErr
 	}
+	certificates.invalidate(id)
 
 	return cert, nil
 }
 
-// Update updates a certificate's name and/or design
-func (r *CertificateRepository) Update(id string, name string, design string) (*model.Certificate, error) {
+// Update updates a certificate's name and/or design. Autosaves only replace the design; an explicit save also
+// moves the design of the previous explicit save into PreviousDesign, so the design diff spans a whole edit.
+func (r *CertificateRepository) Update(id string, name string, design string, autosave bool) (*model.Certificate, error) {
 	cert, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(id)).First()
 	if queryErr != nil {
 		if errors.Is(queryErr, gorm.ErrRecordNotFound) {
@@ -124,23 +221,41 @@ func (r *CertificateRepository) Update(id string, name string, design string) (*
 		return nil, queryErr
 	}
 
-	updates := make(map[string]any)
+	updates := &model.Certificate{Name: name, Design: design}
+	var columns []field.Expr
 	if name != "" {
-		updates["name"] = name
+		columns = append(columns, r.q.Certificate.Name)
 	}
 	if design != "" {
-		updates["design"] = design
+		// The anchor list is re-extracted only when the design changes
+		updates.Anchors = storedAnchors(design)
+		columns = append(columns, r.q.Certificate.Design, r.q.Certificate.Anchors)
+
+		// Keep the last saved design so owners can see what an edit changed
+		if !autosave {
+			savedDesign := cert.SavedDesign
+			if savedDesign == "" {
+				savedDesign = cert.Design
+			}
+			if design != savedDesign {
+				updates.PreviousDesign = savedDesign
+				columns = append(columns, r.q.Certificate.PreviousDesign)
+			}
+			updates.SavedDesign = design
+			columns = append(columns, r.q.Certificate.SavedDesign)
+		}
 	}
 
-	if len(updates) == 0 {
+	if len(columns) == 0 {
 		return cert, nil
 	}
 
-	_, updateErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(id)).Updates(updates)
+	_, updateErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(id)).Select(columns...).Updates(updates)
 	if updateErr != nil {
 		slog.Error("Certificate Update", "error", updateErr)
 		return nil, updateErr
 	}
+	certificates.invalidate(id)
 
 	// Fetch updated certificate
 	updatedCert, fetchErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(id)).First()
@@ -152,6 +267,30 @@ func (r *CertificateRepository) Update(id string, name string, design string) (*
 	return updatedCert, nil
 }
 
+// BulkRename renames the given certificates (ID -> new name) through Update inside a single transaction,
+// so either every rename is applied or none is
+func (r *CertificateRepository) BulkRename(names map[string]string) ([]*model.Certificate, error) {
+	renamed := make([]*model.Certificate, 0, len(names))
+
+	err := r.q.Transaction(func(tx *query.Query) error {
+		txRepo := NewCertificateRepository(tx)
+		for id, name := range names {
+			cert, err := txRepo.Update(id, name, "", false)
+			if err != nil {
+				return fmt.Errorf("rename certificate %s: %w", id, err)
+			}
+			renamed = append(renamed, cert)
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("Certificate BulkRename", "error", err, "count", len(names))
+		return nil, err
+	}
+
+	return renamed, nil
+}
+
 // AddThumbnailUrl adds or updates the thumbnail URL for a certificate
 func (r *CertificateRepository) AddThumbnailUrl(certificateId string, thumbnailUrl string) error {
 	_, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certificateId)).Update(r.q.Certificate.ThumbnailURL, thumbnailUrl)
@@ -182,6 +321,17 @@ func (r *CertificateRepository) MarkAsDistributed(certificateId string) error {
 	return nil
 }
 
+// SetDistributionEmailField stores the participant data field certificate mail was last sent to
+func (r *CertificateRepository) SetDistributionEmailField(certificateId string, emailField string) error {
+	_, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certificateId)).Update(r.q.Certificate.DistributionEmailField, emailField)
+	if queryErr != nil {
+		slog.Error("Set certificate distribution email field Error", "error", queryErr, "certificate_id", certificateId)
+		return queryErr
+	}
+	certificates.invalidate(certificateId)
+	return nil
+}
+
 // MarkAsSigned marks a certificate as fully signed (all signatures complete)
 func (r *CertificateRepository) MarkAsSigned(certificateId string) error {
 	_, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certificateId)).Update(r.q.Certificate.IsSigned, true)
@@ -203,3 +353,66 @@ func (r *CertificateRepository) MarkAsUnsigned(certificateId string) error {
 	slog.Info("Certificate marked as unsigned", "certificate_id", certificateId)
 	return nil
 }
+
+// SetVerifyHost sets the verification host used in QR codes and signing links; empty falls back to the global verify_host
+func (r *CertificateRepository) SetVerifyHost(certificateId string, verifyHost string) error {
+	_, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certificateId)).Update(r.q.Certificate.VerifyHost, verifyHost)
+	if queryErr != nil {
+		slog.Error("Set certificate verify host Error", "error", queryErr, "certificate_id", certificateId)
+		return queryErr
+	}
+	return nil
+}
+
+// SetPdfFooter enables or disables the certificate/participant ID and issue date footer on generated PDFs
+func (r *CertificateRepository) SetPdfFooter(certificateId string, enabled bool) error {
+	_, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certificateId)).Update(r.q.Certificate.PdfFooter, enabled)
+	if queryErr != nil {
+		slog.Error("Set certificate pdf footer Error", "error", queryErr, "certificate_id", certificateId)
+		return queryErr
+	}
+	return nil
+}
+
+// SetArchiveFilenameTemplate sets the template naming PDFs inside generated ZIP archives; empty restores the default
+func (r *CertificateRepository) SetArchiveFilenameTemplate(certificateId string, template string) error {
+	_, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certificateId)).Update(r.q.Certificate.ArchiveFilenameTemplate, template)
+	if queryErr != nil {
+		slog.Error("Set certificate archive filename template Error", "error", queryErr, "certificate_id", certificateId)
+		return queryErr
+	}
+	return nil
+}
+
+// SetSequentialSigning enables or disables enforcing the signature order when signers sign
+func (r *CertificateRepository) SetSequentialSigning(certificateId string, enabled bool) error {
+	_, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certificateId)).Update(r.q.Certificate.SequentialSigning, enabled)
+	if queryErr != nil {
+		slog.Error("Set certificate sequential signing Error", "error", queryErr, "certificate_id", certificateId)
+		return queryErr
+	}
+	return nil
+}
+
+// SetSignatureBackground sets the hex color signatures are flattened onto when signed; empty keeps transparency
+func (r *CertificateRepository) SetSignatureBackground(certificateId string, background string) error {
+	_, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certificateId)).Update(r.q.Certificate.SignatureBackground, background)
+	if queryErr != nil {
+		slog.Error("Set certificate signature background Error", "error", queryErr, "certificate_id", certificateId)
+		return queryErr
+	}
+	return nil
+}
+
+// SetPdfLayout overrides the PDF page margin and image fit mode; a nil margin or empty fit mode uses the configured default
+func (r *CertificateRepository) SetPdfLayout(certificateId string, marginMm *float64, fitMode string) error {
+	_, queryErr := r.q.Certificate.Where(r.q.Certificate.ID.Eq(certificateId)).Updates(map[string]any{
+		"pdf_margin_mm": marginMm,
+		"pdf_fit_mode":  fitMode,
+	})
+	if queryErr != nil {
+		slog.Error("Set certificate pdf layout Error", "error", queryErr, "certificate_id", certificateId)
+		return queryErr
+	}
+	return nil
+}
@@ -112,6 +112,75 @@ func TestCertificateRepository_GetByUser(t *testing.T) {
 	assert.Equal(t, "cert-2", found[1].ID)
 }
 
+// TestCertificateRepository_GetByUserAndStatus tests filtering a user's certificates by signing status
+func TestCertificateRepository_GetByUserAndStatus(t *testing.T) {
+	container := helpers.SetupTestDatabase(t)
+	db := helpers.GetTestDB(t, container)
+	q := query.Use(db)
+	repo := NewCertificateRepository(q)
+
+	certs := []model.Certificate{
+		{ID: "cert-awaiting", UserID: "user-1", Name: "Awaiting", Design: "design-1"},
+		{ID: "cert-ready", UserID: "user-1", Name: "Ready", Design: "design-1", IsSigned: true},
+		{ID: "cert-distributed", UserID: "user-1", Name: "Distributed", Design: "design-1", IsSigned: true, IsDistributed: true},
+		{ID: "cert-other", UserID: "user-2", Name: "Other", Design: "design-1"},
+	}
+	for _, c := range certs {
+		err := db.Create(&c).Error
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		status string
+		wantId string
+	}{
+		{status: StatusAwaitingSignatures, wantId: "cert-awaiting"},
+		{status: StatusReadyToDistribute, wantId: "cert-ready"},
+		{status: StatusDistributed, wantId: "cert-distributed"},
+	}
+
+	for _, tt := range tests {
+		found, err := repo.GetByUserAndStatus("user-1", tt.status)
+		require.NoError(t, err)
+		require.Len(t, found, 1, "status %s", tt.status)
+		assert.Equal(t, tt.wantId, found[0].ID)
+	}
+
+	_, err := repo.GetByUserAndStatus("user-1", "unknown")
+	assert.Error(t, err, "Should reject unknown status")
+}
+
+// TestCertificateRepository_GetByIds tests retrieving certificates by a list of IDs
+func TestCertificateRepository_GetByIds(t *testing.T) {
+	container := helpers.SetupTestDatabase(t)
+	db := helpers.GetTestDB(t, container)
+	q := query.Use(db)
+	repo := NewCertificateRepository(q)
+
+	// Create test certificates
+	certs := []model.Certificate{
+		{ID: "cert-1", UserID: "user-1", Name: "Cert 1", Design: "design-1"},
+		{ID: "cert-2", UserID: "user-1", Name: "Cert 2", Design: "design-1"},
+		{ID: "cert-3", UserID: "user-2", Name: "Cert 3", Design: "design-1"},
+	}
+	for _, c := range certs {
+		err := db.Create(&c).Error
+		require.NoError(t, err)
+	}
+
+	// Test: Get by IDs including a missing one
+	found, err := repo.GetByIds([]string{"cert-1", "cert-3", "missing"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, found, 2, "Should find 2 of the requested certificates")
+
+	// Test: Empty input
+	empty, err := repo.GetByIds([]string{})
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
 // TestCertificateRepository_GetAll tests retrieving all certificates
 func TestCertificateRepository_GetAll(t *testing.T) {
 	container := helpers.SetupTestDatabase(t)
@@ -157,7 +226,7 @@ func TestCertificateRepository_Update(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test: Update name and design
-	updated, err := repo.Update("cert-update", "Updated Name", "new-design")
+	updated, err := repo.Update("cert-update", "Updated Name", "new-design", false)
 
 	// Assert
 	require.NoError(t, err)
@@ -190,7 +259,7 @@ func TestCertificateRepository_Update_PartialUpdate(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test: Update only name
-	updated, err := repo.Update("cert-partial", "New Name", "")
+	updated, err := repo.Update("cert-partial", "New Name", "", false)
 
 	// Assert
 	require.NoError(t, err)
@@ -216,7 +285,7 @@ func TestCertificateRepository_Update_NoChanges(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test: Update with empty values (no changes)
-	updated, err := repo.Update("cert-nochange", "", "")
+	updated, err := repo.Update("cert-nochange", "", "", false)
 
 	// Assert
 	require.NoError(t, err)
@@ -224,6 +293,67 @@ func TestCertificateRepository_Update_NoChanges(t *testing.T) {
 	assert.Equal(t, "original", updated.Design)
 }
 
+// TestCertificateRepository_Update_AutosaveKeepsPreviousDesign tests that autosaves don't move the previous design
+func TestCertificateRepository_Update_AutosaveKeepsPreviousDesign(t *testing.T) {
+	container := helpers.SetupTestDatabase(t)
+	db := helpers.GetTestDB(t, container)
+	q := query.Use(db)
+	repo := NewCertificateRepository(q)
+
+	cert := &model.Certificate{
+		ID:     "cert-autosave",
+		UserID: "user-1",
+		Name:   "Original",
+		Design: "saved-design",
+	}
+	err := db.Create(cert).Error
+	require.NoError(t, err)
+
+	// Test: autosaves replace the design without touching the previous design
+	_, err = repo.Update("cert-autosave", "", "draft-1", true)
+	require.NoError(t, err)
+	updated, err := repo.Update("cert-autosave", "", "draft-2", true)
+	require.NoError(t, err)
+	assert.Equal(t, "draft-2", updated.Design)
+	assert.Empty(t, updated.PreviousDesign)
+
+	// Test: the explicit save compares against the last saved design, not the last autosave
+	updated, err = repo.Update("cert-autosave", "", "final-design", false)
+	require.NoError(t, err)
+	assert.Equal(t, "final-design", updated.Design)
+	assert.Equal(t, "saved-design", updated.PreviousDesign)
+}
+
+// TestCertificateRepository_SetAnchors_InvalidatesCache tests that refreshed anchors are visible to cached reads
+func TestCertificateRepository_SetAnchors_InvalidatesCache(t *testing.T) {
+	container := helpers.SetupTestDatabase(t)
+	db := helpers.GetTestDB(t, container)
+	q := query.Use(db)
+	repo := NewCertificateRepository(q)
+
+	cert := &model.Certificate{
+		ID:      "cert-anchors",
+		UserID:  "user-1",
+		Name:    "Anchors",
+		Design:  "design",
+		Anchors: []string{"name"},
+	}
+	err := db.Create(cert).Error
+	require.NoError(t, err)
+
+	cached, err := repo.GetByIdCached("cert-anchors")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name"}, cached.Anchors)
+
+	// Test: a refresh is served right away instead of the cached anchors
+	err = repo.SetAnchors("cert-anchors", []string{"date", "name"})
+	require.NoError(t, err)
+
+	cached, err = repo.GetByIdCached("cert-anchors")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"date", "name"}, cached.Anchors)
+}
+
 // TestCertificateRepository_Delete tests deleting a certificate
 func TestCertificateRepository_Delete(t *testing.T) {
 	container := helpers.SetupTestDatabase(t)
@@ -461,3 +591,37 @@ func TestCertificateRepository_Concurrency(t *testing.T) {
 		db.Delete(cert)
 	}
 }
+
+// TestCertificateRepository_BulkRename tests renaming several certificates in one transaction
+func TestCertificateRepository_BulkRename(t *testing.T) {
+	container := helpers.SetupTestDatabase(t)
+	db := helpers.GetTestDB(t, container)
+	q := query.Use(db)
+	repo := NewCertificateRepository(q)
+
+	for _, id := range []string{"cert-rename-1", "cert-rename-2"} {
+		require.NoError(t, db.Create(&model.Certificate{ID: id, UserID: "user-1", Name: "Old", Design: "design"}).Error)
+	}
+
+	renamed, err := repo.BulkRename(map[string]string{
+		"cert-rename-1": "2026 First",
+		"cert-rename-2": "2026 Second",
+	})
+	require.NoError(t, err)
+	assert.Len(t, renamed, 2)
+
+	var found model.Certificate
+	require.NoError(t, db.Where("id = ?", "cert-rename-2").First(&found).Error)
+	assert.Equal(t, "2026 Second", found.Name)
+	assert.Equal(t, "design", found.Design)
+
+	// A missing certificate rolls back the whole batch
+	_, err = repo.BulkRename(map[string]string{
+		"cert-rename-1": "Rolled Back",
+		"missing":       "Missing",
+	})
+	require.Error(t, err)
+
+	require.NoError(t, db.Where("id = ?", "cert-rename-1").First(&found).Error)
+	assert.Equal(t, "2026 First", found.Name)
+}
@@ -0,0 +1,115 @@
+package certificatemodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DesignDiff describes how a certificate design changed. Anchors are the PLACEHOLDER- fields participants
+// fill in; objects are the design's objects matched by their id, so objects without an id are not compared.
+type DesignDiff struct {
+	AddedAnchors     []string `json:"added_anchors"`
+	RemovedAnchors   []string `json:"removed_anchors"`
+	UnchangedAnchors []string `json:"unchanged_anchors"`
+	AddedObjects     []string `json:"added_objects"`
+	RemovedObjects   []string `json:"removed_objects"`
+	ChangedObjects   []string `json:"changed_objects"`
+}
+
+// AnchorsChanged reports whether participants' data fields are affected by the change
+func (d *DesignDiff) AnchorsChanged() bool {
+	return len(d.AddedAnchors) > 0 || len(d.RemovedAnchors) > 0
+}
+
+// designObjectsById returns a design's objects that have an id, keyed by it
+func designObjectsById(designJSON string) (map[string]any, error) {
+	var design struct {
+		Objects []any `json:"objects"`
+	}
+	if err := json.Unmarshal([]byte(designJSON), &design); err != nil {
+		return nil, fmt.Errorf("design is not valid JSON: %w", err)
+	}
+
+	objects := make(map[string]any, len(design.Objects))
+	for _, obj := range design.Objects {
+		objMap, ok := obj.(map[string]any)
+		if !ok {
+			continue
+		}
+		if id, ok := objMap["id"].(string); ok && id != "" {
+			objects[id] = objMap
+		}
+	}
+	return objects, nil
+}
+
+// DiffDesigns compares a previous design with the current one. Anchors keep the current design's order
+// (removed anchors the previous design's); object ids are sorted.
+func DiffDesigns(previousJSON string, currentJSON string) (*DesignDiff, error) {
+	previousAnchors, err := ExtractDesignAnchors(previousJSON)
+	if err != nil {
+		return nil, fmt.Errorf("previous design: %w", err)
+	}
+	currentAnchors, err := ExtractDesignAnchors(currentJSON)
+	if err != nil {
+		return nil, fmt.Errorf("current design: %w", err)
+	}
+	previousObjects, err := designObjectsById(previousJSON)
+	if err != nil {
+		return nil, fmt.Errorf("previous design: %w", err)
+	}
+	currentObjects, err := designObjectsById(currentJSON)
+	if err != nil {
+		return nil, fmt.Errorf("current design: %w", err)
+	}
+
+	diff := &DesignDiff{
+		AddedAnchors:     []string{},
+		RemovedAnchors:   []string{},
+		UnchangedAnchors: []string{},
+		AddedObjects:     []string{},
+		RemovedObjects:   []string{},
+		ChangedObjects:   []string{},
+	}
+
+	previousSet := make(map[string]bool, len(previousAnchors))
+	for _, anchor := range previousAnchors {
+		previousSet[anchor] = true
+	}
+	currentSet := make(map[string]bool, len(currentAnchors))
+	for _, anchor := range currentAnchors {
+		currentSet[anchor] = true
+		if previousSet[anchor] {
+			diff.UnchangedAnchors = append(diff.UnchangedAnchors, anchor)
+		} else {
+			diff.AddedAnchors = append(diff.AddedAnchors, anchor)
+		}
+	}
+	for _, anchor := range previousAnchors {
+		if !currentSet[anchor] {
+			diff.RemovedAnchors = append(diff.RemovedAnchors, anchor)
+		}
+	}
+
+	for id, current := range currentObjects {
+		previous, existed := previousObjects[id]
+		switch {
+		case !existed:
+			diff.AddedObjects = append(diff.AddedObjects, id)
+		case !reflect.DeepEqual(previous, current):
+			diff.ChangedObjects = append(diff.ChangedObjects, id)
+		}
+	}
+	for id := range previousObjects {
+		if _, exists := currentObjects[id]; !exists {
+			diff.RemovedObjects = append(diff.RemovedObjects, id)
+		}
+	}
+	sort.Strings(diff.AddedObjects)
+	sort.Strings(diff.RemovedObjects)
+	sort.Strings(diff.ChangedObjects)
+
+	return diff, nil
+}
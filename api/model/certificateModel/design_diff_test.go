@@ -0,0 +1,56 @@
+package certificatemodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffDesigns(t *testing.T) {
+	previous := `{"objects":[
+		{"id":"PLACEHOLDER-name","left":10},
+		{"id":"PLACEHOLDER-date","left":20},
+		{"id":"title","text":"Certificate"},
+		{"id":"logo","src":"a.png"},
+		{"type":"rect"}
+	]}`
+	current := `{"objects":[
+		{"id":"PLACEHOLDER-name","left":15},
+		{"id":"PLACEHOLDER-score","left":20},
+		{"id":"title","text":"Certificate"},
+		{"type":"circle"}
+	]}`
+
+	diff, err := DiffDesigns(previous, current)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"score"}, diff.AddedAnchors)
+	assert.Equal(t, []string{"date"}, diff.RemovedAnchors)
+	assert.Equal(t, []string{"name"}, diff.UnchangedAnchors)
+	assert.Equal(t, []string{"PLACEHOLDER-score"}, diff.AddedObjects)
+	assert.Equal(t, []string{"PLACEHOLDER-date", "logo"}, diff.RemovedObjects)
+	assert.Equal(t, []string{"PLACEHOLDER-name"}, diff.ChangedObjects)
+	assert.True(t, diff.AnchorsChanged())
+}
+
+func TestDiffDesigns_Unchanged(t *testing.T) {
+	design := `{"objects":[{"id":"PLACEHOLDER-name"}]}`
+
+	diff, err := DiffDesigns(design, design)
+	require.NoError(t, err)
+
+	assert.False(t, diff.AnchorsChanged())
+	assert.Empty(t, diff.AddedObjects)
+	assert.Empty(t, diff.RemovedObjects)
+	assert.Empty(t, diff.ChangedObjects)
+	assert.Equal(t, []string{"name"}, diff.UnchangedAnchors)
+}
+
+func TestDiffDesigns_InvalidDesign(t *testing.T) {
+	_, err := DiffDesigns("not json", `{"objects":[]}`)
+	assert.ErrorContains(t, err, "previous design")
+
+	_, err = DiffDesigns(`{"objects":[]}`, `{"layers":[]}`)
+	assert.ErrorContains(t, err, "current design")
+}
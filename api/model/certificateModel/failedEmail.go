@@ -0,0 +1,141 @@
+package certificatemodel
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+	"gorm.io/gorm"
+)
+
+// Kinds of email kept in the failed email queue
+const (
+	FailedEmailParticipantCertificate = "participant_certificate"
+	FailedEmailSignatureRequest       = "signature_request"
+)
+
+// RecordFailedEmail queues an email that could not be sent for retry. recipientId is the participant or signer
+// ID depending on kind; recipientField is the participant data field the address was read from, so a retry
+// uses the participant's current address. A repeated failure for the same unresolved email bumps its attempt
+// count instead of adding another entry.
+func (r *CertificateRepository) RecordFailedEmail(certificateId, kind, recipientId, recipient, recipientField string, sendErr error) error {
+	fe := r.q.FailedEmail
+	errMsg := ""
+	if sendErr != nil {
+		errMsg = sendErr.Error()
+	}
+
+	existing, err := fe.Where(
+		fe.CertificateID.Eq(certificateId),
+		fe.Kind.Eq(kind),
+		fe.RecipientID.Eq(recipientId),
+		fe.Resolved.Is(false),
+	).First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Certificate RecordFailedEmail lookup Error", "error", err, "certificate_id", certificateId, "recipient_id", recipientId)
+		return err
+	}
+
+	if existing != nil {
+		_, err = fe.Where(fe.ID.Eq(existing.ID)).UpdateSimple(
+			fe.Attempts.Add(1),
+			fe.LastError.Value(errMsg),
+			fe.Recipient.Value(recipient),
+			fe.RecipientField.Value(recipientField),
+			fe.LastAttemptAt.Value(time.Now()),
+		)
+	} else {
+		err = fe.Create(&model.FailedEmail{
+			CertificateID:  certificateId,
+			Kind:           kind,
+			RecipientID:    recipientId,
+			Recipient:      recipient,
+			RecipientField: recipientField,
+			LastError:      errMsg,
+			Attempts:       1,
+		})
+	}
+	if err != nil {
+		slog.Error("Certificate RecordFailedEmail Error", "error", err, "certificate_id", certificateId, "recipient_id", recipientId)
+		return err
+	}
+
+	return nil
+}
+
+// GetFailedEmailsByOwner returns the failed emails of the user's certificates, most recent attempt first.
+// Resolved entries are only included when includeResolved is set.
+func (r *CertificateRepository) GetFailedEmailsByOwner(userId string, includeResolved bool) ([]*model.FailedEmail, error) {
+	fe := r.q.FailedEmail
+	cert := r.q.Certificate
+
+	do := fe.Join(cert, cert.ID.EqCol(fe.CertificateID)).Where(cert.UserID.Eq(userId))
+	if !includeResolved {
+		do = do.Where(fe.Resolved.Is(false))
+	}
+
+	failedEmails, err := do.Order(fe.LastAttemptAt.Desc()).Find()
+	if err != nil {
+		slog.Error("Certificate GetFailedEmailsByOwner Error", "error", err, "user_id", userId)
+		return nil, err
+	}
+
+	return failedEmails, nil
+}
+
+// GetFailedEmailById returns a failed email entry, or nil when it does not exist
+func (r *CertificateRepository) GetFailedEmailById(id string) (*model.FailedEmail, error) {
+	failedEmail, err := r.q.FailedEmail.Where(r.q.FailedEmail.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("Certificate GetFailedEmailById Error", "error", err, "id", id)
+		return nil, err
+	}
+
+	return failedEmail, nil
+}
+
+// GetRetryableFailedEmails returns unresolved failed emails that have been attempted fewer than maxAttempts times
+func (r *CertificateRepository) GetRetryableFailedEmails(maxAttempts int) ([]*model.FailedEmail, error) {
+	fe := r.q.FailedEmail
+
+	failedEmails, err := fe.Where(
+		fe.Resolved.Is(false),
+		fe.Attempts.Lt(int32(maxAttempts)),
+	).Order(fe.LastAttemptAt).Find()
+	if err != nil {
+		slog.Error("Certificate GetRetryableFailedEmails Error", "error", err)
+		return nil, err
+	}
+
+	return failedEmails, nil
+}
+
+// MarkFailedEmailAttempt records a retry of a failed email. A nil sendErr means the email went out and the
+// entry is resolved; otherwise the attempt count and last error are updated.
+func (r *CertificateRepository) MarkFailedEmailAttempt(id string, sendErr error) error {
+	fe := r.q.FailedEmail
+
+	var err error
+	if sendErr == nil {
+		_, err = fe.Where(fe.ID.Eq(id)).UpdateSimple(
+			fe.Resolved.Value(true),
+			fe.LastAttemptAt.Value(time.Now()),
+		)
+	} else {
+		_, err = fe.Where(fe.ID.Eq(id)).UpdateSimple(
+			fe.Attempts.Add(1),
+			fe.LastError.Value(sendErr.Error()),
+			fe.LastAttemptAt.Value(time.Now()),
+		)
+	}
+	if err != nil {
+		slog.Error("Certificate MarkFailedEmailAttempt Error", "error", err, "id", id)
+		return err
+	}
+
+	return nil
+}
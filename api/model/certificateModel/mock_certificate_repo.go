@@ -10,14 +10,31 @@ type ICertificateRepository interface {
 	Create(certData payload.CreateCertificatePayload, userId string) (*model.Certificate, error)
 	GetAll() ([]*model.Certificate, error)
 	GetByUser(userId string) ([]*model.Certificate, error)
+	GetByUserAndStatus(userId string, status string) ([]*model.Certificate, error)
 	GetById(certId string) (*model.Certificate, error)
+	GetByIds(certIds []string) ([]*model.Certificate, error)
 	Delete(id string) (*model.Certificate, error)
-	Update(id string, name string, design string) (*model.Certificate, error)
+	Update(id string, name string, design string, autosave bool) (*model.Certificate, error)
+	BulkRename(names map[string]string) ([]*model.Certificate, error)
 	AddThumbnailUrl(certificateId string, thumbnailUrl string) error
 	EditArchiveUrl(certificateId string, archiveUrl string) error
 	MarkAsDistributed(certificateId string) error
+	SetDistributionEmailField(certificateId string, emailField string) error
 	MarkAsSigned(certificateId string) error
 	MarkAsUnsigned(certificateId string) error
+	SetVerifyHost(certificateId string, verifyHost string) error
+	SetPdfFooter(certificateId string, enabled bool) error
+	SetArchiveFilenameTemplate(certificateId string, template string) error
+	SetPdfLayout(certificateId string, marginMm *float64, fitMode string) error
+	SetSequentialSigning(certificateId string, enabled bool) error
+	SetSignatureBackground(certificateId string, background string) error
+	GetByUserAndTemplate(userId string, templateId string) ([]*model.Certificate, error)
+	SetAnchors(certificateId string, anchors []string) error
+	RecordActivity(certificateId, actor, action, detail string) error
+	GetRecentActivity(certificateId string, limit int) ([]*model.CertificateActivity, error)
+	RecordFailedEmail(certificateId, kind, recipientId, recipient, recipientField string, sendErr error) error
+	GetFailedEmailsByOwner(userId string, includeResolved bool) ([]*model.FailedEmail, error)
+	GetFailedEmailById(id string) (*model.FailedEmail, error)
 }
 
 // Ensure CertificateRepository implements ICertificateRepository
@@ -28,14 +45,31 @@ type MockCertificateRepository struct {
 	CreateFunc              func(certData payload.CreateCertificatePayload, userId string) (*model.Certificate, error)
 	GetAllFunc              func() ([]*model.Certificate, error)
 	GetByUserFunc           func(userId string) ([]*model.Certificate, error)
+	GetByUserAndStatusFunc  func(userId string, status string) ([]*model.Certificate, error)
 	GetByIdFunc             func(certId string) (*model.Certificate, error)
+	GetByIdsFunc            func(certIds []string) ([]*model.Certificate, error)
 	DeleteFunc              func(id string) (*model.Certificate, error)
-	UpdateFunc              func(id string, name string, design string) (*model.Certificate, error)
+	UpdateFunc              func(id string, name string, design string, autosave bool) (*model.Certificate, error)
+	BulkRenameFunc          func(names map[string]string) ([]*model.Certificate, error)
 	AddThumbnailUrlFunc     func(certificateId string, thumbnailUrl string) error
 	EditArchiveUrlFunc      func(certificateId string, archiveUrl string) error
 	MarkAsDistributedFunc   func(certificateId string) error
+	SetDistributionEmailFieldFunc func(certificateId string, emailField string) error
 	MarkAsSignedFunc        func(certificateId string) error
 	MarkAsUnsignedFunc      func(certificateId string) error
+	SetVerifyHostFunc       func(certificateId string, verifyHost string) error
+	SetPdfFooterFunc        func(certificateId string, enabled bool) error
+	SetArchiveFilenameTemplateFunc func(certificateId string, template string) error
+	SetPdfLayoutFunc        func(certificateId string, marginMm *float64, fitMode string) error
+	SetSequentialSigningFunc func(certificateId string, enabled bool) error
+	SetSignatureBackgroundFunc func(certificateId string, background string) error
+	GetByUserAndTemplateFunc func(userId string, templateId string) ([]*model.Certificate, error)
+	SetAnchorsFunc          func(certificateId string, anchors []string) error
+	RecordActivityFunc      func(certificateId, actor, action, detail string) error
+	GetRecentActivityFunc   func(certificateId string, limit int) ([]*model.CertificateActivity, error)
+	RecordFailedEmailFunc   func(certificateId, kind, recipientId, recipient, recipientField string, sendErr error) error
+	GetFailedEmailsByOwnerFunc func(userId string, includeResolved bool) ([]*model.FailedEmail, error)
+	GetFailedEmailByIdFunc  func(id string) (*model.FailedEmail, error)
 }
 
 // Ensure MockCertificateRepository implements ICertificateRepository
@@ -81,9 +115,9 @@ func (m *MockCertificateRepository) Delete(id string) (*model.Certificate, error
 	return nil, nil
 }
 
-func (m *MockCertificateRepository) Update(id string, name string, design string) (*model.Certificate, error) {
+func (m *MockCertificateRepository) Update(id string, name string, design string, autosave bool) (*model.Certificate, error) {
 	if m.UpdateFunc != nil {
-		return m.UpdateFunc(id, name, design)
+		return m.UpdateFunc(id, name, design, autosave)
 	}
 	return nil, nil
 }
@@ -109,6 +143,13 @@ func (m *MockCertificateRepository) MarkAsDistributed(certificateId string) erro
 	return nil
 }
 
+func (m *MockCertificateRepository) SetDistributionEmailField(certificateId string, emailField string) error {
+	if m.SetDistributionEmailFieldFunc != nil {
+		return m.SetDistributionEmailFieldFunc(certificateId, emailField)
+	}
+	return nil
+}
+
 func (m *MockCertificateRepository) MarkAsSigned(certificateId string) error {
 	if m.MarkAsSignedFunc != nil {
 		return m.MarkAsSignedFunc(certificateId)
@@ -122,3 +163,115 @@ func (m *MockCertificateRepository) MarkAsUnsigned(certificateId string) error {
 	}
 	return nil
 }
+
+func (m *MockCertificateRepository) GetByIds(certIds []string) ([]*model.Certificate, error) {
+	if m.GetByIdsFunc != nil {
+		return m.GetByIdsFunc(certIds)
+	}
+	return nil, nil
+}
+
+func (m *MockCertificateRepository) SetVerifyHost(certificateId string, verifyHost string) error {
+	if m.SetVerifyHostFunc != nil {
+		return m.SetVerifyHostFunc(certificateId, verifyHost)
+	}
+	return nil
+}
+
+func (m *MockCertificateRepository) SetPdfFooter(certificateId string, enabled bool) error {
+	if m.SetPdfFooterFunc != nil {
+		return m.SetPdfFooterFunc(certificateId, enabled)
+	}
+	return nil
+}
+
+func (m *MockCertificateRepository) GetByUserAndStatus(userId string, status string) ([]*model.Certificate, error) {
+	if m.GetByUserAndStatusFunc != nil {
+		return m.GetByUserAndStatusFunc(userId, status)
+	}
+	return nil, nil
+}
+
+func (m *MockCertificateRepository) SetArchiveFilenameTemplate(certificateId string, template string) error {
+	if m.SetArchiveFilenameTemplateFunc != nil {
+		return m.SetArchiveFilenameTemplateFunc(certificateId, template)
+	}
+	return nil
+}
+
+func (m *MockCertificateRepository) SetPdfLayout(certificateId string, marginMm *float64, fitMode string) error {
+	if m.SetPdfLayoutFunc != nil {
+		return m.SetPdfLayoutFunc(certificateId, marginMm, fitMode)
+	}
+	return nil
+}
+
+func (m *MockCertificateRepository) BulkRename(names map[string]string) ([]*model.Certificate, error) {
+	if m.BulkRenameFunc != nil {
+		return m.BulkRenameFunc(names)
+	}
+	return nil, nil
+}
+
+func (m *MockCertificateRepository) SetSequentialSigning(certificateId string, enabled bool) error {
+	if m.SetSequentialSigningFunc != nil {
+		return m.SetSequentialSigningFunc(certificateId, enabled)
+	}
+	return nil
+}
+
+func (m *MockCertificateRepository) SetAnchors(certificateId string, anchors []string) error {
+	if m.SetAnchorsFunc != nil {
+		return m.SetAnchorsFunc(certificateId, anchors)
+	}
+	return nil
+}
+
+func (m *MockCertificateRepository) RecordActivity(certificateId, actor, action, detail string) error {
+	if m.RecordActivityFunc != nil {
+		return m.RecordActivityFunc(certificateId, actor, action, detail)
+	}
+	return nil
+}
+
+func (m *MockCertificateRepository) GetRecentActivity(certificateId string, limit int) ([]*model.CertificateActivity, error) {
+	if m.GetRecentActivityFunc != nil {
+		return m.GetRecentActivityFunc(certificateId, limit)
+	}
+	return []*model.CertificateActivity{}, nil
+}
+
+func (m *MockCertificateRepository) RecordFailedEmail(certificateId, kind, recipientId, recipient, recipientField string, sendErr error) error {
+	if m.RecordFailedEmailFunc != nil {
+		return m.RecordFailedEmailFunc(certificateId, kind, recipientId, recipient, recipientField, sendErr)
+	}
+	return nil
+}
+
+func (m *MockCertificateRepository) GetFailedEmailsByOwner(userId string, includeResolved bool) ([]*model.FailedEmail, error) {
+	if m.GetFailedEmailsByOwnerFunc != nil {
+		return m.GetFailedEmailsByOwnerFunc(userId, includeResolved)
+	}
+	return []*model.FailedEmail{}, nil
+}
+
+func (m *MockCertificateRepository) GetFailedEmailById(id string) (*model.FailedEmail, error) {
+	if m.GetFailedEmailByIdFunc != nil {
+		return m.GetFailedEmailByIdFunc(id)
+	}
+	return nil, nil
+}
+
+func (m *MockCertificateRepository) SetSignatureBackground(certificateId string, background string) error {
+	if m.SetSignatureBackgroundFunc != nil {
+		return m.SetSignatureBackgroundFunc(certificateId, background)
+	}
+	return nil
+}
+
+func (m *MockCertificateRepository) GetByUserAndTemplate(userId string, templateId string) ([]*model.Certificate, error) {
+	if m.GetByUserAndTemplateFunc != nil {
+		return m.GetByUserAndTemplateFunc(userId, templateId)
+	}
+	return nil, nil
+}
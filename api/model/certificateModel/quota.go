@@ -0,0 +1,40 @@
+package certificatemodel
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+var ErrCertificateQuotaExceeded = errors.New("certificate quota exceeded")
+
+// maxCertificatesPerUser returns the per-user certificate quota configured through max_certificates_per_user;
+// 0 means no quota, which is also what non-positive values fall back to
+func maxCertificatesPerUser() int64 {
+	if common.Config != nil && common.Config.MaxCertificatesPerUser != nil && *common.Config.MaxCertificatesPerUser > 0 {
+		return int64(*common.Config.MaxCertificatesPerUser)
+	}
+	return 0
+}
+
+// checkCertificateQuota rejects creating another certificate for a user who already owns the quota
+func checkCertificateQuota(existing int64) error {
+	quota := maxCertificatesPerUser()
+	if quota > 0 && existing >= quota {
+		return fmt.Errorf("%w: you have %d certificates, which is the maximum of %d",
+			ErrCertificateQuotaExceeded, existing, quota)
+	}
+	return nil
+}
+
+// CountByUser counts the certificates owned by a user
+func (r *CertificateRepository) CountByUser(userId string) (int64, error) {
+	count, err := r.q.Certificate.Where(r.q.Certificate.UserID.Eq(userId)).Count()
+	if err != nil {
+		slog.Error("Certificate CountByUser", "error", err, "userId", userId)
+		return 0, err
+	}
+	return count, nil
+}
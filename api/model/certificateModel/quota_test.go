@@ -0,0 +1,29 @@
+package certificatemodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestCheckCertificateQuota(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	common.Config = &shared.Config{}
+	assert.Equal(t, int64(0), maxCertificatesPerUser())
+	assert.NoError(t, checkCertificateQuota(100000))
+
+	quota := 5
+	common.Config = &shared.Config{MaxCertificatesPerUser: &quota}
+	assert.NoError(t, checkCertificateQuota(4))
+	err := checkCertificateQuota(5)
+	assert.ErrorIs(t, err, ErrCertificateQuotaExceeded)
+	assert.Contains(t, err.Error(), "maximum of 5")
+
+	disabled := -1
+	common.Config = &shared.Config{MaxCertificatesPerUser: &disabled}
+	assert.NoError(t, checkCertificateQuota(100000))
+}
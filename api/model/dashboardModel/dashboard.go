@@ -0,0 +1,75 @@
+package dashboardmodel
+
+import (
+	"log/slog"
+
+	"github.com/sunthewhat/easy-cert-api/type/shared/query"
+)
+
+// DashboardRepository runs aggregate queries across an owner's certificates
+type DashboardRepository struct {
+	q *query.Query
+}
+
+// DashboardSummary holds the counts shown on the owner's dashboard
+type DashboardSummary struct {
+	TotalCertificates         int64 `json:"total_certificates"`
+	UndistributedCertificates int64 `json:"undistributed_certificates"`
+	TotalParticipants         int64 `json:"total_participants"`
+	PendingSignatures         int64 `json:"pending_signatures"`
+}
+
+// NewDashboardRepository creates a new dashboard repository with dependency injection
+func NewDashboardRepository(q *query.Query) *DashboardRepository {
+	return &DashboardRepository{q: q}
+}
+
+// GetSummary aggregates certificate, participant and signature counts for a user with count queries
+func (r *DashboardRepository) GetSummary(userId string) (*DashboardSummary, error) {
+	summary := &DashboardSummary{}
+	cert := r.q.Certificate
+
+	// One grouped query gives both the total and the undistributed certificate count
+	var certificateCounts []struct {
+		IsDistributed bool
+		Count         int64
+	}
+	err := cert.Select(cert.IsDistributed, cert.ID.Count().As("count")).
+		Where(cert.UserID.Eq(userId)).
+		Group(cert.IsDistributed).
+		Scan(&certificateCounts)
+	if err != nil {
+		slog.Error("DashboardModel GetSummary certificate counts failed", "error", err, "user_id", userId)
+		return nil, err
+	}
+
+	for _, row := range certificateCounts {
+		summary.TotalCertificates += row.Count
+		if !row.IsDistributed {
+			summary.UndistributedCertificates += row.Count
+		}
+	}
+
+	participant := r.q.Participant
+	summary.TotalParticipants, err = participant.
+		Join(cert, cert.ID.EqCol(participant.CertificateID)).
+		Where(cert.UserID.Eq(userId)).
+		Count()
+	if err != nil {
+		slog.Error("DashboardModel GetSummary participant count failed", "error", err, "user_id", userId)
+		return nil, err
+	}
+
+	signature := r.q.Signature
+	summary.PendingSignatures, err = signature.
+		Join(cert, cert.ID.EqCol(signature.CertificateID)).
+		Where(cert.UserID.Eq(userId), signature.IsSigned.Is(false)).
+		Count()
+	if err != nil {
+		slog.Error("DashboardModel GetSummary pending signature count failed", "error", err, "user_id", userId)
+		return nil, err
+	}
+
+	slog.Info("DashboardModel GetSummary", "user_id", userId, "summary", summary)
+	return summary, nil
+}
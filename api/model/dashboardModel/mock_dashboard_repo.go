@@ -0,0 +1,38 @@
+package dashboardmodel
+
+// IDashboardRepository defines the interface for dashboard repository operations
+type IDashboardRepository interface {
+	GetSummary(userId string) (*DashboardSummary, error)
+	GetCreationTrends(userId string, granularity string) ([]TrendBucket, error)
+}
+
+// Ensure DashboardRepository implements IDashboardRepository
+var _ IDashboardRepository = (*DashboardRepository)(nil)
+
+// MockDashboardRepository is a mock implementation for testing
+type MockDashboardRepository struct {
+	GetSummaryFunc        func(userId string) (*DashboardSummary, error)
+	GetCreationTrendsFunc func(userId string, granularity string) ([]TrendBucket, error)
+}
+
+// Ensure MockDashboardRepository implements IDashboardRepository
+var _ IDashboardRepository = (*MockDashboardRepository)(nil)
+
+// NewMockDashboardRepository creates a new mock repository
+func NewMockDashboardRepository() *MockDashboardRepository {
+	return &MockDashboardRepository{}
+}
+
+func (m *MockDashboardRepository) GetSummary(userId string) (*DashboardSummary, error) {
+	if m.GetSummaryFunc != nil {
+		return m.GetSummaryFunc(userId)
+	}
+	return &DashboardSummary{}, nil
+}
+
+func (m *MockDashboardRepository) GetCreationTrends(userId string, granularity string) ([]TrendBucket, error) {
+	if m.GetCreationTrendsFunc != nil {
+		return m.GetCreationTrendsFunc(userId, granularity)
+	}
+	return []TrendBucket{}, nil
+}
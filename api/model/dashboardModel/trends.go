@@ -0,0 +1,57 @@
+package dashboardmodel
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gen/field"
+)
+
+// Granularities supported for certificate creation trends
+const (
+	TrendGranularityDay   = "day"
+	TrendGranularityWeek  = "week"
+	TrendGranularityMonth = "month"
+)
+
+// TrendBucket is the number of certificates created in the period starting at Period
+type TrendBucket struct {
+	Period time.Time `json:"period"`
+	Count  int64     `json:"count"`
+}
+
+// IsValidTrendGranularity reports whether granularity is one of day, week or month
+func IsValidTrendGranularity(granularity string) bool {
+	switch granularity {
+	case TrendGranularityDay, TrendGranularityWeek, TrendGranularityMonth:
+		return true
+	}
+	return false
+}
+
+// GetCreationTrends counts the user's certificates per day, week or month of creation with one grouped
+// query, oldest period first. Periods without any certificate are omitted.
+func (r *DashboardRepository) GetCreationTrends(userId string, granularity string) ([]TrendBucket, error) {
+	if !IsValidTrendGranularity(granularity) {
+		return nil, fmt.Errorf("unsupported trend granularity %q", granularity)
+	}
+
+	cert := r.q.Certificate
+	// The granularity is inlined rather than bound so the SELECT and GROUP BY expressions are identical
+	period := field.NewUnsafeFieldRaw(fmt.Sprintf("date_trunc('%s', %s)", granularity, cert.CreatedAt.ColumnName()))
+	periodColumn := field.NewField("", "period")
+
+	buckets := []TrendBucket{}
+	err := cert.Select(period.As("period"), cert.ID.Count().As("count")).
+		Where(cert.UserID.Eq(userId)).
+		Group(periodColumn).
+		Order(periodColumn).
+		Scan(&buckets)
+	if err != nil {
+		slog.Error("DashboardModel GetCreationTrends failed", "error", err, "user_id", userId, "granularity", granularity)
+		return nil, err
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,67 @@
+package participantmodel
+
+import (
+	"fmt"
+	"log/slog"
+
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+)
+
+// NonConformingParticipant is a stored participant whose data misses anchors of the current design
+type NonConformingParticipant struct {
+	ParticipantID string   `json:"participant_id"`
+	MissingFields []string `json:"missing_fields"`
+}
+
+// FieldConsistencyAudit is the result of checking every stored participant against the design anchors
+type FieldConsistencyAudit struct {
+	RequiredFields    []string                   `json:"required_fields"`
+	TotalParticipants int                        `json:"total_participants"`
+	NonConforming     []NonConformingParticipant `json:"non_conforming"`
+}
+
+// findNonConformingParticipants applies the ValidateFieldConsistency rules to each participant
+// and collects every failure instead of stopping at the first one
+func findNonConformingParticipants(requiredFields []string, participants []*CombinedParticipant) []NonConformingParticipant {
+	nonConforming := []NonConformingParticipant{}
+	if len(requiredFields) == 0 {
+		return nonConforming
+	}
+
+	for _, participant := range participants {
+		if missing := missingAnchorFields(requiredFields, participant.DynamicData); len(missing) > 0 {
+			nonConforming = append(nonConforming, NonConformingParticipant{
+				ParticipantID: participant.ID,
+				MissingFields: missing,
+			})
+		}
+	}
+	return nonConforming
+}
+
+// AuditFieldConsistency checks every stored participant of a certificate against the anchors of design.
+// It is read-only, so owners can fix participant data before regenerating.
+func (r *ParticipantRepository) AuditFieldConsistency(certId string, design string) (*FieldConsistencyAudit, error) {
+	requiredFields, err := certificatemodel.ExtractDesignAnchors(design)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract anchor names from certificate design: %w", err)
+	}
+
+	participants, err := r.GetParticipantsByCertId(certId)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := &FieldConsistencyAudit{
+		RequiredFields:    requiredFields,
+		TotalParticipants: len(participants),
+		NonConforming:     findNonConformingParticipants(requiredFields, participants),
+	}
+
+	slog.Info("ParticipantModel AuditFieldConsistency",
+		"cert_id", certId,
+		"participant_count", len(participants),
+		"non_conforming_count", len(audit.NonConforming))
+
+	return audit, nil
+}
@@ -0,0 +1,24 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNonConformingParticipants(t *testing.T) {
+	participants := []*CombinedParticipant{
+		{ID: "p1", DynamicData: map[string]any{"name": "Alice", "course": "Go"}},
+		{ID: "p2", DynamicData: map[string]any{"name": "Bob"}},
+		{ID: "p3", DynamicData: map[string]any{"name": "  ", "course": nil}},
+	}
+
+	got := findNonConformingParticipants([]string{"course", "name"}, participants)
+
+	assert.Equal(t, []NonConformingParticipant{
+		{ParticipantID: "p2", MissingFields: []string{"course"}},
+		{ParticipantID: "p3", MissingFields: []string{"course (empty)", "name (empty)"}},
+	}, got)
+
+	assert.Empty(t, findNonConformingParticipants(nil, participants), "No anchors accepts any data")
+}
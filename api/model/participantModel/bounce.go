@@ -0,0 +1,90 @@
+package participantmodel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EmailStatusBounced marks a certificate email the provider reported as undeliverable
+const EmailStatusBounced = "bounced"
+
+// bounceDefaultEmailField is the participant field matched for certificates distributed before the
+// distribution email field was recorded
+const bounceDefaultEmailField = "email"
+
+// bounceEmailFilter matches participant documents whose emailField holds address, ignoring case
+func bounceEmailFilter(emailField, address string) bson.M {
+	return bson.M{emailField: bson.M{"$regex": "^" + regexp.QuoteMeta(address) + "$", "$options": "i"}}
+}
+
+// MarkBouncedByEmail flags every sent certificate email of the certificate to address as bounced and returns
+// the affected participant IDs. The address is matched case-insensitively on the participant field the
+// certificate was distributed to. Participants whose email was never sent are left untouched.
+func (r *ParticipantRepository) MarkBouncedByEmail(email string, certId string) ([]string, error) {
+	email = strings.TrimSpace(email)
+	if certId == "" {
+		return nil, errors.New("certificate ID is required to match a bounce")
+	}
+
+	cert, err := certificatemodel.NewCertificateRepository(r.q).GetById(certId)
+	if err != nil {
+		return nil, err
+	}
+	if cert == nil {
+		return []string{}, nil
+	}
+
+	emailField := cert.DistributionEmailField
+	if emailField == "" {
+		emailField = bounceDefaultEmailField
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mongoOpTimeout())
+	defer cancel()
+
+	docs, err := findParticipantDocuments(ctx, r.participantCollection(certId),
+		participantFilter(certId, bounceEmailFilter(emailField, email)),
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		slog.Error("ParticipantModel MarkBouncedByEmail find failed", "error", err, "cert_id", certId)
+		return nil, fmt.Errorf("failed to search participants: %w", err)
+	}
+
+	matchedIds := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		matchedIds = append(matchedIds, fmt.Sprint(doc["_id"]))
+	}
+
+	if len(matchedIds) == 0 {
+		return []string{}, nil
+	}
+
+	var bouncedIds []string
+	if err := r.q.Participant.Where(
+		r.q.Participant.ID.In(matchedIds...),
+		r.q.Participant.EmailStatus.Eq("success"),
+	).Pluck(r.q.Participant.ID, &bouncedIds); err != nil {
+		slog.Error("ParticipantModel MarkBouncedByEmail status lookup failed", "error", err)
+		return nil, err
+	}
+
+	if len(bouncedIds) == 0 {
+		return []string{}, nil
+	}
+
+	if err := r.BulkUpdateEmailStatus(bouncedIds, EmailStatusBounced); err != nil {
+		return nil, err
+	}
+
+	slog.Info("ParticipantModel MarkBouncedByEmail", "cert_id", certId, "bounced_count", len(bouncedIds))
+	return bouncedIds, nil
+}
@@ -0,0 +1,17 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBounceEmailFilter(t *testing.T) {
+	filter := bounceEmailFilter("contact", "a.b+c@example.com")
+
+	assert.Equal(t, bson.M{"contact": bson.M{
+		"$regex":   `^a\.b\+c@example\.com$`,
+		"$options": "i",
+	}}, filter, "the address is matched whole and literally, ignoring case, on the distribution field")
+}
@@ -0,0 +1,54 @@
+package participantmodel
+
+import (
+	"context"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	mongodb "github.com/sunthewhat/easy-cert-api/common/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// CollectionModePerCertificate stores each certificate's participants in its own participant-<certId> collection
+	CollectionModePerCertificate = "per_certificate"
+	// CollectionModeShared stores every participant in one collection keyed by certificate_id
+	CollectionModeShared = mongodb.ParticipantCollectionModeShared
+
+	// SharedParticipantCollection is the collection used in shared mode
+	SharedParticipantCollection = mongodb.SharedParticipantCollection
+)
+
+// IsSharedCollectionMode reports whether participants are stored in a single shared collection
+func IsSharedCollectionMode() bool {
+	return common.Config != nil &&
+		common.Config.ParticipantCollectionMode != nil &&
+		*common.Config.ParticipantCollectionMode == CollectionModeShared
+}
+
+// ParticipantCollectionName returns the MongoDB collection holding a certificate's participants
+func ParticipantCollectionName(certId string) string {
+	if IsSharedCollectionMode() {
+		return SharedParticipantCollection
+	}
+	return "participant-" + certId
+}
+
+// ensureCollectionIndexes creates the participant indexes on a single collection
+func ensureCollectionIndexes(ctx context.Context, collection *mongo.Collection) error {
+	return mongodb.EnsureParticipantIndexes(ctx, collection)
+}
+
+// participantCollection returns the collection for a certificate's participants
+func (r *ParticipantRepository) participantCollection(certId string) *mongo.Collection {
+	return r.db.Collection(ParticipantCollectionName(certId))
+}
+
+// participantFilter scopes a filter to a certificate so it is correct in both collection modes
+func participantFilter(certId string, filter bson.M) bson.M {
+	if filter == nil {
+		filter = bson.M{}
+	}
+	filter["certificate_id"] = certId
+	return filter
+}
@@ -0,0 +1,31 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParticipantCollectionName(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	mode := func(m string) *string { return &m }
+
+	common.Config = &shared.Config{}
+	assert.Equal(t, "participant-cert-1", ParticipantCollectionName("cert-1"))
+
+	common.Config = &shared.Config{ParticipantCollectionMode: mode(CollectionModePerCertificate)}
+	assert.Equal(t, "participant-cert-1", ParticipantCollectionName("cert-1"))
+
+	common.Config = &shared.Config{ParticipantCollectionMode: mode(CollectionModeShared)}
+	assert.Equal(t, SharedParticipantCollection, ParticipantCollectionName("cert-1"))
+}
+
+func TestParticipantFilter(t *testing.T) {
+	assert.Equal(t, bson.M{"certificate_id": "cert-1"}, participantFilter("cert-1", nil))
+	assert.Equal(t, bson.M{"_id": "p1", "certificate_id": "cert-1"}, participantFilter("cert-1", bson.M{"_id": "p1"}))
+}
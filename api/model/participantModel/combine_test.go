@@ -0,0 +1,31 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+func TestCombineParticipants(t *testing.T) {
+	postgresParticipants := []*model.Participant{
+		{ID: "p1", CertificateID: "c1", EmailStatus: "success"},
+		{ID: "p2", CertificateID: "c1", EmailStatus: "pending"},
+	}
+	mongoParticipants := []map[string]any{
+		{"_id": "p2", "certificate_id": "c1", "name": "Bob", "tags": []any{"vip"}},
+		{"_id": "p1", "certificate_id": "c1", "name": "Alice"},
+	}
+
+	combined := combineParticipants(postgresParticipants, mongoParticipants)
+
+	assert.Len(t, combined, 2)
+	assert.Equal(t, "p1", combined[0].ID)
+	assert.Equal(t, map[string]any{"name": "Alice"}, combined[0].DynamicData)
+	assert.Equal(t, "success", combined[0].EmailStatus)
+	assert.Equal(t, "p2", combined[1].ID)
+	assert.Equal(t, map[string]any{"name": "Bob"}, combined[1].DynamicData)
+	assert.Equal(t, []string{"vip"}, combined[1].Tags)
+
+	assert.Empty(t, combineParticipants(nil, nil))
+}
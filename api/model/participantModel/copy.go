@@ -0,0 +1,64 @@
+package participantmodel
+
+import (
+	"sort"
+	"strings"
+
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+)
+
+// copiedParticipantExcludedFields are the MongoDB fields tied to the source certificate rather than the participant
+var copiedParticipantExcludedFields = map[string]bool{
+	"_id":            true,
+	"certificate_id": true,
+}
+
+// CopyableParticipantData strips IDs and certificate specific fields from participant documents so they can
+// be added to another certificate. Tags are kept.
+func CopyableParticipantData(documents []map[string]any) []map[string]any {
+	participants := make([]map[string]any, 0, len(documents))
+	for _, document := range documents {
+		data := make(map[string]any, len(document))
+		for key, value := range document {
+			if !copiedParticipantExcludedFields[key] {
+				data[key] = value
+			}
+		}
+		participants = append(participants, data)
+	}
+	return participants
+}
+
+// GetCopyableParticipants reads a certificate's participant documents from MongoDB, ready to be passed to
+// AddParticipants for another certificate. Both copying and merging participants between certificates go
+// through it.
+func (r *ParticipantRepository) GetCopyableParticipants(certId string) ([]map[string]any, error) {
+	documents, err := r.getParticipantsByMongo(certId)
+	if err != nil {
+		return nil, err
+	}
+	return CopyableParticipantData(documents), nil
+}
+
+// MissingDesignAnchors lists the anchors of a design that at least one participant does not provide.
+// A design without anchors accepts any participant data.
+func (r *ParticipantRepository) MissingDesignAnchors(designJSON string, participants []map[string]any) ([]string, error) {
+	anchors, err := certificatemodel.ExtractDesignAnchors(designJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make(map[string]bool)
+	for _, participant := range participants {
+		for _, field := range missingAnchorFields(anchors, participant) {
+			missing[strings.TrimSuffix(field, " (empty)")] = true
+		}
+	}
+
+	result := make([]string, 0, len(missing))
+	for anchor := range missing {
+		result = append(result, anchor)
+	}
+	sort.Strings(result)
+	return result, nil
+}
@@ -0,0 +1,41 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyableParticipantData(t *testing.T) {
+	documents := []map[string]any{
+		{"_id": "p1", "certificate_id": "source", "name": "Alice", "email": "alice@example.com", "tags": []any{"vip"}},
+		{"_id": "p2", "certificate_id": "source", "name": "Bob"},
+	}
+
+	participants := CopyableParticipantData(documents)
+	require.Len(t, participants, 2)
+	assert.Equal(t, map[string]any{"name": "Alice", "email": "alice@example.com", "tags": []any{"vip"}}, participants[0])
+	assert.Equal(t, map[string]any{"name": "Bob"}, participants[1])
+	assert.Equal(t, "p1", documents[0]["_id"], "source documents should not be modified")
+}
+
+func TestMissingDesignAnchors(t *testing.T) {
+	repo := &ParticipantRepository{}
+	design := `{"objects":[{"id":"PLACEHOLDER-name"},{"id":"PLACEHOLDER-course"},{"id":"logo"}]}`
+
+	missing, err := repo.MissingDesignAnchors(design, []map[string]any{
+		{"name": "Alice", "course": "Go"},
+		{"name": "Bob", "course": " "},
+		{"name": "Carol", "extra": "kept"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"course"}, missing)
+
+	missing, err = repo.MissingDesignAnchors(`{"objects":[]}`, []map[string]any{{"anything": 1}})
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+
+	_, err = repo.MissingDesignAnchors("not json", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package participantmodel
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+// ErrParticipantNotEditable is returned when a participant's data may no longer be changed
+var ErrParticipantNotEditable = errors.New("participant is not editable")
+
+// EditCheck describes whether a participant's data may currently be edited and why not
+type EditCheck struct {
+	ParticipantID            string `json:"participant_id"`
+	Editable                 bool   `json:"editable"`
+	EditableWithRegeneration bool   `json:"editable_with_regeneration"`
+	Reason                   string `json:"reason,omitempty"`
+}
+
+// CheckEditable applies the editing rule: once a certificate has been revoked, emailed to
+// or downloaded by the participant, its data is frozen so the issued PDF stays accurate.
+// Issued certificates may still be edited when the edit is followed by a regeneration.
+func CheckEditable(participant *model.Participant, regenerate bool) EditCheck {
+	check := EditCheck{ParticipantID: participant.ID, Editable: true, EditableWithRegeneration: true}
+
+	switch {
+	case participant.Isrevoke:
+		check.Editable = false
+		check.EditableWithRegeneration = false
+		check.Reason = "Participant certificate has been revoked"
+	case participant.EmailStatus == "success":
+		check.Editable = regenerate
+		check.Reason = "Certificate has already been emailed to this participant"
+	case participant.IsDownloaded:
+		check.Editable = regenerate
+		check.Reason = "Certificate has already been downloaded by this participant"
+	}
+
+	if check.Editable {
+		check.Reason = ""
+	}
+
+	return check
+}
+
+// GetEditability returns whether the participant can currently be edited
+func (r *ParticipantRepository) GetEditability(participantId string) (*EditCheck, error) {
+	participant, err := r.getParticipantByIdFromPostgres(participantId)
+	if err != nil {
+		slog.Error("ParticipantModel GetEditability failed", "error", err, "participant_id", participantId)
+		return nil, fmt.Errorf("participant not found: %w", err)
+	}
+
+	check := CheckEditable(participant, false)
+	return &check, nil
+}
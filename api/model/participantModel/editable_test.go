@@ -0,0 +1,40 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+func TestCheckEditable(t *testing.T) {
+	tests := []struct {
+		name        string
+		participant *model.Participant
+		regenerate  bool
+		want        bool
+	}{
+		{name: "pending participant", participant: &model.Participant{ID: "p1", EmailStatus: "pending"}, want: true},
+		{name: "generated but not sent", participant: &model.Participant{ID: "p1", EmailStatus: "pending", CertificateURL: "http://x/cert.pdf"}, want: true},
+		{name: "failed email", participant: &model.Participant{ID: "p1", EmailStatus: "failed"}, want: true},
+		{name: "emailed", participant: &model.Participant{ID: "p1", EmailStatus: "success"}, want: false},
+		{name: "emailed with regeneration", participant: &model.Participant{ID: "p1", EmailStatus: "success"}, regenerate: true, want: true},
+		{name: "downloaded", participant: &model.Participant{ID: "p1", EmailStatus: "pending", IsDownloaded: true}, want: false},
+		{name: "downloaded with regeneration", participant: &model.Participant{ID: "p1", EmailStatus: "pending", IsDownloaded: true}, regenerate: true, want: true},
+		{name: "revoked", participant: &model.Participant{ID: "p1", EmailStatus: "pending", Isrevoke: true}, want: false},
+		{name: "revoked with regeneration", participant: &model.Participant{ID: "p1", EmailStatus: "pending", Isrevoke: true}, regenerate: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := CheckEditable(tt.participant, tt.regenerate)
+			assert.Equal(t, tt.want, check.Editable)
+			assert.Equal(t, "p1", check.ParticipantID)
+			if tt.want {
+				assert.Empty(t, check.Reason)
+			} else {
+				assert.NotEmpty(t, check.Reason)
+			}
+		})
+	}
+}
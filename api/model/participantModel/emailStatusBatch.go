@@ -0,0 +1,70 @@
+package participantmodel
+
+import (
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+)
+
+// EmailStatusWriter persists the same email status for a group of participants
+type EmailStatusWriter interface {
+	BulkUpdateEmailStatus(participantIds []string, status string) error
+}
+
+// EmailStatusBatch accumulates email status results during a distribution so they can be
+// written with one update per status instead of one write per participant.
+// It is safe for concurrent use.
+type EmailStatusBatch struct {
+	mu      sync.Mutex
+	pending map[string][]string
+}
+
+// NewEmailStatusBatch creates an empty email status batch
+func NewEmailStatusBatch() *EmailStatusBatch {
+	return &EmailStatusBatch{pending: make(map[string][]string)}
+}
+
+// Add records the email status for a participant, to be written on the next Flush
+func (b *EmailStatusBatch) Add(participantId string, status string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending[status] = append(b.pending[status], participantId)
+}
+
+// Len returns the number of participant statuses waiting to be flushed
+func (b *EmailStatusBatch) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := 0
+	for _, ids := range b.pending {
+		count += len(ids)
+	}
+	return count
+}
+
+// Flush writes the accumulated statuses with one bulk update per status group.
+// Every group is attempted even if an earlier one fails; errors are joined.
+func (b *EmailStatusBatch) Flush(writer EmailStatusWriter) error {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = make(map[string][]string)
+	b.mu.Unlock()
+
+	statuses := make([]string, 0, len(pending))
+	for status := range pending {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	var errs []error
+	for _, status := range statuses {
+		if err := writer.BulkUpdateEmailStatus(pending[status], status); err != nil {
+			slog.Error("EmailStatusBatch Flush failed", "error", err, "status", status, "count", len(pending[status]))
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,64 @@
+package participantmodel
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailStatusBatch_Flush(t *testing.T) {
+	batch := NewEmailStatusBatch()
+	batch.Add("p1", "success")
+	batch.Add("p2", "failed")
+	batch.Add("p3", "success")
+	assert.Equal(t, 3, batch.Len())
+
+	calls := map[string][]string{}
+	mock := NewMockParticipantRepository()
+	mock.BulkUpdateEmailStatusFunc = func(participantIds []string, status string) error {
+		calls[status] = participantIds
+		return nil
+	}
+
+	assert.NoError(t, batch.Flush(mock))
+	assert.Equal(t, []string{"p1", "p3"}, calls["success"])
+	assert.Equal(t, []string{"p2"}, calls["failed"])
+	assert.Equal(t, 0, batch.Len())
+}
+
+func TestEmailStatusBatch_FlushAttemptsAllGroups(t *testing.T) {
+	batch := NewEmailStatusBatch()
+	batch.Add("p1", "failed")
+	batch.Add("p2", "success")
+
+	var attempted []string
+	mock := NewMockParticipantRepository()
+	mock.BulkUpdateEmailStatusFunc = func(participantIds []string, status string) error {
+		attempted = append(attempted, status)
+		if status == "failed" {
+			return errors.New("db error")
+		}
+		return nil
+	}
+
+	assert.Error(t, batch.Flush(mock))
+	assert.Equal(t, []string{"failed", "success"}, attempted)
+}
+
+func TestEmailStatusBatch_ConcurrentAdd(t *testing.T) {
+	batch := NewEmailStatusBatch()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			batch.Add("p", "success")
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, batch.Len())
+}
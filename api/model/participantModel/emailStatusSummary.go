@@ -0,0 +1,42 @@
+package participantmodel
+
+import (
+	"log/slog"
+)
+
+// Email statuses a participant moves through during distribution
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSuccess = "success"
+	EmailStatusFailed  = "failed"
+)
+
+// CountEmailStatusesByCertificate returns how many of a certificate's participants are in each email status
+// with a single grouped query. The known statuses are always present, with zero when no participant has them.
+func (r *ParticipantRepository) CountEmailStatusesByCertificate(certId string) (map[string]int64, error) {
+	counts := map[string]int64{
+		EmailStatusPending: 0,
+		EmailStatusSuccess: 0,
+		EmailStatusFailed:  0,
+		EmailStatusBounced: 0,
+	}
+
+	p := r.q.Participant
+	var rows []struct {
+		EmailStatus string
+		Count       int64
+	}
+	err := p.Select(p.EmailStatus, p.ID.Count().As("count")).
+		Where(p.CertificateID.Eq(certId)).
+		Group(p.EmailStatus).
+		Scan(&rows)
+	if err != nil {
+		slog.Error("ParticipantModel CountEmailStatusesByCertificate failed", "error", err, "certId", certId)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.EmailStatus] += row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,235 @@
+package participantmodel
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+// encryptedValuePrefix marks a participant field value encrypted by the application. Values without it
+// are plaintext, so documents written before encryption was enabled stay readable.
+const encryptedValuePrefix = "enc:v1:"
+
+// fieldCipher encrypts the configured participant fields with AES-256-GCM. Values are JSON encoded before
+// encryption so numbers and booleans keep their type.
+type fieldCipher struct {
+	gcm    cipher.AEAD
+	fields map[string]bool
+}
+
+// participantEncryptionKey returns the hex key for participant fields (participant_encryption_key). It is
+// deliberately separate from encryption_key so the two can be rotated independently.
+func participantEncryptionKey() string {
+	if common.Config == nil || common.Config.ParticipantEncryptionKey == nil {
+		return ""
+	}
+	return *common.Config.ParticipantEncryptionKey
+}
+
+// cachedFieldCipher is the cipher built from the last seen key and field list; it is only rebuilt when the
+// configuration changes instead of once per document
+var cachedFieldCipher struct {
+	mu     sync.Mutex
+	keyHex string
+	fields string
+	cipher *fieldCipher
+}
+
+// lookupParticipantFields are matched by exact value in MongoDB (email upserts, bounce handling and the
+// dedup index, tag filters) or identify the document, so encrypting them would silently break lookups
+var lookupParticipantFields = map[string]bool{
+	"_id":            true,
+	"certificate_id": true,
+	tagsField:        true,
+	UpsertEmailField: true,
+}
+
+// ValidateEncryptedFields rejects participant_encrypted_fields entries that participant lookups depend on
+func ValidateEncryptedFields(fields []string) error {
+	for _, field := range fields {
+		if lookupParticipantFields[strings.TrimSpace(field)] {
+			return fmt.Errorf("participant_encrypted_fields cannot include %q, participants are looked up by it", strings.TrimSpace(field))
+		}
+	}
+	return nil
+}
+
+// newFieldCipher creates a cipher for the given fields using a 64 character hex key (32 bytes)
+func newFieldCipher(keyHex string, fields []string) (*fieldCipher, error) {
+	if err := ValidateEncryptedFields(fields); err != nil {
+		return nil, err
+	}
+
+	key, err := hex.DecodeString(keyHex)
+	if err != nil {
+		return nil, errors.New("invalid participant encryption key format")
+	}
+	if len(key) != 32 {
+		return nil, errors.New("participant encryption key must be 32 bytes (64 hex chars)")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSet := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			fieldSet[field] = true
+		}
+	}
+	return &fieldCipher{gcm: gcm, fields: fieldSet}, nil
+}
+
+// participantFieldCipher returns the cipher for the configured participant_encrypted_fields. Without a
+// key it returns nil, leaving values as they are; fields configured without a key are an error so they are
+// never silently stored in plaintext.
+func participantFieldCipher() (*fieldCipher, error) {
+	keyHex := participantEncryptionKey()
+
+	var fields []string
+	if common.Config != nil {
+		fields = common.Config.ParticipantEncryptedFields
+	}
+	if keyHex == "" {
+		if len(fields) > 0 {
+			return nil, errors.New("participant_encrypted_fields is set but participant_encryption_key is not")
+		}
+		return nil, nil
+	}
+
+	cachedFieldCipher.mu.Lock()
+	defer cachedFieldCipher.mu.Unlock()
+
+	fieldList := strings.Join(fields, "\x00")
+	if cachedFieldCipher.cipher != nil && cachedFieldCipher.keyHex == keyHex && cachedFieldCipher.fields == fieldList {
+		return cachedFieldCipher.cipher, nil
+	}
+
+	fc, err := newFieldCipher(keyHex, fields)
+	if err != nil {
+		return nil, err
+	}
+	cachedFieldCipher.keyHex = keyHex
+	cachedFieldCipher.fields = fieldList
+	cachedFieldCipher.cipher = fc
+	return fc, nil
+}
+
+// encryptDocument returns a copy of doc with the configured fields encrypted; other fields are shared
+func (fc *fieldCipher) encryptDocument(doc map[string]any) (map[string]any, error) {
+	if fc == nil || len(fc.fields) == 0 {
+		return doc, nil
+	}
+
+	encrypted := make(map[string]any, len(doc))
+	for key, value := range doc {
+		encrypted[key] = value
+		if !fc.fields[key] || value == nil || isEncryptedValue(value) {
+			continue
+		}
+
+		plaintext, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode participant field %s: %w", key, err)
+		}
+
+		nonce := make([]byte, fc.gcm.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, err
+		}
+		sealed := fc.gcm.Seal(nonce, nonce, plaintext, nil)
+		encrypted[key] = encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed)
+	}
+	return encrypted, nil
+}
+
+// decryptDocument decrypts every encrypted value of doc in place, whether or not its field is still
+// configured for encryption
+func (fc *fieldCipher) decryptDocument(doc map[string]any) error {
+	for key, value := range doc {
+		text, ok := value.(string)
+		if !ok || !strings.HasPrefix(text, encryptedValuePrefix) {
+			continue
+		}
+		if fc == nil {
+			return fmt.Errorf("participant field %s is encrypted but no encryption key is configured", key)
+		}
+
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(text, encryptedValuePrefix))
+		if err != nil || len(sealed) < fc.gcm.NonceSize() {
+			return fmt.Errorf("participant field %s has a malformed encrypted value", key)
+		}
+
+		nonceSize := fc.gcm.NonceSize()
+		plaintext, err := fc.gcm.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt participant field %s: %w", key, err)
+		}
+
+		var decoded any
+		if err := json.Unmarshal(plaintext, &decoded); err != nil {
+			return fmt.Errorf("failed to decode participant field %s: %w", key, err)
+		}
+		doc[key] = decoded
+	}
+	return nil
+}
+
+func isEncryptedValue(value any) bool {
+	text, ok := value.(string)
+	return ok && strings.HasPrefix(text, encryptedValuePrefix)
+}
+
+// encryptParticipantData encrypts the configured sensitive fields of a participant document before it is written
+func encryptParticipantData(doc map[string]any) (map[string]any, error) {
+	fc, err := participantFieldCipher()
+	if err != nil {
+		return nil, err
+	}
+	return fc.encryptDocument(doc)
+}
+
+// decryptParticipantDocuments decrypts the encrypted fields of participant documents read from MongoDB
+func decryptParticipantDocuments(docs []map[string]any) error {
+	var fc *fieldCipher
+	for _, doc := range docs {
+		if !hasEncryptedValue(doc) {
+			continue
+		}
+		if fc == nil {
+			var err error
+			if fc, err = participantFieldCipher(); err != nil {
+				return err
+			}
+		}
+		if err := fc.decryptDocument(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasEncryptedValue(doc map[string]any) bool {
+	for _, value := range doc {
+		if isEncryptedValue(value) {
+			return true
+		}
+	}
+	return false
+}
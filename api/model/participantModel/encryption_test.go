@@ -0,0 +1,105 @@
+package participantmodel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+const testParticipantKey = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestFieldCipherRoundTrip(t *testing.T) {
+	fc, err := newFieldCipher(testParticipantKey, []string{"name", "national_id", "score"})
+	require.NoError(t, err)
+
+	original := map[string]any{"name": "Alice", "national_id": "1234567890123", "score": float64(95), "email": "alice@example.com"}
+	encrypted, err := fc.encryptDocument(original)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Alice", original["name"], "the caller's document must not be modified")
+	assert.Equal(t, "alice@example.com", encrypted["email"])
+	for _, field := range []string{"name", "national_id", "score"} {
+		value, _ := encrypted[field].(string)
+		assert.True(t, strings.HasPrefix(value, encryptedValuePrefix), "field %s should be encrypted", field)
+	}
+
+	// Already encrypted values are not encrypted twice
+	again, err := fc.encryptDocument(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, encrypted["name"], again["name"])
+
+	require.NoError(t, fc.decryptDocument(encrypted))
+	assert.Equal(t, original, encrypted)
+}
+
+func TestFieldCipherErrors(t *testing.T) {
+	_, err := newFieldCipher("not-hex", nil)
+	assert.Error(t, err)
+	_, err = newFieldCipher("abcd", nil)
+	assert.Error(t, err)
+	_, err = newFieldCipher(testParticipantKey, []string{"name", " email "})
+	assert.ErrorContains(t, err, `"email"`, "lookup fields cannot be encrypted")
+	_, err = newFieldCipher(testParticipantKey, []string{"tags"})
+	assert.Error(t, err)
+
+	fc, err := newFieldCipher(testParticipantKey, []string{"name"})
+	require.NoError(t, err)
+	encrypted, err := fc.encryptDocument(map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+
+	otherKey := strings.Repeat("f", 64)
+	other, err := newFieldCipher(otherKey, nil)
+	require.NoError(t, err)
+	assert.Error(t, other.decryptDocument(map[string]any{"name": encrypted["name"]}))
+	assert.Error(t, other.decryptDocument(map[string]any{"name": encryptedValuePrefix + "!!"}))
+
+	var disabled *fieldCipher
+	assert.Error(t, disabled.decryptDocument(map[string]any{"name": encrypted["name"]}))
+}
+
+func TestParticipantDataEncryptionConfig(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	key := testParticipantKey
+	common.Config = &shared.Config{ParticipantEncryptionKey: &key}
+
+	// Without configured fields nothing is encrypted
+	doc, err := encryptParticipantData(map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", doc["name"])
+
+	common.Config.ParticipantEncryptedFields = []string{"name"}
+	first, err := participantFieldCipher()
+	require.NoError(t, err)
+	second, err := participantFieldCipher()
+	require.NoError(t, err)
+	assert.Same(t, first, second, "the cipher should be built once per configuration")
+
+	doc, err = encryptParticipantData(map[string]any{"name": "Alice", "email": "alice@example.com"})
+	require.NoError(t, err)
+	assert.NotEqual(t, "Alice", doc["name"])
+
+	// Plaintext documents written before encryption was enabled are read as they are
+	legacy := map[string]any{"name": "Bob"}
+	docs := []map[string]any{doc, legacy}
+	require.NoError(t, decryptParticipantDocuments(docs))
+	assert.Equal(t, "Alice", docs[0]["name"])
+	assert.Equal(t, "Bob", docs[1]["name"])
+
+	// Data encrypted with another key can't be read
+	doc, err = encryptParticipantData(map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+	otherKey := strings.Repeat("a", 64)
+	common.Config.ParticipantEncryptionKey = &otherKey
+	assert.Error(t, decryptParticipantDocuments([]map[string]any{doc}))
+
+	// encryption_key is never reused for participant data
+	common.Config = &shared.Config{EncryptionKey: &key, ParticipantEncryptedFields: []string{"name"}}
+	_, err = encryptParticipantData(map[string]any{"name": "Alice"})
+	assert.Error(t, err, "configured fields without participant_encryption_key must not be stored in plaintext")
+}
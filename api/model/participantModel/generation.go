@@ -0,0 +1,60 @@
+package participantmodel
+
+import (
+	"log/slog"
+
+	"gorm.io/gen/field"
+)
+
+// GenerationCounts summarizes the generated certificates of one certificate's participants.
+// The PDF counters only cover participants whose certificate was generated.
+type GenerationCounts struct {
+	Total       int64
+	Pending     int64
+	PdfSigned   int64
+	PdfUnsigned int64
+	PdfUnknown  int64
+}
+
+// CountGenerationByCertificates returns the generation counts of several certificates with a single
+// grouped query. Certificates without participants are absent from the map.
+func (r *ParticipantRepository) CountGenerationByCertificates(certIds []string) (map[string]GenerationCounts, error) {
+	counts := make(map[string]GenerationCounts, len(certIds))
+	if len(certIds) == 0 {
+		return counts, nil
+	}
+
+	p := r.q.Participant
+	generated := field.NewUnsafeFieldRaw("COALESCE(" + p.CertificateURL.ColumnName().String() + ", '') <> ''")
+	var rows []struct {
+		CertificateID string
+		Generated     bool
+		PdfSigned     *bool
+		Count         int64
+	}
+	err := p.Select(p.CertificateID, generated.As("generated"), p.PdfSigned, p.ID.Count().As("count")).
+		Where(p.CertificateID.In(certIds...)).
+		Group(p.CertificateID, field.NewField("", "generated"), p.PdfSigned).
+		Scan(&rows)
+	if err != nil {
+		slog.Error("ParticipantModel CountGenerationByCertificates failed", "error", err, "certificateCount", len(certIds))
+		return nil, err
+	}
+
+	for _, row := range rows {
+		entry := counts[row.CertificateID]
+		entry.Total += row.Count
+		switch {
+		case !row.Generated:
+			entry.Pending += row.Count
+		case row.PdfSigned == nil:
+			entry.PdfUnknown += row.Count
+		case *row.PdfSigned:
+			entry.PdfSigned += row.Count
+		default:
+			entry.PdfUnsigned += row.Count
+		}
+		counts[row.CertificateID] = entry
+	}
+	return counts, nil
+}
@@ -0,0 +1,32 @@
+package participantmodel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+// defaultMaxParticipantsPerCert is generous for real cohorts but stops runaway imports
+const defaultMaxParticipantsPerCert = 50000
+
+var ErrParticipantLimitExceeded = errors.New("participant limit exceeded")
+
+// maxParticipantsPerCert returns the per-certificate participant cap configured through
+// max_participants_per_cert; non-positive values fall back to the default
+func maxParticipantsPerCert() int64 {
+	if common.Config != nil && common.Config.MaxParticipantsPerCert != nil && *common.Config.MaxParticipantsPerCert > 0 {
+		return int64(*common.Config.MaxParticipantsPerCert)
+	}
+	return defaultMaxParticipantsPerCert
+}
+
+// checkParticipantLimit rejects adding participants that would take a certificate past the cap
+func checkParticipantLimit(existing int64, adding int) error {
+	limit := maxParticipantsPerCert()
+	if existing+int64(adding) > limit {
+		return fmt.Errorf("%w: certificate has %d participants, adding %d would exceed the maximum of %d",
+			ErrParticipantLimitExceeded, existing, adding, limit)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestCheckParticipantLimit(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	common.Config = &shared.Config{}
+	assert.Equal(t, int64(50000), maxParticipantsPerCert())
+	assert.NoError(t, checkParticipantLimit(49000, 1000))
+	assert.ErrorIs(t, checkParticipantLimit(49000, 1001), ErrParticipantLimitExceeded)
+
+	limit := 100
+	common.Config = &shared.Config{MaxParticipantsPerCert: &limit}
+	assert.NoError(t, checkParticipantLimit(0, 100))
+	err := checkParticipantLimit(60, 41)
+	assert.ErrorIs(t, err, ErrParticipantLimitExceeded)
+	assert.Contains(t, err.Error(), "maximum of 100")
+
+	disabled := 0
+	common.Config = &shared.Config{MaxParticipantsPerCert: &disabled}
+	assert.Equal(t, int64(50000), maxParticipantsPerCert())
+}
@@ -8,12 +8,34 @@ import (
 type IParticipantRepository interface {
 	DeleteByCertId(certId string) ([]*model.Participant, error)
 	GetParticipantsByCertId(certId string) ([]*CombinedParticipant, error)
+	GetNotDownloadedByCertId(certId string) ([]*CombinedParticipant, error)
+	GetCopyableParticipants(certId string) ([]map[string]any, error)
 	MarkAsDownloaded(participantId string) error
 	ResetParticipantStatuses(participantIds []string) error
 	UpdateParticipantCertificateUrl(participantId string, certificateUrl string) error
+	ClearParticipantCertificateUrls(participantIds []string) error
+	SetParticipantsPdfSigned(participantIds []string, signed bool) error
 	UpdateEmailStatus(participantId string, status string) error
+	BulkUpdateEmailStatus(participantIds []string, status string) error
 	GetParticipantsById(participantId string) (*CombinedParticipant, error)
 	CleanupDeletedAnchors(certId string, designJSON string) error
+	BulkRevoke(certId string, participantIds []string) (*BulkRevokeResult, error)
+	MarkBouncedByEmail(email string, certId string) ([]string, error)
+	AddParticipants(certId string, participants []map[string]any) (*ParticipantCreateResult, error)
+	CountGenerationByCertificates(certIds []string) (map[string]GenerationCounts, error)
+	CountEmailStatusesByCertificate(certId string) (map[string]int64, error)
+	SearchParticipants(certIds []string, term string, limit int) ([]*ParticipantSearchMatch, bool, error)
+	GetRecoveryStatusByCertId(certId string) ([]*ParticipantRecoveryStatus, error)
+	AuditFieldConsistency(certId string, design string) (*FieldConsistencyAudit, error)
+	DeleteParticipantByID(participantID string) (*model.Participant, error)
+	EditParticipantByID(participantID string, newData map[string]any, regenerate bool) (*CombinedParticipant, error)
+	GetEditability(participantId string) (*EditCheck, error)
+	GetParticipantCollectionCount(certId string) (int64, error)
+	MarkParticipantStale(participantId string) error
+	MissingDesignAnchors(designJSON string, participants []map[string]any) ([]string, error)
+	Revoke(id string) (*model.Participant, error)
+	SetParticipantTags(participantId string, tags []string) (*CombinedParticipant, error)
+	UpsertParticipantsByEmail(certId string, participants []map[string]any) (*ParticipantUpsertResult, error)
 }
 
 // Ensure ParticipantRepository implements IParticipantRepository
@@ -23,12 +45,34 @@ var _ IParticipantRepository = (*ParticipantRepository)(nil)
 type MockParticipantRepository struct {
 	DeleteByCertIdFunc                  func(certId string) ([]*model.Participant, error)
 	GetParticipantsByCertIdFunc         func(certId string) ([]*CombinedParticipant, error)
+	GetNotDownloadedByCertIdFunc        func(certId string) ([]*CombinedParticipant, error)
+	GetCopyableParticipantsFunc         func(certId string) ([]map[string]any, error)
 	MarkAsDownloadedFunc                func(participantId string) error
 	ResetParticipantStatusesFunc        func(participantIds []string) error
 	UpdateParticipantCertificateUrlFunc func(participantId string, certificateUrl string) error
+	ClearParticipantCertificateUrlsFunc func(participantIds []string) error
+	SetParticipantsPdfSignedFunc        func(participantIds []string, signed bool) error
 	UpdateEmailStatusFunc               func(participantId string, status string) error
+	BulkUpdateEmailStatusFunc           func(participantIds []string, status string) error
 	GetParticipantsByIdFunc             func(participantId string) (*CombinedParticipant, error)
 	CleanupDeletedAnchorsFunc           func(certId string, designJSON string) error
+	BulkRevokeFunc                      func(certId string, participantIds []string) (*BulkRevokeResult, error)
+	MarkBouncedByEmailFunc              func(email string, certId string) ([]string, error)
+	AddParticipantsFunc                 func(certId string, participants []map[string]any) (*ParticipantCreateResult, error)
+	CountGenerationByCertificatesFunc   func(certIds []string) (map[string]GenerationCounts, error)
+	CountEmailStatusesByCertificateFunc func(certId string) (map[string]int64, error)
+	SearchParticipantsFunc              func(certIds []string, term string, limit int) ([]*ParticipantSearchMatch, bool, error)
+	GetRecoveryStatusByCertIdFunc       func(certId string) ([]*ParticipantRecoveryStatus, error)
+	AuditFieldConsistencyFunc           func(certId string, design string) (*FieldConsistencyAudit, error)
+	DeleteParticipantByIDFunc           func(participantID string) (*model.Participant, error)
+	EditParticipantByIDFunc             func(participantID string, newData map[string]any, regenerate bool) (*CombinedParticipant, error)
+	GetEditabilityFunc                  func(participantId string) (*EditCheck, error)
+	GetParticipantCollectionCountFunc   func(certId string) (int64, error)
+	MarkParticipantStaleFunc            func(participantId string) error
+	MissingDesignAnchorsFunc            func(designJSON string, participants []map[string]any) ([]string, error)
+	RevokeFunc                          func(id string) (*model.Participant, error)
+	SetParticipantTagsFunc              func(participantId string, tags []string) (*CombinedParticipant, error)
+	UpsertParticipantsByEmailFunc       func(certId string, participants []map[string]any) (*ParticipantUpsertResult, error)
 }
 
 // Ensure MockParticipantRepository implements IParticipantRepository
@@ -53,6 +97,20 @@ func (m *MockParticipantRepository) GetParticipantsByCertId(certId string) ([]*C
 	return nil, nil
 }
 
+func (m *MockParticipantRepository) GetCopyableParticipants(certId string) ([]map[string]any, error) {
+	if m.GetCopyableParticipantsFunc != nil {
+		return m.GetCopyableParticipantsFunc(certId)
+	}
+	return nil, nil
+}
+
+func (m *MockParticipantRepository) GetNotDownloadedByCertId(certId string) ([]*CombinedParticipant, error) {
+	if m.GetNotDownloadedByCertIdFunc != nil {
+		return m.GetNotDownloadedByCertIdFunc(certId)
+	}
+	return nil, nil
+}
+
 func (m *MockParticipantRepository) MarkAsDownloaded(participantId string) error {
 	if m.MarkAsDownloadedFunc != nil {
 		return m.MarkAsDownloadedFunc(participantId)
@@ -74,6 +132,13 @@ func (m *MockParticipantRepository) UpdateParticipantCertificateUrl(participantI
 	return nil
 }
 
+func (m *MockParticipantRepository) ClearParticipantCertificateUrls(participantIds []string) error {
+	if m.ClearParticipantCertificateUrlsFunc != nil {
+		return m.ClearParticipantCertificateUrlsFunc(participantIds)
+	}
+	return nil
+}
+
 func (m *MockParticipantRepository) UpdateEmailStatus(participantId string, status string) error {
 	if m.UpdateEmailStatusFunc != nil {
 		return m.UpdateEmailStatusFunc(participantId, status)
@@ -81,6 +146,13 @@ func (m *MockParticipantRepository) UpdateEmailStatus(participantId string, stat
 	return nil
 }
 
+func (m *MockParticipantRepository) BulkUpdateEmailStatus(participantIds []string, status string) error {
+	if m.BulkUpdateEmailStatusFunc != nil {
+		return m.BulkUpdateEmailStatusFunc(participantIds, status)
+	}
+	return nil
+}
+
 func (m *MockParticipantRepository) GetParticipantsById(participantId string) (*CombinedParticipant, error) {
 	if m.GetParticipantsByIdFunc != nil {
 		return m.GetParticipantsByIdFunc(participantId)
@@ -94,3 +166,129 @@ func (m *MockParticipantRepository) CleanupDeletedAnchors(certId string, designJ
 	}
 	return nil
 }
+
+func (m *MockParticipantRepository) BulkRevoke(certId string, participantIds []string) (*BulkRevokeResult, error) {
+	if m.BulkRevokeFunc != nil {
+		return m.BulkRevokeFunc(certId, participantIds)
+	}
+	return &BulkRevokeResult{}, nil
+}
+
+func (m *MockParticipantRepository) MarkBouncedByEmail(email string, certId string) ([]string, error) {
+	if m.MarkBouncedByEmailFunc != nil {
+		return m.MarkBouncedByEmailFunc(email, certId)
+	}
+	return nil, nil
+}
+
+func (m *MockParticipantRepository) SetParticipantsPdfSigned(participantIds []string, signed bool) error {
+	if m.SetParticipantsPdfSignedFunc != nil {
+		return m.SetParticipantsPdfSignedFunc(participantIds, signed)
+	}
+	return nil
+}
+
+func (m *MockParticipantRepository) AddParticipants(certId string, participants []map[string]any) (*ParticipantCreateResult, error) {
+	if m.AddParticipantsFunc != nil {
+		return m.AddParticipantsFunc(certId, participants)
+	}
+	return &ParticipantCreateResult{CreatedIDs: []string{}, FailedPostgresIDs: []string{}}, nil
+}
+
+func (m *MockParticipantRepository) CountGenerationByCertificates(certIds []string) (map[string]GenerationCounts, error) {
+	if m.CountGenerationByCertificatesFunc != nil {
+		return m.CountGenerationByCertificatesFunc(certIds)
+	}
+	return map[string]GenerationCounts{}, nil
+}
+
+func (m *MockParticipantRepository) CountEmailStatusesByCertificate(certId string) (map[string]int64, error) {
+	if m.CountEmailStatusesByCertificateFunc != nil {
+		return m.CountEmailStatusesByCertificateFunc(certId)
+	}
+	return map[string]int64{}, nil
+}
+
+func (m *MockParticipantRepository) SearchParticipants(certIds []string, term string, limit int) ([]*ParticipantSearchMatch, bool, error) {
+	if m.SearchParticipantsFunc != nil {
+		return m.SearchParticipantsFunc(certIds, term, limit)
+	}
+	return []*ParticipantSearchMatch{}, false, nil
+}
+
+func (m *MockParticipantRepository) GetRecoveryStatusByCertId(certId string) ([]*ParticipantRecoveryStatus, error) {
+	if m.GetRecoveryStatusByCertIdFunc != nil {
+		return m.GetRecoveryStatusByCertIdFunc(certId)
+	}
+	return []*ParticipantRecoveryStatus{}, nil
+}
+
+func (m *MockParticipantRepository) AuditFieldConsistency(certId string, design string) (*FieldConsistencyAudit, error) {
+	if m.AuditFieldConsistencyFunc != nil {
+		return m.AuditFieldConsistencyFunc(certId, design)
+	}
+	return nil, nil
+}
+
+func (m *MockParticipantRepository) DeleteParticipantByID(participantID string) (*model.Participant, error) {
+	if m.DeleteParticipantByIDFunc != nil {
+		return m.DeleteParticipantByIDFunc(participantID)
+	}
+	return nil, nil
+}
+
+func (m *MockParticipantRepository) EditParticipantByID(participantID string, newData map[string]any, regenerate bool) (*CombinedParticipant, error) {
+	if m.EditParticipantByIDFunc != nil {
+		return m.EditParticipantByIDFunc(participantID, newData, regenerate)
+	}
+	return nil, nil
+}
+
+func (m *MockParticipantRepository) GetEditability(participantId string) (*EditCheck, error) {
+	if m.GetEditabilityFunc != nil {
+		return m.GetEditabilityFunc(participantId)
+	}
+	return nil, nil
+}
+
+func (m *MockParticipantRepository) GetParticipantCollectionCount(certId string) (int64, error) {
+	if m.GetParticipantCollectionCountFunc != nil {
+		return m.GetParticipantCollectionCountFunc(certId)
+	}
+	return 0, nil
+}
+
+func (m *MockParticipantRepository) MarkParticipantStale(participantId string) error {
+	if m.MarkParticipantStaleFunc != nil {
+		return m.MarkParticipantStaleFunc(participantId)
+	}
+	return nil
+}
+
+func (m *MockParticipantRepository) MissingDesignAnchors(designJSON string, participants []map[string]any) ([]string, error) {
+	if m.MissingDesignAnchorsFunc != nil {
+		return m.MissingDesignAnchorsFunc(designJSON, participants)
+	}
+	return nil, nil
+}
+
+func (m *MockParticipantRepository) Revoke(id string) (*model.Participant, error) {
+	if m.RevokeFunc != nil {
+		return m.RevokeFunc(id)
+	}
+	return nil, nil
+}
+
+func (m *MockParticipantRepository) SetParticipantTags(participantId string, tags []string) (*CombinedParticipant, error) {
+	if m.SetParticipantTagsFunc != nil {
+		return m.SetParticipantTagsFunc(participantId, tags)
+	}
+	return nil, nil
+}
+
+func (m *MockParticipantRepository) UpsertParticipantsByEmail(certId string, participants []map[string]any) (*ParticipantUpsertResult, error) {
+	if m.UpsertParticipantsByEmailFunc != nil {
+		return m.UpsertParticipantsByEmailFunc(certId, participants)
+	}
+	return nil, nil
+}
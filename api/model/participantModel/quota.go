@@ -0,0 +1,47 @@
+package participantmodel
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+var ErrParticipantQuotaExceeded = errors.New("participant quota exceeded")
+
+// maxParticipantsPerUser returns the quota on participants across all of a user's certificates configured
+// through max_participants_per_user; 0 means no quota, which is also what non-positive values fall back to
+func maxParticipantsPerUser() int64 {
+	if common.Config != nil && common.Config.MaxParticipantsPerUser != nil && *common.Config.MaxParticipantsPerUser > 0 {
+		return int64(*common.Config.MaxParticipantsPerUser)
+	}
+	return 0
+}
+
+// checkParticipantQuota rejects adding participants that would take a user past their quota
+func checkParticipantQuota(existing int64, adding int) error {
+	quota := maxParticipantsPerUser()
+	if quota > 0 && existing+int64(adding) > quota {
+		return fmt.Errorf("%w: your certificates have %d participants, adding %d would exceed the maximum of %d",
+			ErrParticipantQuotaExceeded, existing, adding, quota)
+	}
+	return nil
+}
+
+// countOwnerParticipants counts the participants of every certificate owned by the owner of certId
+func (r *ParticipantRepository) countOwnerParticipants(certId string) (int64, error) {
+	c := r.q.Certificate
+	cert, err := c.Where(c.ID.Eq(certId)).First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get certificate owner: %w", err)
+	}
+
+	p := r.q.Participant
+	count, err := p.Join(c, c.ID.EqCol(p.CertificateID)).Where(c.UserID.Eq(cert.UserID)).Count()
+	if err != nil {
+		slog.Error("ParticipantModel countOwnerParticipants failed", "error", err, "cert_id", certId, "user_id", cert.UserID)
+		return 0, err
+	}
+	return count, nil
+}
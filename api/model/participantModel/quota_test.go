@@ -0,0 +1,29 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestCheckParticipantQuota(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	common.Config = &shared.Config{}
+	assert.Equal(t, int64(0), maxParticipantsPerUser())
+	assert.NoError(t, checkParticipantQuota(1000000, 1000))
+
+	quota := 200
+	common.Config = &shared.Config{MaxParticipantsPerUser: &quota}
+	assert.NoError(t, checkParticipantQuota(150, 50))
+	err := checkParticipantQuota(150, 51)
+	assert.ErrorIs(t, err, ErrParticipantQuotaExceeded)
+	assert.Contains(t, err.Error(), "maximum of 200")
+
+	disabled := 0
+	common.Config = &shared.Config{MaxParticipantsPerUser: &disabled}
+	assert.Equal(t, int64(0), maxParticipantsPerUser())
+}
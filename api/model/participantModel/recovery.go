@@ -0,0 +1,58 @@
+package participantmodel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ParticipantRecoveryStatus is a participant's PostgreSQL record together with whether its MongoDB document,
+// which holds the participant's data fields, still exists
+type ParticipantRecoveryStatus struct {
+	ID             string
+	IsRevoke       bool
+	CertificateURL string
+	HasData        bool
+}
+
+// GetRecoveryStatusByCertId lists every participant PostgreSQL knows of for a certificate and checks which
+// still have a MongoDB document. Only document IDs are read, so data that can no longer be decrypted doesn't fail it.
+func (r *ParticipantRepository) GetRecoveryStatusByCertId(certId string) ([]*ParticipantRecoveryStatus, error) {
+	postgresParticipants, err := r.getParticipantsByPostgres(certId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PostgreSQL participants: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mongoScanTimeout())
+	defer cancel()
+
+	docs, err := findParticipantDocuments(ctx, r.participantCollection(certId),
+		participantFilter(certId, nil),
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		slog.Error("ParticipantModel GetRecoveryStatusByCertId mongo find failed", "error", err, "cert_id", certId)
+		return nil, fmt.Errorf("failed to get MongoDB participants: %w", err)
+	}
+
+	present := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		present[fmt.Sprint(doc["_id"])] = true
+	}
+
+	statuses := make([]*ParticipantRecoveryStatus, 0, len(postgresParticipants))
+	for _, p := range postgresParticipants {
+		statuses = append(statuses, &ParticipantRecoveryStatus{
+			ID:             p.ID,
+			IsRevoke:       p.Isrevoke,
+			CertificateURL: p.CertificateURL,
+			HasData:        present[p.ID],
+		})
+	}
+
+	slog.Info("ParticipantModel GetRecoveryStatusByCertId", "cert_id", certId, "postgres_count", len(postgresParticipants), "mongo_count", len(docs))
+	return statuses, nil
+}
@@ -2,7 +2,6 @@ package participantmodel
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -16,6 +15,7 @@ import (
 	"github.com/sunthewhat/easy-cert-api/type/shared/query"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // ParticipantRepository handles all participant database operations
@@ -41,11 +41,17 @@ type CombinedParticipant struct {
 	CertificateURL string         `json:"certificate_url"`
 	EmailStatus    string         `json:"email_status"`
 	IsDownloaded   bool           `json:"is_downloaded"`
+	IsStale        bool           `json:"is_stale"`
+	PdfSigned      *bool          `json:"pdf_signed"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
+	Tags           []string       `json:"tags"`
 	DynamicData    map[string]any `json:"data"`
 }
 
+// tagsField is the MongoDB document field holding a participant's tags
+const tagsField = "tags"
+
 // NewParticipantRepository creates a new participant repository with dependency injection
 func NewParticipantRepository(q *query.Query, db *mongo.Database) *ParticipantRepository {
 	return &ParticipantRepository{
@@ -56,6 +62,25 @@ func NewParticipantRepository(q *query.Query, db *mongo.Database) *ParticipantRe
 
 // AddParticipants adds participants to both MongoDB (data) and PostgreSQL (index/status) with same IDs
 func (r *ParticipantRepository) AddParticipants(certId string, participants []map[string]any) (*ParticipantCreateResult, error) {
+	existing, err := r.GetParticipantCollectionCount(certId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count existing participants: %w", err)
+	}
+	if err := checkParticipantLimit(existing, len(participants)); err != nil {
+		slog.Warn("ParticipantModel AddParticipants participant limit exceeded", "error", err, "cert_id", certId)
+		return nil, err
+	}
+	if maxParticipantsPerUser() > 0 {
+		ownerCount, err := r.countOwnerParticipants(certId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count the owner's participants: %w", err)
+		}
+		if err := checkParticipantQuota(ownerCount, len(participants)); err != nil {
+			slog.Warn("ParticipantModel AddParticipants participant quota exceeded", "error", err, "cert_id", certId)
+			return nil, err
+		}
+	}
+
 	// Validate field consistency before adding
 	if err := r.ValidateFieldConsistency(certId, participants); err != nil {
 		slog.Warn("ParticipantModel AddParticipants field validation failed", "error", err, "cert_id", certId)
@@ -134,41 +159,7 @@ func (r *ParticipantRepository) GetParticipantsByCertId(certId string) ([]*Combi
 		return nil, fmt.Errorf("failed to get MongoDB participants: %w", mongoErr)
 	}
 
-	// Create a map of MongoDB data by ID for fast lookup
-	mongoDataMap := make(map[string]map[string]any)
-	for _, participant := range mongoParticipants {
-		if id, ok := participant["_id"].(string); ok {
-			mongoDataMap[id] = participant
-		}
-	}
-
-	// Combine data
-	var combinedParticipants []*CombinedParticipant
-	for _, pgParticipant := range postgresParticipants {
-		combined := &CombinedParticipant{
-			ID:             pgParticipant.ID,
-			CertificateID:  pgParticipant.CertificateID,
-			IsRevoke:       pgParticipant.Isrevoke,
-			CertificateURL: pgParticipant.CertificateURL,
-			EmailStatus:    pgParticipant.EmailStatus,
-			IsDownloaded:   pgParticipant.IsDownloaded,
-			CreatedAt:      pgParticipant.CreatedAt,
-			UpdatedAt:      pgParticipant.UpdatedAt,
-			DynamicData:    make(map[string]any),
-		}
-
-		// Add MongoDB data if exists
-		if mongoData, exists := mongoDataMap[pgParticipant.ID]; exists {
-			// Copy all fields except internal ones
-			for key, value := range mongoData {
-				if key != "_id" && key != "certificate_id" {
-					combined.DynamicData[key] = value
-				}
-			}
-		}
-
-		combinedParticipants = append(combinedParticipants, combined)
-	}
+	combinedParticipants := combineParticipants(postgresParticipants, mongoParticipants)
 
 	slog.Info("ParticipantModel GetParticipantsByCertId",
 		"cert_id", certId,
@@ -179,7 +170,50 @@ func (r *ParticipantRepository) GetParticipantsByCertId(certId string) ([]*Combi
 	return combinedParticipants, nil
 }
 
-// GetParticipantsById returns a participant by participant ID
+// GetNotDownloadedByCertId returns participants whose certificate email was sent successfully
+// but who have not downloaded it yet. Revoked participants are excluded.
+func (r *ParticipantRepository) GetNotDownloadedByCertId(certId string) ([]*CombinedParticipant, error) {
+	postgresParticipants, err := r.q.Participant.Where(
+		r.q.Participant.CertificateID.Eq(certId),
+		r.q.Participant.EmailStatus.Eq("success"),
+		r.q.Participant.IsDownloaded.Is(false),
+		r.q.Participant.Isrevoke.Is(false),
+	).Find()
+	if err != nil {
+		slog.Error("ParticipantModel GetNotDownloadedByCertId failed", "error", err, "cert_id", certId)
+		return nil, fmt.Errorf("failed to get PostgreSQL participants: %w", err)
+	}
+
+	if len(postgresParticipants) == 0 {
+		return []*CombinedParticipant{}, nil
+	}
+
+	participantIds := make([]string, 0, len(postgresParticipants))
+	for _, participant := range postgresParticipants {
+		participantIds = append(participantIds, participant.ID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mongoOpTimeout())
+	defer cancel()
+
+	mongoParticipants, err := findParticipantDocuments(ctx, r.participantCollection(certId), participantFilter(certId, bson.M{"_id": bson.M{"$in": participantIds}}))
+	if err != nil {
+		slog.Error("ParticipantModel GetNotDownloadedByCertId mongo find failed", "error", err, "cert_id", certId)
+		return nil, fmt.Errorf("failed to get MongoDB participants: %w", err)
+	}
+
+	if err := decryptParticipantDocuments(mongoParticipants); err != nil {
+		slog.Error("ParticipantModel GetNotDownloadedByCertId decryption failed", "error", err, "cert_id", certId)
+		return nil, fmt.Errorf("failed to decrypt MongoDB participants: %w", err)
+	}
+
+	combinedParticipants := combineParticipants(postgresParticipants, mongoParticipants)
+
+	slog.Info("ParticipantModel GetNotDownloadedByCertId", "cert_id", certId, "count", len(combinedParticipants))
+	return combinedParticipants, nil
+}
+
+// GetParticipantsById returns a participant by participant ID, including their generation state
 func (r *ParticipantRepository) GetParticipantsById(participantId string) (*CombinedParticipant, error) {
 	participant, err := r.getParticipantByIdFromPostgres(participantId)
 	if err != nil {
@@ -198,13 +232,16 @@ func (r *ParticipantRepository) GetParticipantsById(participantId string) (*Comb
 		CertificateURL: participant.CertificateURL,
 		EmailStatus:    participant.EmailStatus,
 		IsDownloaded:   participant.IsDownloaded,
+		IsStale:        participant.IsStale,
+		PdfSigned:      participant.PdfSigned,
 		CreatedAt:      participant.CreatedAt,
 		UpdatedAt:      participant.UpdatedAt,
 		DynamicData:    make(map[string]any),
 	}
 
+	combinedParticipant.Tags = extractTags(participantData)
 	for key, value := range participantData {
-		if key != "_id" && key != "certificate_id" {
+		if key != "_id" && key != "certificate_id" && key != tagsField {
 			combinedParticipant.DynamicData[key] = value
 		}
 	}
@@ -232,8 +269,10 @@ func (r *ParticipantRepository) DeleteByCertId(certId string) ([]*model.Particip
 	return participants, nil
 }
 
-// EditParticipantByID updates a participant's data with structure validation
-func (r *ParticipantRepository) EditParticipantByID(participantID string, newData map[string]any) (*CombinedParticipant, error) {
+// EditParticipantByID updates a participant's data with structure validation.
+// When regenerate is false and a certificate was already generated, the participant is marked stale;
+// callers passing regenerate=true are responsible for regenerating the certificate afterwards.
+func (r *ParticipantRepository) EditParticipantByID(participantID string, newData map[string]any, regenerate bool) (*CombinedParticipant, error) {
 	// First, get the participant from PostgreSQL to get certificate ID
 	participant, err := r.getParticipantByIdFromPostgres(participantID)
 	if err != nil {
@@ -243,6 +282,12 @@ func (r *ParticipantRepository) EditParticipantByID(participantID string, newDat
 
 	certId := participant.CertificateID
 
+	// Refuse edits once the certificate has been issued to the participant
+	if check := CheckEditable(participant, regenerate); !check.Editable {
+		slog.Warn("ParticipantModel EditParticipantByID: Participant not editable", "reason", check.Reason, "participant_id", participantID, "cert_id", certId)
+		return nil, fmt.Errorf("%w: %s", ErrParticipantNotEditable, check.Reason)
+	}
+
 	// Validate that new data structure matches existing structure
 	if err := r.validateEditDataStructure(certId, newData); err != nil {
 		slog.Warn("ParticipantModel EditParticipantByID: Data structure validation failed", "error", err, "participant_id", participantID, "cert_id", certId)
@@ -263,6 +308,16 @@ func (r *ParticipantRepository) EditParticipantByID(participantID string, newDat
 		// Don't fail the operation for timestamp update failure
 	}
 
+	// The generated PDF and QR no longer reflect the data unless it is regenerated
+	isStale := participant.IsStale
+	if participant.CertificateURL != "" && !regenerate {
+		if err := r.MarkParticipantStale(participantID); err != nil {
+			slog.Warn("ParticipantModel EditParticipantByID: Failed to mark participant stale", "error", err, "participant_id", participantID)
+		} else {
+			isStale = true
+		}
+	}
+
 	// Return the updated combined participant data
 	combinedData := &CombinedParticipant{
 		ID:             participant.ID,
@@ -271,6 +326,8 @@ func (r *ParticipantRepository) EditParticipantByID(participantID string, newDat
 		CertificateURL: participant.CertificateURL,
 		EmailStatus:    participant.EmailStatus,
 		IsDownloaded:   participant.IsDownloaded,
+		IsStale:        isStale,
+		PdfSigned:      participant.PdfSigned,
 		CreatedAt:      participant.CreatedAt,
 		UpdatedAt:      time.Now(), // Use current time for updated_at
 		DynamicData:    newData,
@@ -329,9 +386,95 @@ func (r *ParticipantRepository) Revoke(id string) (*model.Participant, error) {
 	return participant, nil
 }
 
+// BulkRevokeResult groups the requested participant IDs of a bulk revoke by outcome
+type BulkRevokeResult struct {
+	Revoked        []string `json:"revoked"`
+	AlreadyRevoked []string `json:"already_revoked"`
+	NotFound       []string `json:"not_found"`
+}
+
+// BulkRevoke revokes the given participants of a certificate with a single update.
+// IDs that do not belong to the certificate are reported as not found and left untouched.
+func (r *ParticipantRepository) BulkRevoke(certId string, participantIds []string) (*BulkRevokeResult, error) {
+	result := &BulkRevokeResult{
+		Revoked:        []string{},
+		AlreadyRevoked: []string{},
+		NotFound:       []string{},
+	}
+
+	// Non-UUID IDs can never match and would make PostgreSQL reject the whole query
+	seen := make(map[string]bool, len(participantIds))
+	validIds := make([]string, 0, len(participantIds))
+	for _, id := range participantIds {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, err := uuid.Parse(id); err != nil {
+			result.NotFound = append(result.NotFound, id)
+			continue
+		}
+		validIds = append(validIds, id)
+	}
+
+	if len(validIds) == 0 {
+		return result, nil
+	}
+
+	participants, err := r.q.Participant.Where(
+		r.q.Participant.CertificateID.Eq(certId),
+		r.q.Participant.ID.In(validIds...),
+	).Find()
+	if err != nil {
+		slog.Error("ParticipantModel BulkRevoke lookup failed", "error", err, "cert_id", certId)
+		return nil, err
+	}
+
+	found := make(map[string]*model.Participant, len(participants))
+	for _, participant := range participants {
+		found[participant.ID] = participant
+	}
+
+	toRevoke := []string{}
+	for _, id := range validIds {
+		participant, ok := found[id]
+		switch {
+		case !ok:
+			result.NotFound = append(result.NotFound, id)
+		case participant.Isrevoke:
+			result.AlreadyRevoked = append(result.AlreadyRevoked, id)
+		default:
+			toRevoke = append(toRevoke, id)
+		}
+	}
+
+	if len(toRevoke) > 0 {
+		if _, err := r.q.Participant.Where(
+			r.q.Participant.CertificateID.Eq(certId),
+			r.q.Participant.ID.In(toRevoke...),
+		).Update(r.q.Participant.Isrevoke, true); err != nil {
+			slog.Error("ParticipantModel BulkRevoke update failed", "error", err, "cert_id", certId, "count", len(toRevoke))
+			return nil, err
+		}
+		result.Revoked = toRevoke
+	}
+
+	slog.Info("ParticipantModel BulkRevoke completed",
+		"cert_id", certId,
+		"revoked", len(result.Revoked),
+		"already_revoked", len(result.AlreadyRevoked),
+		"not_found", len(result.NotFound))
+
+	return result, nil
+}
+
 // UpdateParticipantCertificateUrl updates the certificate URL for a participant
+// A new certificate file is generated from current data, so the stale flag is cleared
 func (r *ParticipantRepository) UpdateParticipantCertificateUrl(participantId string, certificateUrl string) error {
-	_, err := r.q.Participant.Where(r.q.Participant.ID.Eq(participantId)).Update(r.q.Participant.CertificateURL, certificateUrl)
+	_, err := r.q.Participant.Where(r.q.Participant.ID.Eq(participantId)).Updates(map[string]any{
+		"certificate_url": certificateUrl,
+		"is_stale":        false,
+	})
 	if err != nil {
 		slog.Error("ParticipantModel updateParticipantCertificateUrlInPostgres failed", "error", err, "participantId", participantId, "certificateUrl", certificateUrl)
 		return err
@@ -340,6 +483,62 @@ func (r *ParticipantRepository) UpdateParticipantCertificateUrl(participantId st
 	return nil
 }
 
+// ClearParticipantCertificateUrls marks the participants' certificates as not generated, used when their
+// previous file was deleted but the new one was never produced
+func (r *ParticipantRepository) ClearParticipantCertificateUrls(participantIds []string) error {
+	if len(participantIds) == 0 {
+		return nil
+	}
+	_, err := r.q.Participant.Where(r.q.Participant.ID.In(participantIds...)).Updates(map[string]any{
+		"certificate_url": "",
+		"pdf_signed":      nil,
+	})
+	if err != nil {
+		slog.Error("ParticipantModel ClearParticipantCertificateUrls failed", "error", err, "count", len(participantIds))
+		return err
+	}
+	return nil
+}
+
+// SetParticipantsPdfSigned records whether the participants' current certificate PDFs carry a digital signature
+func (r *ParticipantRepository) SetParticipantsPdfSigned(participantIds []string, signed bool) error {
+	if len(participantIds) == 0 {
+		return nil
+	}
+	_, err := r.q.Participant.Where(r.q.Participant.ID.In(participantIds...)).Update(r.q.Participant.PdfSigned, signed)
+	if err != nil {
+		slog.Error("ParticipantModel SetParticipantsPdfSigned failed", "error", err, "count", len(participantIds), "signed", signed)
+		return err
+	}
+	return nil
+}
+
+// MarkParticipantStale flags that a participant's generated certificate no longer matches their data
+func (r *ParticipantRepository) MarkParticipantStale(participantId string) error {
+	_, err := r.q.Participant.Where(r.q.Participant.ID.Eq(participantId)).Update(r.q.Participant.IsStale, true)
+	if err != nil {
+		slog.Error("ParticipantModel MarkParticipantStale failed", "error", err, "participantId", participantId)
+		return err
+	}
+	slog.Info("ParticipantModel MarkParticipantStale success", "participantId", participantId)
+	return nil
+}
+
+// MarkGeneratedParticipantsStale flags every participant of a certificate whose certificate was already
+// generated, e.g. after a signature image changed, and returns how many were flagged
+func (r *ParticipantRepository) MarkGeneratedParticipantsStale(certId string) (int64, error) {
+	info, err := r.q.Participant.Where(
+		r.q.Participant.CertificateID.Eq(certId),
+		r.q.Participant.CertificateURL.Neq(""),
+	).Update(r.q.Participant.IsStale, true)
+	if err != nil {
+		slog.Error("ParticipantModel MarkGeneratedParticipantsStale failed", "error", err, "cert_id", certId)
+		return 0, err
+	}
+	slog.Info("ParticipantModel MarkGeneratedParticipantsStale success", "cert_id", certId, "count", info.RowsAffected)
+	return info.RowsAffected, nil
+}
+
 // UpdateEmailStatus updates the email status for a participant
 func (r *ParticipantRepository) UpdateEmailStatus(participantId string, status string) error {
 	_, err := r.q.Participant.Where(r.q.Participant.ID.Eq(participantId)).Update(r.q.Participant.EmailStatus, status)
@@ -351,6 +550,27 @@ func (r *ParticipantRepository) UpdateEmailStatus(participantId string, status s
 	return nil
 }
 
+// BulkUpdateEmailStatus sets the same email status for multiple participants in a single update
+func (r *ParticipantRepository) BulkUpdateEmailStatus(participantIds []string, status string) error {
+	if len(participantIds) == 0 {
+		return nil
+	}
+
+	_, err := r.q.Participant.Where(
+		r.q.Participant.ID.In(participantIds...),
+	).Updates(map[string]any{
+		"email_status": status,
+	})
+
+	if err != nil {
+		slog.Error("ParticipantModel BulkUpdateEmailStatus failed", "error", err, "count", len(participantIds), "status", status)
+		return err
+	}
+
+	slog.Info("ParticipantModel BulkUpdateEmailStatus success", "count", len(participantIds), "status", status)
+	return nil
+}
+
 // UpdateDownloadStatus updates the download status for a participant
 func (r *ParticipantRepository) UpdateDownloadStatus(participantId string, status bool) error {
 	_, err := r.q.Participant.Where(r.q.Participant.ID.Eq(participantId)).Update(r.q.Participant.IsDownloaded, status)
@@ -384,6 +604,24 @@ func (r *ParticipantRepository) ResetParticipantStatuses(participantIds []string
 	return nil
 }
 
+// SetParticipantTags replaces the tags stored on a participant's MongoDB document
+func (r *ParticipantRepository) SetParticipantTags(participantId string, tags []string) (*CombinedParticipant, error) {
+	participant, err := r.getParticipantByIdFromPostgres(participantId)
+	if err != nil {
+		return nil, fmt.Errorf("participant not found: %w", err)
+	}
+
+	normalized := NormalizeTags(tags)
+
+	if err := r.updateParticipantInMongo(participant.CertificateID, participantId, map[string]any{tagsField: normalized}); err != nil {
+		slog.Error("ParticipantModel SetParticipantTags failed", "error", err, "participant_id", participantId)
+		return nil, err
+	}
+
+	slog.Info("ParticipantModel SetParticipantTags success", "participant_id", participantId, "tags", normalized)
+	return r.GetParticipantsById(participantId)
+}
+
 // MarkAsDownloaded marks a participant as downloaded
 func (r *ParticipantRepository) MarkAsDownloaded(participantId string) error {
 	return r.UpdateDownloadStatus(participantId, true)
@@ -391,13 +629,17 @@ func (r *ParticipantRepository) MarkAsDownloaded(participantId string) error {
 
 // GetParticipantCollectionCount returns the count of participants in the MongoDB collection
 func (r *ParticipantRepository) GetParticipantCollectionCount(certId string) (int64, error) {
-	collectionName := "participant-" + certId
-	collection := r.db.Collection(collectionName)
+	collection := r.participantCollection(certId)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), mongoOpTimeout())
 	defer cancel()
 
-	count, err := collection.CountDocuments(ctx, bson.M{})
+	var count int64
+	err := withMongoRetry(ctx, "count participants", func() error {
+		var err error
+		count, err = collection.CountDocuments(ctx, participantFilter(certId, nil))
+		return err
+	})
 	if err != nil {
 		slog.Error("ParticipantModel GetCollectionCount failed", "error", err, "cert_id", certId)
 		return 0, err
@@ -406,6 +648,22 @@ func (r *ParticipantRepository) GetParticipantCollectionCount(certId string) (in
 	return count, nil
 }
 
+// missingAnchorFields lists the required anchor fields that are absent or empty in a participant's data
+func missingAnchorFields(requiredFields []string, participant map[string]any) []string {
+	var missingFields []string
+	for _, requiredField := range requiredFields {
+		value, exists := participant[requiredField]
+		if !exists {
+			missingFields = append(missingFields, requiredField)
+		} else if value == nil {
+			missingFields = append(missingFields, requiredField+" (empty)")
+		} else if strValue, isString := value.(string); isString && strings.TrimSpace(strValue) == "" {
+			missingFields = append(missingFields, requiredField+" (empty)")
+		}
+	}
+	return missingFields
+}
+
 // CleanupDeletedAnchors removes fields from all participant documents that are no longer anchors in the certificate design
 // ========== Internal helper methods ==========
 
@@ -450,10 +708,10 @@ func (r *ParticipantRepository) addParticipantsToPostgres(certId string, partici
 
 // addParticipantsToMongo handles MongoDB insertion with generated IDs
 func (r *ParticipantRepository) addParticipantsToMongo(certId string, participants []map[string]any, participantIDs []string) (*mongo.InsertManyResult, error) {
-	collectionName := "participant-" + certId
-	collection := r.db.Collection(collectionName)
+	collectionName := ParticipantCollectionName(certId)
+	collection := r.participantCollection(certId)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), mongoOpTimeout())
 	defer cancel()
 
 	// Prepare documents with metadata and custom IDs
@@ -465,18 +723,48 @@ func (r *ParticipantRepository) addParticipantsToMongo(certId string, participan
 			doc[k] = v
 		}
 
+		// Encrypt configured sensitive fields before they reach MongoDB
+		doc, err := encryptParticipantData(doc)
+		if err != nil {
+			slog.Error("ParticipantModel MongoDB field encryption failed", "error", err, "cert_id", certId)
+			return nil, err
+		}
+
 		// Add metadata with custom ID
 		doc["_id"] = participantIDs[i] // Use our generated UUID as MongoDB _id
 		doc["certificate_id"] = certId
 		documents = append(documents, doc)
 	}
 
-	result, err := collection.InsertMany(ctx, documents)
+	// Documents carry their own _id and the insert is unordered, so a retry after a partially applied insert
+	// writes the missing documents and only reports duplicate keys for the ones already stored
+	var result *mongo.InsertManyResult
+	attempt := 0
+	err := withMongoRetry(ctx, "insert participants", func() error {
+		attempt++
+		var err error
+		result, err = collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+		if err != nil && attempt > 1 && isAlreadyInsertedError(err) {
+			slog.Info("ParticipantModel MongoDB retry found participants already inserted", "cert_id", certId, "attempt", attempt)
+			insertedIDs := make([]any, len(participantIDs))
+			for i, id := range participantIDs {
+				insertedIDs[i] = id
+			}
+			result = &mongo.InsertManyResult{InsertedIDs: insertedIDs}
+			return nil
+		}
+		return err
+	})
 	if err != nil {
 		slog.Error("ParticipantModel MongoDB insertion failed", "error", err, "cert_id", certId)
 		return nil, err
 	}
 
+	// New per-certificate collections are created by the insert, so index them here too
+	if err := ensureCollectionIndexes(ctx, collection); err != nil {
+		slog.Warn("ParticipantModel MongoDB index creation failed", "error", err, "cert_id", certId)
+	}
+
 	slog.Info("ParticipantModel MongoDB insertion successful",
 		"cert_id", certId,
 		"collection", collectionName,
@@ -485,6 +773,51 @@ func (r *ParticipantRepository) addParticipantsToMongo(certId string, participan
 	return result, nil
 }
 
+// combineParticipants merges PostgreSQL status records with their MongoDB documents,
+// keeping the order of the PostgreSQL records
+func combineParticipants(postgresParticipants []*model.Participant, mongoParticipants []map[string]any) []*CombinedParticipant {
+	// Create a map of MongoDB data by ID for fast lookup
+	mongoDataMap := make(map[string]map[string]any)
+	for _, participant := range mongoParticipants {
+		if id, ok := participant["_id"].(string); ok {
+			mongoDataMap[id] = participant
+		}
+	}
+
+	// Combine data
+	combinedParticipants := make([]*CombinedParticipant, 0, len(postgresParticipants))
+	for _, pgParticipant := range postgresParticipants {
+		combined := &CombinedParticipant{
+			ID:             pgParticipant.ID,
+			CertificateID:  pgParticipant.CertificateID,
+			IsRevoke:       pgParticipant.Isrevoke,
+			CertificateURL: pgParticipant.CertificateURL,
+			EmailStatus:    pgParticipant.EmailStatus,
+			IsDownloaded:   pgParticipant.IsDownloaded,
+			IsStale:        pgParticipant.IsStale,
+			PdfSigned:      pgParticipant.PdfSigned,
+			CreatedAt:      pgParticipant.CreatedAt,
+			UpdatedAt:      pgParticipant.UpdatedAt,
+			DynamicData:    make(map[string]any),
+		}
+
+		// Add MongoDB data if exists
+		if mongoData, exists := mongoDataMap[pgParticipant.ID]; exists {
+			combined.Tags = extractTags(mongoData)
+			// Copy all fields except internal ones
+			for key, value := range mongoData {
+				if key != "_id" && key != "certificate_id" && key != tagsField {
+					combined.DynamicData[key] = value
+				}
+			}
+		}
+
+		combinedParticipants = append(combinedParticipants, combined)
+	}
+
+	return combinedParticipants
+}
+
 // getParticipantsByPostgres returns participants from PostgreSQL by certificate ID
 func (r *ParticipantRepository) getParticipantsByPostgres(certId string) ([]*model.Participant, error) {
 	participants, err := r.q.Participant.Where(r.q.Participant.CertificateID.Eq(certId)).Find()
@@ -499,22 +832,19 @@ func (r *ParticipantRepository) getParticipantsByPostgres(certId string) ([]*mod
 
 // getParticipantsByMongo returns participants from MongoDB by certificate ID
 func (r *ParticipantRepository) getParticipantsByMongo(certId string) ([]map[string]any, error) {
-	collectionName := "participant-" + certId
-	collection := r.db.Collection(collectionName)
+	collection := r.participantCollection(certId)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), mongoOpTimeout())
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.M{"certificate_id": certId})
+	participants, err := findParticipantDocuments(ctx, collection, bson.M{"certificate_id": certId})
 	if err != nil {
 		slog.Error("ParticipantModel GetParticipantsByMongo find failed", "error", err, "cert_id", certId)
 		return nil, err
 	}
-	defer cursor.Close(ctx)
 
-	var participants []map[string]any
-	if err = cursor.All(ctx, &participants); err != nil {
-		slog.Error("ParticipantModel GetParticipantsByMongo cursor failed", "error", err, "cert_id", certId)
+	if err := decryptParticipantDocuments(participants); err != nil {
+		slog.Error("ParticipantModel GetParticipantsByMongo decryption failed", "error", err, "cert_id", certId)
 		return nil, err
 	}
 
@@ -536,14 +866,15 @@ func (r *ParticipantRepository) getParticipantByIdFromPostgres(participantId str
 
 // getParticipantByIdFromMongo returns a specific participant from MongoDB by participant ID
 func (r *ParticipantRepository) getParticipantByIdFromMongo(certId string, participantID string) (map[string]any, error) {
-	collectionName := "participant-" + certId
-	collection := r.db.Collection(collectionName)
+	collection := r.participantCollection(certId)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), mongoOpTimeout())
 	defer cancel()
 
 	var participant map[string]any
-	err := collection.FindOne(ctx, bson.M{"_id": participantID}).Decode(&participant)
+	err := withMongoRetry(ctx, "find participant", func() error {
+		return collection.FindOne(ctx, participantFilter(certId, bson.M{"_id": participantID})).Decode(&participant)
+	})
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			slog.Warn("ParticipantModel GetParticipantByIdFromMongo: participant not found", "cert_id", certId, "participant_id", participantID)
@@ -553,6 +884,11 @@ func (r *ParticipantRepository) getParticipantByIdFromMongo(certId string, parti
 		return nil, err
 	}
 
+	if err := decryptParticipantDocuments([]map[string]any{participant}); err != nil {
+		slog.Error("ParticipantModel GetParticipantByIdFromMongo decryption failed", "error", err, "cert_id", certId, "participant_id", participantID)
+		return nil, err
+	}
+
 	slog.Info("ParticipantModel GetParticipantByIdFromMongo", "cert_id", certId, "participant_id", participantID)
 	return participant, nil
 }
@@ -577,11 +913,21 @@ func (r *ParticipantRepository) deleteByCertIdFromPostgres(certId string) ([]*mo
 	return participants, nil
 }
 
-// deleteCollectionByCertIdFromMongo deletes the entire MongoDB collection for a certificate
+// deleteCollectionByCertIdFromMongo deletes the entire MongoDB collection for a certificate,
+// or only the certificate's documents when participants share one collection
 func (r *ParticipantRepository) deleteCollectionByCertIdFromMongo(certId string) error {
-	collectionName := fmt.Sprintf("participant-%s", certId)
+	collectionName := ParticipantCollectionName(certId)
+
+	ctx, cancel := context.WithTimeout(context.Background(), mongoScanTimeout())
+	defer cancel()
 
-	err := r.db.Collection(collectionName).Drop(context.Background())
+	err := withMongoRetry(ctx, "delete participants", func() error {
+		if IsSharedCollectionMode() {
+			_, err := r.participantCollection(certId).DeleteMany(ctx, participantFilter(certId, nil))
+			return err
+		}
+		return r.participantCollection(certId).Drop(ctx)
+	})
 	if err != nil {
 		slog.Error("ParticipantModel DeleteCollectionByCertId failed", "error", err, "cert_id", certId, "collection", collectionName)
 		return err
@@ -610,14 +956,18 @@ func (r *ParticipantRepository) deleteParticipantByIdFromPostgres(participantId
 
 // deleteParticipantByIdFromMongo deletes a single participant from MongoDB by participant ID
 func (r *ParticipantRepository) deleteParticipantByIdFromMongo(certId, participantID string) error {
-	collectionName := "participant-" + certId
-	collection := r.db.Collection(collectionName)
+	collection := r.participantCollection(certId)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), mongoOpTimeout())
 	defer cancel()
 
 	// Delete the document with the specified ID
-	result, err := collection.DeleteOne(ctx, bson.M{"_id": participantID})
+	var result *mongo.DeleteResult
+	err := withMongoRetry(ctx, "delete participant", func() error {
+		var err error
+		result, err = collection.DeleteOne(ctx, participantFilter(certId, bson.M{"_id": participantID}))
+		return err
+	})
 	if err != nil {
 		slog.Error("ParticipantModel deleteParticipantByIdFromMongo failed", "error", err, "cert_id", certId, "participant_id", participantID)
 		return err
@@ -650,21 +1000,31 @@ func (r *ParticipantRepository) updateParticipantTimestampInPostgres(participant
 
 // updateParticipantInMongo updates a participant's data in MongoDB
 func (r *ParticipantRepository) updateParticipantInMongo(certId, participantID string, newData map[string]any) error {
-	collectionName := "participant-" + certId
-	collection := r.db.Collection(collectionName)
+	collection := r.participantCollection(certId)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), mongoOpTimeout())
 	defer cancel()
 
-	// Create update document - only update the provided fields
-	updateDoc := bson.M{"$set": newData}
+	encryptedData, err := encryptParticipantData(newData)
+	if err != nil {
+		slog.Error("ParticipantModel updateParticipantInMongo field encryption failed", "error", err, "cert_id", certId, "participant_id", participantID)
+		return err
+	}
 
-	// Update the document
-	result, err := collection.UpdateOne(
-		ctx,
-		bson.M{"_id": participantID},
-		updateDoc,
-	)
+	// Create update document - only update the provided fields
+	updateDoc := bson.M{"$set": encryptedData}
+
+	// Update the document; $set is idempotent so it is safe to retry
+	var result *mongo.UpdateResult
+	err = withMongoRetry(ctx, "update participant", func() error {
+		var err error
+		result, err = collection.UpdateOne(
+			ctx,
+			participantFilter(certId, bson.M{"_id": participantID}),
+			updateDoc,
+		)
+		return err
+	})
 
 	if err != nil {
 		slog.Error("ParticipantModel updateParticipantInMongo failed", "error", err, "cert_id", certId, "participant_id", participantID)
@@ -689,7 +1049,7 @@ func (r *ParticipantRepository) updateParticipantInMongo(certId, participantID s
 func (r *ParticipantRepository) validateEditDataStructure(certId string, newData map[string]any) error {
 	// Get certificate design to validate against current anchors
 	certRepo := certificatemodel.NewCertificateRepository(r.q)
-	cert, err := certRepo.GetById(certId)
+	cert, err := certRepo.GetByIdCached(certId)
 	if err != nil {
 		return fmt.Errorf("failed to get certificate: %w", err)
 	}
@@ -698,7 +1058,7 @@ func (r *ParticipantRepository) validateEditDataStructure(certId string, newData
 	}
 
 	// Extract required anchor fields from certificate design
-	requiredAnchors, err := r.extractAnchorNames(cert.Design)
+	requiredAnchors, err := r.certificateAnchorNames(cert)
 	if err != nil {
 		return fmt.Errorf("failed to extract anchor names from certificate design: %w", err)
 	}
@@ -708,6 +1068,7 @@ func (r *ParticipantRepository) validateEditDataStructure(certId string, newData
 		"_id":            true,
 		"certificate_id": true,
 		"email":          true,
+		tagsField:        true,
 	}
 
 	// Get fields from new data (excluding protected fields)
@@ -774,41 +1135,20 @@ func (r *ParticipantRepository) validateEditDataStructure(certId string, newData
 
 // ========== Private Helper Methods for Validation ==========
 
-// extractAnchorNames extracts anchor names from certificate design JSON
-func (r *ParticipantRepository) extractAnchorNames(designJSON string) ([]string, error) {
-	var design map[string]any
-	if err := json.Unmarshal([]byte(designJSON), &design); err != nil {
-		return nil, fmt.Errorf("failed to parse certificate design: %w", err)
+// certificateAnchorNames returns a certificate's ordered anchor names, using the list stored with its design
+// and parsing the design only for certificates saved before anchors were stored
+func (r *ParticipantRepository) certificateAnchorNames(cert *model.Certificate) ([]string, error) {
+	if cert.Anchors == nil {
+		return certificatemodel.ExtractDesignAnchors(cert.Design)
 	}
-
-	objects, ok := design["objects"].([]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid design format - objects array not found")
-	}
-
-	var anchorNames []string
-	for _, obj := range objects {
-		objMap, ok := obj.(map[string]any)
-		if !ok {
-			continue
-		}
-
-		id, exists := objMap["id"].(string)
-		if exists && strings.HasPrefix(id, "PLACEHOLDER-") {
-			anchorName := strings.TrimPrefix(id, "PLACEHOLDER-")
-			anchorNames = append(anchorNames, anchorName)
-		}
-	}
-
-	sort.Strings(anchorNames) // Sort for consistent ordering
-	return anchorNames, nil
+	return cert.Anchors, nil
 }
 
 // ValidateFieldConsistency validates that new participants match the certificate design anchors
 func (r *ParticipantRepository) ValidateFieldConsistency(certId string, newParticipants []map[string]any) error {
 	// Get certificate design to extract required anchor fields
 	certRepo := certificatemodel.NewCertificateRepository(r.q)
-	cert, err := certRepo.GetById(certId)
+	cert, err := certRepo.GetByIdCached(certId)
 	if err != nil {
 		return fmt.Errorf("failed to get certificate: %w", err)
 	}
@@ -817,7 +1157,7 @@ func (r *ParticipantRepository) ValidateFieldConsistency(certId string, newParti
 	}
 
 	// Extract anchor names from certificate design
-	requiredFields, err := r.extractAnchorNames(cert.Design)
+	requiredFields, err := r.certificateAnchorNames(cert)
 	if err != nil {
 		return fmt.Errorf("failed to extract anchor names from certificate design: %w", err)
 	}
@@ -831,17 +1171,7 @@ func (r *ParticipantRepository) ValidateFieldConsistency(certId string, newParti
 	// Check each new participant against required anchor fields
 	for i, participant := range newParticipants {
 		// Check if all required anchor fields are present
-		var missingFields []string
-		for _, requiredField := range requiredFields {
-			value, exists := participant[requiredField]
-			if !exists {
-				missingFields = append(missingFields, requiredField)
-			} else if value == nil {
-				missingFields = append(missingFields, requiredField+" (empty)")
-			} else if strValue, isString := value.(string); isString && strings.TrimSpace(strValue) == "" {
-				missingFields = append(missingFields, requiredField+" (empty)")
-			}
-		}
+		missingFields := missingAnchorFields(requiredFields, participant)
 
 		if len(missingFields) > 0 {
 			var participantFields []string
@@ -877,7 +1207,7 @@ func (r *ParticipantRepository) ValidateFieldConsistency(certId string, newParti
 // CleanupDeletedAnchors removes fields from all participant documents that are no longer anchors in the certificate design
 func (r *ParticipantRepository) CleanupDeletedAnchors(certId string, designJSON string) error {
 	// Extract current anchor names from certificate design
-	currentAnchors, err := r.extractAnchorNames(designJSON)
+	currentAnchors, err := certificatemodel.ExtractDesignAnchors(designJSON)
 	if err != nil {
 		return fmt.Errorf("failed to extract anchor names: %w", err)
 	}
@@ -893,27 +1223,20 @@ func (r *ParticipantRepository) CleanupDeletedAnchors(certId string, designJSON
 		"_id":            true,
 		"certificate_id": true,
 		"email":          true,
+		tagsField:        true,
 	}
 
-	collectionName := "participant-" + certId
-	collection := r.db.Collection(collectionName)
+	collection := r.participantCollection(certId)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), mongoScanTimeout())
 	defer cancel()
 
 	// Get all participants
-	cursor, err := collection.Find(ctx, bson.M{"certificate_id": certId})
+	participants, err := findParticipantDocuments(ctx, collection, bson.M{"certificate_id": certId})
 	if err != nil {
 		slog.Error("ParticipantModel CleanupDeletedAnchors: failed to find participants", "error", err, "cert_id", certId)
 		return fmt.Errorf("failed to find participants: %w", err)
 	}
-	defer cursor.Close(ctx)
-
-	var participants []map[string]any
-	if err = cursor.All(ctx, &participants); err != nil {
-		slog.Error("ParticipantModel CleanupDeletedAnchors: failed to decode participants", "error", err, "cert_id", certId)
-		return fmt.Errorf("failed to decode participants: %w", err)
-	}
 
 	// Process each participant and find fields to remove
 	updatedCount := 0
@@ -942,7 +1265,12 @@ func (r *ParticipantRepository) CleanupDeletedAnchors(certId string, designJSON
 			filter := bson.M{"_id": participantID, "certificate_id": certId}
 			update := bson.M{"$unset": unsetFields}
 
-			result, err := collection.UpdateOne(ctx, filter, update)
+			var result *mongo.UpdateResult
+			err := withMongoRetry(ctx, "unset participant fields", func() error {
+				var err error
+				result, err = collection.UpdateOne(ctx, filter, update)
+				return err
+			})
 			if err != nil {
 				slog.Error("ParticipantModel CleanupDeletedAnchors: failed to update participant",
 					"error", err,
@@ -0,0 +1,130 @@
+package participantmodel
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultMongoMaxRetries = 3
+	mongoRetryBackoff      = 200 * time.Millisecond
+
+	// duplicateKeyCode is the server error code of a unique index violation
+	duplicateKeyCode = 11000
+)
+
+// retryableMongoCodes are server error codes raised while a replica set elects a new primary or a node shuts
+// down, which succeed once the cluster settles
+var retryableMongoCodes = map[int]bool{
+	6:     true, // HostUnreachable
+	7:     true, // HostNotFound
+	89:    true, // NetworkTimeout
+	91:    true, // ShutdownInProgress
+	189:   true, // PrimarySteppedDown
+	9001:  true, // SocketException
+	10107: true, // NotWritablePrimary
+	11600: true, // InterruptedAtShutdown
+	11602: true, // InterruptedDueToReplStateChange
+	13435: true, // NotPrimaryNoSecondaryOk
+	13436: true, // NotPrimaryOrSecondary
+}
+
+// mongoMaxRetries returns how many times a failed participant MongoDB operation is retried (mongo_max_retries)
+func mongoMaxRetries() int {
+	if common.Config != nil && common.Config.MongoMaxRetries != nil && *common.Config.MongoMaxRetries >= 0 {
+		return *common.Config.MongoMaxRetries
+	}
+	return defaultMongoMaxRetries
+}
+
+// isRetryableMongoError reports whether a MongoDB failure is transient: network errors and the errors raised
+// during a primary stepdown or failover. Duplicate keys, validation failures, missing documents and expired
+// contexts are permanent.
+func isRetryableMongoError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, mongo.ErrNoDocuments) || mongo.IsDuplicateKeyError(err) {
+		return false
+	}
+
+	if mongo.IsNetworkError(err) {
+		return true
+	}
+
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		if serverErr.HasErrorLabel("RetryableWriteError") || serverErr.HasErrorLabel("TransientTransactionError") {
+			return true
+		}
+		for code := range retryableMongoCodes {
+			if serverErr.HasErrorCode(code) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isAlreadyInsertedError reports whether an unordered insert failed only with duplicate keys. Participant
+// documents carry freshly generated IDs, so on a retry this means an earlier attempt stored them before failing.
+func isAlreadyInsertedError(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != duplicateKeyCode {
+			return false
+		}
+	}
+	return true
+}
+
+// withMongoRetry runs op, retrying transient failures up to mongo_max_retries times with a linear backoff.
+// op must be safe to run again, so it should redo the whole operation including reading its cursor.
+func withMongoRetry(ctx context.Context, operation string, op func() error) error {
+	maxRetries := mongoMaxRetries()
+
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil {
+			if attempt > 1 {
+				slog.Info("MongoDB operation succeeded after retry", "operation", operation, "attempt", attempt)
+			}
+			return nil
+		}
+
+		if attempt > maxRetries || !isRetryableMongoError(err) {
+			return err
+		}
+
+		slog.Warn("MongoDB operation failed, retrying", "error", err, "operation", operation, "attempt", attempt)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * mongoRetryBackoff):
+		}
+	}
+}
+
+// findParticipantDocuments runs a find and reads every matching document, retrying both together on transient failures
+func findParticipantDocuments(ctx context.Context, collection *mongo.Collection, filter any, opts ...*options.FindOptions) ([]map[string]any, error) {
+	var docs []map[string]any
+	err := withMongoRetry(ctx, "find "+collection.Name(), func() error {
+		cursor, err := collection.Find(ctx, filter, opts...)
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(ctx)
+
+		docs = nil
+		return cursor.All(ctx, &docs)
+	})
+	return docs, err
+}
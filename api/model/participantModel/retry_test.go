@@ -0,0 +1,112 @@
+package participantmodel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIsRetryableMongoError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "network error", err: mongo.CommandError{Labels: []string{"NetworkError"}, Wrapped: &net.OpError{Op: "read", Err: errors.New("connection reset")}}, want: true},
+		{name: "primary stepped down", err: mongo.CommandError{Code: 189, Name: "PrimarySteppedDown"}, want: true},
+		{name: "not writable primary", err: mongo.WriteException{WriteConcernError: &mongo.WriteConcernError{Code: 10107}}, want: true},
+		{name: "retryable write label", err: mongo.CommandError{Code: 1, Labels: []string{"RetryableWriteError"}}, want: true},
+		{name: "wrapped stepdown", err: fmt.Errorf("failed: %w", mongo.CommandError{Code: 11602}), want: true},
+		{name: "duplicate key", err: mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}, want: false},
+		{name: "validation failure", err: mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 121}}}, want: false},
+		{name: "no documents", err: mongo.ErrNoDocuments, want: false},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryableMongoError(tt.err))
+		})
+	}
+}
+
+func TestIsAlreadyInsertedError(t *testing.T) {
+	duplicate := mongo.BulkWriteError{WriteError: mongo.WriteError{Code: 11000}}
+	validation := mongo.BulkWriteError{WriteError: mongo.WriteError{Code: 121}}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "only duplicate keys", err: mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate, duplicate}}, want: true},
+		{name: "duplicate and validation failure", err: mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate, validation}}, want: false},
+		{name: "duplicate with write concern error", err: mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate}, WriteConcernError: &mongo.WriteConcernError{Code: 64}}, want: false},
+		{name: "no write errors", err: mongo.BulkWriteException{}, want: false},
+		{name: "network error", err: mongo.CommandError{Labels: []string{"NetworkError"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isAlreadyInsertedError(tt.err))
+		})
+	}
+}
+
+func TestWithMongoRetry(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	retries := func(n int) *int { return &n }
+	stepdown := mongo.CommandError{Code: 189, Name: "PrimarySteppedDown"}
+
+	common.Config = &shared.Config{MongoMaxRetries: retries(2)}
+
+	attempts := 0
+	err := withMongoRetry(context.Background(), "test", func() error {
+		attempts++
+		if attempts < 3 {
+			return stepdown
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts, "recovers within the configured retries")
+
+	attempts = 0
+	err = withMongoRetry(context.Background(), "test", func() error {
+		attempts++
+		return stepdown
+	})
+	assert.Equal(t, stepdown, err)
+	assert.Equal(t, 3, attempts, "gives up after the configured retries")
+
+	attempts = 0
+	duplicate := mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+	err = withMongoRetry(context.Background(), "test", func() error {
+		attempts++
+		return duplicate
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts, "permanent errors fail immediately")
+
+	common.Config = &shared.Config{MongoMaxRetries: retries(0)}
+	attempts = 0
+	_ = withMongoRetry(context.Background(), "test", func() error {
+		attempts++
+		return stepdown
+	})
+	assert.Equal(t, 1, attempts, "zero retries disables retrying")
+
+	common.Config = &shared.Config{}
+	assert.Equal(t, defaultMongoMaxRetries, mongoMaxRetries())
+}
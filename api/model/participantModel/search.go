@@ -0,0 +1,185 @@
+package participantmodel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MaxSearchMatches caps how many participants SearchParticipants returns across all certificates
+const MaxSearchMatches = 500
+
+// maxSearchScannedDocuments caps how many candidate documents one search reads from MongoDB across all
+// certificates, so a broad term or many encrypted documents never turn a search into a full scan
+const maxSearchScannedDocuments = 5000
+
+// ParticipantSearchMatch is a participant with the first field whose value matched a search term
+type ParticipantSearchMatch struct {
+	ParticipantID string
+	CertificateID string
+	Email         string
+	Field         string
+	Value         string
+}
+
+// searchSkippedFields are document fields that never match a search
+var searchSkippedFields = map[string]bool{
+	"_id":            true,
+	"certificate_id": true,
+	tagsField:        true,
+}
+
+// participantSearchFilter narrows a search in MongoDB to documents with a top-level field whose value contains
+// term case-insensitively, or holding encrypted values that can only be checked after decryption. Candidates
+// are matched again in Go, so the filter only has to keep every possible match.
+func participantSearchFilter(term string) bson.M {
+	skipped := make([]string, 0, len(searchSkippedFields))
+	for field := range searchSkippedFields {
+		skipped = append(skipped, field)
+	}
+	sort.Strings(skipped)
+
+	value := bson.M{"$convert": bson.M{"input": "$$field.v", "to": "string", "onError": "", "onNull": ""}}
+	return bson.M{"$expr": bson.M{"$gt": bson.A{
+		bson.M{"$size": bson.M{"$filter": bson.M{
+			"input": bson.M{"$objectToArray": "$$ROOT"},
+			"as":    "field",
+			"cond": bson.M{"$and": bson.A{
+				bson.M{"$not": bson.A{bson.M{"$in": bson.A{"$$field.k", skipped}}}},
+				bson.M{"$or": bson.A{
+					bson.M{"$regexMatch": bson.M{"input": value, "regex": regexp.QuoteMeta(term), "options": "i"}},
+					bson.M{"$regexMatch": bson.M{"input": value, "regex": "^" + regexp.QuoteMeta(encryptedValuePrefix)}},
+				}},
+			}},
+		}}},
+		0,
+	}}}
+}
+
+// SearchParticipants finds the participants of the given certificates having a field whose value contains term,
+// case-insensitively, in certificate order. MongoDB only returns documents that can match; encrypted fields are
+// matched after decryption. Stops at limit matches or after reading maxSearchScannedDocuments documents and
+// reports whether more were left unsearched.
+func (r *ParticipantRepository) SearchParticipants(certIds []string, term string, limit int) ([]*ParticipantSearchMatch, bool, error) {
+	term = strings.ToLower(strings.TrimSpace(term))
+	matches := []*ParticipantSearchMatch{}
+	if term == "" || limit <= 0 {
+		return matches, false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mongoScanTimeout())
+	defer cancel()
+
+	budget := maxSearchScannedDocuments
+	for _, certId := range certIds {
+		var scanned int
+		var truncated bool
+		var err error
+		matches, scanned, truncated, err = r.searchCertificateParticipants(ctx, certId, term, matches, limit, budget)
+		if err != nil {
+			return nil, false, err
+		}
+		if truncated {
+			return matches, true, nil
+		}
+		budget -= scanned
+	}
+
+	return matches, false, nil
+}
+
+// searchCertificateParticipants streams the candidate documents of one certificate, appending matches until
+// limit matches were found or budget documents were read. It returns the matches, how many documents it read
+// and whether it stopped with documents left unsearched.
+func (r *ParticipantRepository) searchCertificateParticipants(ctx context.Context, certId, term string, matches []*ParticipantSearchMatch, limit, budget int) ([]*ParticipantSearchMatch, int, bool, error) {
+	collection := r.participantCollection(certId)
+
+	var cursor *mongo.Cursor
+	err := withMongoRetry(ctx, "search "+collection.Name(), func() error {
+		var err error
+		cursor, err = collection.Find(ctx,
+			participantFilter(certId, participantSearchFilter(term)),
+			options.Find().SetSort(bson.M{"_id": 1}),
+		)
+		return err
+	})
+	if err != nil {
+		slog.Error("ParticipantModel SearchParticipants find failed", "error", err, "cert_id", certId)
+		return nil, 0, false, fmt.Errorf("failed to search participants: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	scanned := 0
+	for cursor.Next(ctx) {
+		if scanned == budget {
+			return matches, scanned, true, nil
+		}
+		scanned++
+
+		var doc map[string]any
+		if err := cursor.Decode(&doc); err != nil {
+			slog.Error("ParticipantModel SearchParticipants decode failed", "error", err, "cert_id", certId)
+			return nil, scanned, false, fmt.Errorf("failed to search participants: %w", err)
+		}
+		if err := decryptParticipantDocuments([]map[string]any{doc}); err != nil {
+			slog.Error("ParticipantModel SearchParticipants decryption failed", "error", err, "cert_id", certId)
+			return nil, scanned, false, err
+		}
+
+		field, value, ok := matchParticipantDocument(doc, term)
+		if !ok {
+			continue
+		}
+		if len(matches) == limit {
+			return matches, scanned, true, nil
+		}
+
+		email, _ := doc["email"].(string)
+		matches = append(matches, &ParticipantSearchMatch{
+			ParticipantID: fmt.Sprint(doc["_id"]),
+			CertificateID: certId,
+			Email:         email,
+			Field:         field,
+			Value:         value,
+		})
+	}
+	if err := cursor.Err(); err != nil {
+		slog.Error("ParticipantModel SearchParticipants cursor failed", "error", err, "cert_id", certId)
+		return nil, scanned, false, fmt.Errorf("failed to search participants: %w", err)
+	}
+
+	return matches, scanned, false, nil
+}
+
+// matchParticipantDocument returns the first field of doc whose value contains the lowercase term, checking
+// email first and then the remaining fields alphabetically so the reported field is stable
+func matchParticipantDocument(doc map[string]any, term string) (string, string, bool) {
+	fields := make([]string, 0, len(doc))
+	for field := range doc {
+		if !searchSkippedFields[field] && field != "email" {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	if _, ok := doc["email"]; ok {
+		fields = append([]string{"email"}, fields...)
+	}
+
+	for _, field := range fields {
+		switch value := doc[field].(type) {
+		case string, int32, int64, float64, bool:
+			text := fmt.Sprint(value)
+			if strings.Contains(strings.ToLower(text), term) {
+				return field, text, true
+			}
+		}
+	}
+	return "", "", false
+}
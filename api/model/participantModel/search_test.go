@@ -0,0 +1,58 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMatchParticipantDocument(t *testing.T) {
+	doc := map[string]any{
+		"_id":            "alice-id",
+		"certificate_id": "cert-alice",
+		"tags":           []any{"alice"},
+		"email":          "Alice@Example.com",
+		"name":           "Alice Smith",
+		"score":          int32(42),
+		"nested":         map[string]any{"name": "hidden"},
+	}
+
+	field, value, ok := matchParticipantDocument(doc, "alice")
+	assert.True(t, ok)
+	assert.Equal(t, "email", field)
+	assert.Equal(t, "Alice@Example.com", value)
+
+	field, value, ok = matchParticipantDocument(doc, "smith")
+	assert.True(t, ok)
+	assert.Equal(t, "name", field)
+	assert.Equal(t, "Alice Smith", value)
+
+	field, _, ok = matchParticipantDocument(doc, "42")
+	assert.True(t, ok)
+	assert.Equal(t, "score", field)
+
+	_, _, ok = matchParticipantDocument(doc, "cert-alice")
+	assert.False(t, ok, "internal fields are not searched")
+
+	_, _, ok = matchParticipantDocument(doc, "hidden")
+	assert.False(t, ok, "nested values are not searched")
+}
+
+func TestParticipantSearchFilter(t *testing.T) {
+	filter := participantSearchFilter("a.b+c")
+
+	expr := filter["$expr"].(bson.M)["$gt"].(bson.A)
+	cond := expr[0].(bson.M)["$size"].(bson.M)["$filter"].(bson.M)["cond"].(bson.M)["$and"].(bson.A)
+
+	skipped := cond[0].(bson.M)["$not"].(bson.A)[0].(bson.M)["$in"].(bson.A)[1]
+	assert.Equal(t, []string{"_id", "certificate_id", "tags"}, skipped)
+
+	matchers := cond[1].(bson.M)["$or"].(bson.A)
+	term := matchers[0].(bson.M)["$regexMatch"].(bson.M)
+	assert.Equal(t, `a\.b\+c`, term["regex"], "the term is matched literally")
+	assert.Equal(t, "i", term["options"])
+
+	encrypted := matchers[1].(bson.M)["$regexMatch"].(bson.M)
+	assert.Equal(t, "^enc:v1:", encrypted["regex"], "encrypted values are kept for matching after decryption")
+}
@@ -0,0 +1,64 @@
+package participantmodel
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NormalizeTags trims whitespace and drops empty or duplicate tags while keeping order
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// FilterByTag returns only the participants carrying the given tag.
+// An empty tag returns the input unchanged.
+func FilterByTag(participants []*CombinedParticipant, tag string) []*CombinedParticipant {
+	if tag == "" {
+		return participants
+	}
+
+	filtered := make([]*CombinedParticipant, 0, len(participants))
+	for _, participant := range participants {
+		for _, t := range participant.Tags {
+			if t == tag {
+				filtered = append(filtered, participant)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// extractTags reads the tags array from a MongoDB participant document
+func extractTags(doc map[string]any) []string {
+	var raw []any
+	switch v := doc[tagsField].(type) {
+	case primitive.A:
+		raw = v
+	case []any:
+		raw = v
+	case []string:
+		return v
+	default:
+		return []string{}
+	}
+
+	tags := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if tag, ok := item.(string); ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
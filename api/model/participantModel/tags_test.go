@@ -0,0 +1,35 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"track-a", "day-1"}, NormalizeTags([]string{" track-a ", "", "day-1", "track-a"}))
+	assert.Equal(t, []string{}, NormalizeTags(nil))
+}
+
+func TestFilterByTag(t *testing.T) {
+	participants := []*CombinedParticipant{
+		{ID: "p1", Tags: []string{"track-a"}},
+		{ID: "p2", Tags: []string{"track-b", "track-a"}},
+		{ID: "p3", Tags: []string{}},
+	}
+
+	filtered := FilterByTag(participants, "track-a")
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, "p1", filtered[0].ID)
+	assert.Equal(t, "p2", filtered[1].ID)
+
+	assert.Len(t, FilterByTag(participants, "track-c"), 0)
+	assert.Len(t, FilterByTag(participants, ""), 3)
+}
+
+func TestExtractTags(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, extractTags(map[string]any{"tags": primitive.A{"a", "b"}}))
+	assert.Equal(t, []string{"a"}, extractTags(map[string]any{"tags": []any{"a", 1}}))
+	assert.Equal(t, []string{}, extractTags(map[string]any{"name": "x"}))
+}
@@ -0,0 +1,28 @@
+package participantmodel
+
+import (
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const (
+	defaultMongoOpTimeout = 10 * time.Second
+
+	// collectionScanTimeoutFactor scales the timeout of operations that walk a whole participant collection
+	collectionScanTimeoutFactor = 3
+)
+
+// mongoOpTimeout returns the timeout for a single participant MongoDB operation,
+// configured through mongo_op_timeout (seconds)
+func mongoOpTimeout() time.Duration {
+	if common.Config != nil && common.Config.MongoOpTimeout != nil && *common.Config.MongoOpTimeout > 0 {
+		return time.Duration(*common.Config.MongoOpTimeout) * time.Second
+	}
+	return defaultMongoOpTimeout
+}
+
+// mongoScanTimeout returns the timeout for operations that read or rewrite every participant of a certificate
+func mongoScanTimeout() time.Duration {
+	return mongoOpTimeout() * collectionScanTimeoutFactor
+}
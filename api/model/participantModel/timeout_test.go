@@ -0,0 +1,28 @@
+package participantmodel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestMongoTimeouts(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	seconds := func(s int) *int { return &s }
+
+	common.Config = &shared.Config{}
+	assert.Equal(t, 10*time.Second, mongoOpTimeout())
+	assert.Equal(t, 30*time.Second, mongoScanTimeout())
+
+	common.Config = &shared.Config{MongoOpTimeout: seconds(4)}
+	assert.Equal(t, 4*time.Second, mongoOpTimeout())
+	assert.Equal(t, 12*time.Second, mongoScanTimeout())
+
+	common.Config = &shared.Config{MongoOpTimeout: seconds(0)}
+	assert.Equal(t, 10*time.Second, mongoOpTimeout())
+}
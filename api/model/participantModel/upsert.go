@@ -0,0 +1,139 @@
+package participantmodel
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// UpsertEmailField is the participant data field imports are matched on in upsert mode
+const UpsertEmailField = "email"
+
+// SkippedParticipantUpdate is an existing participant whose data an upsert import could not update
+type SkippedParticipantUpdate struct {
+	ParticipantID string `json:"participant_id"`
+	Email         string `json:"email"`
+	Reason        string `json:"reason"`
+}
+
+// ParticipantUpsertResult reports which participants an upsert import inserted and which it updated
+type ParticipantUpsertResult struct {
+	Created        *ParticipantCreateResult
+	UpdatedIDs     []string
+	SkippedUpdates []SkippedParticipantUpdate
+}
+
+// emailUpsertPlan splits imported rows into new participants and updates of existing ones
+type emailUpsertPlan struct {
+	inserts []map[string]any
+	// updates maps an existing participant ID to its new data, applied in updateOrder
+	updates     map[string]map[string]any
+	updateOrder []string
+	emails      map[string]string
+}
+
+// upsertEmailKey returns the comparable email of participant data, or "" when it has none
+func upsertEmailKey(data map[string]any) string {
+	email, _ := data[UpsertEmailField].(string)
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// planEmailUpsert matches rows to existing participant documents by email. Rows without an email are always
+// inserted; when the import repeats an email, the last row wins.
+func planEmailUpsert(existing []map[string]any, rows []map[string]any) *emailUpsertPlan {
+	existingIds := make(map[string]string, len(existing))
+	for _, document := range existing {
+		id, _ := document["_id"].(string)
+		email := upsertEmailKey(document)
+		if id == "" || email == "" {
+			continue
+		}
+		if _, seen := existingIds[email]; !seen {
+			existingIds[email] = id
+		}
+	}
+
+	plan := &emailUpsertPlan{
+		inserts: []map[string]any{},
+		updates: make(map[string]map[string]any),
+		emails:  make(map[string]string),
+	}
+	insertIndex := make(map[string]int)
+
+	for _, row := range rows {
+		email := upsertEmailKey(row)
+		if email == "" {
+			plan.inserts = append(plan.inserts, row)
+			continue
+		}
+
+		if id, ok := existingIds[email]; ok {
+			if _, planned := plan.updates[id]; !planned {
+				plan.updateOrder = append(plan.updateOrder, id)
+			}
+			plan.updates[id] = row
+			plan.emails[id] = email
+			continue
+		}
+
+		if i, ok := insertIndex[email]; ok {
+			plan.inserts[i] = row
+			continue
+		}
+		insertIndex[email] = len(plan.inserts)
+		plan.inserts = append(plan.inserts, row)
+	}
+
+	return plan
+}
+
+// UpsertParticipantsByEmail imports participants keyed on their email so re-running an import is safe:
+// participants whose email already exists in the certificate get their data updated, the rest are inserted.
+// Updates follow the editing rules, so issued certificates are marked stale and frozen participants are skipped.
+func (r *ParticipantRepository) UpsertParticipantsByEmail(certId string, participants []map[string]any) (*ParticipantUpsertResult, error) {
+	existing, err := r.getParticipantsByMongo(certId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read existing participants: %w", err)
+	}
+
+	plan := planEmailUpsert(existing, participants)
+	result := &ParticipantUpsertResult{
+		UpdatedIDs:     []string{},
+		SkippedUpdates: []SkippedParticipantUpdate{},
+	}
+
+	if len(plan.inserts) > 0 {
+		created, err := r.AddParticipants(certId, plan.inserts)
+		if err != nil {
+			return nil, err
+		}
+		result.Created = created
+	}
+
+	for _, id := range plan.updateOrder {
+		if _, err := r.EditParticipantByID(id, plan.updates[id], false); err != nil {
+			reason := err.Error()
+			if errors.Is(err, ErrParticipantNotEditable) {
+				reason = strings.TrimPrefix(reason, ErrParticipantNotEditable.Error()+": ")
+			}
+			slog.Warn("ParticipantModel UpsertParticipantsByEmail update skipped", "error", err, "cert_id", certId, "participant_id", id)
+			result.SkippedUpdates = append(result.SkippedUpdates, SkippedParticipantUpdate{
+				ParticipantID: id,
+				Email:         plan.emails[id],
+				Reason:        reason,
+			})
+			continue
+		}
+		result.UpdatedIDs = append(result.UpdatedIDs, id)
+	}
+
+	slog.Info("ParticipantModel UpsertParticipantsByEmail completed",
+		"cert_id", certId,
+		"requested", len(participants),
+		"inserted", len(plan.inserts),
+		"updated", len(result.UpdatedIDs),
+		"skipped_updates", len(result.SkippedUpdates))
+
+	return result, nil
+}
@@ -0,0 +1,41 @@
+package participantmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanEmailUpsert(t *testing.T) {
+	existing := []map[string]any{
+		{"_id": "p1", "email": "alice@example.com", "name": "Alice"},
+		{"_id": "p2", "email": "Bob@Example.com ", "name": "Bob"},
+		{"_id": "p3", "name": "No Email"},
+	}
+	rows := []map[string]any{
+		{"email": "alice@example.com", "name": "Alice Smith"},
+		{"email": "carol@example.com", "name": "Carol"},
+		{"email": "bob@example.com", "name": "Bob Jones"},
+		{"name": "No Email"},
+		{"email": "carol@example.com", "name": "Carol King"},
+		{"email": "ALICE@example.com", "name": "Alice Brown"},
+	}
+
+	plan := planEmailUpsert(existing, rows)
+
+	require.Len(t, plan.inserts, 2)
+	assert.Equal(t, "Carol King", plan.inserts[0]["name"], "a repeated new email keeps the last row")
+	assert.Equal(t, "No Email", plan.inserts[1]["name"], "rows without an email are always inserted")
+
+	assert.Equal(t, []string{"p1", "p2"}, plan.updateOrder)
+	assert.Equal(t, "Alice Brown", plan.updates["p1"]["name"], "a repeated existing email keeps the last row")
+	assert.Equal(t, "Bob Jones", plan.updates["p2"]["name"], "emails match regardless of case and spacing")
+	assert.Equal(t, "bob@example.com", plan.emails["p2"])
+}
+
+func TestPlanEmailUpsertEmptyCollection(t *testing.T) {
+	plan := planEmailUpsert(nil, []map[string]any{{"email": "alice@example.com"}})
+	assert.Len(t, plan.inserts, 1)
+	assert.Empty(t, plan.updateOrder)
+}
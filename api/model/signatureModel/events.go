@@ -0,0 +1,115 @@
+package signaturemodel
+
+import (
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+	"gorm.io/gorm"
+)
+
+// Signature event types recorded in the signature timeline
+const (
+	SignatureEventRequested       = "requested"
+	SignatureEventReminded        = "reminded"
+	SignatureEventResignRequested = "resign_requested"
+	SignatureEventSigned          = "signed"
+	SignatureEventReplaced        = "replaced"
+)
+
+// RecordEvent appends an event to the timeline of the signature identified by certificate and signer
+func (r *SignatureRepository) RecordEvent(certificateId, signerId, eventType string) error {
+	signature, err := r.q.Signature.Select(r.q.Signature.ID).Where(
+		r.q.Signature.CertificateID.Eq(certificateId),
+	).Where(
+		r.q.Signature.SignerID.Eq(signerId),
+	).First()
+
+	if err != nil {
+		slog.Error("RecordEvent: Error getting signature", "error", err, "certificateId", certificateId, "signerId", signerId, "eventType", eventType)
+		return err
+	}
+
+	event := &model.SignatureEvent{
+		SignatureID:   signature.ID,
+		CertificateID: certificateId,
+		SignerID:      signerId,
+		EventType:     eventType,
+	}
+
+	if err := r.q.SignatureEvent.Create(event); err != nil {
+		slog.Error("RecordEvent Error", "error", err, "certificateId", certificateId, "signerId", signerId, "eventType", eventType)
+		return err
+	}
+
+	return nil
+}
+
+// GetEventsByCertificate returns all signature events of a certificate, oldest first
+func (r *SignatureRepository) GetEventsByCertificate(certificateId string) ([]*model.SignatureEvent, error) {
+	events, queryErr := r.q.SignatureEvent.Where(
+		r.q.SignatureEvent.CertificateID.Eq(certificateId),
+	).Order(r.q.SignatureEvent.CreatedAt).Find()
+
+	if queryErr != nil {
+		if errors.Is(queryErr, gorm.ErrRecordNotFound) {
+			return []*model.SignatureEvent{}, nil
+		}
+		slog.Error("GetEventsByCertificate Error", "error", queryErr, "certificateId", certificateId)
+		return nil, queryErr
+	}
+
+	return events, nil
+}
+
+// TimelineEvent is a single entry of a signer's timeline
+type TimelineEvent struct {
+	Type string    `json:"type"`
+	At   time.Time `json:"at"`
+}
+
+// SignerTimeline is the ordered request/reminder history of one signature
+type SignerTimeline struct {
+	SignatureID string          `json:"signature_id"`
+	SignerID    string          `json:"signer_id"`
+	IsSigned    bool            `json:"is_signed"`
+	Events      []TimelineEvent `json:"events"`
+}
+
+// BuildSignerTimelines groups events per signature in signature order. Signatures requested before
+// events were recorded get a single "requested" entry at their last_request timestamp.
+func BuildSignerTimelines(signatures []*model.Signature, events []*model.SignatureEvent) []SignerTimeline {
+	eventsBySignature := make(map[string][]TimelineEvent)
+	for _, event := range events {
+		eventsBySignature[event.SignatureID] = append(eventsBySignature[event.SignatureID], TimelineEvent{
+			Type: event.EventType,
+			At:   event.CreatedAt,
+		})
+	}
+
+	timelines := make([]SignerTimeline, 0, len(signatures))
+	for _, signature := range signatures {
+		signatureEvents := eventsBySignature[signature.ID]
+		if len(signatureEvents) == 0 && signature.IsRequested {
+			signatureEvents = []TimelineEvent{{Type: SignatureEventRequested, At: signature.LastRequest}}
+		}
+		if signatureEvents == nil {
+			signatureEvents = []TimelineEvent{}
+		}
+
+		sort.SliceStable(signatureEvents, func(i, j int) bool {
+			return signatureEvents[i].At.Before(signatureEvents[j].At)
+		})
+
+		timelines = append(timelines, SignerTimeline{
+			SignatureID: signature.ID,
+			SignerID:    signature.SignerID,
+			IsSigned:    signature.IsSigned,
+			Events:      signatureEvents,
+		})
+	}
+
+	return timelines
+}
@@ -0,0 +1,42 @@
+package signaturemodel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+func TestBuildSignerTimelines(t *testing.T) {
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	signatures := []*model.Signature{
+		{ID: "sig-1", SignerID: "signer-1", IsRequested: true, IsSigned: true, LastRequest: base},
+		{ID: "sig-2", SignerID: "signer-2", IsRequested: true, LastRequest: base.Add(time.Hour)},
+		{ID: "sig-3", SignerID: "signer-3"},
+	}
+	events := []*model.SignatureEvent{
+		{SignatureID: "sig-1", EventType: SignatureEventSigned, CreatedAt: base.Add(48 * time.Hour)},
+		{SignatureID: "sig-1", EventType: SignatureEventRequested, CreatedAt: base},
+		{SignatureID: "sig-1", EventType: SignatureEventReminded, CreatedAt: base.Add(24 * time.Hour)},
+	}
+
+	timelines := BuildSignerTimelines(signatures, events)
+
+	assert.Len(t, timelines, 3)
+
+	assert.Equal(t, "signer-1", timelines[0].SignerID)
+	assert.True(t, timelines[0].IsSigned)
+	assert.Equal(t, []TimelineEvent{
+		{Type: SignatureEventRequested, At: base},
+		{Type: SignatureEventReminded, At: base.Add(24 * time.Hour)},
+		{Type: SignatureEventSigned, At: base.Add(48 * time.Hour)},
+	}, timelines[0].Events)
+
+	// Requested before event history existed: falls back to last_request
+	assert.Equal(t, []TimelineEvent{{Type: SignatureEventRequested, At: base.Add(time.Hour)}}, timelines[1].Events)
+
+	assert.NotNil(t, timelines[2].Events)
+	assert.Empty(t, timelines[2].Events)
+}
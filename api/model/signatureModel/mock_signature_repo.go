@@ -12,6 +12,11 @@ type ISignatureRepository interface {
 	AreAllSignaturesComplete(certificateId string) (bool, error)
 	BulkCreateSignatures(certificateId string, signerIds []string, userId string) error
 	DeleteSignature(certificateId, signerId string) error
+	CountCertificatesBySigners(signerIds []string) (map[string]int64, error)
+	SetSignOrder(certificateId string, signerIds []string) error
+	GetSignatureProgressByCertificates(certIds []string) (map[string]SignatureProgress, error)
+	GetPendingSignaturesByOwner(userId string) ([]PendingSignatureCertificate, error)
+	GetSignerStatusesByCertificate(certificateId string) ([]CertificateSignerStatus, error)
 }
 
 // Ensure SignatureRepository implements ISignatureRepository
@@ -19,12 +24,17 @@ var _ ISignatureRepository = (*SignatureRepository)(nil)
 
 // MockSignatureRepository is a mock implementation for testing
 type MockSignatureRepository struct {
-	GetSignaturesByCertificateFunc    func(certId string) ([]*model.Signature, error)
-	GetByIdFunc                       func(signatureId string) (*model.Signature, error)
-	DeleteSignaturesByCertificateFunc func(certificateId string) ([]*model.Signature, error)
-	AreAllSignaturesCompleteFunc      func(certificateId string) (bool, error)
-	BulkCreateSignaturesFunc          func(certificateId string, signerIds []string, userId string) error
-	DeleteSignatureFunc               func(certificateId, signerId string) error
+	GetSignaturesByCertificateFunc         func(certId string) ([]*model.Signature, error)
+	GetByIdFunc                            func(signatureId string) (*model.Signature, error)
+	DeleteSignaturesByCertificateFunc      func(certificateId string) ([]*model.Signature, error)
+	AreAllSignaturesCompleteFunc           func(certificateId string) (bool, error)
+	BulkCreateSignaturesFunc               func(certificateId string, signerIds []string, userId string) error
+	DeleteSignatureFunc                    func(certificateId, signerId string) error
+	CountCertificatesBySignersFunc         func(signerIds []string) (map[string]int64, error)
+	SetSignOrderFunc                       func(certificateId string, signerIds []string) error
+	GetSignatureProgressByCertificatesFunc func(certIds []string) (map[string]SignatureProgress, error)
+	GetPendingSignaturesByOwnerFunc        func(userId string) ([]PendingSignatureCertificate, error)
+	GetSignerStatusesByCertificateFunc     func(certificateId string) ([]CertificateSignerStatus, error)
 }
 
 // Ensure MockSignatureRepository implements ISignatureRepository
@@ -76,3 +86,38 @@ func (m *MockSignatureRepository) DeleteSignature(certificateId, signerId string
 	}
 	return nil
 }
+
+func (m *MockSignatureRepository) CountCertificatesBySigners(signerIds []string) (map[string]int64, error) {
+	if m.CountCertificatesBySignersFunc != nil {
+		return m.CountCertificatesBySignersFunc(signerIds)
+	}
+	return map[string]int64{}, nil
+}
+
+func (m *MockSignatureRepository) SetSignOrder(certificateId string, signerIds []string) error {
+	if m.SetSignOrderFunc != nil {
+		return m.SetSignOrderFunc(certificateId, signerIds)
+	}
+	return nil
+}
+
+func (m *MockSignatureRepository) GetSignatureProgressByCertificates(certIds []string) (map[string]SignatureProgress, error) {
+	if m.GetSignatureProgressByCertificatesFunc != nil {
+		return m.GetSignatureProgressByCertificatesFunc(certIds)
+	}
+	return map[string]SignatureProgress{}, nil
+}
+
+func (m *MockSignatureRepository) GetPendingSignaturesByOwner(userId string) ([]PendingSignatureCertificate, error) {
+	if m.GetPendingSignaturesByOwnerFunc != nil {
+		return m.GetPendingSignaturesByOwnerFunc(userId)
+	}
+	return []PendingSignatureCertificate{}, nil
+}
+
+func (m *MockSignatureRepository) GetSignerStatusesByCertificate(certificateId string) ([]CertificateSignerStatus, error) {
+	if m.GetSignerStatusesByCertificateFunc != nil {
+		return m.GetSignerStatusesByCertificateFunc(certificateId)
+	}
+	return []CertificateSignerStatus{}, nil
+}
@@ -0,0 +1,93 @@
+package signaturemodel
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+	"github.com/sunthewhat/easy-cert-api/type/shared/query"
+	"gorm.io/gen/field"
+)
+
+// nextSignOrder returns the sign order for a signature appended to a certificate, after all existing ones.
+// It follows the highest order rather than the count so removed signers can't cause duplicates.
+func (r *SignatureRepository) nextSignOrder(certificateId string) (int32, error) {
+	s := r.q.Signature
+	var row struct {
+		MaxOrder int32
+	}
+	maxOrder := field.NewUnsafeFieldRaw("COALESCE(MAX(" + s.SignOrder.ColumnName().String() + "), 0)")
+	err := s.Select(maxOrder.As("max_order")).Where(s.CertificateID.Eq(certificateId)).Scan(&row)
+	if err != nil {
+		slog.Error("nextSignOrder Error", "error", err, "certificateId", certificateId)
+		return 0, err
+	}
+	return row.MaxOrder + 1, nil
+}
+
+// SetSignOrder assigns sign orders 1..n to the certificate's signatures following signerIds.
+// Signers not listed keep their relative order after the listed ones.
+func (r *SignatureRepository) SetSignOrder(certificateId string, signerIds []string) error {
+	signatures, err := r.GetSignaturesByCertificate(certificateId)
+	if err != nil {
+		return err
+	}
+
+	position := make(map[string]int, len(signerIds))
+	for i, signerId := range signerIds {
+		position[signerId] = i
+	}
+
+	ordered := make([]*model.Signature, 0, len(signatures))
+	for _, signerId := range signerIds {
+		for _, signature := range signatures {
+			if signature.SignerID == signerId {
+				ordered = append(ordered, signature)
+			}
+		}
+	}
+	for _, signature := range SortBySignOrder(signatures) {
+		if _, listed := position[signature.SignerID]; !listed {
+			ordered = append(ordered, signature)
+		}
+	}
+
+	err = r.q.Transaction(func(tx *query.Query) error {
+		for i, signature := range ordered {
+			if _, err := tx.Signature.Where(tx.Signature.ID.Eq(signature.ID)).Update(tx.Signature.SignOrder, int32(i+1)); err != nil {
+				return fmt.Errorf("set sign order of signature %s: %w", signature.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("SetSignOrder Error", "error", err, "certificateId", certificateId)
+		return err
+	}
+	return nil
+}
+
+// SortBySignOrder returns the signatures ordered by sign order, keeping the original order for ties
+func SortBySignOrder(signatures []*model.Signature) []*model.Signature {
+	sorted := make([]*model.Signature, len(signatures))
+	copy(sorted, signatures)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].SignOrder < sorted[j].SignOrder
+	})
+	return sorted
+}
+
+// FirstPendingBefore returns the earliest unsigned signature ordered before target, or nil when every
+// signature ahead of target has been signed
+func FirstPendingBefore(signatures []*model.Signature, target *model.Signature) *model.Signature {
+	for _, signature := range SortBySignOrder(signatures) {
+		if signature.ID == target.ID || signature.SignOrder >= target.SignOrder {
+			continue
+		}
+		if !signature.IsSigned {
+			return signature
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package signaturemodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+func TestFirstPendingBefore(t *testing.T) {
+	signatures := []*model.Signature{
+		{ID: "sig-3", SignerID: "signer-3", SignOrder: 3},
+		{ID: "sig-1", SignerID: "signer-1", SignOrder: 1, IsSigned: true},
+		{ID: "sig-2", SignerID: "signer-2", SignOrder: 2},
+	}
+
+	assert.Nil(t, FirstPendingBefore(signatures, signatures[1]))
+	assert.Nil(t, FirstPendingBefore(signatures, signatures[2]))
+
+	pending := FirstPendingBefore(signatures, signatures[0])
+	assert.NotNil(t, pending)
+	assert.Equal(t, "signer-2", pending.SignerID)
+
+	signatures[2].IsSigned = true
+	assert.Nil(t, FirstPendingBefore(signatures, signatures[0]))
+}
+
+func TestFirstPendingBefore_UnorderedSignatures(t *testing.T) {
+	// Signatures created before ordering existed all share order 0 and never block each other
+	signatures := []*model.Signature{
+		{ID: "sig-1", SignerID: "signer-1"},
+		{ID: "sig-2", SignerID: "signer-2"},
+	}
+
+	assert.Nil(t, FirstPendingBefore(signatures, signatures[1]))
+}
+
+func TestSortBySignOrder(t *testing.T) {
+	signatures := []*model.Signature{
+		{ID: "sig-b", SignOrder: 2},
+		{ID: "sig-a", SignOrder: 1},
+		{ID: "sig-c", SignOrder: 2},
+	}
+
+	sorted := SortBySignOrder(signatures)
+
+	assert.Equal(t, []string{"sig-a", "sig-b", "sig-c"}, []string{sorted[0].ID, sorted[1].ID, sorted[2].ID})
+	assert.Equal(t, "sig-b", signatures[0].ID, "input must not be reordered")
+}
@@ -3,6 +3,7 @@ package signaturemodel
 import (
 	"errors"
 	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/sunthewhat/easy-cert-api/type/payload"
@@ -27,10 +28,16 @@ func NewSignatureRepository(q *query.Query) *SignatureRepository {
 
 // Create creates a new signature
 func (r *SignatureRepository) Create(signatureData payload.CreateSignaturePayload, userId string) (*model.Signature, error) {
+	signOrder, orderErr := r.nextSignOrder(signatureData.CertificateId)
+	if orderErr != nil {
+		return nil, orderErr
+	}
+
 	signature := &model.Signature{
 		SignerID:      signatureData.SignerId,
 		CertificateID: signatureData.CertificateId,
 		CreatedBy:     userId,
+		SignOrder:     signOrder,
 	}
 
 	createErr := r.q.Signature.Create(signature)
@@ -164,7 +171,12 @@ func (r *SignatureRepository) BulkCreateSignatures(certificateId string, signerI
 		existingSignerIds[sig.SignerID] = true
 	}
 
-	// Prepare new signatures to create
+	signOrder, orderErr := r.nextSignOrder(certificateId)
+	if orderErr != nil {
+		return orderErr
+	}
+
+	// Prepare new signatures to create, appended after the existing ones in the given order
 	var newSignatures []*model.Signature
 	for _, signerId := range signerIds {
 		if !existingSignerIds[signerId] {
@@ -172,7 +184,9 @@ func (r *SignatureRepository) BulkCreateSignatures(certificateId string, signerI
 				SignerID:      signerId,
 				CertificateID: certificateId,
 				CreatedBy:     userId,
+				SignOrder:     signOrder,
 			})
+			signOrder++
 		}
 	}
 
@@ -245,6 +259,14 @@ func (r *SignatureRepository) DeleteSignature(certificateId, signerId string) er
 		return err
 	}
 
+	if _, err := r.q.SignatureEvent.Where(
+		r.q.SignatureEvent.CertificateID.Eq(certificateId),
+	).Where(
+		r.q.SignatureEvent.SignerID.Eq(signerId),
+	).Delete(); err != nil {
+		slog.Warn("DeleteSignature: Failed to delete signature events", "error", err, "certificateId", certificateId, "signerId", signerId)
+	}
+
 	slog.Info("DeleteSignature successful", "certificateId", certificateId, "signerId", signerId, "rowsAffected", result.RowsAffected)
 	return nil
 }
@@ -274,6 +296,12 @@ func (r *SignatureRepository) DeleteSignaturesByCertificate(certificateId string
 		return nil, err
 	}
 
+	if _, err := r.q.SignatureEvent.Where(
+		r.q.SignatureEvent.CertificateID.Eq(certificateId),
+	).Delete(); err != nil {
+		slog.Warn("DeleteSignaturesByCertificate: Failed to delete signature events", "error", err, "certificateId", certificateId)
+	}
+
 	slog.Info("DeleteSignaturesByCertificate successful", "certificateId", certificateId, "deletedCount", result.RowsAffected)
 	return signatures, nil
 }
@@ -303,3 +331,150 @@ func (r *SignatureRepository) AreAllSignaturesComplete(certificateId string) (bo
 	slog.Info("All signatures complete for certificate", "certificateId", certificateId, "totalSignatures", len(signatures))
 	return true, nil
 }
+
+// SignatureProgress is how many signatures a certificate has and how many of them are signed
+type SignatureProgress struct {
+	Total  int64
+	Signed int64
+}
+
+// Complete matches AreAllSignaturesComplete: a certificate without signatures is not complete
+func (p SignatureProgress) Complete() bool {
+	return p.Total > 0 && p.Signed == p.Total
+}
+
+// GetSignatureProgressByCertificates returns the signature progress of several certificates with a single
+// grouped query. Certificates without signatures are absent from the map.
+func (r *SignatureRepository) GetSignatureProgressByCertificates(certIds []string) (map[string]SignatureProgress, error) {
+	progress := make(map[string]SignatureProgress, len(certIds))
+	if len(certIds) == 0 {
+		return progress, nil
+	}
+
+	sig := r.q.Signature
+	var rows []struct {
+		CertificateID string
+		IsSigned      bool
+		Count         int64
+	}
+	err := sig.Select(sig.CertificateID, sig.IsSigned, sig.ID.Count().As("count")).
+		Where(sig.CertificateID.In(certIds...)).
+		Group(sig.CertificateID, sig.IsSigned).
+		Scan(&rows)
+	if err != nil {
+		slog.Error("GetSignatureProgressByCertificates Error", "error", err, "certificateCount", len(certIds))
+		return nil, err
+	}
+
+	for _, row := range rows {
+		entry := progress[row.CertificateID]
+		entry.Total += row.Count
+		if row.IsSigned {
+			entry.Signed += row.Count
+		}
+		progress[row.CertificateID] = entry
+	}
+	return progress, nil
+}
+
+// PendingSignatureCertificate is one of an owner's certificates still waiting on signatures
+type PendingSignatureCertificate struct {
+	CertificateID   string    `json:"certificate_id"`
+	CertificateName string    `json:"certificate_name"`
+	PendingCount    int64     `json:"pending_count"`
+	OldestRequest   time.Time `json:"oldest_request"`
+}
+
+// GetPendingSignaturesByOwner returns the owner's certificates with at least one requested, unsigned signature and how many
+// are outstanding, using a single grouped query. Certificates waiting longest come first.
+func (r *SignatureRepository) GetPendingSignaturesByOwner(userId string) ([]PendingSignatureCertificate, error) {
+	sig := r.q.Signature
+	cert := r.q.Certificate
+
+	pending := []PendingSignatureCertificate{}
+	err := sig.Select(
+		sig.CertificateID,
+		cert.Name.As("certificate_name"),
+		sig.ID.Count().As("pending_count"),
+		sig.LastRequest.Min().As("oldest_request"),
+	).
+		Join(cert, cert.ID.EqCol(sig.CertificateID)).
+		Where(cert.UserID.Eq(userId), sig.IsRequested.Is(true), sig.IsSigned.Is(false)).
+		Group(sig.CertificateID, cert.Name).
+		Scan(&pending)
+	if err != nil {
+		slog.Error("GetPendingSignaturesByOwner Error", "error", err, "userId", userId)
+		return nil, err
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].OldestRequest.Before(pending[j].OldestRequest)
+	})
+	return pending, nil
+}
+
+// CountCertificatesBySigners returns how many distinct certificates each signer is part of, using a single
+// grouped query. Signers without any signature are absent from the map.
+func (r *SignatureRepository) CountCertificatesBySigners(signerIds []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(signerIds))
+	if len(signerIds) == 0 {
+		return counts, nil
+	}
+
+	sig := r.q.Signature
+	var rows []struct {
+		SignerID string
+		Count    int64
+	}
+	err := sig.Select(sig.SignerID, sig.CertificateID.Distinct().Count().As("count")).
+		Where(sig.SignerID.In(signerIds...)).
+		Group(sig.SignerID).
+		Scan(&rows)
+	if err != nil {
+		slog.Error("CountCertificatesBySigners Error", "error", err, "signerCount", len(signerIds))
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.SignerID] = row.Count
+	}
+	return counts, nil
+}
+
+// CertificateSignerStatus is a certificate's signature joined with the signer it belongs to
+type CertificateSignerStatus struct {
+	SignerID    string    `json:"signer_id"`
+	DisplayName string    `json:"display_name"`
+	Email       string    `json:"email"`
+	SignOrder   int32     `json:"sign_order"`
+	IsSigned    bool      `json:"is_signed"`
+	IsRequested bool      `json:"is_requested"`
+	LastRequest time.Time `json:"last_request"`
+}
+
+// GetSignerStatusesByCertificate returns the signers of a certificate with their signing status, ordered by
+// signing order and then name
+func (r *SignatureRepository) GetSignerStatusesByCertificate(certificateId string) ([]CertificateSignerStatus, error) {
+	sig := r.q.Signature
+	signer := r.q.Signer
+
+	statuses := []CertificateSignerStatus{}
+	err := sig.Select(
+		sig.SignerID,
+		signer.DisplayName,
+		signer.Email,
+		sig.SignOrder,
+		sig.IsSigned,
+		sig.IsRequested,
+		sig.LastRequest,
+	).
+		Join(signer, signer.ID.EqCol(sig.SignerID)).
+		Where(sig.CertificateID.Eq(certificateId)).
+		Order(sig.SignOrder, signer.DisplayName).
+		Scan(&statuses)
+	if err != nil {
+		slog.Error("GetSignerStatusesByCertificate Error", "error", err, "certificateId", certificateId)
+		return nil, err
+	}
+	return statuses, nil
+}
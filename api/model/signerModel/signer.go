@@ -15,6 +15,12 @@ type SignerRepository struct {
 	q *query.Query
 }
 
+// SignerWithCertificateCount is a signer together with how many certificates they are part of
+type SignerWithCertificateCount struct {
+	*model.Signer
+	CertificateCount int64 `json:"certificate_count"`
+}
+
 // NewSignerRepository creates a new signer repository with dependency injection
 func NewSignerRepository(q *query.Query) *SignerRepository {
 	return &SignerRepository{q: q}
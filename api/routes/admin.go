@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	admin_controller "github.com/sunthewhat/easy-cert-api/api/controllers/admin"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+)
+
+// SetupAdminRoutes configures operational checks for signed-in owners and admins
+func SetupAdminRoutes(router fiber.Router) {
+	ssoService := util.NewSSOService()
+
+	certRepo := certificatemodel.NewCertificateRepository(common.Gorm)
+
+	adminCtrl := admin_controller.NewAdminController(certRepo)
+
+	adminGroup := router.Group("admin")
+
+	adminGroup.Use(middleware.AuthMiddleware(ssoService))
+
+	adminGroup.Post("test-mail", adminCtrl.TestMail)
+	adminGroup.Get("failed-emails", adminCtrl.GetFailedEmails)
+	adminGroup.Post("failed-emails/:id/retry", adminCtrl.RetryFailedEmail)
+}
@@ -23,17 +23,53 @@ func SetupCertificateRoutes(router fiber.Router) {
 
 	certificateGroup := router.Group("certificate")
 
+	// Public so recipients can verify the signer of their PDF
+	certificateGroup.Get(":certId/signature-info", certCtrl.GetSignatureInfo)
+
 	certificateGroup.Use(middleware.AuthMiddleware(ssoService))
 
 	certificateGroup.Get("", certCtrl.GetByUser)
+	certificateGroup.Get("pending-signatures", certCtrl.GetPendingSignatures)
 	certificateGroup.Get(":certId", certCtrl.GetById)
-	certificateGroup.Post("", certCtrl.Create)
-	certificateGroup.Put(":id", certCtrl.Update)
+	certificateGroup.Post("", middleware.DesignBodyLimit(), certCtrl.Create)
+	certificateGroup.Post("batch-get", certCtrl.BatchGet)
+	certificateGroup.Post("batch-status", certCtrl.BatchStatus)
+	certificateGroup.Patch("bulk-rename", certCtrl.BulkRename)
+	certificateGroup.Post("import-definition", middleware.DesignBodyLimit(), certCtrl.ImportDefinition)
+	certificateGroup.Put(":id", middleware.DesignBodyLimit(), certCtrl.Update)
 	certificateGroup.Delete(":certId", certCtrl.Delete)
 	certificateGroup.Post("render/:certId", certCtrl.Render)
 	certificateGroup.Get("mail/:certId", certCtrl.DistributeByMail)
 	certificateGroup.Post("mail/resend/:participantId", certCtrl.ResendParticipantMail)
 	certificateGroup.Get("anchor/:certId", certCtrl.GetAnchorList)
+	certificateGroup.Get("anchor/:certId/details", certCtrl.GetAnchorDetails)
+	certificateGroup.Post("anchor/:certId/refresh", certCtrl.RefreshAnchors)
 	certificateGroup.Get("generate/status/:certificateId", certCtrl.CheckGenerateStatus)
 	certificateGroup.Get("archive/:certId", certCtrl.DownloadArchive)
+	certificateGroup.Get(":certId/combined-pdf", certCtrl.DownloadCombinedPDF)
+	certificateGroup.Get(":certId/verification-report", certCtrl.DownloadVerificationReport)
+	certificateGroup.Get(":certId/qr-sheet", certCtrl.DownloadQRSheet)
+	certificateGroup.Post(":certId/reset-status", certCtrl.ResetStatus)
+	certificateGroup.Post(":certId/distribute", certCtrl.DistributeSelected)
+	certificateGroup.Get(":certId/distribution-preview", certCtrl.GetDistributionPreview)
+	certificateGroup.Get(":certId/email-status-summary", certCtrl.GetEmailStatusSummary)
+	certificateGroup.Get(":certId/storage", certCtrl.GetStorageUsage)
+	certificateGroup.Post(":certId/remind-downloads", certCtrl.RemindDownloads)
+	certificateGroup.Post(":certId/notify-complete", certCtrl.NotifyComplete)
+	certificateGroup.Post(":certId/revoke", certCtrl.BulkRevoke)
+	certificateGroup.Get(":certId/export-definition", certCtrl.ExportDefinition)
+	certificateGroup.Get(":certId/thumbnail", certCtrl.GetThumbnail)
+	certificateGroup.Put(":certId/verify-host", certCtrl.SetVerifyHost)
+	certificateGroup.Put(":certId/pdf-footer", certCtrl.SetPdfFooter)
+	certificateGroup.Put(":certId/signature-background", certCtrl.SetSignatureBackground)
+	certificateGroup.Put(":certId/signing-order", certCtrl.SetSigningOrder)
+	certificateGroup.Put(":certId/pdf-layout", certCtrl.SetPdfLayout)
+	certificateGroup.Put(":certId/archive-filename", certCtrl.SetArchiveFilenameTemplate)
+	certificateGroup.Get(":certId/verify-archive", certCtrl.VerifyArchive)
+	certificateGroup.Get(":certId/archive-recovery", certCtrl.GetArchiveRecovery)
+	certificateGroup.Get(":certId/design-diff", certCtrl.GetDesignDiff)
+	certificateGroup.Post(":certId/regenerate-qr", certCtrl.RegenerateQRCodes)
+	certificateGroup.Post(":targetId/merge-from/:sourceId", certCtrl.MergeFrom)
+	certificateGroup.Get(":certId/generation-errors", certCtrl.GetGenerationErrors)
+	certificateGroup.Get(":certId/activity", certCtrl.GetActivity)
 }
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	dashboard_controller "github.com/sunthewhat/easy-cert-api/api/controllers/dashboard"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	dashboardmodel "github.com/sunthewhat/easy-cert-api/api/model/dashboardModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+)
+
+func SetupDashboardRoutes(router fiber.Router) {
+	// Initialize repositories
+	dashboardRepo := dashboardmodel.NewDashboardRepository(common.Gorm)
+	ssoService := util.NewSSOService()
+
+	// Initialize controller with repositories
+	dashboardCtrl := dashboard_controller.NewDashboardController(dashboardRepo)
+
+	dashboardGroup := router.Group("dashboard")
+
+	dashboardGroup.Use(middleware.AuthMiddleware(ssoService))
+
+	dashboardGroup.Get("summary", dashboardCtrl.GetSummary)
+	dashboardGroup.Get("trends", dashboardCtrl.GetTrends)
+}
@@ -15,6 +15,9 @@ func SetupFileRoutes(app fiber.Router) {
 	// Apply JWT middleware to protect file operations
 	fileGroup.Use(middleware.AuthMiddleware(ssoService))
 
+	// Design asset upload endpoint (whitelisted image types only), registered before /:type
+	fileGroup.Post("/asset", file.UploadAsset)
+
 	// File upload endpoint
 	fileGroup.Post("/:type", file.UploadResource)
 
@@ -2,10 +2,12 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	certificate_controller "github.com/sunthewhat/easy-cert-api/api/controllers/certificate"
 	participant_controller "github.com/sunthewhat/easy-cert-api/api/controllers/participant"
 	"github.com/sunthewhat/easy-cert-api/api/middleware"
 	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
 	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
 	"github.com/sunthewhat/easy-cert-api/common"
 	"github.com/sunthewhat/easy-cert-api/common/util"
 )
@@ -19,6 +21,9 @@ func SetupParticipantRoutes(router fiber.Router) {
 	// Initialize controller with repositories
 	participantCtrl := participant_controller.NewParticipantController(participantRepo, certificateRepo)
 
+	// Resending mail reuses the certificate controller's distribution logic
+	certCtrl := certificate_controller.NewCertificateController(certificateRepo, signaturemodel.NewSignatureRepository(common.Gorm), participantRepo)
+
 	participantGroup := router.Group("participant")
 
 	participantGroup.Get("validation/:participantId", participantCtrl.GetValidationDataByParticipantId)
@@ -26,8 +31,18 @@ func SetupParticipantRoutes(router fiber.Router) {
 	participantGroup.Use(middleware.AuthMiddleware(ssoService))
 
 	participantGroup.Get(":certId", participantCtrl.GetByCert)
-	participantGroup.Post("add/:certId", participantCtrl.Add)
+	participantGroup.Get(":certId/not-downloaded", participantCtrl.GetNotDownloaded)
+	participantGroup.Get(":certId/validate-all", participantCtrl.ValidateAll)
+	participantGroup.Get(":certId/verify-urls", participantCtrl.GetVerifyUrls)
+	participantGroup.Get(":participantId/editable", participantCtrl.GetEditable)
+	participantGroup.Get(":participantId/certificate", participantCtrl.DownloadCertificate)
+	participantGroup.Get(":participantId/detail", participantCtrl.GetDetail)
+	participantGroup.Post("add/:certId", middleware.ImportBodyLimit(), participantCtrl.Add)
+	participantGroup.Post(":certId/copy-from/:sourceId", participantCtrl.CopyFrom)
+	participantGroup.Put(":certId/import-update", middleware.ImportBodyLimit(), participantCtrl.ImportUpdate)
+	participantGroup.Post(":participantId/resend", certCtrl.ResendParticipantMail)
 	participantGroup.Put("revoke/:id", participantCtrl.Revoke)
 	participantGroup.Put("edit/:id", participantCtrl.EditByID)
+	participantGroup.Put("tags/:id", participantCtrl.SetTags)
 	participantGroup.Delete(":id", participantCtrl.Delete)
 }
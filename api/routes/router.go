@@ -2,14 +2,12 @@ package routes
 
 import (
 	"log/slog"
-	"strings"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/sunthewhat/easy-cert-api/api/handler"
-	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
 )
 
 // Init initializes all routes and middleware
@@ -18,27 +16,6 @@ func Init(app *fiber.App) {
 	app.Use(recover.New())
 	app.Use(logger.New())
 
-	// Configure CORS with origins from config
-	var allowedOrigins string
-	if len(common.Config.Cors) > 0 {
-		// Convert []*string to []string
-		origins := make([]string, len(common.Config.Cors))
-		for i, origin := range common.Config.Cors {
-			if origin != nil {
-				origins[i] = *origin
-			}
-		}
-		allowedOrigins = strings.Join(origins, ",")
-	} else {
-		allowedOrigins = "*" // Fallback to wildcard if no config
-	}
-
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: allowedOrigins,
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
-	}))
-
 	// API routes
 	api := app.Group("/api")
 
@@ -50,6 +27,22 @@ func Init(app *fiber.App) {
 		})
 	})
 
+	// Readiness check endpoint; "degraded" means certificates are being issued without a working PDF signature
+	// or can't be rendered at all because the renderer is unavailable (rendering_disabled is not degraded)
+	api.Get("/ready", func(c *fiber.Ctx) error {
+		signing := renderer.GetSigningCertificateInfo()
+		rendering := renderer.CheckRendererCapability()
+		status := "ready"
+		if signing.IsSigningDegraded() || (!rendering.Available && !rendering.Disabled) {
+			status = "degraded"
+		}
+		return c.JSON(fiber.Map{
+			"status":    status,
+			"signing":   signing,
+			"rendering": rendering,
+		})
+	})
+
 	// Handle OPTIONS requests (CORS preflight)
 	api.Options("/health", func(c *fiber.Ctx) error {
 		slog.Debug("Health Check OPTIONS request",
@@ -72,6 +65,12 @@ func Init(app *fiber.App) {
 	SetupFileRoutes(v1)
 	SetupSignerRoutes(v1)
 	SetupSignatureRoutes(v1)
+	SetupDashboardRoutes(v1)
+	SetupValidateRoutes(v1)
+	SetupWebhookRoutes(v1)
+	SetupAdminRoutes(v1)
+	SetupTemplateRoutes(v1)
+	SetupSearchRoutes(v1)
 
 	// Handle favicon requests to prevent 404s
 	app.Get("/favicon.ico", func(c *fiber.Ctx) error {
@@ -25,6 +25,10 @@ func SetupSignatureRoutes(router fiber.Router) {
 
 	signatureGroup := router.Group("signature")
 
+	// Public route: signing links carry their own HMAC token
+	signatureGroup.Get("link/:token", signatureCtrl.ValidateSigningLink)
+	signatureGroup.Get("context/:token", signatureCtrl.GetSigningContext)
+
 	signatureGroup.Use(middleware.AuthMiddleware(ssoService))
 
 	signatureGroup.Post("", signatureCtrl.Create)
@@ -32,5 +36,9 @@ func SetupSignatureRoutes(router fiber.Router) {
 	signatureGroup.Get("signer/:certificateId", signatureCtrl.GetSignerData)
 	signatureGroup.Get(":id", signatureCtrl.GetById)
 	signatureGroup.Put("sign/:id", signatureCtrl.Sign)
+	signatureGroup.Get(":certId/timeline", signatureCtrl.GetTimeline)
+	signatureGroup.Post(":certId/upload", signatureCtrl.UploadSignatures)
+	signatureGroup.Put(":certId/signer/:signerId", signatureCtrl.ReplaceSignature)
+	signatureGroup.Delete(":certId/signer/:signerId", signatureCtrl.RemoveSigner)
 	signatureGroup.Get(":certificateId/:signerId", signatureCtrl.GetSignatureImage)
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	certificate_controller "github.com/sunthewhat/easy-cert-api/api/controllers/certificate"
+	"github.com/sunthewhat/easy-cert-api/api/middleware"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/util"
+)
+
+func SetupTemplateRoutes(router fiber.Router) {
+	// Initialize repositories
+	certRepo := certificatemodel.NewCertificateRepository(common.Gorm)
+	signatureRepo := signaturemodel.NewSignatureRepository(common.Gorm)
+	participantRepo := participantmodel.NewParticipantRepository(common.Gorm, common.Mongo)
+	ssoService := util.NewSSOService()
+
+	certCtrl := certificate_controller.NewCertificateController(certRepo, signatureRepo, participantRepo)
+
+	templateGroup := router.Group("template")
+
+	templateGroup.Use(middleware.AuthMiddleware(ssoService))
+
+	templateGroup.Get(":templateId/certificates", certCtrl.GetByTemplate)
+}
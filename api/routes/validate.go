@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	participant_controller "github.com/sunthewhat/easy-cert-api/api/controllers/participant"
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+// SetupValidateRoutes configures public certificate verification routes for third parties
+func SetupValidateRoutes(router fiber.Router) {
+	// Initialize repositories
+	participantRepo := participantmodel.NewParticipantRepository(common.Gorm, common.Mongo)
+	certificateRepo := certificatemodel.NewCertificateRepository(common.Gorm)
+
+	// Initialize controller with repositories
+	participantCtrl := participant_controller.NewParticipantController(participantRepo, certificateRepo)
+
+	validateGroup := router.Group("validate")
+
+	validateGroup.Post("verify", participantCtrl.Verify)
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	webhook_controller "github.com/sunthewhat/easy-cert-api/api/controllers/webhook"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+// SetupWebhookRoutes configures provider callbacks authenticated by a shared secret
+func SetupWebhookRoutes(router fiber.Router) {
+	// Initialize repositories
+	participantRepo := participantmodel.NewParticipantRepository(common.Gorm, common.Mongo)
+
+	// Initialize controller with repositories
+	webhookCtrl := webhook_controller.NewWebhookController(participantRepo)
+
+	webhookGroup := router.Group("webhook")
+
+	webhookGroup.Post("mail-bounce", webhookCtrl.MailBounce)
+}
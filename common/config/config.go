@@ -1,9 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
 	"github.com/sunthewhat/easy-cert-api/common"
 	"github.com/sunthewhat/easy-cert-api/common/util"
 	"github.com/sunthewhat/easy-cert-api/type/shared"
@@ -30,7 +33,56 @@ func LoadConfig() {
 		os.Exit(1)
 	}
 
+	if err := validateSecrets(config); err != nil {
+		slog.Error("Invalid config.yml", "error", err)
+		os.Exit(1)
+	}
+
+	if err := participantmodel.ValidateEncryptedFields(config.ParticipantEncryptedFields); err != nil {
+		slog.Error("Invalid config.yml", "error", err)
+		os.Exit(1)
+	}
+
 	slog.Info("Configuration loaded successfully")
 
 	common.Config = config
 }
+
+// minSecretLength is the shortest shared secret accepted, so short guessable values are never deployed
+const minSecretLength = 16
+
+// placeholderSecrets are example values that must be replaced before deploying
+var placeholderSecrets = []string{"change-me", "changeme", "change_me", "replace-me", "secret", "password"}
+
+// validateSecrets rejects secrets left empty or at a placeholder value. An empty mail_webhook_secret keeps
+// the bounce webhook disabled; mail_api_key is required whenever the http mail provider is selected.
+func validateSecrets(config *shared.Config) error {
+	if config.MailWebhookSecret != nil && *config.MailWebhookSecret != "" {
+		if err := checkSecret("mail_webhook_secret", *config.MailWebhookSecret); err != nil {
+			return err
+		}
+	}
+
+	if config.MailProvider != nil && *config.MailProvider == util.MailProviderHTTP {
+		if config.MailApiKey == nil || *config.MailApiKey == "" {
+			return fmt.Errorf("mail_api_key is required when mail_provider is %s", util.MailProviderHTTP)
+		}
+		if err := checkSecret("mail_api_key", *config.MailApiKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkSecret(name string, value string) error {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	for _, placeholder := range placeholderSecrets {
+		if strings.Contains(normalized, placeholder) {
+			return fmt.Errorf("%s is set to a placeholder value, generate a random secret", name)
+		}
+	}
+	if len(value) < minSecretLength {
+		return fmt.Errorf("%s must be at least %d characters", name, minSecretLength)
+	}
+	return nil
+}
@@ -44,6 +44,9 @@ func Push_db() {
 		new(model.Participant),
 		new(model.Signer),
 		new(model.Signature),
+		new(model.SignatureEvent),
+		new(model.CertificateActivity),
+		new(model.FailedEmail),
 	); err != nil {
 		slog.Error("Failed to migrate database", "error", err)
 		os.Exit(1)
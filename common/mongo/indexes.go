@@ -0,0 +1,63 @@
+package mongo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// ParticipantCollectionModeShared stores every participant in one collection keyed by certificate_id
+	ParticipantCollectionModeShared = "shared"
+	// SharedParticipantCollection is the participant collection used in shared mode
+	SharedParticipantCollection = "participants"
+
+	startupIndexTimeout = 5 * time.Minute
+)
+
+// EnsureParticipantIndexes idempotently creates the participant indexes on a single collection.
+// MongoDB always keeps a unique index on _id, so only certificate_id (and email when dedup-by-email
+// is enabled) need to be created here.
+func EnsureParticipantIndexes(ctx context.Context, collection *mongo.Collection) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "certificate_id", Value: 1}},
+			Options: options.Index().SetName("certificate_id_1"),
+		},
+	}
+
+	if common.Config != nil && common.Config.DedupByEmail != nil && *common.Config.DedupByEmail {
+		indexes = append(indexes, mongo.IndexModel{
+			Keys:    bson.D{{Key: "certificate_id", Value: 1}, {Key: "email", Value: 1}},
+			Options: options.Index().SetName("certificate_id_1_email_1"),
+		})
+	}
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// ensureStartupIndexes indexes the shared participant collection in the background so a large collection
+// or a slow server never holds up boot; failures are only logged. Per-certificate collections are indexed
+// when participants are inserted into them, so they are not walked here.
+func ensureStartupIndexes(db *mongo.Database) {
+	if common.Config.ParticipantCollectionMode == nil || *common.Config.ParticipantCollectionMode != ParticipantCollectionModeShared {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), startupIndexTimeout)
+		defer cancel()
+
+		if err := EnsureParticipantIndexes(ctx, db.Collection(SharedParticipantCollection)); err != nil {
+			slog.Error("Failed to create participant collection indexes, continuing without them", "error", err)
+			return
+		}
+		slog.Info("Participant collection indexes ensured", "collection", SharedParticipantCollection)
+	}()
+}
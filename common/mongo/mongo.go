@@ -35,4 +35,5 @@ func InitMongo() {
 
 	common.Mongo = client.Database(*common.Config.MongoDatabase)
 
+	ensureStartupIndexes(common.Mongo)
 }
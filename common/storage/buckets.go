@@ -0,0 +1,21 @@
+package storage
+
+import "github.com/sunthewhat/easy-cert-api/common"
+
+// PreviewBucket returns the bucket for thumbnails and watermarked previews.
+// Falls back to the certificate bucket when no preview bucket is configured.
+func PreviewBucket() string {
+	if common.Config.BucketPreview != nil && *common.Config.BucketPreview != "" {
+		return *common.Config.BucketPreview
+	}
+	return *common.Config.BucketCertificate
+}
+
+// PublicBuckets returns the buckets whose objects may be served through the download proxy
+func PublicBuckets() map[string]bool {
+	return map[string]bool{
+		*common.Config.BucketCertificate: true,
+		*common.Config.BucketResource:    true,
+		PreviewBucket():                  true,
+	}
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const healthCheckInterval = 30 * time.Second
+
+var (
+	mu sync.Mutex
+	// stopHealthCheck stops the health check goroutine of the current client
+	stopHealthCheck context.CancelFunc
+)
+
+// Connect creates a MinIO client from config and publishes it as common.MinIOClient
+func Connect() (*minio.Client, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return connect()
+}
+
+func connect() (*minio.Client, error) {
+	if common.Config.MinIoEndpoint == nil || common.Config.MinIoAccessKey == nil || common.Config.MinIoSecretKey == nil {
+		return nil, fmt.Errorf("MinIO configuration is incomplete")
+	}
+
+	client, err := minio.New(*common.Config.MinIoEndpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(*common.Config.MinIoAccessKey, *common.Config.MinIoSecretKey, ""),
+		Secure: *common.Config.IsHTTPS,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize MinIO client: %w", err)
+	}
+
+	// A replaced client must not keep its health check goroutine running
+	if stopHealthCheck != nil {
+		stopHealthCheck()
+		stopHealthCheck = nil
+	}
+
+	// Enables IsOffline so a dropped connection can be reported
+	cancel, err := client.HealthCheck(healthCheckInterval)
+	if err != nil {
+		slog.Warn("Failed to start MinIO health check", "error", err)
+	} else {
+		stopHealthCheck = cancel
+	}
+
+	common.MinIOClient = client
+	return client, nil
+}
+
+// Client returns the shared MinIO client, lazily connecting when it was never initialized.
+// An offline client is reused as is: it reconnects on its own once MinIO is reachable again.
+func Client() (*minio.Client, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if common.MinIOClient != nil {
+		return common.MinIOClient, nil
+	}
+
+	slog.Warn("MinIO client not initialized, attempting to connect")
+	client, err := connect()
+	if err != nil {
+		return nil, fmt.Errorf("MinIO client not available: %w", err)
+	}
+
+	slog.Info("MinIO client connected")
+	return client, nil
+}
+
+// IsOffline reports whether the shared client's health check currently sees MinIO as unreachable
+func IsOffline() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return common.MinIOClient == nil || common.MinIOClient.IsOffline()
+}
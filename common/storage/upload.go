@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const (
+	defaultUploadMaxRetries = 3
+	uploadRetryBackoff      = 500 * time.Millisecond
+	minUploadPartSizeMB     = 5
+)
+
+// retryableUploadCodes are S3 error codes for failures on the server side that may succeed when retried
+var retryableUploadCodes = map[string]bool{
+	"InternalError":              true,
+	"RequestTimeout":             true,
+	"ServiceUnavailable":         true,
+	"SlowDown":                   true,
+	"XMinioServerNotInitialized": true,
+}
+
+// uploadPartSize returns the multipart part size in bytes (minio_upload_part_size_mb), or 0 to let the
+// client choose. S3 rejects parts below 5 MiB, so smaller values are raised to that minimum.
+func uploadPartSize() uint64 {
+	if common.Config == nil || common.Config.MinioUploadPartSizeMB == nil || *common.Config.MinioUploadPartSizeMB <= 0 {
+		return 0
+	}
+	return uint64(max(*common.Config.MinioUploadPartSizeMB, minUploadPartSizeMB)) << 20
+}
+
+// uploadMaxRetries returns how many times a failed upload is retried (minio_upload_max_retries)
+func uploadMaxRetries() int {
+	if common.Config != nil && common.Config.MinioUploadMaxRetries != nil && *common.Config.MinioUploadMaxRetries >= 0 {
+		return *common.Config.MinioUploadMaxRetries
+	}
+	return defaultUploadMaxRetries
+}
+
+// IsRetryableUploadError reports whether a PutObject failure is transient: network errors, timeouts and
+// server-side errors. Client errors such as bad credentials, denied access or a missing bucket are permanent.
+func IsRetryableUploadError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errResponse := minio.ToErrorResponse(err); errResponse.Code != "" || errResponse.StatusCode != 0 {
+		if retryableUploadCodes[errResponse.Code] {
+			return true
+		}
+		return errResponse.StatusCode >= http.StatusInternalServerError ||
+			errResponse.StatusCode == http.StatusRequestTimeout ||
+			errResponse.StatusCode == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// PutObject uploads data with the configured part size, retrying transient failures up to
+// minio_upload_max_retries times with a linear backoff
+func PutObject(ctx context.Context, bucketName string, objectName string, data []byte, contentType string) (minio.UploadInfo, error) {
+	maxRetries := uploadMaxRetries()
+	options := minio.PutObjectOptions{
+		ContentType: contentType,
+		PartSize:    uploadPartSize(),
+	}
+
+	for attempt := 1; ; attempt++ {
+		client, err := Client()
+		if err != nil {
+			return minio.UploadInfo{}, err
+		}
+
+		info, err := client.PutObject(ctx, bucketName, objectName, bytes.NewReader(data), int64(len(data)), options)
+		if err == nil {
+			if attempt > 1 {
+				slog.Info("MinIO upload succeeded after retry", "bucket", bucketName, "object", objectName, "attempt", attempt)
+			}
+			return info, nil
+		}
+
+		if attempt > maxRetries || !IsRetryableUploadError(err) {
+			return minio.UploadInfo{}, fmt.Errorf("upload failed after %d attempt(s): %w", attempt, err)
+		}
+
+		slog.Warn("MinIO upload failed, retrying", "error", err, "bucket", bucketName, "object", objectName, "attempt", attempt, "size", len(data))
+		select {
+		case <-ctx.Done():
+			return minio.UploadInfo{}, ctx.Err()
+		case <-time.After(time.Duration(attempt) * uploadRetryBackoff):
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestIsRetryableUploadError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "server error", err: minio.ErrorResponse{Code: "InternalError", StatusCode: http.StatusInternalServerError}, want: true},
+		{name: "slow down", err: minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable}, want: true},
+		{name: "bad gateway without code", err: minio.ErrorResponse{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "access denied", err: minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}, want: false},
+		{name: "invalid access key", err: minio.ErrorResponse{Code: "InvalidAccessKeyId", StatusCode: http.StatusForbidden}, want: false},
+		{name: "missing bucket", err: minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound}, want: false},
+		{name: "connection reset", err: fmt.Errorf("put: %w", syscall.ECONNRESET), want: true},
+		{name: "network error", err: &net.OpError{Op: "dial", Err: errors.New("i/o timeout")}, want: true},
+		{name: "unexpected EOF", err: io.ErrUnexpectedEOF, want: true},
+		{name: "context canceled", err: context.Canceled, want: false},
+		{name: "other error", err: errors.New("invalid object name"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryableUploadError(tt.err))
+		})
+	}
+}
+
+func TestUploadPartSize(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	common.Config = &shared.Config{}
+	assert.Equal(t, uint64(0), uploadPartSize())
+
+	size := 16
+	common.Config = &shared.Config{MinioUploadPartSizeMB: &size}
+	assert.Equal(t, uint64(16<<20), uploadPartSize())
+
+	tooSmall := 1
+	common.Config = &shared.Config{MinioUploadPartSizeMB: &tooSmall}
+	assert.Equal(t, uint64(5<<20), uploadPartSize())
+}
+
+func TestUploadMaxRetries(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	common.Config = &shared.Config{}
+	assert.Equal(t, defaultUploadMaxRetries, uploadMaxRetries())
+
+	none := 0
+	common.Config = &shared.Config{MinioUploadMaxRetries: &none}
+	assert.Equal(t, 0, uploadMaxRetries())
+}
@@ -0,0 +1,109 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+// DefaultAllowedAssetTypes are the design asset content types accepted when allowed_asset_types is unset.
+// SVG is left out since it can carry scripts and assets are served from a public bucket.
+var DefaultAllowedAssetTypes = []string{"image/png", "image/jpeg", "image/webp"}
+
+// ErrAssetTypeNotAllowed is returned when an uploaded asset's sniffed content type is not whitelisted
+var ErrAssetTypeNotAllowed = errors.New("asset content type is not allowed")
+
+// assetSniffLength is how many leading bytes are inspected, matching http.DetectContentType
+const assetSniffLength = 512
+
+// AllowedAssetTypes returns the content types design assets may have (allowed_asset_types)
+func AllowedAssetTypes() []string {
+	if common.Config == nil || len(common.Config.AllowedAssetTypes) == 0 {
+		return DefaultAllowedAssetTypes
+	}
+	return common.Config.AllowedAssetTypes
+}
+
+// SniffAssetContentType detects the content type of an asset from its leading bytes. SVG is recognised
+// separately since http.DetectContentType reports it as plain text or XML.
+func SniffAssetContentType(head []byte) string {
+	if looksLikeSVG(head) {
+		return "image/svg+xml"
+	}
+	contentType := http.DetectContentType(head)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return contentType
+}
+
+// looksLikeSVG reports whether head starts an SVG document, allowing a leading BOM, XML declaration,
+// comments and doctype before the <svg> root element
+func looksLikeSVG(head []byte) bool {
+	rest := bytes.TrimPrefix(head, []byte("\xef\xbb\xbf"))
+	for {
+		rest = bytes.TrimLeft(rest, " \t\r\n")
+		switch {
+		case bytes.HasPrefix(rest, []byte("<svg")):
+			return true
+		case bytes.HasPrefix(rest, []byte("<?")):
+			rest = skipPast(rest, "?>")
+		case bytes.HasPrefix(rest, []byte("<!--")):
+			rest = skipPast(rest, "-->")
+		case bytes.HasPrefix(rest, []byte("<!")):
+			rest = skipPast(rest, ">")
+		default:
+			return false
+		}
+		if rest == nil {
+			return false
+		}
+	}
+}
+
+// skipPast returns the bytes after the first occurrence of marker, or nil when it is missing
+func skipPast(data []byte, marker string) []byte {
+	i := bytes.Index(data, []byte(marker))
+	if i < 0 {
+		return nil
+	}
+	return data[i+len(marker):]
+}
+
+// IsAllowedAssetType reports whether contentType is in the asset whitelist
+func IsAllowedAssetType(contentType string) bool {
+	for _, allowed := range AllowedAssetTypes() {
+		if strings.EqualFold(strings.TrimSpace(allowed), contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectAssetContentType sniffs the uploaded file's content type from its bytes, ignoring the type the
+// client claimed, and returns ErrAssetTypeNotAllowed when it is not whitelisted
+func DetectAssetContentType(file *multipart.FileHeader) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	head := make([]byte, assetSniffLength)
+	n, err := io.ReadFull(src, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	contentType := SniffAssetContentType(head[:n])
+	if !IsAllowedAssetType(contentType) {
+		return contentType, fmt.Errorf("%w: %s", ErrAssetTypeNotAllowed, contentType)
+	}
+	return contentType, nil
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+// TestSniffAssetContentType tests content type detection from asset bytes
+func TestSniffAssetContentType(t *testing.T) {
+	var pngBuf bytes.Buffer
+	assert.NoError(t, png.Encode(&pngBuf, image.NewRGBA(image.Rect(0, 0, 2, 2))))
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"png", pngBuf.Bytes(), "image/png"},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"), "image/jpeg"},
+		{"webp", []byte("RIFF\x24\x00\x00\x00WEBPVP8 "), "image/webp"},
+		{"svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), "image/svg+xml"},
+		{"svg with prolog", []byte("\xef\xbb\xbf<?xml version=\"1.0\"?>\n<!-- logo -->\n<!DOCTYPE svg>\n<svg></svg>"), "image/svg+xml"},
+		{"html", []byte("<html><body>hi</body></html>"), "text/html"},
+		{"xml without svg", []byte(`<?xml version="1.0"?><note/>`), "text/xml"},
+		{"pdf", []byte("%PDF-1.4\n"), "application/pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SniffAssetContentType(tt.data))
+		})
+	}
+}
+
+// TestIsAllowedAssetType tests the default and configured asset whitelist
+func TestIsAllowedAssetType(t *testing.T) {
+	original := common.Config
+	defer func() { common.Config = original }()
+
+	common.Config = &shared.Config{}
+	assert.True(t, IsAllowedAssetType("image/png"))
+	assert.False(t, IsAllowedAssetType("image/svg+xml"), "SVG must be opted into explicitly")
+	assert.False(t, IsAllowedAssetType("text/html"))
+
+	common.Config = &shared.Config{AllowedAssetTypes: []string{"image/png", "image/svg+xml"}}
+	assert.True(t, IsAllowedAssetType("image/png"))
+	assert.True(t, IsAllowedAssetType("image/svg+xml"))
+	assert.False(t, IsAllowedAssetType("image/jpeg"))
+}
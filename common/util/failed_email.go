@@ -0,0 +1,197 @@
+package util
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
+	signermodel "github.com/sunthewhat/easy-cert-api/api/model/signerModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+const (
+	defaultFailedEmailRetryInterval = 30 * time.Minute
+	defaultFailedEmailMaxAttempts   = 5
+)
+
+// failedEmailRetryInterval returns how often queued failed emails are retried (failed_email_retry_interval_minutes)
+func failedEmailRetryInterval() time.Duration {
+	if common.Config != nil && common.Config.FailedEmailRetryIntervalMinutes != nil && *common.Config.FailedEmailRetryIntervalMinutes > 0 {
+		return time.Duration(*common.Config.FailedEmailRetryIntervalMinutes) * time.Minute
+	}
+	return defaultFailedEmailRetryInterval
+}
+
+// FailedEmailMaxAttempts returns how many times a failed email is attempted before the retry job gives up on it
+// (failed_email_max_attempts); it can still be retried manually
+func FailedEmailMaxAttempts() int {
+	if common.Config != nil && common.Config.FailedEmailMaxAttempts != nil && *common.Config.FailedEmailMaxAttempts > 0 {
+		return *common.Config.FailedEmailMaxAttempts
+	}
+	return defaultFailedEmailMaxAttempts
+}
+
+// StartFailedEmailRetryJob starts a background job that periodically resends queued failed emails
+func StartFailedEmailRetryJob() {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.Error("Panic occurred in failed email retry job", "panic", r)
+			}
+		}()
+
+		ticker := time.NewTicker(failedEmailRetryInterval())
+		defer ticker.Stop()
+
+		for range ticker.C {
+			slog.Info("Failed email retry job: Scheduled run starting")
+			RetryFailedEmails()
+		}
+	}()
+
+	slog.Info("Failed email retry job started successfully", "interval", failedEmailRetryInterval())
+}
+
+// RetryFailedEmails resends every queued failed email that has attempts left
+func RetryFailedEmails() {
+	startTime := time.Now()
+	certRepo := certificatemodel.NewCertificateRepository(common.Gorm)
+
+	failedEmails, err := certRepo.GetRetryableFailedEmails(FailedEmailMaxAttempts())
+	if err != nil {
+		slog.Error("RetryFailedEmails: Failed to get failed emails", "error", err)
+		return
+	}
+
+	var successCount, failedCount int
+	for _, failedEmail := range failedEmails {
+		if err := RetryFailedEmail(failedEmail); err != nil {
+			failedCount++
+			continue
+		}
+		successCount++
+	}
+
+	slog.Info("RetryFailedEmails: Completed", "total", len(failedEmails), "success", successCount, "failed", failedCount, "duration", time.Since(startTime))
+}
+
+// RetryFailedEmail resends a queued failed email and records the outcome: the entry is resolved when the email
+// is sent or no longer needed, otherwise its attempt count and last error are updated
+func RetryFailedEmail(failedEmail *model.FailedEmail) error {
+	var sendErr error
+	switch failedEmail.Kind {
+	case certificatemodel.FailedEmailParticipantCertificate:
+		sendErr = resendParticipantCertificate(failedEmail)
+	case certificatemodel.FailedEmailSignatureRequest:
+		sendErr = resendSignatureRequest(failedEmail)
+	default:
+		sendErr = fmt.Errorf("unknown failed email kind %q", failedEmail.Kind)
+	}
+
+	if sendErr != nil {
+		slog.Warn("RetryFailedEmail: Retry failed", "error", sendErr, "id", failedEmail.ID, "kind", failedEmail.Kind, "attempts", failedEmail.Attempts+1)
+	} else {
+		slog.Info("RetryFailedEmail: Resolved", "id", failedEmail.ID, "kind", failedEmail.Kind, "certificateId", failedEmail.CertificateID)
+	}
+
+	certRepo := certificatemodel.NewCertificateRepository(common.Gorm)
+	if err := certRepo.MarkFailedEmailAttempt(failedEmail.ID, sendErr); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// currentParticipantEmail reads the participant's address again from the field it was taken from, so a
+// corrected address is used rather than the one that failed. Entries queued without a field fall back to
+// the recorded address.
+func currentParticipantEmail(participant *participantmodel.CombinedParticipant, failedEmail *model.FailedEmail) (string, error) {
+	if failedEmail.RecipientField == "" {
+		return failedEmail.Recipient, nil
+	}
+	email, _ := participant.DynamicData[failedEmail.RecipientField].(string)
+	if email = strings.TrimSpace(email); email == "" {
+		return "", fmt.Errorf("participant %s has no address in field %s", participant.ID, failedEmail.RecipientField)
+	}
+	return email, nil
+}
+
+// resendParticipantCertificate mails a participant their certificate again unless it was delivered in the meantime
+func resendParticipantCertificate(failedEmail *model.FailedEmail) error {
+	participantRepo := participantmodel.NewParticipantRepository(common.Gorm, common.Mongo)
+
+	participant, err := participantRepo.GetParticipantsById(failedEmail.RecipientID)
+	if err != nil {
+		return fmt.Errorf("failed to get participant: %w", err)
+	}
+	if participant == nil {
+		return fmt.Errorf("participant %s no longer exists", failedEmail.RecipientID)
+	}
+	if participant.EmailStatus == "success" {
+		return nil
+	}
+	if participant.IsRevoke {
+		return fmt.Errorf("participant %s is revoked", failedEmail.RecipientID)
+	}
+	if participant.CertificateURL == "" {
+		return fmt.Errorf("participant %s has no generated certificate", failedEmail.RecipientID)
+	}
+
+	recipient, err := currentParticipantEmail(participant, failedEmail)
+	if err != nil {
+		return err
+	}
+
+	if err := SendMail(recipient, participant.CertificateURL); err != nil {
+		return err
+	}
+
+	if err := participantRepo.UpdateEmailStatus(participant.ID, "success"); err != nil {
+		slog.Warn("RetryFailedEmail: Failed to update email status", "error", err, "participantId", participant.ID)
+	}
+	return nil
+}
+
+// resendSignatureRequest sends a signer their signature request again unless they have signed in the meantime
+func resendSignatureRequest(failedEmail *model.FailedEmail) error {
+	signatureRepo := signaturemodel.NewSignatureRepository(common.Gorm)
+	signerRepo := signermodel.NewSignerRepository(common.Gorm)
+	certRepo := certificatemodel.NewCertificateRepository(common.Gorm)
+
+	signature, err := signatureRepo.GetByCertificateAndSignerId(failedEmail.CertificateID, failedEmail.RecipientID)
+	if err != nil {
+		return fmt.Errorf("failed to get signature: %w", err)
+	}
+	if signature == nil {
+		return fmt.Errorf("signer %s is no longer part of the certificate", failedEmail.RecipientID)
+	}
+	if signature.IsSigned {
+		return nil
+	}
+
+	signer, err := signerRepo.GetById(failedEmail.RecipientID)
+	if err != nil || signer == nil {
+		return fmt.Errorf("failed to get signer %s: %v", failedEmail.RecipientID, err)
+	}
+
+	certificate, err := certRepo.GetById(failedEmail.CertificateID)
+	if err != nil || certificate == nil {
+		return fmt.Errorf("failed to get certificate %s: %v", failedEmail.CertificateID, err)
+	}
+
+	if err := SendSignatureRequestMail(signer.Email, signer.DisplayName, certificate.ID, certificate.Name, signer.ID, certificate.VerifyHost); err != nil {
+		return err
+	}
+
+	if err := signatureRepo.MarkAsRequested(certificate.ID, signer.ID); err != nil {
+		slog.Warn("RetryFailedEmail: Failed to mark as requested", "error", err, "signerId", signer.ID)
+	}
+	if err := signatureRepo.RecordEvent(certificate.ID, signer.ID, signaturemodel.SignatureEventRequested); err != nil {
+		slog.Warn("RetryFailedEmail: Failed to record request event", "error", err, "signerId", signer.ID)
+	}
+	return nil
+}
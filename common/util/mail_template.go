@@ -0,0 +1,112 @@
+package util
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+//go:embed mail_templates/*.html
+var embeddedMailTemplates embed.FS
+
+// Mail body templates; deployments override one by placing a file of the same name in mail_template_dir
+const (
+	mailTemplateCertificateAttached = "certificate_attached.html"
+	mailTemplateCertificateLink     = "certificate_link.html"
+	mailTemplateSignatureRequest    = "signature_request.html"
+	mailTemplateSignatureReminder   = "signature_reminder.html"
+	mailTemplateDownloadReminder    = "download_reminder.html"
+	mailTemplateSignaturesComplete  = "signatures_complete.html"
+	mailTemplateTest                = "test.html"
+)
+
+// certificateMailData is rendered into the participant certificate and download reminder emails
+type certificateMailData struct {
+	CertificateName string
+	DownloadURL     string
+}
+
+// signingMailData is rendered into the signature request and reminder emails
+type signingMailData struct {
+	SignerName      string
+	CertificateName string
+	SigningURL      string
+}
+
+// signaturesCompleteMailData is rendered into the owner's signing complete email. NoteHTML is already escaped.
+type signaturesCompleteMailData struct {
+	CertificateName string
+	CertificateID   string
+	DashboardURL    string
+	NoteHTML        string
+	HasPreview      bool
+}
+
+// testMailData is rendered into the mail settings test email
+type testMailData struct {
+	SentAt string
+}
+
+// MailSender returns the From address of outgoing emails (mail_from), defaulting to the mail_user account
+func MailSender() string {
+	if common.Config.MailFrom != nil && *common.Config.MailFrom != "" {
+		return *common.Config.MailFrom
+	}
+	return *common.Config.MailUser
+}
+
+// mailTemplateOverride reads name from mail_template_dir; found is false when no override exists
+func mailTemplateOverride(name string) (source []byte, found bool) {
+	if common.Config == nil || common.Config.MailTemplateDir == nil || *common.Config.MailTemplateDir == "" {
+		return nil, false
+	}
+
+	source, err := os.ReadFile(filepath.Join(*common.Config.MailTemplateDir, name))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Warn("Failed to read mail template override, using the default", "error", err, "template", name)
+		}
+		return nil, false
+	}
+	return source, true
+}
+
+// executeMailTemplate parses source as a text/template and renders it with data
+func executeMailTemplate(name string, source []byte, data any) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(source))
+	if err != nil {
+		return "", err
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return "", err
+	}
+	return body.String(), nil
+}
+
+// renderMailTemplate renders an email body, preferring an override from mail_template_dir over the embedded
+// default. Overrides are read on every send so edits apply without a restart; one that fails to render is
+// logged and the default is used, so a broken customization never stops emails.
+func renderMailTemplate(name string, data any) (string, error) {
+	if source, found := mailTemplateOverride(name); found {
+		body, err := executeMailTemplate(name, source, data)
+		if err == nil {
+			return body, nil
+		}
+		slog.Warn("Mail template override failed to render, using the default", "error", err, "template", name)
+	}
+
+	source, err := embeddedMailTemplates.ReadFile("mail_templates/" + name)
+	if err != nil {
+		return "", err
+	}
+	return executeMailTemplate(name, source, data)
+}
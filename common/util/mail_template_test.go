@@ -0,0 +1,84 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+// TestRenderMailTemplateDefaults tests that every embedded template renders with its data
+func TestRenderMailTemplateDefaults(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	common.Config = &shared.Config{}
+
+	tests := []struct {
+		name string
+		data any
+		want string
+	}{
+		{mailTemplateCertificateAttached, certificateMailData{CertificateName: "Workshop"}, "certificate attached"},
+		{mailTemplateCertificateLink, certificateMailData{CertificateName: "Workshop", DownloadURL: "https://cdn/a.pdf?x=1&y=2"}, "https://cdn/a.pdf?x=1&amp;y=2"},
+		{mailTemplateSignatureRequest, signingMailData{SignerName: "<Ann>", CertificateName: "Workshop", SigningURL: "https://app/sign"}, "&lt;Ann&gt;"},
+		{mailTemplateSignatureReminder, signingMailData{SignerName: "Ann", CertificateName: "Workshop", SigningURL: "https://app/sign"}, "https://app/sign"},
+		{mailTemplateDownloadReminder, certificateMailData{CertificateName: "Workshop", DownloadURL: "https://cdn/a.pdf"}, "https://cdn/a.pdf"},
+		{mailTemplateSignaturesComplete, signaturesCompleteMailData{CertificateName: "Workshop", CertificateID: "cert-1", NoteHTML: "Thanks"}, "Thanks"},
+		{mailTemplateTest, testMailData{SentAt: "2026-01-02"}, "2026-01-02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := renderMailTemplate(tt.name, tt.data)
+			require.NoError(t, err)
+			assert.Contains(t, body, tt.want)
+		})
+	}
+}
+
+// TestRenderMailTemplateOverride tests that templates in mail_template_dir replace the defaults and broken ones fall back
+func TestRenderMailTemplateOverride(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	dir := t.TempDir()
+	common.Config = &shared.Config{MailTemplateDir: &dir}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, mailTemplateTest), []byte("Custom test sent {{.SentAt}}"), 0o644))
+	body, err := renderMailTemplate(mailTemplateTest, testMailData{SentAt: "now"})
+	require.NoError(t, err)
+	assert.Equal(t, "Custom test sent now", body)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, mailTemplateTest), []byte("Broken {{.SentAt"), 0o644))
+	body, err = renderMailTemplate(mailTemplateTest, testMailData{SentAt: "now"})
+	require.NoError(t, err)
+	assert.Contains(t, body, "test email")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, mailTemplateTest), []byte("Unknown {{.Missing}}"), 0o644))
+	body, err = renderMailTemplate(mailTemplateTest, testMailData{SentAt: "now"})
+	require.NoError(t, err)
+	assert.Contains(t, body, "test email")
+
+	// Templates without an override keep using the default
+	body, err = renderMailTemplate(mailTemplateDownloadReminder, certificateMailData{CertificateName: "Workshop", DownloadURL: "https://cdn/a.pdf"})
+	require.NoError(t, err)
+	assert.Contains(t, body, "https://cdn/a.pdf")
+}
+
+// TestMailSender tests the configurable From address
+func TestMailSender(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	user := "mailer@example.com"
+	common.Config = &shared.Config{MailUser: &user}
+	assert.Equal(t, user, MailSender())
+
+	from := "EasyCert <noreply@example.com>"
+	common.Config = &shared.Config{MailUser: &user, MailFrom: &from}
+	assert.Equal(t, from, MailSender())
+}
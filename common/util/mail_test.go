@@ -0,0 +1,100 @@
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+// TestExceedsMaxAttachmentSize tests the attachment size limit check
+func TestExceedsMaxAttachmentSize(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	filename := filepath.Join(t.TempDir(), "certificate.pdf")
+	require.NoError(t, os.WriteFile(filename, make([]byte, 100), 0o644))
+
+	limit := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name     string
+		maxBytes *int64
+		want     bool
+	}{
+		{name: "no limit configured", maxBytes: nil, want: false},
+		{name: "zero limit disables check", maxBytes: limit(0), want: false},
+		{name: "file under limit", maxBytes: limit(1000), want: false},
+		{name: "file equal to limit", maxBytes: limit(100), want: false},
+		{name: "file over limit", maxBytes: limit(50), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common.Config = &shared.Config{MailMaxAttachmentBytes: tt.maxBytes}
+			exceeded, _ := exceedsMaxAttachmentSize(filename)
+			assert.Equal(t, tt.want, exceeded)
+		})
+	}
+}
+
+func TestRunSignatureRequests(t *testing.T) {
+	signerIds := []string{"s1", "s2", "s3", "s4", "s5", "s6"}
+
+	var mu sync.Mutex
+	active, peak := 0, 0
+	results := runSignatureRequests(signerIds, 2, func(signerId string) error {
+		mu.Lock()
+		active++
+		peak = max(peak, active)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+
+		if signerId == "s3" {
+			return errors.New("smtp unavailable")
+		}
+		return nil
+	})
+
+	require.Len(t, results, len(signerIds))
+	assert.Error(t, results["s3"])
+	assert.NoError(t, results["s1"])
+	assert.Equal(t, 2, peak, "expected the worker pool to cap concurrency at 2")
+}
+
+func TestMailThrottle(t *testing.T) {
+	throttle := &mailThrottle{}
+
+	start := time.Now()
+	throttle.wait(0)
+	assert.Less(t, time.Since(start), 5*time.Millisecond, "a zero interval must not wait")
+
+	for range 3 {
+		throttle.wait(20 * time.Millisecond)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "three sends 20ms apart take at least 40ms")
+}
+
+func TestMailSendInterval(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	common.Config = &shared.Config{}
+	assert.Equal(t, time.Duration(0), mailSendInterval())
+
+	rate := 4.0
+	common.Config = &shared.Config{MailRateLimitPerSecond: &rate}
+	assert.Equal(t, 250*time.Millisecond, mailSendInterval())
+}
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"gopkg.in/gomail.v2"
+)
+
+var (
+	mailHiddenBlocks = regexp.MustCompile(`(?is)<(head|style|script)\b.*?</(head|style|script)>`)
+	mailLinks        = regexp.MustCompile(`(?is)<a\b[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	mailBreaks       = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|li|tr|center)>`)
+	mailTags         = regexp.MustCompile(`(?s)<[^>]*>`)
+	mailSpaces       = regexp.MustCompile(`[ \t]+`)
+)
+
+// htmlToPlainText derives a readable text/plain version of an HTML email: styles and markup are dropped,
+// block elements become line breaks and links keep their target so they stay usable
+func htmlToPlainText(htmlBody string) string {
+	text := mailHiddenBlocks.ReplaceAllString(htmlBody, "")
+	text = mailLinks.ReplaceAllStringFunc(text, func(link string) string {
+		parts := mailLinks.FindStringSubmatch(link)
+		href := parts[1]
+		label := strings.TrimSpace(mailTags.ReplaceAllString(parts[2], ""))
+		if label == "" || strings.TrimSpace(html.UnescapeString(label)) == href {
+			return href
+		}
+		return label + ": " + href
+	})
+	text = mailBreaks.ReplaceAllString(text, "\n")
+	text = mailTags.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	var lines []string
+	blank := true
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(mailSpaces.ReplaceAllString(line, " "))
+		if line == "" {
+			if !blank {
+				lines = append(lines, "")
+			}
+			blank = true
+			continue
+		}
+		lines = append(lines, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// setMailBody sets a text/plain body derived from htmlBody with the HTML as the preferred alternative,
+// so text-only clients and spam filters still see the content
+func setMailBody(mailer *gomail.Message, htmlBody string) {
+	mailer.SetBody("text/plain", htmlToPlainText(htmlBody))
+	mailer.AddAlternative("text/html", htmlBody)
+}
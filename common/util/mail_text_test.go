@@ -0,0 +1,58 @@
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/gomail.v2"
+)
+
+// TestHtmlToPlainText tests deriving the text/plain alternative of an HTML email
+func TestHtmlToPlainText(t *testing.T) {
+	htmlBody := `
+		<!DOCTYPE html>
+		<html>
+		<head><style>.button { color: red; }</style></head>
+		<body>
+			<h1>Signature Request</h1>
+			<p>Dear Alice &amp; Bob,</p>
+			<p>Please <strong>review</strong> the certificate:</p>
+			<center><a href="https://example.com/sign/1" class="button">Sign Certificate →</a></center>
+			<div class="link-text"><a href="https://example.com/sign/1">https://example.com/sign/1</a></div>
+			<p>Best regards,<br>Easy Cert Team</p>
+		</body>
+		</html>`
+
+	want := strings.Join([]string{
+		"Signature Request",
+		"Dear Alice & Bob,",
+		"Please review the certificate:",
+		"Sign Certificate →: https://example.com/sign/1",
+		"https://example.com/sign/1",
+		"Best regards,",
+		"Easy Cert Team",
+	}, "\n")
+
+	got := htmlToPlainText(htmlBody)
+	assert.NotContains(t, got, "color: red")
+	assert.Equal(t, want, strings.ReplaceAll(got, "\n\n", "\n"))
+}
+
+// TestSetMailBody tests that both the plain text and HTML parts are sent
+func TestSetMailBody(t *testing.T) {
+	mailer := gomail.NewMessage()
+	setMailBody(mailer, "<p>Hello <b>there</b></p>")
+
+	var buf bytes.Buffer
+	_, err := mailer.WriteTo(&buf)
+	require.NoError(t, err)
+
+	raw := buf.String()
+	assert.Contains(t, raw, "multipart/alternative")
+	assert.Contains(t, raw, "text/plain")
+	assert.Contains(t, raw, "text/html")
+	assert.Less(t, strings.Index(raw, "text/plain"), strings.Index(raw, "text/html"), "HTML should be the preferred, last alternative")
+}
@@ -0,0 +1,235 @@
+package util
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	"gopkg.in/gomail.v2"
+)
+
+// Mail providers selectable through mail_provider
+const (
+	MailProviderSMTP = "smtp"
+	MailProviderHTTP = "http"
+)
+
+const httpMailerTimeout = 30 * time.Second
+
+// MailAttachment is a file on disk attached to an email under Filename
+type MailAttachment struct {
+	Path        string
+	Filename    string
+	ContentType string
+}
+
+// MailMessage is a provider independent HTML email; the plain text part is derived from HTMLBody
+type MailMessage struct {
+	From        string
+	To          string
+	Subject     string
+	HTMLBody    string
+	Attachments []MailAttachment
+}
+
+// Mailer delivers emails through a mail provider
+type Mailer interface {
+	Send(message *MailMessage) error
+}
+
+// SMTPMailer sends emails over SMTP with gomail
+type SMTPMailer struct {
+	dialer *gomail.Dialer
+}
+
+// NewSMTPMailer creates a mailer for the given SMTP server
+func NewSMTPMailer(host string, port int, username string, password string) *SMTPMailer {
+	return &SMTPMailer{dialer: gomail.NewDialer(host, port, username, password)}
+}
+
+func (m *SMTPMailer) Send(message *MailMessage) error {
+	mailer := gomail.NewMessage()
+	mailer.SetHeader("From", message.From)
+	mailer.SetHeader("To", message.To)
+	mailer.SetHeader("Subject", message.Subject)
+	setMailBody(mailer, message.HTMLBody)
+
+	for _, attachment := range message.Attachments {
+		mailer.Attach(attachment.Path, gomail.Rename(attachment.Filename), gomail.SetHeader(map[string][]string{
+			"Content-Type": {attachment.ContentType},
+		}))
+	}
+
+	return m.dialer.DialAndSend(mailer)
+}
+
+// HTTPMailer sends emails by POSTing them as JSON to a transactional mail API,
+// for environments where outbound SMTP is blocked
+type HTTPMailer struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+// NewHTTPMailer creates a mailer posting to endpoint, authenticated with apiKey as a bearer token
+func NewHTTPMailer(endpoint string, apiKey string) *HTTPMailer {
+	return &HTTPMailer{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: httpMailerTimeout},
+	}
+}
+
+type httpMailAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     string `json:"content"`
+}
+
+type httpMailRequest struct {
+	From        string               `json:"from"`
+	To          []string             `json:"to"`
+	Subject     string               `json:"subject"`
+	HTML        string               `json:"html"`
+	Text        string               `json:"text"`
+	Attachments []httpMailAttachment `json:"attachments,omitempty"`
+}
+
+func (m *HTTPMailer) Send(message *MailMessage) error {
+	request := httpMailRequest{
+		From:    message.From,
+		To:      []string{message.To},
+		Subject: message.Subject,
+		HTML:    message.HTMLBody,
+		Text:    htmlToPlainText(message.HTMLBody),
+	}
+
+	for _, attachment := range message.Attachments {
+		content, err := os.ReadFile(attachment.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read attachment %s: %w", attachment.Filename, err)
+		}
+		request.Attachments = append(request.Attachments, httpMailAttachment{
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Content:     base64.StdEncoding.EncodeToString(content),
+		})
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode mail request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, m.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create mail request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("mail API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("mail API returned %s: %s", resp.Status, string(detail))
+	}
+
+	return nil
+}
+
+var (
+	activeMailer   Mailer
+	activeMailerMu sync.RWMutex
+)
+
+// InitMailer sets up the mailer selected by mail_provider: smtp (default) or http
+func InitMailer() {
+	SetMailer(newConfiguredMailer())
+}
+
+func newConfiguredMailer() Mailer {
+	if common.Config.MailProvider != nil && *common.Config.MailProvider == MailProviderHTTP {
+		apiKey := ""
+		if common.Config.MailApiKey != nil {
+			apiKey = *common.Config.MailApiKey
+		}
+		if common.Config.MailApiUrl == nil || *common.Config.MailApiUrl == "" {
+			slog.Error("mail_provider is http but mail_api_url is not set, falling back to SMTP")
+		} else {
+			slog.Info("Using HTTP mail provider", "endpoint", *common.Config.MailApiUrl)
+			return NewHTTPMailer(*common.Config.MailApiUrl, apiKey)
+		}
+	}
+
+	return NewSMTPMailer(*common.Config.MailHost, 587, *common.Config.MailUser, *common.Config.MailPass)
+}
+
+// SetMailer replaces the mailer used to send all emails
+func SetMailer(mailer Mailer) {
+	activeMailerMu.Lock()
+	defer activeMailerMu.Unlock()
+	activeMailer = mailer
+}
+
+// mailThrottle spaces outgoing emails evenly so concurrent senders don't trip the mail server's rate limit
+type mailThrottle struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+var outgoingMailThrottle = &mailThrottle{}
+
+// wait blocks until the caller's send slot, reserving slots interval apart; a zero interval never waits
+func (t *mailThrottle) wait(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	slot := t.next
+	if slot.Before(now) {
+		slot = now
+	}
+	t.next = slot.Add(interval)
+	t.mu.Unlock()
+
+	time.Sleep(time.Until(slot))
+}
+
+// mailSendInterval returns the minimum time between two outgoing emails (mail_rate_limit_per_second, 0 = unlimited)
+func mailSendInterval() time.Duration {
+	if common.Config == nil || common.Config.MailRateLimitPerSecond == nil || *common.Config.MailRateLimitPerSecond <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / *common.Config.MailRateLimitPerSecond)
+}
+
+// sendMail delivers a message through the configured mailer, respecting the outgoing mail rate limit
+func sendMail(message *MailMessage) error {
+	activeMailerMu.RLock()
+	mailer := activeMailer
+	activeMailerMu.RUnlock()
+
+	if mailer == nil {
+		return fmt.Errorf("mailer is not initialized")
+	}
+
+	outgoingMailThrottle.wait(mailSendInterval())
+	return mailer.Send(message)
+}
@@ -0,0 +1,148 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestHTTPMailer_Send(t *testing.T) {
+	attachmentPath := filepath.Join(t.TempDir(), "certificate.pdf")
+	require.NoError(t, os.WriteFile(attachmentPath, []byte("%PDF-1.4"), 0o644))
+
+	var received httpMailRequest
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	err := NewHTTPMailer(server.URL, "secret-key").Send(&MailMessage{
+		From:     "noreply@example.com",
+		To:       "alice@example.com",
+		Subject:  "Your Certificate",
+		HTMLBody: "<p>Hello <b>Alice</b></p>",
+		Attachments: []MailAttachment{
+			{Path: attachmentPath, Filename: "Certificate.pdf", ContentType: "application/pdf"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer secret-key", authorization)
+	assert.Equal(t, "noreply@example.com", received.From)
+	assert.Equal(t, []string{"alice@example.com"}, received.To)
+	assert.Equal(t, "Your Certificate", received.Subject)
+	assert.Equal(t, "<p>Hello <b>Alice</b></p>", received.HTML)
+	assert.Equal(t, "Hello Alice", received.Text)
+	require.Len(t, received.Attachments, 1)
+	assert.Equal(t, "Certificate.pdf", received.Attachments[0].Filename)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("%PDF-1.4")), received.Attachments[0].Content)
+}
+
+func TestHTTPMailer_SendErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid api key", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	err := NewHTTPMailer(server.URL, "wrong").Send(&MailMessage{To: "alice@example.com"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid api key")
+}
+
+func TestNewConfiguredMailer(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	str := func(s string) *string { return &s }
+	base := func() *shared.Config {
+		return &shared.Config{MailHost: str("smtp.example.com"), MailUser: str("noreply@example.com"), MailPass: str("pass")}
+	}
+
+	common.Config = base()
+	assert.IsType(t, &SMTPMailer{}, newConfiguredMailer())
+
+	common.Config = base()
+	common.Config.MailProvider = str(MailProviderHTTP)
+	common.Config.MailApiUrl = str("https://mail.example.com/send")
+	assert.IsType(t, &HTTPMailer{}, newConfiguredMailer())
+
+	// Without an endpoint the HTTP provider can't work, so SMTP is kept
+	common.Config = base()
+	common.Config.MailProvider = str(MailProviderHTTP)
+	assert.IsType(t, &SMTPMailer{}, newConfiguredMailer())
+}
+
+type recordingMailer struct {
+	messages []*MailMessage
+}
+
+func (m *recordingMailer) Send(message *MailMessage) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func TestSendMailUsesConfiguredMailer(t *testing.T) {
+	defer SetMailer(nil)
+
+	SetMailer(nil)
+	assert.Error(t, sendMail(&MailMessage{}))
+
+	recorder := &recordingMailer{}
+	SetMailer(recorder)
+	require.NoError(t, sendMail(&MailMessage{To: "alice@example.com"}))
+	require.Len(t, recorder.messages, 1)
+	assert.Equal(t, "alice@example.com", recorder.messages[0].To)
+}
+
+func TestSendTestMail(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	defer SetMailer(nil)
+
+	sender := "noreply@example.com"
+	common.Config = &shared.Config{MailUser: &sender}
+
+	recorder := &recordingMailer{}
+	SetMailer(recorder)
+	require.NoError(t, SendTestMail("alice@example.com"))
+	require.Len(t, recorder.messages, 1)
+	assert.Equal(t, "alice@example.com", recorder.messages[0].To)
+	assert.Equal(t, sender, recorder.messages[0].From)
+	assert.Contains(t, recorder.messages[0].HTMLBody, "test email")
+
+	SetMailer(nil)
+	assert.Error(t, SendTestMail("alice@example.com"))
+}
+
+func TestSendAllSignaturesCompleteMailWithNote(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	defer SetMailer(nil)
+
+	sender := "noreply@example.com"
+	verifyHost := "https://verify.example.com"
+	common.Config = &shared.Config{MailUser: &sender, VerifyHost: &verifyHost}
+
+	recorder := &recordingMailer{}
+	SetMailer(recorder)
+	require.NoError(t, SendAllSignaturesCompleteMailWithNote("owner@example.com", "Award", "cert1", "", "", "Thanks <b>all</b>\nSee you"))
+	require.NoError(t, SendAllSignaturesCompleteMail("owner@example.com", "Award", "cert1", "", ""))
+	require.Len(t, recorder.messages, 2)
+
+	body := recorder.messages[0].HTMLBody
+	assert.Contains(t, body, "Thanks &lt;b&gt;all&lt;/b&gt;<br>See you")
+	assert.NotContains(t, body, "<b>all</b>")
+	assert.NotContains(t, recorder.messages[1].HTMLBody, ">Note<")
+}
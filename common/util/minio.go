@@ -5,36 +5,41 @@ import (
 	"fmt"
 	"mime/multipart"
 	"strings"
+	"time"
 
 	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/storage"
 )
 
-var minioClient *minio.Client
-
+// InitMinIO creates the shared MinIO client. Later calls through storage.Client
+// connect lazily if this fails.
 func InitMinIO() error {
-	if common.Config.MinIoEndpoint == nil || common.Config.MinIoAccessKey == nil || common.Config.MinIoSecretKey == nil {
-		return fmt.Errorf("MinIO configuration is incomplete")
+	client, err := storage.Connect()
+	if err != nil {
+		return err
 	}
 
-	client, err := minio.New(*common.Config.MinIoEndpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(*common.Config.MinIoAccessKey, *common.Config.MinIoSecretKey, ""),
-		Secure: *common.Config.IsHTTPS,
-	})
+	// Probe the server so an unreachable MinIO is reported at startup
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	if err != nil {
-		return fmt.Errorf("failed to initialize MinIO client: %w", err)
+	if _, err := client.BucketExists(ctx, *common.Config.BucketCertificate); err != nil {
+		return fmt.Errorf("MinIO is unreachable: %w", err)
 	}
 
-	minioClient = client
-	common.MinIOClient = client
 	return nil
 }
 
 func UploadFile(ctx context.Context, bucketName string, objectName string, file *multipart.FileHeader) (string, error) {
-	if minioClient == nil {
-		return "", fmt.Errorf("MinIO client not initialized")
+	return UploadFileWithContentType(ctx, bucketName, objectName, file, file.Header.Get("Content-Type"))
+}
+
+// UploadFileWithContentType uploads file storing contentType instead of the type the client sent
+func UploadFileWithContentType(ctx context.Context, bucketName string, objectName string, file *multipart.FileHeader, contentType string) (string, error) {
+	minioClient, err := storage.Client()
+	if err != nil {
+		return "", err
 	}
 
 	// Open the uploaded file
@@ -59,7 +64,7 @@ func UploadFile(ctx context.Context, bucketName string, objectName string, file
 
 	// Upload the file
 	info, err := minioClient.PutObject(ctx, bucketName, objectName, src, file.Size, minio.PutObjectOptions{
-		ContentType: file.Header.Get("Content-Type"),
+		ContentType: contentType,
 	})
 
 	if err != nil {
@@ -78,8 +83,9 @@ func UploadFile(ctx context.Context, bucketName string, objectName string, file
 }
 
 func DownloadFile(ctx context.Context, bucketName string, objectName string) (*minio.Object, error) {
-	if minioClient == nil {
-		return nil, fmt.Errorf("MinIO client not initialized")
+	minioClient, err := storage.Client()
+	if err != nil {
+		return nil, err
 	}
 
 	object, err := minioClient.GetObject(ctx, bucketName, objectName, minio.GetObjectOptions{})
@@ -91,11 +97,12 @@ func DownloadFile(ctx context.Context, bucketName string, objectName string) (*m
 }
 
 func DeleteFile(ctx context.Context, bucketName string, objectName string) error {
-	if minioClient == nil {
-		return fmt.Errorf("MinIO client not initialized")
+	minioClient, err := storage.Client()
+	if err != nil {
+		return err
 	}
 
-	err := minioClient.RemoveObject(ctx, bucketName, objectName, minio.RemoveObjectOptions{})
+	err = minioClient.RemoveObject(ctx, bucketName, objectName, minio.RemoveObjectOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to delete file: %w", err)
 	}
@@ -104,8 +111,9 @@ func DeleteFile(ctx context.Context, bucketName string, objectName string) error
 }
 
 func ListFilesByPrefix(ctx context.Context, bucketName string, prefix string, limit int) ([]string, error) {
-	if minioClient == nil {
-		return nil, fmt.Errorf("MinIO client not initialized")
+	minioClient, err := storage.Client()
+	if err != nil {
+		return nil, err
 	}
 
 	var fileURLs []string
@@ -192,4 +200,33 @@ func ConvertToProxyURL(minioURL string, bucketName string) (string, error) {
 // GenerateProxyURL generates a backend proxy URL for a given bucket and object path
 func GenerateProxyURL(bucketName string, objectPath string) string {
 	return fmt.Sprintf("%s/api/public/files/download/%s/%s", *common.Config.BackendURL, bucketName, objectPath)
-}
\ No newline at end of file
+}
+
+// StoredObject is the key and size of an object in MinIO
+type StoredObject struct {
+	Key  string
+	Size int64
+}
+
+// ListObjectsByPrefix returns the key and size of every object under prefix
+func ListObjectsByPrefix(ctx context.Context, bucketName string, prefix string) ([]StoredObject, error) {
+	minioClient, err := storage.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []StoredObject
+	objectCh := minioClient.ListObjects(ctx, bucketName, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	})
+
+	for object := range objectCh {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", object.Err)
+		}
+		objects = append(objects, StoredObject{Key: object.Key, Size: object.Size})
+	}
+
+	return objects, nil
+}
@@ -13,6 +13,8 @@ type MockSSOService struct {
 	RefreshFunc func(token string) (*shared.SsoTokenType, error)
 	VerifyFunc  func(token string) (*shared.SsoVerifyType, error)
 	DecodeFunc  func(token string) (*shared.SsoJwtPayload, error)
+
+	LookupEmailFunc func(userId string) (string, error)
 }
 
 // NewMockSSOService returns a new mock implementation
@@ -51,3 +53,11 @@ func (m *MockSSOService) Decode(token string) (*shared.SsoJwtPayload, error) {
 	}
 	return nil, errors.New("Decode not implemented in mock")
 }
+
+// LookupEmail calls the configured mock function or returns an error
+func (m *MockSSOService) LookupEmail(userId string) (string, error) {
+	if m.LookupEmailFunc != nil {
+		return m.LookupEmailFunc(userId)
+	}
+	return "", errors.New("LookupEmail not implemented in mock")
+}
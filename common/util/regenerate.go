@@ -0,0 +1,141 @@
+package util
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	participantmodel "github.com/sunthewhat/easy-cert-api/api/model/participantModel"
+	signaturemodel "github.com/sunthewhat/easy-cert-api/api/model/signatureModel"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+// DecryptSignatureImages decrypts the signed signature images of a certificate into a
+// signerId -> base64 image map for the renderer. Undecryptable signatures are skipped.
+func DecryptSignatureImages(signatures []*model.Signature) map[string]string {
+	decryptedSignatures := make(map[string]string)
+	for _, sig := range signatures {
+		if !sig.IsSigned || sig.Signature == "" {
+			continue
+		}
+
+		decryptedImage, err := DecryptData(sig.Signature, *common.Config.EncryptionKey)
+		if err != nil {
+			slog.Warn("Failed to decrypt signature",
+				"error", err,
+				"cert_id", sig.CertificateID,
+				"signer_id", sig.SignerID)
+			continue
+		}
+
+		decryptedSignatures[sig.SignerID] = base64.StdEncoding.EncodeToString(decryptedImage)
+	}
+	return decryptedSignatures
+}
+
+// RendererCertificateMap converts a certificate into the map the embedded renderer expects,
+// including the verify host its QR codes should point at
+func RendererCertificateMap(certificate *model.Certificate) map[string]any {
+	design := certificate.Design
+	if *common.Config.Environment {
+		design = strings.ReplaceAll(design, "http://easycert.sit.kmutt.ac.th", "http://backend:8000")
+	}
+
+	return map[string]any{
+		"id":          certificate.ID,
+		"name":        certificate.Name,
+		"design":      design,
+		"verify_host": CertificateVerifyHost(certificate),
+		"pdf_footer":  certificate.PdfFooter,
+
+		"archive_filename_template": certificate.ArchiveFilenameTemplate,
+		"pdf_margin_mm":             certificate.PdfMarginMm,
+		"pdf_fit_mode":              certificate.PdfFitMode,
+	}
+}
+
+// RegenerateParticipantCertificate re-renders a single participant's certificate from their current data,
+// replaces the previous PDF and resets their distribution status so the new file is sent again
+func RegenerateParticipantCertificate(certificate *model.Certificate, participant *participantmodel.CombinedParticipant) (string, error) {
+	signatureRepo := signaturemodel.NewSignatureRepository(common.Gorm)
+	participantRepo := participantmodel.NewParticipantRepository(common.Gorm, common.Mongo)
+
+	signatures, err := signatureRepo.GetSignaturesByCertificate(certificate.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get signatures: %w", err)
+	}
+
+	embeddedRenderer, err := renderer.NewEmbeddedRenderer()
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize renderer: %w", err)
+	}
+	defer embeddedRenderer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	results, err := embeddedRenderer.RenderAndUploadCertificates(ctx, RendererCertificateMap(certificate), []any{participant}, DecryptSignatureImages(signatures))
+	if err != nil {
+		return "", fmt.Errorf("failed to render certificate: %w", err)
+	}
+
+	if len(results) == 0 || results[0].Status != "success" || results[0].FilePath == "" {
+		reason := "no result"
+		if len(results) > 0 {
+			reason = results[0].Error
+		}
+		return "", fmt.Errorf("certificate rendering failed: %s", reason)
+	}
+
+	if participant.CertificateURL != "" {
+		if err := DeleteFileByURL(context.Background(), *common.Config.BucketCertificate, participant.CertificateURL); err != nil {
+			slog.Warn("RegenerateParticipantCertificate: Failed to delete old certificate file",
+				"error", err,
+				"participant_id", participant.ID,
+				"certificate_url", participant.CertificateURL)
+		}
+	}
+
+	certificateURL := GenerateProxyURL(*common.Config.BucketCertificate, results[0].FilePath)
+	if err := participantRepo.UpdateParticipantCertificateUrl(participant.ID, certificateURL); err != nil {
+		return "", fmt.Errorf("failed to update certificate URL: %w", err)
+	}
+
+	if err := participantRepo.SetParticipantsPdfSigned([]string{participant.ID}, results[0].Signed); err != nil {
+		slog.Warn("RegenerateParticipantCertificate: Failed to record PDF signing state", "error", err, "participant_id", participant.ID)
+	}
+
+	if err := participantRepo.ResetParticipantStatuses([]string{participant.ID}); err != nil {
+		slog.Warn("RegenerateParticipantCertificate: Failed to reset participant status", "error", err, "participant_id", participant.ID)
+	}
+
+	slog.Info("RegenerateParticipantCertificate completed", "cert_id", certificate.ID, "participant_id", participant.ID, "url", certificateURL)
+	return certificateURL, nil
+}
+
+// RenderParticipantCertificateFile renders a single participant's certificate in the given renderer format
+// and returns the file bytes without storing them or touching the participant's distribution status
+func RenderParticipantCertificateFile(certificate *model.Certificate, participant *participantmodel.CombinedParticipant, format string) ([]byte, error) {
+	signatureRepo := signaturemodel.NewSignatureRepository(common.Gorm)
+
+	signatures, err := signatureRepo.GetSignaturesByCertificate(certificate.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signatures: %w", err)
+	}
+
+	embeddedRenderer, err := renderer.NewEmbeddedRenderer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize renderer: %w", err)
+	}
+	defer embeddedRenderer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	return embeddedRenderer.RenderParticipantFile(ctx, RendererCertificateMap(certificate), participant, DecryptSignatureImages(signatures), format)
+}
@@ -91,7 +91,7 @@ func SendSignatureReminders() {
 		}
 
 		// Send reminder email
-		err = SendSignatureReminderMail(signer.Email, signer.DisplayName, certificate.ID, certificate.Name)
+		err = SendSignatureReminderMail(signer.Email, signer.DisplayName, certificate.ID, certificate.Name, signature.SignerID, certificate.VerifyHost)
 		if err != nil {
 			slog.Error("SendSignatureReminders: Failed to send reminder", "error", err, "signerId", signature.SignerID)
 			failedCount++
@@ -104,6 +104,10 @@ func SendSignatureReminders() {
 			slog.Warn("SendSignatureReminders: Failed to update last_request", "error", markErr, "signerId", signature.SignerID)
 		}
 
+		if eventErr := signatureRepo.RecordEvent(certificate.ID, signature.SignerID, signaturemodel.SignatureEventReminded); eventErr != nil {
+			slog.Warn("SendSignatureReminders: Failed to record reminder event", "error", eventErr, "signerId", signature.SignerID)
+		}
+
 		successCount++
 	}
 
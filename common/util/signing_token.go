@@ -0,0 +1,109 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const defaultSigningLinkTTLHours = 14 * 24
+
+var (
+	ErrInvalidSigningToken = errors.New("invalid signing token")
+	ErrExpiredSigningToken = errors.New("signing token has expired")
+)
+
+// SigningTokenClaims identifies the signature record a signing link was issued for
+type SigningTokenClaims struct {
+	CertificateID string
+	SignerID      string
+	ExpiresAt     time.Time
+}
+
+// SigningLinkTTL returns how long a per-signer signing link stays valid
+func SigningLinkTTL() time.Duration {
+	hours := defaultSigningLinkTTLHours
+	if common.Config.SigningLinkTTLHours != nil && *common.Config.SigningLinkTTLHours > 0 {
+		hours = *common.Config.SigningLinkTTLHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// GenerateSigningToken creates an HMAC-signed token binding a certificate, a signer and an expiry
+// Format: base64url(certId|signerId|expiryUnix) + "." + base64url(hmac-sha256)
+func GenerateSigningToken(certificateId, signerId string, expiresAt time.Time) (string, error) {
+	if certificateId == "" || signerId == "" {
+		return "", errors.New("certificate ID and signer ID are required")
+	}
+
+	payload := fmt.Sprintf("%s|%s|%d", certificateId, signerId, expiresAt.Unix())
+	encodedPayload := base64.RawURLEncoding.EncodeToString([]byte(payload))
+
+	return encodedPayload + "." + signSigningPayload(encodedPayload), nil
+}
+
+// ValidateSigningToken verifies the token signature and expiry and returns its claims
+func ValidateSigningToken(token string) (*SigningTokenClaims, error) {
+	encodedPayload, signature, found := strings.Cut(token, ".")
+	if !found || encodedPayload == "" || signature == "" {
+		return nil, ErrInvalidSigningToken
+	}
+
+	expected := signSigningPayload(encodedPayload)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return nil, ErrInvalidSigningToken
+	}
+
+	rawPayload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidSigningToken
+	}
+
+	parts := strings.Split(string(rawPayload), "|")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return nil, ErrInvalidSigningToken
+	}
+
+	expiryUnix, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSigningToken
+	}
+
+	claims := &SigningTokenClaims{
+		CertificateID: parts[0],
+		SignerID:      parts[1],
+		ExpiresAt:     time.Unix(expiryUnix, 0),
+	}
+
+	if time.Now().After(claims.ExpiresAt) {
+		return claims, ErrExpiredSigningToken
+	}
+
+	return claims, nil
+}
+
+// BuildSigningURL returns the signing page link for a signer on the given verify host, falling back
+// to the plain certificate link if a token cannot be generated
+func BuildSigningURL(verifyHost, certificateId, signerId string) string {
+	signatureURL := fmt.Sprintf("%s/signature/%s", ResolveVerifyHost(verifyHost), certificateId)
+
+	token, err := GenerateSigningToken(certificateId, signerId, time.Now().Add(SigningLinkTTL()))
+	if err != nil {
+		return signatureURL
+	}
+
+	return fmt.Sprintf("%s?token=%s", signatureURL, token)
+}
+
+func signSigningPayload(encodedPayload string) string {
+	mac := hmac.New(sha256.New, []byte(*common.Config.JWTSecret))
+	mac.Write([]byte(encodedPayload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
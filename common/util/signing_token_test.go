@@ -0,0 +1,73 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+// TestSigningToken tests signing token generation and validation
+func TestSigningToken(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	secret := "test-secret"
+	common.Config = &shared.Config{JWTSecret: &secret}
+
+	t.Run("round trip", func(t *testing.T) {
+		expiry := time.Now().Add(time.Hour)
+		token, err := GenerateSigningToken("cert-1", "signer-1", expiry)
+		require.NoError(t, err)
+
+		claims, err := ValidateSigningToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, "cert-1", claims.CertificateID)
+		assert.Equal(t, "signer-1", claims.SignerID)
+		assert.Equal(t, expiry.Unix(), claims.ExpiresAt.Unix())
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		token, err := GenerateSigningToken("cert-1", "signer-1", time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+
+		_, err = ValidateSigningToken(token)
+		assert.ErrorIs(t, err, ErrExpiredSigningToken)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		token, err := GenerateSigningToken("cert-1", "signer-1", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		other, err := GenerateSigningToken("cert-1", "signer-2", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		_, signature, _ := strings.Cut(token, ".")
+		otherPayload, _, _ := strings.Cut(other, ".")
+
+		_, err = ValidateSigningToken(otherPayload + "." + signature)
+		assert.ErrorIs(t, err, ErrInvalidSigningToken)
+	})
+
+	t.Run("different secret", func(t *testing.T) {
+		token, err := GenerateSigningToken("cert-1", "signer-1", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		otherSecret := "other-secret"
+		common.Config = &shared.Config{JWTSecret: &otherSecret}
+		defer func() { common.Config = &shared.Config{JWTSecret: &secret} }()
+
+		_, err = ValidateSigningToken(token)
+		assert.ErrorIs(t, err, ErrInvalidSigningToken)
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "abc.", ".abc"} {
+			_, err := ValidateSigningToken(token)
+			assert.ErrorIs(t, err, ErrInvalidSigningToken, token)
+		}
+	})
+}
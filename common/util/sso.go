@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/sunthewhat/easy-cert-api/common"
 	"github.com/sunthewhat/easy-cert-api/type/shared"
@@ -17,6 +18,7 @@ type ISSOService interface {
 	Refresh(token string) (*shared.SsoTokenType, error)
 	Verify(token string) (*shared.SsoVerifyType, error)
 	Decode(token string) (*shared.SsoJwtPayload, error)
+	LookupEmail(userId string) (string, error)
 }
 
 type SSOService struct{}
@@ -151,5 +153,151 @@ func (s *SSOService) Decode(token string) (*shared.SsoJwtPayload, error) {
 		return nil, fmt.Errorf("failed to unmarshal JWT payload: %v", err)
 	}
 
+	if err := json.Unmarshal(decoded, &jwtPayload.Claims); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JWT claims: %v", err)
+	}
+
 	return &jwtPayload, nil
 }
+
+// LookupEmail returns the email address of the SSO user whose configured user id claim equals userId.
+// It uses the Keycloak admin API with the client's service account, which needs the view-users role.
+func (s *SSOService) LookupEmail(userId string) (string, error) {
+	token, err := s.serviceAccountToken()
+	if err != nil {
+		return "", err
+	}
+
+	adminURL := strings.Replace(strings.TrimRight(*common.Config.SsoIssuerUrl, "/"), "/realms/", "/admin/realms/", 1) + "/users"
+	claim := UserIdClaim()
+	single := false
+	switch claim {
+	case "sub":
+		adminURL += "/" + url.PathEscape(userId)
+		single = true
+	case "preferred_username":
+		adminURL += "?exact=true&username=" + url.QueryEscape(userId)
+	default:
+		adminURL += "?exact=true&q=" + url.QueryEscape(claim+":"+userId)
+	}
+
+	req, err := http.NewRequest("GET", adminURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("SSO user lookup failed with status %d", resp.StatusCode)
+	}
+
+	type ssoUser struct {
+		Email string `json:"email"`
+	}
+	var users []ssoUser
+	if single {
+		var user ssoUser
+		err = json.NewDecoder(resp.Body).Decode(&user)
+		users = []ssoUser{user}
+	} else {
+		err = json.NewDecoder(resp.Body).Decode(&users)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if len(users) != 1 || users[0].Email == "" {
+		return "", fmt.Errorf("no SSO user with an email address found for %s %q", claim, userId)
+	}
+	return users[0].Email, nil
+}
+
+// serviceAccountToken requests an access token for the client's own service account
+func (s *SSOService) serviceAccountToken() (string, error) {
+	tokenURL := fmt.Sprintf("%s/protocol/openid-connect/token", *common.Config.SsoIssuerUrl)
+
+	data := url.Values{}
+	data.Set("client_id", *common.Config.SsoClient)
+	data.Set("client_secret", *common.Config.SsoSecret)
+	data.Set("grant_type", "client_credentials")
+
+	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("SSO service account token request failed with status %d", resp.StatusCode)
+	}
+
+	var ssoResponse shared.SsoTokenType
+	if err := json.NewDecoder(resp.Body).Decode(&ssoResponse); err != nil {
+		return "", err
+	}
+	return ssoResponse.AccessToken, nil
+}
+
+const defaultUserIdClaim = "email"
+
+// UserIdClaim returns the token claim configured as the user identifier
+func UserIdClaim() string {
+	if common.Config != nil && common.Config.UserIdClaim != nil && *common.Config.UserIdClaim != "" {
+		return *common.Config.UserIdClaim
+	}
+	return defaultUserIdClaim
+}
+
+// ResolveUserId selects the configured identity claim from a decoded token.
+// Payloads without raw claims fall back to the typed email, preferred_username and sub fields.
+func ResolveUserId(jwtPayload *shared.SsoJwtPayload) string {
+	if jwtPayload == nil {
+		return ""
+	}
+
+	claim := UserIdClaim()
+	if value, ok := jwtPayload.Claims[claim].(string); ok {
+		return value
+	}
+
+	switch claim {
+	case "email":
+		return jwtPayload.Email
+	case "preferred_username":
+		return jwtPayload.PreferredUsername
+	case "sub":
+		return jwtPayload.Sub
+	}
+	return ""
+}
+
+// userEmailLookup resolves user ids that aren't email addresses; replaced in tests
+var userEmailLookup ISSOService = NewSSOService()
+
+// ResolveUserEmail returns the address to notify a user at, such as a certificate owner. With the default
+// email claim the user id is the address itself; other claims are resolved through the SSO service.
+func ResolveUserEmail(userId string) (string, error) {
+	if UserIdClaim() == "email" {
+		return userId, nil
+	}
+	email, err := userEmailLookup.LookupEmail(userId)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve email of user %s: %w", userId, err)
+	}
+	return email, nil
+}
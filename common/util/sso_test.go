@@ -0,0 +1,134 @@
+package util
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+// TestResolveUserId tests selecting the configured identity claim from a decoded token
+func TestResolveUserId(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	body := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"uuid-1","email":"user@example.com","preferred_username":"user1","employee_id":"E42"}`))
+	jwtPayload, err := NewSSOService().Decode("header." + body + ".signature")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		claim *string
+		want  string
+	}{
+		{name: "defaults to email", claim: nil, want: "user@example.com"},
+		{name: "preferred_username", claim: strPtr("preferred_username"), want: "user1"},
+		{name: "sub", claim: strPtr("sub"), want: "uuid-1"},
+		{name: "custom claim", claim: strPtr("employee_id"), want: "E42"},
+		{name: "missing claim", claim: strPtr("upn"), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common.Config = &shared.Config{UserIdClaim: tt.claim}
+			assert.Equal(t, tt.want, ResolveUserId(jwtPayload))
+		})
+	}
+
+	t.Run("falls back to typed fields without raw claims", func(t *testing.T) {
+		common.Config = &shared.Config{UserIdClaim: strPtr("sub")}
+		assert.Equal(t, "uuid-2", ResolveUserId(&shared.SsoJwtPayload{Sub: "uuid-2"}))
+	})
+
+	t.Run("nil payload", func(t *testing.T) {
+		common.Config = &shared.Config{}
+		assert.Empty(t, ResolveUserId(nil))
+	})
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// TestResolveUserEmail tests resolving the notification address of a user id
+func TestResolveUserEmail(t *testing.T) {
+	originalConfig := common.Config
+	originalLookup := userEmailLookup
+	defer func() {
+		common.Config = originalConfig
+		userEmailLookup = originalLookup
+	}()
+
+	lookup := NewMockSSOService()
+	lookup.LookupEmailFunc = func(userId string) (string, error) {
+		if userId == "uuid-1" {
+			return "owner@example.com", nil
+		}
+		return "", errors.New("user not found")
+	}
+	userEmailLookup = lookup
+
+	common.Config = &shared.Config{}
+	email, err := ResolveUserEmail("owner@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "owner@example.com", email, "the email claim is used as the address directly")
+
+	common.Config = &shared.Config{UserIdClaim: strPtr("sub")}
+	email, err = ResolveUserEmail("uuid-1")
+	require.NoError(t, err)
+	assert.Equal(t, "owner@example.com", email)
+
+	_, err = ResolveUserEmail("uuid-unknown")
+	assert.Error(t, err)
+}
+
+// TestSSOService_LookupEmail tests the Keycloak admin API lookup for each kind of claim
+func TestSSOService_LookupEmail(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/realms/test/protocol/openid-connect/token":
+			assert.Equal(t, "client_credentials", r.FormValue("grant_type"))
+			w.Write([]byte(`{"access_token":"service-token"}`))
+		case strings.HasPrefix(r.URL.Path, "/admin/realms/test/users"):
+			assert.Equal(t, "Bearer service-token", r.Header.Get("Authorization"))
+			requests = append(requests, r.URL.RequestURI())
+			if r.URL.Path == "/admin/realms/test/users/uuid-1" {
+				w.Write([]byte(`{"email":"sub@example.com"}`))
+				return
+			}
+			w.Write([]byte(`[{"email":"query@example.com"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	issuer := server.URL + "/realms/test"
+	common.Config = &shared.Config{SsoIssuerUrl: &issuer, SsoClient: strPtr("client"), SsoSecret: strPtr("secret")}
+
+	common.Config.UserIdClaim = strPtr("sub")
+	email, err := NewSSOService().LookupEmail("uuid-1")
+	require.NoError(t, err)
+	assert.Equal(t, "sub@example.com", email)
+
+	common.Config.UserIdClaim = strPtr("preferred_username")
+	email, err = NewSSOService().LookupEmail("user1")
+	require.NoError(t, err)
+	assert.Equal(t, "query@example.com", email)
+
+	assert.Equal(t, []string{
+		"/admin/realms/test/users/uuid-1",
+		"/admin/realms/test/users?exact=true&username=user1",
+	}, requests)
+}
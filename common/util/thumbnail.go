@@ -5,14 +5,21 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
 	"time"
 
 	certificatemodel "github.com/sunthewhat/easy-cert-api/api/model/certificateModel"
 	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/storage"
 	"github.com/sunthewhat/easy-cert-api/internal/renderer"
 	"github.com/sunthewhat/easy-cert-api/type/shared/model"
 )
 
+// ThumbnailOnDesignChange reports whether autosaves that change the design refresh the thumbnail (thumbnail_on_design_change)
+func ThumbnailOnDesignChange() bool {
+	return common.Config != nil && common.Config.ThumbnailOnDesignChange != nil && *common.Config.ThumbnailOnDesignChange
+}
+
 func RenderCertificateThumbnail(certificate *model.Certificate) error {
 	slog.Info("Render Thumbnail starting embedded renderer", "cert_id", certificate.ID)
 
@@ -51,7 +58,7 @@ func RenderCertificateThumbnail(certificate *model.Certificate) error {
 	}
 
 	// Generate presigned URL for thumbnail access
-	thumbnailURL := embeddedRenderer.GenerateAccessibleURL(*common.Config.BucketCertificate, thumbnailPath)
+	thumbnailURL := embeddedRenderer.GenerateAccessibleURL(storage.PreviewBucket(), thumbnailPath)
 	certRepo := certificatemodel.NewCertificateRepository(common.Gorm)
 	err = certRepo.AddThumbnailUrl(certificate.ID, thumbnailURL)
 	if err != nil {
@@ -63,20 +70,95 @@ func RenderCertificateThumbnail(certificate *model.Certificate) error {
 	return nil
 }
 
-// RenderCertificateThumbnailAsync renders the certificate thumbnail in the background
-// This function does not block the calling goroutine and logs any errors that occur
-func RenderCertificateThumbnailAsync(certificate *model.Certificate) {
-	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				slog.Error("Panic occurred during background thumbnail rendering", "cert_id", certificate.ID, "panic", r)
-			}
-		}()
-
-		if err := RenderCertificateThumbnail(certificate); err != nil {
-			slog.Error("Background thumbnail rendering failed", "error", err, "cert_id", certificate.ID)
+// thumbnailQueueTimeout bounds how long a background thumbnail render waits for a generation slot
+const thumbnailQueueTimeout = 5 * time.Minute
+
+// thumbnailRenderer coalesces background thumbnail renders per certificate. While a render runs, later
+// requests only replace the certificate waiting to be rendered next, so at most one render per certificate
+// is in flight and the newest design is always rendered last.
+type thumbnailRenderer struct {
+	mu      sync.Mutex
+	running map[string]bool
+	next    map[string]*model.Certificate
+	render  func(certificate *model.Certificate)
+}
+
+var thumbnails = newThumbnailRenderer(renderLimitedThumbnail)
+
+func newThumbnailRenderer(render func(certificate *model.Certificate)) *thumbnailRenderer {
+	return &thumbnailRenderer{
+		running: make(map[string]bool),
+		next:    make(map[string]*model.Certificate),
+		render:  render,
+	}
+}
+
+// schedule starts rendering the certificate's thumbnail, or keeps it as the next render when one is already
+// running for the certificate. It reports whether a render was started.
+func (t *thumbnailRenderer) schedule(certificate *model.Certificate) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.running[certificate.ID] {
+		t.next[certificate.ID] = certificate
+		return false
+	}
+	t.running[certificate.ID] = true
+	go t.run(certificate)
+	return true
+}
+
+// run renders the certificate, then whatever was scheduled for it in the meantime, until nothing is left
+func (t *thumbnailRenderer) run(certificate *model.Certificate) {
+	for certificate != nil {
+		t.renderRecovered(certificate)
+
+		t.mu.Lock()
+		next := t.next[certificate.ID]
+		delete(t.next, certificate.ID)
+		if next == nil {
+			delete(t.running, certificate.ID)
+		}
+		t.mu.Unlock()
+
+		certificate = next
+	}
+}
+
+func (t *thumbnailRenderer) renderRecovered(certificate *model.Certificate) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Panic occurred during background thumbnail rendering", "cert_id", certificate.ID, "panic", r)
 		}
 	}()
+	t.render(certificate)
+}
+
+// renderLimitedThumbnail renders a thumbnail once the generation limiter grants a slot, so background renders
+// share the renderer capacity with certificate generation
+func renderLimitedThumbnail(certificate *model.Certificate) {
+	ctx, cancel := context.WithTimeout(context.Background(), thumbnailQueueTimeout)
+	defer cancel()
+
+	release, err := renderer.Generations().Acquire(ctx, "thumbnail:"+certificate.ID)
+	if err != nil {
+		slog.Warn("Background thumbnail rendering skipped, no generation slot", "error", err, "cert_id", certificate.ID)
+		return
+	}
+	defer release()
+
+	if err := RenderCertificateThumbnail(certificate); err != nil {
+		slog.Error("Background thumbnail rendering failed", "error", err, "cert_id", certificate.ID)
+	}
+}
 
-	slog.Info("Background thumbnail rendering started", "cert_id", certificate.ID)
+// RenderCertificateThumbnailAsync renders the certificate thumbnail in the background
+// This function does not block the calling goroutine and logs any errors that occur. Requests made while the
+// certificate's thumbnail is rendering are coalesced into one follow-up render of the newest design.
+func RenderCertificateThumbnailAsync(certificate *model.Certificate) {
+	if thumbnails.schedule(certificate) {
+		slog.Info("Background thumbnail rendering started", "cert_id", certificate.ID)
+		return
+	}
+	slog.Info("Background thumbnail rendering queued behind the running render", "cert_id", certificate.ID)
 }
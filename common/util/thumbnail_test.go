@@ -0,0 +1,60 @@
+package util
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+// TestThumbnailRenderer_CoalescesPerCertificate tests that renders requested while one runs collapse into
+// a single render of the newest design
+func TestThumbnailRenderer_CoalescesPerCertificate(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	done := make(chan struct{})
+
+	var mu sync.Mutex
+	var rendered []string
+	renders := 0
+	coalescer := newThumbnailRenderer(func(certificate *model.Certificate) {
+		mu.Lock()
+		rendered = append(rendered, certificate.Design)
+		renders++
+		count := renders
+		mu.Unlock()
+
+		switch count {
+		case 1:
+			close(started)
+			<-unblock
+		case 2:
+			close(done)
+		}
+	})
+
+	assert.True(t, coalescer.schedule(&model.Certificate{ID: "cert1", Design: "d1"}))
+	<-started
+
+	assert.False(t, coalescer.schedule(&model.Certificate{ID: "cert1", Design: "d2"}))
+	assert.False(t, coalescer.schedule(&model.Certificate{ID: "cert1", Design: "d3"}))
+	close(unblock)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("follow-up render did not run")
+	}
+
+	assert.Eventually(t, func() bool {
+		coalescer.mu.Lock()
+		defer coalescer.mu.Unlock()
+		return !coalescer.running["cert1"]
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"d1", "d3"}, rendered, "only the newest pending design is rendered")
+}
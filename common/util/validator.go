@@ -1,6 +1,9 @@
 package util
 
 import (
+	"reflect"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -8,6 +11,17 @@ var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+	validate.RegisterTagNameFunc(jsonFieldName)
+}
+
+// jsonFieldName reports fields by their JSON name so structured errors match the request body keys;
+// fields without a json tag keep their Go name
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
 }
 
 // ValidateStruct validates a struct using validator tags
@@ -15,24 +29,58 @@ func ValidateStruct(s any) error {
 	return validate.Struct(s)
 }
 
+// NormalizeEmail trims surrounding whitespace and lowercases an email address
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateEmail validates a single email address with the same rules as the email tag
+func ValidateEmail(email string) error {
+	return validate.Var(email, "required,email")
+}
+
+// ValidationFieldError is a single field-level validation failure returned to clients
+type ValidationFieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
 // GetValidationErrors formats validation errors into readable messages
 func GetValidationErrors(err error) []string {
 	var errors []string
+	for _, fieldError := range GetFieldValidationErrors(err) {
+		errors = append(errors, fieldError.Message)
+	}
+	return errors
+}
+
+// GetFieldValidationErrors returns one entry per failed field, keyed by the field's JSON name
+func GetFieldValidationErrors(err error) []ValidationFieldError {
+	var errors []ValidationFieldError
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
 		for _, fieldError := range validationErrors {
-			switch fieldError.Tag() {
-			case "required":
-				errors = append(errors, fieldError.Field()+" is required")
-			case "email":
-				errors = append(errors, fieldError.Field()+" must be a valid email")
-			case "min":
-				errors = append(errors, fieldError.Field()+" must be at least "+fieldError.Param()+" characters")
-			case "max":
-				errors = append(errors, fieldError.Field()+" must be at most "+fieldError.Param()+" characters")
-			default:
-				errors = append(errors, fieldError.Field()+" is invalid")
-			}
+			errors = append(errors, ValidationFieldError{
+				Field:   fieldError.Field(),
+				Tag:     fieldError.Tag(),
+				Message: validationMessage(fieldError),
+			})
 		}
 	}
 	return errors
-}
\ No newline at end of file
+}
+
+func validationMessage(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required":
+		return fieldError.StructField() + " is required"
+	case "email":
+		return fieldError.StructField() + " must be a valid email"
+	case "min":
+		return fieldError.StructField() + " must be at least " + fieldError.Param() + " characters"
+	case "max":
+		return fieldError.StructField() + " must be at most " + fieldError.Param() + " characters"
+	default:
+		return fieldError.StructField() + " is invalid"
+	}
+}
@@ -545,3 +545,43 @@ func TestValidationRealWorldScenario(t *testing.T) {
 		t.Logf("Validation error: %s", errMsg)
 	}
 }
+
+// TestGetFieldValidationErrors tests field-level errors keyed by JSON name
+func TestGetFieldValidationErrors(t *testing.T) {
+	type payload struct {
+		DisplayName string `json:"display_name" validate:"required"`
+		Email       string `json:"email,omitempty" validate:"required,email"`
+		Note        string `validate:"max=3"`
+	}
+
+	err := ValidateStruct(payload{Email: "not-an-email", Note: "too long"})
+	require.Error(t, err)
+
+	fieldErrors := GetFieldValidationErrors(err)
+	assert.Equal(t, []ValidationFieldError{
+		{Field: "display_name", Tag: "required", Message: "DisplayName is required"},
+		{Field: "email", Tag: "email", Message: "Email must be a valid email"},
+		{Field: "Note", Tag: "max", Message: "Note must be at most 3 characters"},
+	}, fieldErrors)
+
+	assert.Equal(t, []string{
+		"DisplayName is required",
+		"Email must be a valid email",
+		"Note must be at most 3 characters",
+	}, GetValidationErrors(err))
+
+	assert.Empty(t, GetFieldValidationErrors(nil))
+}
+
+// TestNormalizeEmail tests trimming and lowercasing of email addresses
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "alice@example.com", NormalizeEmail("  Alice@Example.COM \t"))
+	assert.Equal(t, "", NormalizeEmail("   "))
+}
+
+// TestValidateEmail tests single email validation
+func TestValidateEmail(t *testing.T) {
+	assert.NoError(t, ValidateEmail("alice@example.com"))
+	assert.Error(t, ValidateEmail("alice.example.com"))
+	assert.Error(t, ValidateEmail(""))
+}
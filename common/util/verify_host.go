@@ -0,0 +1,24 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared/model"
+)
+
+// ResolveVerifyHost returns the per-certificate verify host when set, otherwise the global verify_host
+func ResolveVerifyHost(verifyHost string) string {
+	if host := strings.TrimRight(strings.TrimSpace(verifyHost), "/"); host != "" {
+		return host
+	}
+	return *common.Config.VerifyHost
+}
+
+// CertificateVerifyHost returns the verify host used for a certificate's QR codes and signing links
+func CertificateVerifyHost(certificate *model.Certificate) string {
+	if certificate == nil {
+		return ResolveVerifyHost("")
+	}
+	return ResolveVerifyHost(certificate.VerifyHost)
+}
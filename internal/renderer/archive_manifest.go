@@ -0,0 +1,106 @@
+package renderer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const (
+	// ArchiveManifestEntry lists every file of the ZIP archive with its SHA-256 hash
+	ArchiveManifestEntry = "manifest.json"
+	// ArchiveManifestSignatureEntry is the detached signature of the manifest by the PDF signing key
+	ArchiveManifestSignatureEntry = "manifest.json.sig"
+	// ArchiveManifestCertificateEntry is the PEM certificate whose key signed the manifest
+	ArchiveManifestCertificateEntry = "manifest_signer.pem"
+
+	archiveManifestHashAlgorithm = "SHA-256"
+)
+
+// ArchiveManifestFile is one archived file; ParticipantID is empty for files that aren't a participant's certificate
+type ArchiveManifestFile struct {
+	ParticipantID string `json:"participant_id,omitempty"`
+	Filename      string `json:"filename"`
+	SHA256        string `json:"sha256"`
+	Size          int    `json:"size"`
+}
+
+// ArchiveManifest is written to the ZIP archive as manifest.json
+type ArchiveManifest struct {
+	CertificateID      string                `json:"certificate_id"`
+	GeneratedAt        time.Time             `json:"generated_at"`
+	HashAlgorithm      string                `json:"hash_algorithm"`
+	SignatureAlgorithm string                `json:"signature_algorithm,omitempty"`
+	Files              []ArchiveManifestFile `json:"files"`
+}
+
+// archiveIncludesManifest reports whether generated ZIP archives carry a signed manifest (archive_include_manifest)
+func archiveIncludesManifest() bool {
+	return common.Config != nil && common.Config.ArchiveIncludeManifest != nil && *common.Config.ArchiveIncludeManifest
+}
+
+// isArchiveSupplementEntry reports whether an archive entry is an optional extra rather than a participant's certificate
+func isArchiveSupplementEntry(name string) bool {
+	switch name {
+	case QRSheetArchiveEntry, ArchiveManifestEntry, ArchiveManifestSignatureEntry, ArchiveManifestCertificateEntry:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewArchiveManifestFile hashes one file as it is written to the archive
+func NewArchiveManifestFile(participantID string, filename string, data []byte) ArchiveManifestFile {
+	sum := sha256.Sum256(data)
+	return ArchiveManifestFile{
+		ParticipantID: participantID,
+		Filename:      filename,
+		SHA256:        hex.EncodeToString(sum[:]),
+		Size:          len(data),
+	}
+}
+
+// archiveManifestEntries builds manifest.json for files, sorted by filename, and when signer is enabled signs it
+// into manifest.json.sig alongside the signer's certificate. Without a working signer the manifest still lists
+// the hashes but is left unsigned.
+func archiveManifestEntries(certificateID string, files []ArchiveManifestFile, signer *CertificateSigner) (map[string][]byte, error) {
+	signed := signer != nil && signer.IsEnabled()
+
+	sorted := append([]ArchiveManifestFile{}, files...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Filename < sorted[j].Filename })
+
+	manifest := ArchiveManifest{
+		CertificateID: certificateID,
+		GeneratedAt:   time.Now().UTC(),
+		HashAlgorithm: archiveManifestHashAlgorithm,
+		Files:         sorted,
+	}
+	if signed {
+		manifest.SignatureAlgorithm = manifestSignatureAlgorithm
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode archive manifest: %w", err)
+	}
+
+	entries := map[string][]byte{ArchiveManifestEntry: manifestBytes}
+	if !signed {
+		slog.Warn("PDF signing is disabled, leaving the archive manifest unsigned", "cert_id", certificateID)
+		return entries, nil
+	}
+
+	signature, err := signer.SignDetached(manifestBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign archive manifest: %w", err)
+	}
+	entries[ArchiveManifestSignatureEntry] = signature
+	entries[ArchiveManifestCertificateEntry] = signer.CertificatePEM()
+	return entries, nil
+}
@@ -0,0 +1,125 @@
+package renderer
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func newTestCertificateSigner(t *testing.T) *CertificateSigner {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Easy Cert Test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+	return &CertificateSigner{certificate: certificate, privateKey: key, enabled: true}
+}
+
+func TestArchiveManifestEntries_Signed(t *testing.T) {
+	signer := newTestCertificateSigner(t)
+	files := []ArchiveManifestFile{
+		NewArchiveManifestFile("p2", "certificate_p2.pdf", []byte("second")),
+		NewArchiveManifestFile("p1", "certificate_p1.pdf", []byte("first")),
+	}
+
+	entries, err := archiveManifestEntries("cert-1", files, signer)
+	if err != nil {
+		t.Fatalf("archiveManifestEntries() error = %v", err)
+	}
+
+	var manifest ArchiveManifest
+	if err := json.Unmarshal(entries[ArchiveManifestEntry], &manifest); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	if manifest.CertificateID != "cert-1" || manifest.SignatureAlgorithm != manifestSignatureAlgorithm {
+		t.Errorf("manifest header = %+v", manifest)
+	}
+	if len(manifest.Files) != 2 || manifest.Files[0].Filename != "certificate_p1.pdf" {
+		t.Fatalf("manifest files = %+v, want sorted by filename", manifest.Files)
+	}
+	firstSum := sha256.Sum256([]byte("first"))
+	if manifest.Files[0].SHA256 != hex.EncodeToString(firstSum[:]) || manifest.Files[0].ParticipantID != "p1" || manifest.Files[0].Size != 5 {
+		t.Errorf("first file = %+v", manifest.Files[0])
+	}
+
+	block, _ := pem.Decode(entries[ArchiveManifestCertificateEntry])
+	if block == nil {
+		t.Fatal("signer certificate is not PEM encoded")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+
+	digest := sha256.Sum256(entries[ArchiveManifestEntry])
+	publicKey := certificate.PublicKey.(*rsa.PublicKey)
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], entries[ArchiveManifestSignatureEntry]); err != nil {
+		t.Errorf("manifest signature does not verify: %v", err)
+	}
+
+	tampered := append([]byte{}, entries[ArchiveManifestEntry]...)
+	tampered[len(tampered)-2] ^= 1
+	tamperedDigest := sha256.Sum256(tampered)
+	if rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, tamperedDigest[:], entries[ArchiveManifestSignatureEntry]) == nil {
+		t.Error("signature verified a tampered manifest")
+	}
+}
+
+func TestArchiveManifestEntries_Unsigned(t *testing.T) {
+	for name, signer := range map[string]*CertificateSigner{
+		"no signer":       nil,
+		"disabled signer": {enabled: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			entries, err := archiveManifestEntries("cert-1", nil, signer)
+			if err != nil {
+				t.Fatalf("archiveManifestEntries() error = %v", err)
+			}
+			if len(entries) != 1 || entries[ArchiveManifestEntry] == nil {
+				t.Errorf("entries = %v, want only the manifest", entries)
+			}
+
+			var manifest ArchiveManifest
+			if err := json.Unmarshal(entries[ArchiveManifestEntry], &manifest); err != nil {
+				t.Fatalf("manifest is not valid JSON: %v", err)
+			}
+			if manifest.SignatureAlgorithm != "" {
+				t.Errorf("unsigned manifest names signature algorithm %q", manifest.SignatureAlgorithm)
+			}
+		})
+	}
+}
+
+func TestSignDetached_Disabled(t *testing.T) {
+	signer := &CertificateSigner{enabled: false}
+	if _, err := signer.SignDetached([]byte("data")); err == nil {
+		t.Error("expected an error when signing is disabled")
+	}
+	if signer.CertificatePEM() != nil {
+		t.Error("expected no certificate when signing is disabled")
+	}
+}
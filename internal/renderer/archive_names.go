@@ -0,0 +1,132 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// maxArchiveFilenameLength keeps ZIP entry names within common filesystem limits
+const maxArchiveFilenameLength = 150
+
+var archiveTemplatePlaceholder = regexp.MustCompile(`{{\s*([^{}]+?)\s*}}`)
+
+// ValidateArchiveFilenameTemplate rejects templates that cannot produce a filename
+func ValidateArchiveFilenameTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+	if strings.Count(template, "{{") != strings.Count(template, "}}") {
+		return fmt.Errorf("unbalanced placeholder braces in filename template")
+	}
+	if sanitizeArchiveFilename(archiveTemplatePlaceholder.ReplaceAllString(template, "x")) == "" {
+		return fmt.Errorf("filename template does not produce a usable filename")
+	}
+	return nil
+}
+
+// archiveFilename resolves a template like "{{name}}-certificate.pdf" against participant fields.
+// Missing or empty fields resolve to the participant ID, and an empty template keeps the
+// historical certificate_<participantId>.pdf name.
+func archiveFilename(template string, fields map[string]any, participantID string) string {
+	if template == "" {
+		return fmt.Sprintf("certificate_%s.pdf", participantID)
+	}
+
+	resolved := archiveTemplatePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		key := archiveTemplatePlaceholder.FindStringSubmatch(placeholder)[1]
+		value, ok := fields[key]
+		if !ok || value == nil {
+			return participantID
+		}
+		text := strings.TrimSpace(fmt.Sprint(value))
+		if text == "" {
+			return participantID
+		}
+		return text
+	})
+
+	name := sanitizeArchiveFilename(strings.TrimSuffix(resolved, ".pdf"))
+	if name == "" {
+		name = participantID
+	}
+	return name + ".pdf"
+}
+
+// sanitizeArchiveFilename removes path separators, reserved and control characters so
+// the name is safe to extract on Windows, macOS and Linux
+func sanitizeArchiveFilename(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case strings.ContainsRune(`/\:*?"<>|`, r):
+			b.WriteRune('_')
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		case unicode.IsControl(r):
+			continue
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	cleaned := strings.Join(strings.Fields(b.String()), " ")
+	cleaned = strings.Trim(cleaned, " .")
+
+	if runes := []rune(cleaned); len(runes) > maxArchiveFilenameLength {
+		cleaned = strings.TrimRight(string(runes[:maxArchiveFilenameLength]), " .")
+	}
+	return cleaned
+}
+
+// participantFields flattens a participant (struct or map) into its top-level fields overlaid with its
+// dynamic "data" fields, which is where the anchor values live. A data field named "id" never replaces the
+// participant ID.
+func participantFields(p any) map[string]any {
+	fields := make(map[string]any)
+
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return fields
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return fields
+	}
+
+	if data, ok := fields["data"].(map[string]any); ok {
+		participantID, hasID := fields["id"]
+		for key, value := range data {
+			fields[key] = value
+		}
+		if hasID {
+			fields["id"] = participantID
+		}
+	}
+	return fields
+}
+
+// ArchiveEntryNames maps every participant ID to a unique ZIP entry name from the template.
+// Colliding names get a " (2)", " (3)", ... suffix in participant order.
+func ArchiveEntryNames(template string, participants []any) map[string]string {
+	names := make(map[string]string, len(participants))
+	used := make(map[string]bool, len(participants))
+
+	for _, p := range participants {
+		fields := participantFields(p)
+		participantID, _ := fields["id"].(string)
+		if participantID == "" {
+			continue
+		}
+
+		name := archiveFilename(template, fields, participantID)
+		base := strings.TrimSuffix(name, ".pdf")
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			name = fmt.Sprintf("%s (%d).pdf", base, n)
+		}
+		used[strings.ToLower(name)] = true
+		names[participantID] = name
+	}
+	return names
+}
@@ -0,0 +1,89 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+)
+
+type archiveTestParticipant struct {
+	ID          string         `json:"id"`
+	DynamicData map[string]any `json:"data"`
+}
+
+func TestArchiveFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		fields   map[string]any
+		want     string
+	}{
+		{name: "default template", template: "", fields: nil, want: "certificate_p1.pdf"},
+		{name: "field resolved", template: "{{name}}-certificate.pdf", fields: map[string]any{"name": "Alice Smith"}, want: "Alice Smith-certificate.pdf"},
+		{name: "pdf suffix added", template: "{{ name }}", fields: map[string]any{"name": "Alice"}, want: "Alice.pdf"},
+		{name: "missing field uses participant id", template: "{{nickname}}-certificate.pdf", fields: map[string]any{"name": "Alice"}, want: "p1-certificate.pdf"},
+		{name: "empty field uses participant id", template: "{{name}}.pdf", fields: map[string]any{"name": "  "}, want: "p1.pdf"},
+		{name: "unsafe characters replaced", template: "{{name}}.pdf", fields: map[string]any{"name": "../etc/pass:wd"}, want: "_etc_pass_wd.pdf"},
+		{name: "numeric field", template: "{{year}}-{{name}}", fields: map[string]any{"year": float64(2026), "name": "Bob"}, want: "2026-Bob.pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := archiveFilename(tt.template, tt.fields, "p1"); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSanitizeArchiveFilename(t *testing.T) {
+	if got := sanitizeArchiveFilename("  a\tb\x00c  "); got != "a bc" {
+		t.Errorf("expected %q, got %q", "a bc", got)
+	}
+	if got := sanitizeArchiveFilename(strings.Repeat("x", 300)); len(got) != maxArchiveFilenameLength {
+		t.Errorf("expected length %d, got %d", maxArchiveFilenameLength, len(got))
+	}
+}
+
+func TestBuildArchiveEntryNames(t *testing.T) {
+	participants := []any{
+		&archiveTestParticipant{ID: "p1", DynamicData: map[string]any{"name": "Alice"}},
+		&archiveTestParticipant{ID: "p2", DynamicData: map[string]any{"name": "alice"}},
+		map[string]any{"id": "p3", "name": "Alice"},
+		&archiveTestParticipant{ID: "p4", DynamicData: map[string]any{}},
+		&archiveTestParticipant{ID: "p5", DynamicData: map[string]any{"id": "S-100", "name": "Carol"}},
+	}
+
+	names := ArchiveEntryNames("{{name}}", participants)
+
+	want := map[string]string{
+		"p1": "Alice.pdf",
+		"p2": "alice (2).pdf",
+		"p3": "Alice (3).pdf",
+		"p4": "p4.pdf",
+		"p5": "Carol.pdf",
+	}
+	if _, ok := names["S-100"]; ok {
+		t.Errorf("data field id replaced the participant ID: %v", names)
+	}
+	for id, expected := range want {
+		if names[id] != expected {
+			t.Errorf("participant %s: expected %q, got %q", id, expected, names[id])
+		}
+	}
+}
+
+func TestValidateArchiveFilenameTemplate(t *testing.T) {
+	valid := []string{"", "{{name}}.pdf", "certificate-{{ id }}"}
+	for _, template := range valid {
+		if err := ValidateArchiveFilenameTemplate(template); err != nil {
+			t.Errorf("expected %q to be valid, got %v", template, err)
+		}
+	}
+
+	invalid := []string{"{{name}.pdf", "...", " . "}
+	for _, template := range invalid {
+		if err := ValidateArchiveFilenameTemplate(template); err == nil {
+			t.Errorf("expected %q to be rejected", template)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package renderer
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ListArchiveEntries returns the names of the files in a ZIP archive, sorted
+func ListArchiveEntries(archive io.ReaderAt, size int64) ([]string, error) {
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, fmt.Errorf("archive is not a readable ZIP file: %w", err)
+	}
+
+	names := make([]string, 0, len(reader.File))
+	for _, file := range reader.File {
+		if !file.FileInfo().IsDir() {
+			names = append(names, file.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// MatchArchiveEntries finds the archive entry of each participant from the participant ID in its name, which
+// is there for the default certificate_<participantId>.pdf names and for templates whose fields were missing.
+// Entries named purely from participant data can't be matched without that data and are returned as unmatched.
+func MatchArchiveEntries(entries []string, participantIDs []string) (map[string]string, []string) {
+	matched := make(map[string]string, len(participantIDs))
+	used := make(map[string]bool, len(entries))
+
+	// The exact default name wins over an ID that merely appears in another entry's name
+	for _, participantID := range participantIDs {
+		name := fmt.Sprintf("certificate_%s.pdf", participantID)
+		for _, entry := range entries {
+			if entry == name && !used[entry] {
+				matched[participantID] = entry
+				used[entry] = true
+				break
+			}
+		}
+	}
+
+	for _, participantID := range participantIDs {
+		if _, ok := matched[participantID]; ok || participantID == "" {
+			continue
+		}
+		for _, entry := range entries {
+			if !used[entry] && strings.Contains(entry, participantID) {
+				matched[participantID] = entry
+				used[entry] = true
+				break
+			}
+		}
+	}
+
+	unmatched := []string{}
+	for _, entry := range entries {
+		if !used[entry] && !isArchiveSupplementEntry(entry) {
+			unmatched = append(unmatched, entry)
+		}
+	}
+	return matched, unmatched
+}
@@ -0,0 +1,49 @@
+package renderer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListArchiveEntries(t *testing.T) {
+	archive := buildTestArchive(t, map[string][]byte{
+		"b.pdf": []byte("b"),
+		"a.pdf": []byte("a"),
+	})
+
+	entries, err := ListArchiveEntries(archive, archive.Size())
+	if err != nil {
+		t.Fatalf("ListArchiveEntries() error = %v", err)
+	}
+	if !reflect.DeepEqual(entries, []string{"a.pdf", "b.pdf"}) {
+		t.Errorf("ListArchiveEntries() = %v", entries)
+	}
+
+	if _, err := ListArchiveEntries(buildTestArchive(t, nil), 3); err == nil {
+		t.Error("expected an error for a truncated archive")
+	}
+}
+
+func TestMatchArchiveEntries(t *testing.T) {
+	entries := []string{
+		"Alice.pdf",
+		"certificate_p1.pdf",
+		"Bob (p2).pdf",
+		"certificate_p10.pdf",
+		QRSheetArchiveEntry,
+	}
+
+	matched, unmatched := MatchArchiveEntries(entries, []string{"p1", "p2", "p3", "p10"})
+
+	want := map[string]string{
+		"p1":  "certificate_p1.pdf",
+		"p2":  "Bob (p2).pdf",
+		"p10": "certificate_p10.pdf",
+	}
+	if !reflect.DeepEqual(matched, want) {
+		t.Errorf("matched = %v, want %v", matched, want)
+	}
+	if !reflect.DeepEqual(unmatched, []string{"Alice.pdf"}) {
+		t.Errorf("unmatched = %v, want [Alice.pdf]", unmatched)
+	}
+}
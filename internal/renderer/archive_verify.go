@@ -0,0 +1,111 @@
+package renderer
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ArchiveEntryError describes an archive entry that exists but cannot be read back
+type ArchiveEntryError struct {
+	Name          string `json:"name"`
+	ParticipantID string `json:"participant_id"`
+	Error         string `json:"error"`
+}
+
+// ArchiveVerification reports how a stored archive compares to the participants it should contain
+type ArchiveVerification struct {
+	Valid         bool                `json:"valid"`
+	ExpectedCount int                 `json:"expected_count"`
+	EntryCount    int                 `json:"entry_count"`
+	Missing       []string            `json:"missing"`
+	Unreadable    []ArchiveEntryError `json:"unreadable"`
+	Unexpected    []string            `json:"unexpected"`
+}
+
+// VerifyArchive opens a ZIP archive and checks it holds a readable PDF for every expected participant.
+// expected maps participant IDs to their entry name; the legacy certificate_<participantId>.pdf name is
+// accepted too so archives built before a filename template change still verify.
+func VerifyArchive(archive io.ReaderAt, size int64, expected map[string]string) (*ArchiveVerification, error) {
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, fmt.Errorf("archive is not a readable ZIP file: %w", err)
+	}
+
+	entries := make(map[string]*zip.File, len(reader.File))
+	for _, file := range reader.File {
+		if !file.FileInfo().IsDir() {
+			entries[file.Name] = file
+		}
+	}
+
+	result := &ArchiveVerification{
+		ExpectedCount: len(expected),
+		EntryCount:    len(entries),
+		Missing:       []string{},
+		Unreadable:    []ArchiveEntryError{},
+		Unexpected:    []string{},
+	}
+
+	matched := make(map[string]bool, len(expected))
+	for participantID, name := range expected {
+		file, ok := entries[name]
+		if !ok {
+			name = fmt.Sprintf("certificate_%s.pdf", participantID)
+			file, ok = entries[name]
+		}
+		if !ok {
+			result.Missing = append(result.Missing, participantID)
+			continue
+		}
+
+		matched[name] = true
+		if err := checkArchiveEntry(file); err != nil {
+			result.Unreadable = append(result.Unreadable, ArchiveEntryError{
+				Name:          name,
+				ParticipantID: participantID,
+				Error:         err.Error(),
+			})
+		}
+	}
+
+	for name := range entries {
+		// The optional QR codes sheet and manifest are not participant certificates
+		if !matched[name] && !isArchiveSupplementEntry(name) {
+			result.Unexpected = append(result.Unexpected, name)
+		}
+	}
+
+	sort.Strings(result.Missing)
+	sort.Strings(result.Unexpected)
+	sort.Slice(result.Unreadable, func(i, j int) bool {
+		return result.Unreadable[i].Name < result.Unreadable[j].Name
+	})
+
+	result.Valid = len(result.Missing) == 0 && len(result.Unreadable) == 0
+	return result, nil
+}
+
+// checkArchiveEntry reads an entry to the end, which validates its checksum, and checks it is a PDF
+func checkArchiveEntry(file *zip.File) error {
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return fmt.Errorf("entry is not a PDF document")
+	}
+	if !strings.Contains(string(data[max(0, len(data)-1024):]), "%%EOF") {
+		return fmt.Errorf("PDF document is truncated")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package renderer
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func buildTestArchive(t *testing.T, entries map[string][]byte) *bytes.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, data := range entries {
+		w, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create entry: %v", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatalf("failed to write entry: %v", err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestVerifyArchive(t *testing.T) {
+	pdf := []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n%%EOF\n")
+
+	archive := buildTestArchive(t, map[string][]byte{
+		"Alice.pdf":          pdf,
+		"certificate_p2.pdf": pdf,
+		"Carol.pdf":          []byte("not a pdf"),
+		"stray.pdf":          pdf,
+	})
+
+	expected := map[string]string{
+		"p1": "Alice.pdf",
+		"p2": "Bob.pdf",
+		"p3": "Carol.pdf",
+		"p4": "Dave.pdf",
+	}
+
+	result, err := VerifyArchive(archive, archive.Size(), expected)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Valid {
+		t.Error("expected archive to be invalid")
+	}
+	if result.ExpectedCount != 4 || result.EntryCount != 4 {
+		t.Errorf("expected 4 expected and 4 entries, got %d and %d", result.ExpectedCount, result.EntryCount)
+	}
+	if len(result.Missing) != 1 || result.Missing[0] != "p4" {
+		t.Errorf("expected p4 missing, got %v", result.Missing)
+	}
+	if len(result.Unreadable) != 1 || result.Unreadable[0].ParticipantID != "p3" {
+		t.Errorf("expected p3 unreadable, got %v", result.Unreadable)
+	}
+	if len(result.Unexpected) != 1 || result.Unexpected[0] != "stray.pdf" {
+		t.Errorf("expected stray.pdf unexpected, got %v", result.Unexpected)
+	}
+}
+
+func TestVerifyArchive_Valid(t *testing.T) {
+	archive := buildTestArchive(t, map[string][]byte{
+		"certificate_p1.pdf": []byte("%PDF-1.7\n%%EOF"),
+	})
+
+	result, err := VerifyArchive(archive, archive.Size(), map[string]string{"p1": "certificate_p1.pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("expected archive to be valid, got %+v", result)
+	}
+}
+
+func TestVerifyArchive_Corrupt(t *testing.T) {
+	data := []byte("definitely not a zip file")
+	if _, err := VerifyArchive(bytes.NewReader(data), int64(len(data)), nil); err == nil {
+		t.Error("expected error for corrupt archive")
+	}
+}
+
+func TestVerifyArchiveIgnoresQRSheet(t *testing.T) {
+	pdf := []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n%%EOF\n")
+	archive := buildTestArchive(t, map[string][]byte{
+		"Alice.pdf":         pdf,
+		QRSheetArchiveEntry: pdf,
+	})
+
+	result, err := VerifyArchive(archive, archive.Size(), map[string]string{"p1": "Alice.pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Valid || len(result.Unexpected) != 0 {
+		t.Errorf("expected the QR codes sheet to be accepted, got valid=%v unexpected=%v", result.Valid, result.Unexpected)
+	}
+}
@@ -0,0 +1,89 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+var (
+	ErrRenderingDisabled   = errors.New("certificate rendering is disabled on this server")
+	ErrRendererUnavailable = errors.New("certificate renderer is unavailable")
+)
+
+// Pre-installed renderer directories, tried in order: the Docker image, then a local checkout
+const (
+	dockerRendererDir = "/root/internal/renderer"
+	localRendererDir  = "internal/renderer"
+)
+
+// RendererCapability describes whether this server can render certificates. FallbackInstall means no
+// pre-installed renderer was found, so the first render installs its dependencies into a temporary directory.
+type RendererCapability struct {
+	Available       bool   `json:"available"`
+	Disabled        bool   `json:"disabled"`
+	Binary          string `json:"binary,omitempty"`
+	RendererDir     string `json:"renderer_dir,omitempty"`
+	FallbackInstall bool   `json:"fallback_install"`
+	Error           string `json:"error,omitempty"`
+}
+
+// renderingDisabled reports whether rendering is switched off through rendering_disabled
+func renderingDisabled() bool {
+	return common.Config != nil && common.Config.RenderingDisabled != nil && *common.Config.RenderingDisabled
+}
+
+// preinstalledRendererDir returns the first renderer directory with its dependencies installed, or ""
+func preinstalledRendererDir() string {
+	for _, dir := range []string{dockerRendererDir, localRendererDir} {
+		if _, err := os.Stat(filepath.Join(dir, "node_modules")); err == nil {
+			return dir
+		}
+	}
+	return ""
+}
+
+// CheckRendererCapability checks the renderer runtime and configuration without starting it, so a missing
+// binary shows up at startup and in readiness rather than as a subprocess error mid-generation
+func CheckRendererCapability() RendererCapability {
+	if renderingDisabled() {
+		return RendererCapability{Disabled: true, Error: ErrRenderingDisabled.Error()}
+	}
+
+	binary, err := resolveRendererBinary()
+	if err != nil {
+		return RendererCapability{Error: err.Error()}
+	}
+	if _, err := resolveDefaultFont(); err != nil {
+		return RendererCapability{Binary: binary, Error: err.Error()}
+	}
+
+	dir := preinstalledRendererDir()
+	return RendererCapability{
+		Available:       true,
+		Binary:          binary,
+		RendererDir:     dir,
+		FallbackInstall: dir == "",
+	}
+}
+
+// RenderingAvailable returns ErrRenderingDisabled or ErrRendererUnavailable when certificates can't be rendered
+func RenderingAvailable() error {
+	capability := CheckRendererCapability()
+	switch {
+	case capability.Disabled:
+		return ErrRenderingDisabled
+	case !capability.Available:
+		return fmt.Errorf("%w: %s", ErrRendererUnavailable, capability.Error)
+	default:
+		return nil
+	}
+}
+
+// IsRendererUnavailable reports whether err means rendering is disabled or the renderer can't run
+func IsRendererUnavailable(err error) bool {
+	return errors.Is(err, ErrRenderingDisabled) || errors.Is(err, ErrRendererUnavailable)
+}
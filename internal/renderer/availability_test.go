@@ -0,0 +1,73 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestCheckRendererCapability(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	disabled := true
+	common.Config = &shared.Config{RenderingDisabled: &disabled}
+	capability := CheckRendererCapability()
+	if !capability.Disabled || capability.Available {
+		t.Errorf("disabled capability = %+v", capability)
+	}
+	if err := RenderingAvailable(); !errors.Is(err, ErrRenderingDisabled) {
+		t.Errorf("RenderingAvailable() = %v, want ErrRenderingDisabled", err)
+	}
+	if _, err := NewEmbeddedRenderer(); !errors.Is(err, ErrRenderingDisabled) {
+		t.Errorf("NewEmbeddedRenderer() = %v, want ErrRenderingDisabled", err)
+	}
+
+	missing := "easy-cert-missing-renderer-binary"
+	common.Config = &shared.Config{RendererBinary: &missing}
+	capability = CheckRendererCapability()
+	if capability.Available || capability.Disabled || capability.Error == "" {
+		t.Errorf("missing binary capability = %+v", capability)
+	}
+	if err := RenderingAvailable(); !errors.Is(err, ErrRendererUnavailable) {
+		t.Errorf("RenderingAvailable() = %v, want ErrRendererUnavailable", err)
+	}
+	if _, err := NewEmbeddedRenderer(); !errors.Is(err, ErrRendererUnavailable) {
+		t.Errorf("NewEmbeddedRenderer() = %v, want ErrRendererUnavailable", err)
+	}
+
+	shell := "sh"
+	badFont := "Comic Sans"
+	common.Config = &shared.Config{RendererBinary: &shell, RendererDefaultFont: &badFont}
+	capability = CheckRendererCapability()
+	if capability.Available || capability.Binary == "" {
+		t.Errorf("unsupported font capability = %+v", capability)
+	}
+
+	common.Config = &shared.Config{RendererBinary: &shell}
+	capability = CheckRendererCapability()
+	if !capability.Available || capability.Error != "" {
+		t.Errorf("available capability = %+v", capability)
+	}
+	if capability.FallbackInstall != (capability.RendererDir == "") {
+		t.Errorf("fallback install %v with renderer dir %q", capability.FallbackInstall, capability.RendererDir)
+	}
+	if err := RenderingAvailable(); err != nil {
+		t.Errorf("RenderingAvailable() = %v, want nil", err)
+	}
+}
+
+func TestIsRendererUnavailable(t *testing.T) {
+	if !IsRendererUnavailable(fmt.Errorf("failed to initialize renderer: %w", ErrRenderingDisabled)) {
+		t.Error("expected a wrapped ErrRenderingDisabled to be unavailable")
+	}
+	if !IsRendererUnavailable(fmt.Errorf("%w: bun not found", ErrRendererUnavailable)) {
+		t.Error("expected ErrRendererUnavailable to be unavailable")
+	}
+	if IsRendererUnavailable(errors.New("render failed")) {
+		t.Error("expected other errors not to be unavailable")
+	}
+}
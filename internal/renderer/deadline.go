@@ -0,0 +1,38 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const defaultGenerationDeadline = 120 * time.Second
+
+// ErrGenerationDeadlineExceeded is returned when a generation runs out of its overall time budget.
+// Results returned alongside it hold the certificates finished before the deadline.
+var ErrGenerationDeadlineExceeded = errors.New("generation deadline exceeded")
+
+// GenerationDeadline returns the overall time budget of a certificate generation (generation_deadline_seconds)
+func GenerationDeadline() time.Duration {
+	if common.Config != nil && common.Config.GenerationDeadlineSecs != nil && *common.Config.GenerationDeadlineSecs > 0 {
+		return time.Duration(*common.Config.GenerationDeadlineSecs) * time.Second
+	}
+	return defaultGenerationDeadline
+}
+
+// NewGenerationContext derives the context a whole generation runs under, bounded by GenerationDeadline
+func NewGenerationContext(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, GenerationDeadline())
+}
+
+// generationDeadlineError reports ErrGenerationDeadlineExceeded when ctx has expired, so a stage failing because
+// its context was cancelled is told apart from a genuine failure; otherwise it returns nil
+func generationDeadlineError(ctx context.Context, stage string) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	return fmt.Errorf("%w while %s", ErrGenerationDeadlineExceeded, stage)
+}
@@ -0,0 +1,58 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestGenerationDeadline(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	common.Config = &shared.Config{}
+	if got := GenerationDeadline(); got != defaultGenerationDeadline {
+		t.Errorf("GenerationDeadline() = %v, want default %v", got, defaultGenerationDeadline)
+	}
+
+	seconds := 900
+	common.Config = &shared.Config{GenerationDeadlineSecs: &seconds}
+	if got := GenerationDeadline(); got != 15*time.Minute {
+		t.Errorf("GenerationDeadline() = %v, want 15m", got)
+	}
+}
+
+func TestGenerationDeadlineError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := generationDeadlineError(ctx, "rendering"); err != nil {
+		t.Fatalf("generationDeadlineError() = %v for a live context", err)
+	}
+
+	cancel()
+	err := generationDeadlineError(ctx, "rendering")
+	if !errors.Is(err, ErrGenerationDeadlineExceeded) {
+		t.Fatalf("generationDeadlineError() = %v, want ErrGenerationDeadlineExceeded", err)
+	}
+	if err.Error() != "generation deadline exceeded while rendering" {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+}
+
+func TestRenderAndUploadCertificatesDeadline(t *testing.T) {
+	r := fakeRenderer(t, `exec sleep 5`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	results, err := r.RenderAndUploadCertificates(ctx, map[string]any{"id": "cert-1", "verify_host": "https://verify.example.com"}, []any{map[string]any{"id": "p1"}}, nil)
+	if !errors.Is(err, ErrGenerationDeadlineExceeded) {
+		t.Fatalf("RenderAndUploadCertificates() error = %v, want ErrGenerationDeadlineExceeded", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no results when rendering itself ran out of time, got %d", len(results))
+	}
+}
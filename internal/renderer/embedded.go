@@ -8,6 +8,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"image"
+	_ "image/png"
 	"io"
 	"log/slog"
 	"os"
@@ -15,6 +17,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +27,7 @@ import (
 	"github.com/minio/minio-go/v7"
 	"github.com/skip2/go-qrcode"
 	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/common/storage"
 )
 
 //go:embed renderer.ts
@@ -41,6 +45,7 @@ type RenderRequest struct {
 	QRCodes      map[string]string `json:"qrCodes,omitempty"`
 	Signatures   map[string]string `json:"signatures,omitempty"`
 	Watermark    string            `json:"watermark,omitempty"`
+	DefaultFont  string            `json:"defaultFont,omitempty"`
 }
 
 type ThumbnailRequest struct {
@@ -65,16 +70,81 @@ type CertificateResult struct {
 	ParticipantID string `json:"participantId"`
 	FilePath      string `json:"filePath"`
 	Status        string `json:"status"`
+	Signed        bool   `json:"signed"`
 	Error         string `json:"error,omitempty"`
 }
 
 type EmbeddedRenderer struct {
 	rendererDir string
-	minIO       *minio.Client
+	binary      string
+	defaultFont string
 	signer      *CertificateSigner
 }
 
+const defaultRendererBinary = "bun"
+
+// resolveRendererBinary looks up the configured renderer runtime (default "bun")
+// so a missing binary fails at construction instead of at first render
+func resolveRendererBinary() (string, error) {
+	binary := defaultRendererBinary
+	if common.Config != nil && common.Config.RendererBinary != nil && *common.Config.RendererBinary != "" {
+		binary = *common.Config.RendererBinary
+	}
+
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return "", fmt.Errorf("renderer binary %q not found: %w", binary, err)
+	}
+
+	return path, nil
+}
+
+const defaultRendererFont = "Arial"
+
+// SupportedRendererFonts are the font families installed in the renderer image
+// that may be used as the default font for text without a usable family
+var SupportedRendererFonts = []string{
+	"Arial",
+	"DejaVu Sans",
+	"DejaVu Serif",
+	"Droid Sans",
+	"FreeSans",
+	"FreeSerif",
+	"Liberation Sans",
+	"Liberation Serif",
+	"Noto Sans Thai",
+}
+
+// resolveDefaultFont returns the configured renderer default font, rejecting fonts
+// that are not installed so text is never silently rendered with missing glyphs
+func resolveDefaultFont() (string, error) {
+	if common.Config == nil || common.Config.RendererDefaultFont == nil || *common.Config.RendererDefaultFont == "" {
+		return defaultRendererFont, nil
+	}
+
+	font := *common.Config.RendererDefaultFont
+	if !slices.Contains(SupportedRendererFonts, font) {
+		return "", fmt.Errorf("renderer default font %q is not supported, use one of: %s", font, strings.Join(SupportedRendererFonts, ", "))
+	}
+
+	return font, nil
+}
+
 func NewEmbeddedRenderer() (*EmbeddedRenderer, error) {
+	if renderingDisabled() {
+		return nil, ErrRenderingDisabled
+	}
+
+	binary, err := resolveRendererBinary()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRendererUnavailable, err)
+	}
+
+	defaultFont, err := resolveDefaultFont()
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize PDF signer
 	signer, err := NewCertificateSigner()
 	if err != nil {
@@ -82,34 +152,15 @@ func NewEmbeddedRenderer() (*EmbeddedRenderer, error) {
 		signer = &CertificateSigner{enabled: false}
 	}
 
-	// Try Docker pre-installed path first
-	dockerRendererDir := "/root/internal/renderer"
-	if _, err := os.Stat(dockerRendererDir); err == nil {
-		// Verify node_modules exists
-		nodeModulesPath := filepath.Join(dockerRendererDir, "node_modules")
-		if _, err := os.Stat(nodeModulesPath); err == nil {
-			slog.Info("Using Docker pre-installed embedded renderer", "renderer_dir", dockerRendererDir)
-			return &EmbeddedRenderer{
-				rendererDir: dockerRendererDir,
-				minIO:       common.MinIOClient,
-				signer:      signer,
-			}, nil
-		}
-	}
-
-	// Try local development pre-installed path
-	localRendererDir := "internal/renderer"
-	if _, err := os.Stat(localRendererDir); err == nil {
-		// Verify node_modules exists
-		nodeModulesPath := filepath.Join(localRendererDir, "node_modules")
-		if _, err := os.Stat(nodeModulesPath); err == nil {
-			slog.Info("Using local pre-installed embedded renderer", "renderer_dir", localRendererDir)
-			return &EmbeddedRenderer{
-				rendererDir: localRendererDir,
-				minIO:       common.MinIOClient,
-				signer:      signer,
-			}, nil
-		}
+	// Use the Docker or local development pre-installed renderer when there is one
+	if rendererDir := preinstalledRendererDir(); rendererDir != "" {
+		slog.Info("Using pre-installed embedded renderer", "renderer_dir", rendererDir)
+		return &EmbeddedRenderer{
+			rendererDir: rendererDir,
+			binary:      binary,
+			defaultFont: defaultFont,
+			signer:      signer,
+		}, nil
 	}
 
 	// Final fallback - create temp directory and install fresh dependencies
@@ -134,18 +185,19 @@ func NewEmbeddedRenderer() (*EmbeddedRenderer, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 	defer cancel()
 
-	installCmd := exec.CommandContext(ctx, "bun", "install")
+	installCmd := exec.CommandContext(ctx, binary, "install")
 	installCmd.Dir = tempDir
 	if err := installCmd.Run(); err != nil {
 		os.RemoveAll(tempDir)
-		return nil, fmt.Errorf("failed to install Bun dependencies: %w", err)
+		return nil, fmt.Errorf("%w: failed to install Bun dependencies: %w", ErrRendererUnavailable, err)
 	}
 
 	slog.Info("Fallback embedded renderer initialized", "temp_dir", tempDir)
 
 	return &EmbeddedRenderer{
 		rendererDir: tempDir,
-		minIO:       common.MinIOClient,
+		binary:      binary,
+		defaultFont: defaultFont,
 		signer:      signer,
 	}, nil
 }
@@ -175,7 +227,7 @@ type QRJob struct {
 
 func (r *EmbeddedRenderer) Close() {
 	// Only cleanup if using temporary directory (fallback mode)
-	if r.rendererDir != "/root/internal/renderer" && r.rendererDir != "internal/renderer" && r.rendererDir != "" {
+	if r.rendererDir != dockerRendererDir && r.rendererDir != localRendererDir && r.rendererDir != "" {
 		// This is a temporary directory, safe to remove
 		os.RemoveAll(r.rendererDir)
 		slog.Info("Embedded renderer cleaned up", "temp_dir", r.rendererDir)
@@ -247,8 +299,23 @@ func (r *EmbeddedRenderer) generateSingleQR(job QRJob) QRResult {
 	}
 }
 
-// GenerateQRCodes generates QR codes for all participants in parallel
-func (r *EmbeddedRenderer) GenerateQRCodes(participants []any, certificateID string) map[string]string {
+// certificateVerifyHost returns the verify host carried in the certificate map, or the global verify_host
+func certificateVerifyHost(certificate any) string {
+	if certMap, ok := certificate.(map[string]any); ok {
+		if host, _ := certMap["verify_host"].(string); host != "" {
+			return host
+		}
+	}
+	return *common.Config.VerifyHost
+}
+
+// VerificationURL builds the public verification link a participant's QR code points at
+func VerificationURL(verifyHost string, participantID string) string {
+	return fmt.Sprintf("%s/validate/result/%s", verifyHost, participantID)
+}
+
+// GenerateQRCodes generates QR codes for all participants in parallel, pointing at verifyHost
+func (r *EmbeddedRenderer) GenerateQRCodes(participants []any, certificateID string, verifyHost string) map[string]string {
 	participantCount := len(participants)
 	slog.Info("Starting parallel QR code generation", "participant_count", participantCount, "certificate_id", certificateID)
 
@@ -264,7 +331,7 @@ func (r *EmbeddedRenderer) GenerateQRCodes(participants []any, certificateID str
 			continue
 		}
 
-		verifyURL := fmt.Sprintf("%s/validate/result/%s", *common.Config.VerifyHost, participantID)
+		verifyURL := VerificationURL(verifyHost, participantID)
 		jobs = append(jobs, QRJob{
 			ParticipantID: participantID,
 			VerifyURL:     verifyURL,
@@ -345,7 +412,7 @@ func (r *EmbeddedRenderer) RenderCertificates(ctx context.Context, certificate a
 	}
 
 	certificateID, _ := certMap["id"].(string)
-	qrCodes := r.GenerateQRCodes(participants, certificateID)
+	qrCodes := r.GenerateQRCodes(participants, certificateID, certificateVerifyHost(certMap))
 
 	// Debug: Log QR codes generation
 	slog.Info("Generated QR codes", "certificate_id", certificateID, "qr_count", len(qrCodes))
@@ -366,6 +433,7 @@ func (r *EmbeddedRenderer) RenderCertificates(ctx context.Context, certificate a
 		QRCodes:      qrCodes,
 		Signatures:   signatures,
 		Watermark:    watermarkBase64,
+		DefaultFont:  r.defaultFont,
 	}
 
 	requestJSON, err := json.Marshal(request)
@@ -373,50 +441,10 @@ func (r *EmbeddedRenderer) RenderCertificates(ctx context.Context, certificate a
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Execute Bun renderer
-	cmd := exec.CommandContext(ctx, "bun", "renderer.ts")
-	cmd.Dir = r.rendererDir
-
-	stdin, err := cmd.StdinPipe()
+	// Execute Bun renderer, retrying transient failures
+	outputBytes, err := r.runRendererWithRetry(ctx, requestJSON, certificateID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
-	}
-
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
-	}
-
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
-	}
-
-	// Start the command
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start Bun renderer: %w", err)
-	}
-
-	// Send request data
-	go func() {
-		defer stdin.Close()
-		stdin.Write(requestJSON)
-	}()
-
-	// Read output
-	outputBytes, err := io.ReadAll(stdout)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read stdout: %w", err)
-	}
-
-	errorBytes, err := io.ReadAll(stderr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read stderr: %w", err)
-	}
-
-	// Wait for command to finish
-	if err := cmd.Wait(); err != nil {
-		return nil, fmt.Errorf("bun renderer failed: %w, stderr: %s", err, string(errorBytes))
+		return nil, err
 	}
 
 	// Parse results
@@ -441,7 +469,7 @@ func (r *EmbeddedRenderer) RenderThumbnail(ctx context.Context, certificate any)
 	}
 
 	// Execute Bun renderer for thumbnail
-	cmd := exec.CommandContext(ctx, "bun", "renderer.ts")
+	cmd := exec.CommandContext(ctx, r.binary, "renderer.ts")
 	cmd.Dir = r.rendererDir
 
 	stdin, err := cmd.StdinPipe()
@@ -470,10 +498,10 @@ func (r *EmbeddedRenderer) RenderThumbnail(ctx context.Context, certificate any)
 		stdin.Write(requestJSON)
 	}()
 
-	// Read output
-	outputBytes, err := io.ReadAll(stdout)
+	// Read output, bounded so a runaway render cannot exhaust memory
+	outputBytes, err := readRendererOutput(cmd, stdout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read stdout: %w", err)
+		return nil, err
 	}
 
 	errorBytes, err := io.ReadAll(stderr)
@@ -496,7 +524,7 @@ func (r *EmbeddedRenderer) RenderThumbnail(ctx context.Context, certificate any)
 }
 
 func (r *EmbeddedRenderer) ProcessThumbnail(ctx context.Context, certificate any, certificateID string) (string, error) {
-	bucketName := *common.Config.BucketCertificate
+	bucketName := storage.PreviewBucket()
 
 	// Delete all existing thumbnails for this certificate before generating new one
 	r.deleteOldThumbnails(bucketName, certificateID)
@@ -517,23 +545,38 @@ func (r *EmbeddedRenderer) ProcessThumbnail(ctx context.Context, certificate any
 		return "", fmt.Errorf("failed to decode base64 thumbnail: %w", err)
 	}
 
-	// Generate filename with certificate ID folder (using PNG to avoid black background)
+	// Shrink the full-resolution render to a JPEG thumbnail; fall back to the original PNG if that fails
+	extension, contentType := "png", "image/png"
+	if optimized, err := optimizeThumbnail(imageBytes, thumbnailMaxDimension(), thumbnailJPEGQuality()); err != nil {
+		slog.Warn("Thumbnail optimization failed, uploading original render", "error", err, "cert_id", certificateID)
+	} else {
+		slog.Debug("Thumbnail optimized", "cert_id", certificateID, "original_bytes", len(imageBytes), "optimized_bytes", len(optimized))
+		imageBytes = optimized
+		extension, contentType = "jpg", "image/jpeg"
+	}
+
+	// Generate filename with certificate ID folder
 	timestamp := time.Now().Unix()
-	filename := fmt.Sprintf("%s/thumbnail_%d_%s.png", certificateID, timestamp, strings.ReplaceAll(uuid.New().String(), "-", ""))
+	filename := fmt.Sprintf("%s/thumbnail_%d_%s.%s", certificateID, timestamp, strings.ReplaceAll(uuid.New().String(), "-", ""), extension)
 
 	// Ensure bucket exists and has public read policy
 	if err := r.ensureBucketPublic(bucketName); err != nil {
 		slog.Warn("Failed to ensure bucket is public", "error", err, "bucket", bucketName)
 	}
 
-	_, err = r.minIO.PutObject(
+	minioClient, err := storage.Client()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = minioClient.PutObject(
 		context.Background(),
 		bucketName,
 		filename,
 		bytes.NewReader(imageBytes),
 		int64(len(imageBytes)),
 		minio.PutObjectOptions{
-			ContentType: "image/png",
+			ContentType: contentType,
 		},
 	)
 
@@ -550,7 +593,13 @@ func (r *EmbeddedRenderer) ProcessThumbnail(ctx context.Context, certificate any
 func (r *EmbeddedRenderer) deleteOldThumbnails(bucketName, certificateID string) {
 	prefix := fmt.Sprintf("%s/thumbnail_", certificateID)
 
-	objectCh := r.minIO.ListObjects(context.Background(), bucketName, minio.ListObjectsOptions{
+	minioClient, err := storage.Client()
+	if err != nil {
+		slog.Warn("Skipping old thumbnail cleanup", "error", err, "cert_id", certificateID)
+		return
+	}
+
+	objectCh := minioClient.ListObjects(context.Background(), bucketName, minio.ListObjectsOptions{
 		Prefix:    prefix,
 		Recursive: true,
 	})
@@ -562,7 +611,7 @@ func (r *EmbeddedRenderer) deleteOldThumbnails(bucketName, certificateID string)
 			continue
 		}
 
-		err := r.minIO.RemoveObject(context.Background(), bucketName, object.Key, minio.RemoveObjectOptions{})
+		err := minioClient.RemoveObject(context.Background(), bucketName, object.Key, minio.RemoveObjectOptions{})
 		if err != nil {
 			slog.Warn("Failed to delete old thumbnail", "error", err, "object", object.Key, "cert_id", certificateID)
 		} else {
@@ -578,15 +627,20 @@ func (r *EmbeddedRenderer) deleteOldThumbnails(bucketName, certificateID string)
 
 // ensureBucketPublic sets the bucket policy to allow public read access
 func (r *EmbeddedRenderer) ensureBucketPublic(bucketName string) error {
+	minioClient, err := storage.Client()
+	if err != nil {
+		return err
+	}
+
 	// Check if bucket exists
-	exists, err := r.minIO.BucketExists(context.Background(), bucketName)
+	exists, err := minioClient.BucketExists(context.Background(), bucketName)
 	if err != nil {
 		return fmt.Errorf("failed to check bucket existence: %w", err)
 	}
 
 	// Create bucket if it doesn't exist
 	if !exists {
-		err = r.minIO.MakeBucket(context.Background(), bucketName, minio.MakeBucketOptions{})
+		err = minioClient.MakeBucket(context.Background(), bucketName, minio.MakeBucketOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to create bucket: %w", err)
 		}
@@ -605,7 +659,7 @@ func (r *EmbeddedRenderer) ensureBucketPublic(bucketName string) error {
 		]
 	}`, bucketName)
 
-	err = r.minIO.SetBucketPolicy(context.Background(), bucketName, policy)
+	err = minioClient.SetBucketPolicy(context.Background(), bucketName, policy)
 	if err != nil {
 		return fmt.Errorf("failed to set bucket policy: %w", err)
 	}
@@ -619,23 +673,46 @@ func (r *EmbeddedRenderer) GenerateAccessibleURL(bucketName, objectName string)
 	return fmt.Sprintf("%s/api/public/files/download/%s/%s", *common.Config.BackendURL, bucketName, objectName)
 }
 
-func (r *EmbeddedRenderer) ConvertToPDF(imageBase64 string, participantID string, certificateID string) ([]byte, error) {
+// pdfFooterHeight is the strip (in mm) reserved below the design for the traceability footer
+const pdfFooterHeight = 6.0
+
+// pdfFooterText is the traceability line stamped under the design when the footer is enabled
+func pdfFooterText(certificateID, participantID string, issuedAt time.Time) string {
+	return fmt.Sprintf("Certificate ID: %s  |  Participant ID: %s  |  Issued: %s", certificateID, participantID, issuedAt.Format("2006-01-02"))
+}
+
+// ConvertToPDF places the rendered image on an A4 landscape page using the configured layout.
+// With withFooter the image is scaled down to leave a strip at the bottom for the certificate ID,
+// participant ID and issue date.
+func (r *EmbeddedRenderer) ConvertToPDF(imageBase64 string, participantID string, certificateID string, withFooter bool) ([]byte, error) {
+	return r.ConvertToPDFWithLayout(imageBase64, participantID, certificateID, withFooter, DefaultPdfLayout())
+}
+
+// ConvertToPDFWithLayout is ConvertToPDF with explicit page margins and image fit mode
+func (r *EmbeddedRenderer) ConvertToPDFWithLayout(imageBase64 string, participantID string, certificateID string, withFooter bool, layout PdfLayout) ([]byte, error) {
+	pdfBytes, _, err := r.convertToPDF(imageBase64, participantID, certificateID, withFooter, layout)
+	return pdfBytes, err
+}
+
+// convertToPDF builds the certificate PDF and reports whether it actually carries a digital signature.
+// Signing failures fall back to the unsigned PDF, so callers must not assume a signature.
+func (r *EmbeddedRenderer) convertToPDF(imageBase64 string, participantID string, certificateID string, withFooter bool, layout PdfLayout) ([]byte, bool, error) {
 	// Decode base64 image
 	imageBytes, err := base64.StdEncoding.DecodeString(imageBase64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64 image: %w", err)
+		return nil, false, fmt.Errorf("failed to decode base64 image: %w", err)
 	}
 
 	// Create temporary image file
 	tempFile, err := os.CreateTemp("", "cert-*.png")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp image file: %w", err)
+		return nil, false, fmt.Errorf("failed to create temp image file: %w", err)
 	}
 	defer os.Remove(tempFile.Name())
 	defer tempFile.Close()
 
 	if _, err := tempFile.Write(imageBytes); err != nil {
-		return nil, fmt.Errorf("failed to write temp image: %w", err)
+		return nil, false, fmt.Errorf("failed to write temp image: %w", err)
 	}
 	tempFile.Close()
 
@@ -646,16 +723,52 @@ func (r *EmbeddedRenderer) ConvertToPDF(imageBase64 string, participantID string
 	// Get page dimensions
 	pageWidth, pageHeight := pdf.GetPageSize()
 
-	// Add image to PDF (fit to page)
-	pdf.Image(tempFile.Name(), 0, 0, pageWidth, pageHeight, false, "", 0, "")
+	area := pdfRect{
+		X: layout.MarginMm,
+		Y: layout.MarginMm,
+		W: pageWidth - 2*layout.MarginMm,
+		H: pageHeight - 2*layout.MarginMm,
+	}
+
+	fitMode := layout.FitMode
+	imageAspect := 0.0
+	if config, _, err := image.DecodeConfig(bytes.NewReader(imageBytes)); err == nil && config.Height > 0 {
+		imageAspect = float64(config.Width) / float64(config.Height)
+	}
+
+	if withFooter {
+		area.H -= pdfFooterHeight
+		if fitMode == PdfFitFill {
+			// The design is rendered at the page's aspect ratio, so keep it and center it above the footer strip
+			fitMode = PdfFitContain
+			imageAspect = pageWidth / pageHeight
+		}
+	}
+
+	placement := pdfImagePlacement(fitMode, area, imageAspect)
+	if fitMode == PdfFitCover {
+		pdf.ClipRect(area.X, area.Y, area.W, area.H, false)
+		pdf.Image(tempFile.Name(), placement.X, placement.Y, placement.W, placement.H, false, "", 0, "")
+		pdf.ClipEnd()
+	} else {
+		pdf.Image(tempFile.Name(), placement.X, placement.Y, placement.W, placement.H, false, "", 0, "")
+	}
+
+	if withFooter {
+		pdf.SetFont("Helvetica", "", 6)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.SetXY(0, area.Y+area.H)
+		pdf.CellFormat(pageWidth, pdfFooterHeight, pdfFooterText(certificateID, participantID, time.Now()), "", 0, "C", false, 0, "")
+	}
 
 	// Output PDF to buffer
 	var buf bytes.Buffer
 	if err := pdf.Output(&buf); err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+		return nil, false, fmt.Errorf("failed to generate PDF: %w", err)
 	}
 
 	pdfBytes := buf.Bytes()
+	signed := false
 
 	// Sign the PDF if signer is available and enabled
 	if r.signer != nil && r.signer.IsEnabled() {
@@ -678,13 +791,21 @@ func (r *EmbeddedRenderer) ConvertToPDF(imageBase64 string, participantID string
 				return
 			}
 
-			if len(signedPDF) > 0 {
+			// Signing appends an incremental update, so an unchanged size means SignPDF fell back to the input
+			if len(signedPDF) > len(pdfBytes) {
 				pdfBytes = signedPDF
+				signed = true
 			}
 		}()
 	}
 
-	return pdfBytes, nil
+	if !signed && r.signer != nil && r.signer.IsEnabled() {
+		slog.Warn("Certificate PDF left unsigned although signing is enabled",
+			"cert_id", certificateID,
+			"participant_id", participantID)
+	}
+
+	return pdfBytes, signed, nil
 }
 
 func (r *EmbeddedRenderer) UploadToMinIO(data []byte, filename string) (string, error) {
@@ -699,18 +820,8 @@ func (r *EmbeddedRenderer) UploadToMinIOWithContentType(data []byte, filename st
 		slog.Warn("Failed to ensure bucket is public", "error", err, "bucket", bucketName)
 	}
 
-	_, err := r.minIO.PutObject(
-		context.Background(),
-		bucketName,
-		filename,
-		bytes.NewReader(data),
-		int64(len(data)),
-		minio.PutObjectOptions{
-			ContentType: contentType,
-		},
-	)
-
-	if err != nil {
+	// Retries transient failures so a single network hiccup doesn't abort a generation run
+	if _, err := storage.PutObject(context.Background(), bucketName, filename, data, contentType); err != nil {
 		return "", fmt.Errorf("failed to upload to MinIO: %w", err)
 	}
 
@@ -719,18 +830,28 @@ func (r *EmbeddedRenderer) UploadToMinIOWithContentType(data []byte, filename st
 	return filename, nil
 }
 
-func (r *EmbeddedRenderer) CreateZipArchive(results []CertificateResult) ([]byte, error) {
+// CreateZipArchive bundles the successfully rendered PDFs. Entries are named from entryNames
+// (participant ID -> filename), falling back to certificate_<participantId>.pdf. With archive_include_manifest
+// the archive also carries a manifest of every file's SHA-256 hash, signed when PDF signing is enabled.
+func (r *EmbeddedRenderer) CreateZipArchive(certificateID string, results []CertificateResult, entryNames map[string]string, extraFiles map[string][]byte) ([]byte, error) {
+	minioClient, err := storage.Client()
+	if err != nil {
+		return nil, err
+	}
+
 	var buf bytes.Buffer
 	zipWriter := zip.NewWriter(&buf)
 	defer zipWriter.Close()
 
+	var manifestFiles []ArchiveManifestFile
+
 	for _, result := range results {
 		if result.Status != "success" || result.FilePath == "" {
 			continue
 		}
 
 		// Download file from MinIO
-		object, err := r.minIO.GetObject(
+		object, err := minioClient.GetObject(
 			context.Background(),
 			*common.Config.BucketCertificate,
 			result.FilePath,
@@ -749,7 +870,10 @@ func (r *EmbeddedRenderer) CreateZipArchive(results []CertificateResult) ([]byte
 		}
 
 		// Add to ZIP
-		filename := fmt.Sprintf("certificate_%s.pdf", result.ParticipantID)
+		filename, ok := entryNames[result.ParticipantID]
+		if !ok {
+			filename = fmt.Sprintf("certificate_%s.pdf", result.ParticipantID)
+		}
 		zipFile, err := zipWriter.Create(filename)
 		if err != nil {
 			slog.Warn("Failed to create ZIP entry", "filename", filename, "error", err)
@@ -760,6 +884,39 @@ func (r *EmbeddedRenderer) CreateZipArchive(results []CertificateResult) ([]byte
 			slog.Warn("Failed to write ZIP entry", "filename", filename, "error", err)
 			continue
 		}
+		manifestFiles = append(manifestFiles, NewArchiveManifestFile(result.ParticipantID, filename, data))
+	}
+
+	for filename, data := range extraFiles {
+		zipFile, err := zipWriter.Create(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ZIP entry %s: %w", filename, err)
+		}
+		if _, err := zipFile.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to write ZIP entry %s: %w", filename, err)
+		}
+		manifestFiles = append(manifestFiles, NewArchiveManifestFile("", filename, data))
+	}
+
+	// Optionally list every file's hash in a manifest signed by the PDF signer, so recipients can check the archive
+	if archiveIncludesManifest() {
+		manifestEntries, err := archiveManifestEntries(certificateID, manifestFiles, r.signer)
+		if err != nil {
+			return nil, err
+		}
+		for _, filename := range []string{ArchiveManifestEntry, ArchiveManifestSignatureEntry, ArchiveManifestCertificateEntry} {
+			data, ok := manifestEntries[filename]
+			if !ok {
+				continue
+			}
+			zipFile, err := zipWriter.Create(filename)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ZIP entry %s: %w", filename, err)
+			}
+			if _, err := zipFile.Write(data); err != nil {
+				return nil, fmt.Errorf("failed to write ZIP entry %s: %w", filename, err)
+			}
+		}
 	}
 
 	if err := zipWriter.Close(); err != nil {
@@ -772,7 +929,7 @@ func (r *EmbeddedRenderer) CreateZipArchive(results []CertificateResult) ([]byte
 // GeneratePreviewWithWatermark generates a preview certificate image with all signatures and a watermark
 func (r *EmbeddedRenderer) GeneratePreviewWithWatermark(ctx context.Context, certificate any, participants []any, signatures map[string]string, certificateID string) ([]byte, error) {
 	// Generate QR codes for preview
-	qrCodes := r.GenerateQRCodes(participants, certificateID)
+	qrCodes := r.GenerateQRCodes(participants, certificateID, certificateVerifyHost(certificate))
 
 	// Encode watermark image to base64 - using the embedded watermark
 	watermarkBase64 := base64.StdEncoding.EncodeToString(watermarkPNG)
@@ -789,6 +946,7 @@ func (r *EmbeddedRenderer) GeneratePreviewWithWatermark(ctx context.Context, cer
 		QRCodes:      qrCodes,
 		Signatures:   signatures,
 		Watermark:    watermarkBase64,
+		DefaultFont:  r.defaultFont,
 	}
 
 	requestJSON, err := json.Marshal(request)
@@ -797,7 +955,7 @@ func (r *EmbeddedRenderer) GeneratePreviewWithWatermark(ctx context.Context, cer
 	}
 
 	// Execute Bun renderer
-	cmd := exec.CommandContext(ctx, "bun", "renderer.ts")
+	cmd := exec.CommandContext(ctx, r.binary, "renderer.ts")
 	cmd.Dir = r.rendererDir
 
 	stdin, err := cmd.StdinPipe()
@@ -826,10 +984,10 @@ func (r *EmbeddedRenderer) GeneratePreviewWithWatermark(ctx context.Context, cer
 		stdin.Write(requestJSON)
 	}()
 
-	// Read output
-	outputBytes, err := io.ReadAll(stdout)
+	// Read output, bounded so a runaway render cannot exhaust memory
+	outputBytes, err := readRendererOutput(cmd, stdout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read stdout: %w", err)
+		return nil, err
 	}
 
 	errorBytes, err := io.ReadAll(stderr)
@@ -863,7 +1021,7 @@ func (r *EmbeddedRenderer) GeneratePreviewWithWatermark(ctx context.Context, cer
 
 // SavePreviewToMinIO saves a preview image to MinIO in the previews folder
 func (r *EmbeddedRenderer) SavePreviewToMinIO(previewBytes []byte, certificateID string) (string, error) {
-	bucketName := *common.Config.BucketCertificate
+	bucketName := storage.PreviewBucket()
 
 	// Delete old previews for this certificate first
 	r.deleteOldPreviews(bucketName, certificateID)
@@ -877,7 +1035,12 @@ func (r *EmbeddedRenderer) SavePreviewToMinIO(previewBytes []byte, certificateID
 		slog.Warn("Failed to ensure bucket is public", "error", err, "bucket", bucketName)
 	}
 
-	_, err := r.minIO.PutObject(
+	minioClient, err := storage.Client()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = minioClient.PutObject(
 		context.Background(),
 		bucketName,
 		filename,
@@ -900,7 +1063,13 @@ func (r *EmbeddedRenderer) SavePreviewToMinIO(previewBytes []byte, certificateID
 func (r *EmbeddedRenderer) deleteOldPreviews(bucketName, certificateID string) {
 	prefix := fmt.Sprintf("previews/%s/", certificateID)
 
-	objectCh := r.minIO.ListObjects(context.Background(), bucketName, minio.ListObjectsOptions{
+	minioClient, err := storage.Client()
+	if err != nil {
+		slog.Warn("Skipping old preview cleanup", "error", err, "cert_id", certificateID)
+		return
+	}
+
+	objectCh := minioClient.ListObjects(context.Background(), bucketName, minio.ListObjectsOptions{
 		Prefix:    prefix,
 		Recursive: true,
 	})
@@ -912,7 +1081,7 @@ func (r *EmbeddedRenderer) deleteOldPreviews(bucketName, certificateID string) {
 			continue
 		}
 
-		err := r.minIO.RemoveObject(context.Background(), bucketName, object.Key, minio.RemoveObjectOptions{})
+		err := minioClient.RemoveObject(context.Background(), bucketName, object.Key, minio.RemoveObjectOptions{})
 		if err != nil {
 			slog.Warn("Failed to delete old preview", "error", err, "object", object.Key, "cert_id", certificateID)
 		} else {
@@ -929,12 +1098,17 @@ func (r *EmbeddedRenderer) deleteOldPreviews(bucketName, certificateID string) {
 // CleanupExpiredPreviews removes preview files older than the specified duration
 // This function should be called periodically (e.g., daily via cron job)
 func (r *EmbeddedRenderer) CleanupExpiredPreviews(maxAge time.Duration) error {
-	bucketName := *common.Config.BucketCertificate
+	bucketName := storage.PreviewBucket()
 	prefix := "previews/"
 
 	slog.Info("Starting cleanup of expired preview images", "maxAge", maxAge.String())
 
-	objectCh := r.minIO.ListObjects(context.Background(), bucketName, minio.ListObjectsOptions{
+	minioClient, err := storage.Client()
+	if err != nil {
+		return err
+	}
+
+	objectCh := minioClient.ListObjects(context.Background(), bucketName, minio.ListObjectsOptions{
 		Prefix:    prefix,
 		Recursive: true,
 	})
@@ -952,7 +1126,7 @@ func (r *EmbeddedRenderer) CleanupExpiredPreviews(maxAge time.Duration) error {
 
 		// Check if the object is older than the cutoff time
 		if object.LastModified.Before(cutoffTime) {
-			err := r.minIO.RemoveObject(context.Background(), bucketName, object.Key, minio.RemoveObjectOptions{})
+			err := minioClient.RemoveObject(context.Background(), bucketName, object.Key, minio.RemoveObjectOptions{})
 			if err != nil {
 				slog.Warn("Failed to delete expired preview",
 					"error", err,
@@ -980,24 +1154,96 @@ func (r *EmbeddedRenderer) CleanupExpiredPreviews(maxAge time.Duration) error {
 	return nil
 }
 
+// ProcessCertificates renders and uploads every participant's PDF and bundles them into a ZIP archive.
+// When ctx expires the run stops and the results finished so far are returned with ErrGenerationDeadlineExceeded.
 func (r *EmbeddedRenderer) ProcessCertificates(ctx context.Context, certificate any, participants []any, signatures map[string]string) ([]CertificateResult, string, error) {
+	startedAt := time.Now()
+	certMap, _ := certificate.(map[string]any)
+	certificateID, _ := certMap["id"].(string)
+
+	certificateResults, err := r.RenderAndUploadCertificates(ctx, certificate, participants, signatures)
+	RecordGenerationRun(certificateID, startedAt, len(participants), certificateResults, err)
+	if err != nil {
+		return certificateResults, "", err
+	}
+	if err := generationDeadlineError(ctx, "creating the ZIP archive"); err != nil {
+		return certificateResults, "", err
+	}
+
+	// Optionally add a printable sheet of every participant's QR code
+	extraFiles := map[string][]byte{}
+	if archiveIncludesQRSheet() {
+		certificateName, _ := certMap["name"].(string)
+		qrCodes := r.GenerateQRCodes(participants, certificateID, certificateVerifyHost(certificate))
+		sheet, err := BuildQRCodeSheet(certificateName, QRSheetEntries(participants, qrCodes, DefaultQRSheetLabelField))
+		if err != nil {
+			slog.Warn("Leaving the QR codes sheet out of the archive", "error", err, "cert_id", certificateID)
+		} else {
+			extraFiles[QRSheetArchiveEntry] = sheet
+		}
+	}
+
+	// Create ZIP archive
+	filenameTemplate, _ := certMap["archive_filename_template"].(string)
+	zipBytes, err := r.CreateZipArchive(certificateID, certificateResults, ArchiveEntryNames(filenameTemplate, participants), extraFiles)
+	if err != nil {
+		return certificateResults, "", fmt.Errorf("failed to create ZIP archive: %w", err)
+	}
+
+	// Upload ZIP to MinIO with correct content type
+	timestamp := time.Now().Unix()
+	zipFilename := fmt.Sprintf("%s/certificates_%d_%s.zip", certificateID, timestamp, strings.ReplaceAll(uuid.New().String(), "-", ""))
+
+	if err := generationDeadlineError(ctx, "uploading the ZIP archive"); err != nil {
+		return certificateResults, "", err
+	}
+	zipFilePath, err := r.UploadToMinIOWithContentType(zipBytes, zipFilename, "application/zip")
+	if err != nil {
+		return certificateResults, "", fmt.Errorf("failed to upload ZIP: %w", err)
+	}
+
+	return certificateResults, zipFilePath, nil
+}
+
+// RenderAndUploadCertificates renders, signs and uploads one PDF per participant without building a ZIP archive.
+// When ctx expires midway it returns the results so far with ErrGenerationDeadlineExceeded.
+func (r *EmbeddedRenderer) RenderAndUploadCertificates(ctx context.Context, certificate any, participants []any, signatures map[string]string) ([]CertificateResult, error) {
 	// Extract certificate ID
 	certMap, ok := certificate.(map[string]any)
 	if !ok {
-		return nil, "", fmt.Errorf("invalid certificate format for folder creation")
+		return nil, fmt.Errorf("invalid certificate format for folder creation")
 	}
 	certificateID, _ := certMap["id"].(string)
+	withFooter, _ := certMap["pdf_footer"].(bool)
+	pdfLayout := pdfLayoutFromCertificate(certMap)
 
 	// Render certificates
 	renderResults, err := r.RenderCertificates(ctx, certificate, participants, signatures)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to render certificates: %w", err)
+		if deadlineErr := generationDeadlineError(ctx, "rendering"); deadlineErr != nil {
+			return nil, deadlineErr
+		}
+		return nil, fmt.Errorf("failed to render certificates: %w", err)
 	}
 
 	var certificateResults []CertificateResult
+	var deadlineErr error
 
 	// Process each rendered certificate
 	for _, renderResult := range renderResults {
+		// Once the generation budget is spent, the remaining certificates are reported as not generated
+		if deadlineErr == nil {
+			deadlineErr = generationDeadlineError(ctx, "converting and uploading PDFs")
+		}
+		if deadlineErr != nil {
+			certificateResults = append(certificateResults, CertificateResult{
+				ParticipantID: renderResult.ParticipantID,
+				Status:        "error",
+				Error:         ErrGenerationDeadlineExceeded.Error(),
+			})
+			continue
+		}
+
 		if renderResult.Status != "success" {
 			certificateResults = append(certificateResults, CertificateResult{
 				ParticipantID: renderResult.ParticipantID,
@@ -1008,7 +1254,7 @@ func (r *EmbeddedRenderer) ProcessCertificates(ctx context.Context, certificate
 		}
 
 		// Convert to PDF
-		pdfBytes, err := r.ConvertToPDF(renderResult.ImageBase64, renderResult.ParticipantID, certificateID)
+		pdfBytes, signed, err := r.convertToPDF(renderResult.ImageBase64, renderResult.ParticipantID, certificateID, withFooter, pdfLayout)
 		if err != nil {
 			slog.Error("Failed to convert to PDF", "participant_id", renderResult.ParticipantID, "error", err)
 			certificateResults = append(certificateResults, CertificateResult{
@@ -1039,23 +1285,9 @@ func (r *EmbeddedRenderer) ProcessCertificates(ctx context.Context, certificate
 			ParticipantID: renderResult.ParticipantID,
 			FilePath:      filePath,
 			Status:        "success",
+			Signed:        signed,
 		})
 	}
 
-	// Create ZIP archive
-	zipBytes, err := r.CreateZipArchive(certificateResults)
-	if err != nil {
-		return certificateResults, "", fmt.Errorf("failed to create ZIP archive: %w", err)
-	}
-
-	// Upload ZIP to MinIO with correct content type
-	timestamp := time.Now().Unix()
-	zipFilename := fmt.Sprintf("%s/certificates_%d_%s.zip", certificateID, timestamp, strings.ReplaceAll(uuid.New().String(), "-", ""))
-
-	zipFilePath, err := r.UploadToMinIOWithContentType(zipBytes, zipFilename, "application/zip")
-	if err != nil {
-		return certificateResults, "", fmt.Errorf("failed to upload ZIP: %w", err)
-	}
-
-	return certificateResults, zipFilePath, nil
+	return certificateResults, deadlineErr
 }
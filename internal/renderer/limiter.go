@@ -0,0 +1,173 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const (
+	defaultMaxConcurrentGenerations = 2
+	defaultMaxQueuedGenerations     = 10
+)
+
+const (
+	GenerationStateIdle    = "idle"
+	GenerationStateQueued  = "queued"
+	GenerationStateRunning = "running"
+)
+
+var (
+	ErrGenerationQueueFull  = errors.New("generation queue is full")
+	ErrGenerationInProgress = errors.New("generation already running or queued for this certificate")
+)
+
+// GenerationLimiter bounds how many certificate generations run at once.
+// Requests beyond the limit wait in a bounded FIFO queue; once the queue is full they are rejected.
+type GenerationLimiter struct {
+	mu        sync.Mutex
+	maxActive int
+	maxQueued int
+	running   map[string]struct{}
+	queue     []*generationTicket
+}
+
+type generationTicket struct {
+	key   string
+	ready chan struct{}
+}
+
+var (
+	generationLimiter     *GenerationLimiter
+	generationLimiterOnce sync.Once
+)
+
+// NewGenerationLimiter creates a limiter allowing maxActive concurrent generations and maxQueued waiting ones
+func NewGenerationLimiter(maxActive int, maxQueued int) *GenerationLimiter {
+	if maxActive < 1 {
+		maxActive = 1
+	}
+	if maxQueued < 0 {
+		maxQueued = 0
+	}
+	return &GenerationLimiter{
+		maxActive: maxActive,
+		maxQueued: maxQueued,
+		running:   make(map[string]struct{}),
+	}
+}
+
+// Generations returns the process-wide generation limiter configured from
+// max_concurrent_generations and max_queued_generations
+func Generations() *GenerationLimiter {
+	generationLimiterOnce.Do(func() {
+		maxActive := defaultMaxConcurrentGenerations
+		maxQueued := defaultMaxQueuedGenerations
+		if common.Config != nil && common.Config.MaxConcurrentGenerations != nil {
+			maxActive = *common.Config.MaxConcurrentGenerations
+		}
+		if common.Config != nil && common.Config.MaxQueuedGenerations != nil {
+			maxQueued = *common.Config.MaxQueuedGenerations
+		}
+		generationLimiter = NewGenerationLimiter(maxActive, maxQueued)
+	})
+	return generationLimiter
+}
+
+// Acquire blocks until a generation slot is free for key and returns a release function.
+// It returns ErrGenerationInProgress when key already holds or waits for a slot,
+// ErrGenerationQueueFull immediately when no slot is free and the queue is full,
+// or the context error if ctx ends while waiting.
+func (l *GenerationLimiter) Acquire(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	if l.holdsLocked(key) {
+		l.mu.Unlock()
+		return nil, ErrGenerationInProgress
+	}
+
+	if len(l.running) < l.maxActive && len(l.queue) == 0 {
+		l.running[key] = struct{}{}
+		l.mu.Unlock()
+		return l.releaseFunc(key), nil
+	}
+
+	if len(l.queue) >= l.maxQueued {
+		l.mu.Unlock()
+		return nil, ErrGenerationQueueFull
+	}
+
+	ticket := &generationTicket{key: key, ready: make(chan struct{})}
+	l.queue = append(l.queue, ticket)
+	l.mu.Unlock()
+
+	select {
+	case <-ticket.ready:
+		return l.releaseFunc(key), nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if idx := slices.Index(l.queue, ticket); idx >= 0 {
+			l.queue = slices.Delete(l.queue, idx, idx+1)
+			return nil, ctx.Err()
+		}
+		// The slot was handed over just as the context ended; give it back
+		delete(l.running, key)
+		l.promoteLocked()
+		return nil, ctx.Err()
+	}
+}
+
+func (l *GenerationLimiter) releaseFunc(key string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			delete(l.running, key)
+			l.promoteLocked()
+		})
+	}
+}
+
+// promoteLocked hands free slots to the oldest waiting requests. l.mu must be held.
+func (l *GenerationLimiter) promoteLocked() {
+	for len(l.running) < l.maxActive && len(l.queue) > 0 {
+		next := l.queue[0]
+		l.queue = l.queue[1:]
+		l.running[next.key] = struct{}{}
+		close(next.ready)
+	}
+}
+
+func (l *GenerationLimiter) holdsLocked(key string) bool {
+	if _, ok := l.running[key]; ok {
+		return true
+	}
+	return slices.ContainsFunc(l.queue, func(ticket *generationTicket) bool { return ticket.key == key })
+}
+
+// State reports whether a generation for key is running or queued, with its 1-based queue position
+func (l *GenerationLimiter) State(key string) (string, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.running[key]; ok {
+		return GenerationStateRunning, 0
+	}
+	for i, ticket := range l.queue {
+		if ticket.key == key {
+			return GenerationStateQueued, i + 1
+		}
+	}
+	return GenerationStateIdle, 0
+}
+
+// QueueLength returns the number of generations waiting for a slot
+func (l *GenerationLimiter) QueueLength() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.queue)
+}
@@ -0,0 +1,97 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerationLimiter_QueuesBeyondLimit(t *testing.T) {
+	limiter := NewGenerationLimiter(1, 1)
+
+	releaseA, err := limiter.Acquire(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("unexpected error acquiring first slot: %v", err)
+	}
+
+	acquired := make(chan func(), 1)
+	go func() {
+		release, err := limiter.Acquire(context.Background(), "b")
+		if err != nil {
+			t.Errorf("unexpected error waiting for slot: %v", err)
+			return
+		}
+		acquired <- release
+	}()
+
+	waitForState(t, limiter, "b", GenerationStateQueued)
+	if _, position := limiter.State("b"); position != 1 {
+		t.Errorf("expected queue position 1, got %d", position)
+	}
+
+	if _, err := limiter.Acquire(context.Background(), "c"); !errors.Is(err, ErrGenerationQueueFull) {
+		t.Errorf("expected ErrGenerationQueueFull, got %v", err)
+	}
+
+	releaseA()
+	select {
+	case releaseB := <-acquired:
+		if state, _ := limiter.State("b"); state != GenerationStateRunning {
+			t.Errorf("expected b to be running, got %s", state)
+		}
+		releaseB()
+	case <-time.After(time.Second):
+		t.Fatal("queued generation was not started after release")
+	}
+
+	if state, _ := limiter.State("b"); state != GenerationStateIdle {
+		t.Errorf("expected b to be idle after release, got %s", state)
+	}
+}
+
+func TestGenerationLimiter_RejectsDuplicateKey(t *testing.T) {
+	limiter := NewGenerationLimiter(2, 2)
+
+	release, err := limiter.Acquire(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer release()
+
+	if _, err := limiter.Acquire(context.Background(), "a"); !errors.Is(err, ErrGenerationInProgress) {
+		t.Errorf("expected ErrGenerationInProgress, got %v", err)
+	}
+}
+
+func TestGenerationLimiter_CancelledWaitLeavesQueue(t *testing.T) {
+	limiter := NewGenerationLimiter(1, 1)
+
+	release, err := limiter.Acquire(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := limiter.Acquire(ctx, "b"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if limiter.QueueLength() != 0 {
+		t.Errorf("expected empty queue after cancelled wait, got %d", limiter.QueueLength())
+	}
+}
+
+func waitForState(t *testing.T, limiter *GenerationLimiter, key string, want string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if state, _ := limiter.State(key); state == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%s did not reach state %s", key, want)
+}
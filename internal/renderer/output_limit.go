@@ -0,0 +1,60 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+// ErrRendererOutputTooLarge is returned when the renderer writes more than renderer_max_output_mb to stdout
+var ErrRendererOutputTooLarge = errors.New("renderer output exceeds the configured maximum size")
+
+// rendererMaxOutputBytes returns the most renderer stdout that is read into memory (renderer_max_output_mb),
+// or 0 when no limit is configured. One run carries every participant's PDF, so the limit is opt-in.
+func rendererMaxOutputBytes() int64 {
+	if common.Config != nil && common.Config.RendererMaxOutputMB != nil && *common.Config.RendererMaxOutputMB > 0 {
+		return int64(*common.Config.RendererMaxOutputMB) * 1024 * 1024
+	}
+	return 0
+}
+
+// readLimited reads r to the end, failing with ErrRendererOutputTooLarge once more than limit bytes arrive
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w (%d bytes)", ErrRendererOutputTooLarge, limit)
+	}
+	return data, nil
+}
+
+// readRendererOutput reads the renderer's stdout up to the configured limit. When the limit is exceeded the
+// process is killed, so a pathological design cannot exhaust memory or leave the renderer blocked on a full pipe.
+func readRendererOutput(cmd *exec.Cmd, stdout io.Reader) ([]byte, error) {
+	limit := rendererMaxOutputBytes()
+	if limit == 0 {
+		output, err := io.ReadAll(stdout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdout: %w", err)
+		}
+		return output, nil
+	}
+
+	output, err := readLimited(stdout, limit)
+	if errors.Is(err, ErrRendererOutputTooLarge) {
+		if cmd.Process != nil {
+			cmd.Process.Kill()
+		}
+		cmd.Wait()
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdout: %w", err)
+	}
+	return output, nil
+}
@@ -0,0 +1,69 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestReadLimited(t *testing.T) {
+	data, err := readLimited(strings.NewReader("12345"), 5)
+	if err != nil || string(data) != "12345" {
+		t.Errorf("readLimited() at the limit = %q, %v", data, err)
+	}
+
+	if _, err := readLimited(strings.NewReader("123456"), 5); !errors.Is(err, ErrRendererOutputTooLarge) {
+		t.Errorf("expected ErrRendererOutputTooLarge, got %v", err)
+	}
+}
+
+func TestRunRendererOutputLimit(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	retries, maxOutputMB := 2, 1
+	common.Config = &shared.Config{RendererMaxRetries: &retries, RendererMaxOutputMB: &maxOutputMB}
+
+	// Endless output: the renderer has to be killed rather than read to the end
+	r := fakeRenderer(t, `echo attempt >> attempts
+cat /dev/zero
+`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.runRendererWithRetry(ctx, []byte("{}"), "cert-1")
+	if !errors.Is(err, ErrRendererOutputTooLarge) {
+		t.Fatalf("expected ErrRendererOutputTooLarge, got %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("renderer was not stopped when its output exceeded the limit")
+	}
+
+	attempts, _ := os.ReadFile(filepath.Join(r.rendererDir, "attempts"))
+	if string(attempts) != "attempt\n" {
+		t.Errorf("oversized output should not be retried, got attempts %q", attempts)
+	}
+}
+
+func TestRendererMaxOutputBytes(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	common.Config = &shared.Config{}
+	if got := rendererMaxOutputBytes(); got != 0 {
+		t.Errorf("rendererMaxOutputBytes() without config = %d, want 0 (unlimited)", got)
+	}
+
+	maxOutputMB := 3
+	common.Config = &shared.Config{RendererMaxOutputMB: &maxOutputMB}
+	if got := rendererMaxOutputBytes(); got != 3*1024*1024 {
+		t.Errorf("rendererMaxOutputBytes() = %d, want %d", got, 3*1024*1024)
+	}
+}
@@ -0,0 +1,85 @@
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image/png"
+	"strings"
+)
+
+// Output formats for a single participant's certificate file
+const (
+	CertificateFormatPDF = "pdf"
+	CertificateFormatPNG = "png"
+)
+
+// IsValidCertificateFormat reports whether format is a supported single certificate output
+func IsValidCertificateFormat(format string) bool {
+	return format == CertificateFormatPDF || format == CertificateFormatPNG
+}
+
+// CertificateFormatContentType returns the MIME type served for a certificate format
+func CertificateFormatContentType(format string) string {
+	if format == CertificateFormatPNG {
+		return "image/png"
+	}
+	return "application/pdf"
+}
+
+// ParticipantFilename names a participant's certificate file from the archive filename template,
+// with the extension matching format
+func ParticipantFilename(template string, participant any, format string) string {
+	fields := participantFields(participant)
+	participantID, _ := fields["id"].(string)
+	name := strings.TrimSuffix(archiveFilename(template, fields, participantID), ".pdf")
+	return name + "." + format
+}
+
+// decodeRenderedImage turns the renderer's base64 output into PNG bytes, rejecting anything that is not a PNG
+func decodeRenderedImage(imageBase64 string) ([]byte, error) {
+	imageBytes, err := base64.StdEncoding.DecodeString(imageBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 image: %w", err)
+	}
+	if _, err := png.DecodeConfig(bytes.NewReader(imageBytes)); err != nil {
+		return nil, fmt.Errorf("rendered image is not a valid PNG: %w", err)
+	}
+	return imageBytes, nil
+}
+
+// RenderParticipantFile renders one participant's certificate and returns it without uploading.
+// CertificateFormatPNG returns the rendered image as is, skipping PDF conversion;
+// CertificateFormatPDF returns the same signed PDF the generation run produces.
+func (r *EmbeddedRenderer) RenderParticipantFile(ctx context.Context, certificate any, participant any, signatures map[string]string, format string) ([]byte, error) {
+	if !IsValidCertificateFormat(format) {
+		return nil, fmt.Errorf("unsupported certificate format %q", format)
+	}
+
+	certMap, ok := certificate.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid certificate format")
+	}
+	certificateID, _ := certMap["id"].(string)
+
+	renderResults, err := r.RenderCertificates(ctx, certificate, []any{participant}, signatures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render certificate: %w", err)
+	}
+
+	if len(renderResults) == 0 {
+		return nil, fmt.Errorf("renderer returned no result")
+	}
+	result := renderResults[0]
+	if result.Status != "success" {
+		return nil, fmt.Errorf("certificate rendering failed: %s", result.Error)
+	}
+
+	if format == CertificateFormatPNG {
+		return decodeRenderedImage(result.ImageBase64)
+	}
+
+	withFooter, _ := certMap["pdf_footer"].(bool)
+	return r.ConvertToPDFWithLayout(result.ImageBase64, result.ParticipantID, certificateID, withFooter, pdfLayoutFromCertificate(certMap))
+}
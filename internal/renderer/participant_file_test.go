@@ -0,0 +1,62 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func TestIsValidCertificateFormat(t *testing.T) {
+	for _, format := range []string{CertificateFormatPDF, CertificateFormatPNG} {
+		if !IsValidCertificateFormat(format) {
+			t.Errorf("IsValidCertificateFormat(%q) = false, want true", format)
+		}
+	}
+	for _, format := range []string{"", "jpg", "PDF"} {
+		if IsValidCertificateFormat(format) {
+			t.Errorf("IsValidCertificateFormat(%q) = true, want false", format)
+		}
+	}
+
+	if got := CertificateFormatContentType(CertificateFormatPNG); got != "image/png" {
+		t.Errorf("content type for png = %q", got)
+	}
+	if got := CertificateFormatContentType(CertificateFormatPDF); got != "application/pdf" {
+		t.Errorf("content type for pdf = %q", got)
+	}
+}
+
+func TestParticipantFilename(t *testing.T) {
+	participant := archiveTestParticipant{ID: "p1", DynamicData: map[string]any{"name": "Alice"}}
+
+	if got := ParticipantFilename("{{name}}-certificate.pdf", participant, CertificateFormatPNG); got != "Alice-certificate.png" {
+		t.Errorf("png filename = %q", got)
+	}
+	if got := ParticipantFilename("", participant, CertificateFormatPDF); got != "certificate_p1.pdf" {
+		t.Errorf("default pdf filename = %q", got)
+	}
+}
+
+func TestDecodeRenderedImage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 3))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+
+	decoded, err := decodeRenderedImage(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("decodeRenderedImage returned error: %v", err)
+	}
+	if !bytes.Equal(decoded, buf.Bytes()) {
+		t.Error("decoded image differs from the rendered PNG")
+	}
+
+	if _, err := decodeRenderedImage("not base64!"); err == nil {
+		t.Error("expected error for invalid base64")
+	}
+	if _, err := decodeRenderedImage(base64.StdEncoding.EncodeToString([]byte("%PDF-1.4"))); err == nil {
+		t.Error("expected error for non-PNG data")
+	}
+}
@@ -0,0 +1,82 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestPdfFooterText(t *testing.T) {
+	issuedAt := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	got := pdfFooterText("cert-1", "participant-1", issuedAt)
+	want := "Certificate ID: cert-1  |  Participant ID: participant-1  |  Issued: 2026-03-14"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestConvertToPDF_WithFooter(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	img.Set(0, 0, color.Black)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+
+	r := &EmbeddedRenderer{}
+	for _, withFooter := range []bool{false, true} {
+		pdfBytes, err := r.ConvertToPDF(base64.StdEncoding.EncodeToString(buf.Bytes()), "participant-1", "cert-1", withFooter)
+		if err != nil {
+			t.Fatalf("ConvertToPDF(withFooter=%v) failed: %v", withFooter, err)
+		}
+		if !bytes.HasPrefix(pdfBytes, []byte("%PDF")) {
+			t.Errorf("ConvertToPDF(withFooter=%v) did not return a PDF", withFooter)
+		}
+	}
+}
+
+func TestConvertToPDF_ReportsUnsigned(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 3))); err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+	imageBase64 := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	for name, r := range map[string]*EmbeddedRenderer{
+		"no signer":                   {},
+		"disabled signer":             {signer: &CertificateSigner{enabled: false}},
+		"signer without key material": {signer: &CertificateSigner{enabled: true}},
+	} {
+		_, signed, err := r.convertToPDF(imageBase64, "participant-1", "cert-1", false, DefaultPdfLayout())
+		if err != nil {
+			t.Fatalf("%s: convertToPDF failed: %v", name, err)
+		}
+		if signed {
+			t.Errorf("%s: expected the PDF to be reported as unsigned", name)
+		}
+	}
+}
+
+func TestSigningCertificateInfo_IsSigningDegraded(t *testing.T) {
+	tests := []struct {
+		name string
+		info SigningCertificateInfo
+		want bool
+	}{
+		{name: "signing off by configuration", info: SigningCertificateInfo{}, want: false},
+		{name: "signer failed to load", info: SigningCertificateInfo{Configured: true, Error: "missing key"}, want: true},
+		{name: "signer active", info: SigningCertificateInfo{Configured: true, Enabled: true}, want: false},
+		{name: "certificate expired", info: SigningCertificateInfo{Configured: true, Enabled: true, Expired: true}, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.info.IsSigningDegraded(); got != tt.want {
+			t.Errorf("%s: IsSigningDegraded() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
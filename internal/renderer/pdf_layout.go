@@ -0,0 +1,107 @@
+package renderer
+
+import (
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+// PDF image fit modes
+const (
+	// PdfFitFill stretches the design over the printable area (historical behavior)
+	PdfFitFill = "fill"
+	// PdfFitContain scales the design to fit inside the printable area, keeping its aspect ratio
+	PdfFitContain = "contain"
+	// PdfFitCover scales the design to cover the printable area, clipping what overflows
+	PdfFitCover = "cover"
+)
+
+// maxPdfMarginMm keeps at least a usable printable area on an A4 landscape page
+const maxPdfMarginMm = 50.0
+
+// PdfLayout controls how the rendered design is placed on the PDF page
+type PdfLayout struct {
+	MarginMm float64
+	FitMode  string
+}
+
+// IsValidPdfFitMode reports whether mode is a supported fit mode
+func IsValidPdfFitMode(mode string) bool {
+	return mode == PdfFitFill || mode == PdfFitContain || mode == PdfFitCover
+}
+
+// DefaultPdfLayout returns the layout configured through pdf_margin_mm and pdf_fit_mode
+func DefaultPdfLayout() PdfLayout {
+	layout := PdfLayout{FitMode: PdfFitFill}
+	if common.Config == nil {
+		return layout
+	}
+	if common.Config.PdfMarginMm != nil {
+		layout.MarginMm = clampPdfMargin(*common.Config.PdfMarginMm)
+	}
+	if common.Config.PdfFitMode != nil && IsValidPdfFitMode(*common.Config.PdfFitMode) {
+		layout.FitMode = *common.Config.PdfFitMode
+	}
+	return layout
+}
+
+// ResolvePdfLayout applies a certificate's overrides on top of the configured defaults.
+// A nil margin or empty fit mode keeps the default.
+func ResolvePdfLayout(marginMm *float64, fitMode string) PdfLayout {
+	layout := DefaultPdfLayout()
+	if marginMm != nil {
+		layout.MarginMm = clampPdfMargin(*marginMm)
+	}
+	if IsValidPdfFitMode(fitMode) {
+		layout.FitMode = fitMode
+	}
+	return layout
+}
+
+// pdfLayoutFromCertificate reads the pdf_margin_mm and pdf_fit_mode overrides from a renderer certificate map
+func pdfLayoutFromCertificate(certMap map[string]any) PdfLayout {
+	marginMm, _ := certMap["pdf_margin_mm"].(*float64)
+	fitMode, _ := certMap["pdf_fit_mode"].(string)
+	return ResolvePdfLayout(marginMm, fitMode)
+}
+
+func clampPdfMargin(marginMm float64) float64 {
+	if marginMm < 0 {
+		return 0
+	}
+	if marginMm > maxPdfMarginMm {
+		return maxPdfMarginMm
+	}
+	return marginMm
+}
+
+// pdfRect is a rectangle on the PDF page in mm
+type pdfRect struct {
+	X, Y, W, H float64
+}
+
+// pdfImagePlacement computes where the design goes inside area. imageAspect is the design's
+// width/height ratio; fill stretches to the area, contain letterboxes and cover overflows it.
+func pdfImagePlacement(fitMode string, area pdfRect, imageAspect float64) pdfRect {
+	if fitMode == PdfFitFill || imageAspect <= 0 || area.W <= 0 || area.H <= 0 {
+		return area
+	}
+
+	areaAspect := area.W / area.H
+	w, h := area.W, area.H
+	fitWidth := imageAspect > areaAspect
+	if fitMode == PdfFitCover {
+		fitWidth = !fitWidth
+	}
+
+	if fitWidth {
+		h = area.W / imageAspect
+	} else {
+		w = area.H * imageAspect
+	}
+
+	return pdfRect{
+		X: area.X + (area.W-w)/2,
+		Y: area.Y + (area.H-h)/2,
+		W: w,
+		H: h,
+	}
+}
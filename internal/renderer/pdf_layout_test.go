@@ -0,0 +1,86 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestPdfImagePlacement(t *testing.T) {
+	area := pdfRect{X: 10, Y: 10, W: 200, H: 100}
+
+	tests := []struct {
+		name   string
+		mode   string
+		aspect float64
+		want   pdfRect
+	}{
+		{name: "fill stretches", mode: PdfFitFill, aspect: 1, want: area},
+		{name: "contain wide image", mode: PdfFitContain, aspect: 4, want: pdfRect{X: 10, Y: 35, W: 200, H: 50}},
+		{name: "contain tall image", mode: PdfFitContain, aspect: 1, want: pdfRect{X: 60, Y: 10, W: 100, H: 100}},
+		{name: "cover tall image", mode: PdfFitCover, aspect: 1, want: pdfRect{X: 10, Y: -40, W: 200, H: 200}},
+		{name: "cover wide image", mode: PdfFitCover, aspect: 4, want: pdfRect{X: -90, Y: 10, W: 400, H: 100}},
+		{name: "unknown aspect falls back to area", mode: PdfFitContain, aspect: 0, want: area},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pdfImagePlacement(tt.mode, area, tt.aspect)
+			if math.Abs(got.X-tt.want.X) > 1e-9 || math.Abs(got.Y-tt.want.Y) > 1e-9 ||
+				math.Abs(got.W-tt.want.W) > 1e-9 || math.Abs(got.H-tt.want.H) > 1e-9 {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResolvePdfLayout(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+
+	margin := func(m float64) *float64 { return &m }
+	mode := func(m string) *string { return &m }
+
+	common.Config = &shared.Config{}
+	if got := ResolvePdfLayout(nil, ""); got != (PdfLayout{MarginMm: 0, FitMode: PdfFitFill}) {
+		t.Errorf("expected fill without margin by default, got %+v", got)
+	}
+
+	common.Config = &shared.Config{PdfMarginMm: margin(8), PdfFitMode: mode(PdfFitContain)}
+	if got := ResolvePdfLayout(nil, ""); got != (PdfLayout{MarginMm: 8, FitMode: PdfFitContain}) {
+		t.Errorf("expected config defaults, got %+v", got)
+	}
+	if got := ResolvePdfLayout(margin(0), PdfFitCover); got != (PdfLayout{MarginMm: 0, FitMode: PdfFitCover}) {
+		t.Errorf("expected certificate overrides, got %+v", got)
+	}
+	if got := ResolvePdfLayout(margin(500), "stretch"); got != (PdfLayout{MarginMm: maxPdfMarginMm, FitMode: PdfFitContain}) {
+		t.Errorf("expected clamped margin and default mode, got %+v", got)
+	}
+}
+
+func TestConvertToPDFWithLayout(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+	imageBase64 := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	r := &EmbeddedRenderer{}
+	for _, fitMode := range []string{PdfFitFill, PdfFitContain, PdfFitCover} {
+		for _, withFooter := range []bool{false, true} {
+			pdfBytes, err := r.ConvertToPDFWithLayout(imageBase64, "participant-1", "cert-1", withFooter, PdfLayout{MarginMm: 10, FitMode: fitMode})
+			if err != nil {
+				t.Fatalf("ConvertToPDFWithLayout(%s, footer=%v) failed: %v", fitMode, withFooter, err)
+			}
+			if !bytes.HasPrefix(pdfBytes, []byte("%PDF")) {
+				t.Errorf("ConvertToPDFWithLayout(%s, footer=%v) did not return a PDF", fitMode, withFooter)
+			}
+		}
+	}
+}
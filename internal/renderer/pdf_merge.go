@@ -0,0 +1,398 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	digitorus_pdf "github.com/digitorus/pdf"
+)
+
+// Page attributes a page may inherit from its ancestors in the page tree
+var inheritablePageKeys = []string{"Resources", "MediaBox", "CropBox", "Rotate"}
+
+// Page entries that are not copied: the page tree is rebuilt, and annotations such as signature
+// widgets belong to the source document's form
+var skippedPageKeys = map[string]bool{
+	"Parent": true,
+	"Annots": true,
+}
+
+const (
+	mergedCatalogID = 1
+	mergedPagesID   = 2
+)
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// PDFMerger writes one PDF holding the pages of every added document. Each document's objects are
+// written as soon as it is added, so only one source document has to be held in memory at a time.
+// Page content and resources are copied as is; document level data such as outlines, forms and
+// digital signatures is not carried over.
+type PDFMerger struct {
+	w       *countingWriter
+	offsets []int64 // offsets[i] is the offset of object i+1, 0 while reserved
+	kids    []int
+}
+
+// NewPDFMerger starts a merged PDF on w
+func NewPDFMerger(w io.Writer) (*PDFMerger, error) {
+	m := &PDFMerger{
+		w:       &countingWriter{w: w},
+		offsets: make([]int64, mergedPagesID),
+	}
+	if _, err := io.WriteString(m.w, "%PDF-1.7\n%\xe2\xe3\xcf\xd3\n"); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PageCount returns the number of pages merged so far
+func (m *PDFMerger) PageCount() int {
+	return len(m.kids)
+}
+
+type pdfObject struct {
+	id   int
+	body []byte
+}
+
+type pdfObjectKey struct {
+	id  uint32
+	gen uint16
+}
+
+// pdfDocumentCopy collects the objects of one source document before they are written, so a document
+// that fails halfway leaves no trace in the merged output
+type pdfDocumentCopy struct {
+	merger  *PDFMerger
+	data    []byte
+	nextID  int
+	copied  map[pdfObjectKey]int
+	objects []pdfObject
+	kids    []int
+}
+
+// AddDocument appends every page of a PDF and returns how many pages were added.
+// On error nothing of the document is written and the merged PDF stays usable.
+func (m *PDFMerger) AddDocument(data []byte) (pages int, err error) {
+	defer func() {
+		// The PDF reader panics on malformed documents
+		if r := recover(); r != nil {
+			pages, err = 0, fmt.Errorf("malformed PDF: %v", r)
+		}
+	}()
+
+	reader, err := digitorus_pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read PDF: %w", err)
+	}
+	if !reader.Trailer().Key("Encrypt").IsNull() {
+		return 0, fmt.Errorf("encrypted PDFs cannot be merged")
+	}
+
+	doc := &pdfDocumentCopy{
+		merger: m,
+		data:   data,
+		nextID: len(m.offsets) + 1,
+		copied: make(map[pdfObjectKey]int),
+	}
+	for i := 1; i <= reader.NumPage(); i++ {
+		if err := doc.copyPage(reader.Page(i).V); err != nil {
+			return 0, fmt.Errorf("failed to copy page %d: %w", i, err)
+		}
+	}
+
+	for _, object := range doc.objects {
+		for len(m.offsets) < object.id {
+			m.offsets = append(m.offsets, 0)
+		}
+		m.offsets[object.id-1] = m.w.n
+		if err := m.writeObject(object.id, object.body); err != nil {
+			return 0, err
+		}
+	}
+	m.kids = append(m.kids, doc.kids...)
+
+	return len(doc.kids), nil
+}
+
+// Close writes the page tree, catalog and cross-reference table that complete the merged PDF
+func (m *PDFMerger) Close() error {
+	kids := make([]string, len(m.kids))
+	for i, id := range m.kids {
+		kids[i] = fmt.Sprintf("%d 0 R", id)
+	}
+
+	m.offsets[mergedPagesID-1] = m.w.n
+	pages := fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(m.kids))
+	if err := m.writeObject(mergedPagesID, []byte(pages)); err != nil {
+		return err
+	}
+
+	m.offsets[mergedCatalogID-1] = m.w.n
+	catalog := fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", mergedPagesID)
+	if err := m.writeObject(mergedCatalogID, []byte(catalog)); err != nil {
+		return err
+	}
+
+	xrefOffset := m.w.n
+	var xref bytes.Buffer
+	fmt.Fprintf(&xref, "xref\n0 %d\n0000000000 65535 f \n", len(m.offsets)+1)
+	for _, offset := range m.offsets {
+		fmt.Fprintf(&xref, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&xref, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(m.offsets)+1, mergedCatalogID, xrefOffset)
+
+	_, err := m.w.Write(xref.Bytes())
+	return err
+}
+
+func (m *PDFMerger) writeObject(id int, body []byte) error {
+	if _, err := fmt.Fprintf(m.w, "%d 0 obj\n", id); err != nil {
+		return err
+	}
+	if _, err := m.w.Write(body); err != nil {
+		return err
+	}
+	_, err := io.WriteString(m.w, "\nendobj\n")
+	return err
+}
+
+func (d *pdfDocumentCopy) allocate() int {
+	id := d.nextID
+	d.nextID++
+	return id
+}
+
+// copyPage copies a page dictionary, resolving inherited attributes and pointing it at the merged page tree
+func (d *pdfDocumentCopy) copyPage(page digitorus_pdf.Value) error {
+	id := d.allocate()
+	d.copied[objectKey(page)] = id
+
+	var body strings.Builder
+	body.WriteString("<<")
+	for _, key := range page.Keys() {
+		if skippedPageKeys[key] {
+			continue
+		}
+		value, err := d.value(page.Key(key), page)
+		if err != nil {
+			return err
+		}
+		body.WriteString(" /" + escapePDFName(key) + " " + value)
+	}
+	for _, key := range inheritablePageKeys {
+		if !page.Key(key).IsNull() {
+			continue
+		}
+		inherited, owner := inheritedPageValue(page, key)
+		if inherited.IsNull() {
+			continue
+		}
+		value, err := d.value(inherited, owner)
+		if err != nil {
+			return err
+		}
+		body.WriteString(" /" + key + " " + value)
+	}
+	fmt.Fprintf(&body, " /Parent %d 0 R >>", mergedPagesID)
+
+	d.objects = append(d.objects, pdfObject{id: id, body: []byte(body.String())})
+	d.kids = append(d.kids, id)
+	return nil
+}
+
+// inheritedPageValue looks key up in the page's ancestors and returns it with the node it was found on
+func inheritedPageValue(page digitorus_pdf.Value, key string) (digitorus_pdf.Value, digitorus_pdf.Value) {
+	for node := page.Key("Parent"); !node.IsNull(); node = node.Key("Parent") {
+		if value := node.Key(key); !value.IsNull() {
+			return value, node
+		}
+	}
+	return digitorus_pdf.Value{}, digitorus_pdf.Value{}
+}
+
+func objectKey(v digitorus_pdf.Value) pdfObjectKey {
+	ptr := v.GetPtr()
+	return pdfObjectKey{id: ptr.GetID(), gen: ptr.GetGen()}
+}
+
+// value serializes v, which was read from parent. Values stored as separate objects in the source
+// (the reader reports them with their own object pointer) are copied once and referenced.
+func (d *pdfDocumentCopy) value(v digitorus_pdf.Value, parent digitorus_pdf.Value) (string, error) {
+	if v.Kind() == digitorus_pdf.Stream || (v.Kind() != digitorus_pdf.Null && v.GetPtr() != parent.GetPtr()) {
+		id, err := d.reference(v)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d 0 R", id), nil
+	}
+	return d.direct(v)
+}
+
+func (d *pdfDocumentCopy) reference(v digitorus_pdf.Value) (int, error) {
+	key := objectKey(v)
+	if id, ok := d.copied[key]; ok {
+		return id, nil
+	}
+
+	id := d.allocate()
+	d.copied[key] = id
+
+	var body []byte
+	if v.Kind() == digitorus_pdf.Stream {
+		stream, err := d.stream(v)
+		if err != nil {
+			return 0, err
+		}
+		body = stream
+	} else {
+		direct, err := d.direct(v)
+		if err != nil {
+			return 0, err
+		}
+		body = []byte(direct)
+	}
+
+	d.objects = append(d.objects, pdfObject{id: id, body: body})
+	return id, nil
+}
+
+func (d *pdfDocumentCopy) direct(v digitorus_pdf.Value) (string, error) {
+	switch v.Kind() {
+	case digitorus_pdf.Null:
+		return "null", nil
+	case digitorus_pdf.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case digitorus_pdf.Integer:
+		return strconv.FormatInt(v.Int64(), 10), nil
+	case digitorus_pdf.Real:
+		return strconv.FormatFloat(v.Float64(), 'f', -1, 64), nil
+	case digitorus_pdf.String:
+		return "<" + hex.EncodeToString([]byte(v.RawString())) + ">", nil
+	case digitorus_pdf.Name:
+		return "/" + escapePDFName(v.Name()), nil
+	case digitorus_pdf.Array:
+		items := make([]string, v.Len())
+		for i := range items {
+			item, err := d.value(v.Index(i), v)
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return "[" + strings.Join(items, " ") + "]", nil
+	case digitorus_pdf.Dict:
+		return d.dict(v, nil)
+	default:
+		return "", fmt.Errorf("unexpected PDF value %v", v)
+	}
+}
+
+func (d *pdfDocumentCopy) dict(v digitorus_pdf.Value, skip map[string]bool) (string, error) {
+	var body strings.Builder
+	body.WriteString("<<")
+	for _, key := range v.Keys() {
+		if skip[key] {
+			continue
+		}
+		value, err := d.value(v.Key(key), v)
+		if err != nil {
+			return "", err
+		}
+		body.WriteString(" /" + escapePDFName(key) + " " + value)
+	}
+	body.WriteString(" >>")
+	return body.String(), nil
+}
+
+// stream copies a stream with its data still encoded, so filters the reader cannot decode are kept intact
+func (d *pdfDocumentCopy) stream(v digitorus_pdf.Value) ([]byte, error) {
+	length := v.Key("Length").Int64()
+	data, err := rawStreamData(d.data, objectKey(v), length)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := d.dict(v, map[string]bool{"Length": true})
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	body.WriteString(strings.TrimSuffix(header, ">>"))
+	fmt.Fprintf(&body, "/Length %d >>\nstream\n", len(data))
+	body.Write(data)
+	body.WriteString("\nendstream")
+	return body.Bytes(), nil
+}
+
+// rawStreamData returns the encoded data of a stream object by locating its latest definition in the file.
+// Objects packed into object streams are not supported.
+func rawStreamData(data []byte, key pdfObjectKey, length int64) ([]byte, error) {
+	marker := []byte(fmt.Sprintf("%d %d obj", key.id, key.gen))
+
+	search := data
+	start := -1
+	for {
+		idx := bytes.LastIndex(search, marker)
+		if idx < 0 {
+			break
+		}
+		if idx == 0 || !isPDFDigit(search[idx-1]) {
+			start = idx + len(marker)
+			break
+		}
+		search = search[:idx]
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("stream object %d %d not found", key.id, key.gen)
+	}
+
+	keyword := bytes.Index(data[start:], []byte("stream"))
+	if keyword < 0 {
+		return nil, fmt.Errorf("stream object %d %d has no data", key.id, key.gen)
+	}
+	offset := start + keyword + len("stream")
+	if bytes.HasPrefix(data[offset:], []byte("\r\n")) {
+		offset += 2
+	} else if offset < len(data) && (data[offset] == '\n' || data[offset] == '\r') {
+		offset++
+	}
+
+	if length < 0 || int64(offset)+length > int64(len(data)) {
+		return nil, fmt.Errorf("stream object %d %d is truncated", key.id, key.gen)
+	}
+	return data[offset : int64(offset)+length], nil
+}
+
+func isPDFDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// escapePDFName encodes the characters a PDF name cannot hold literally as #xx
+func escapePDFName(name string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c < '!' || c > '~' || strings.IndexByte("#/()<>[]{}%", c) >= 0 {
+			fmt.Fprintf(&escaped, "#%02X", c)
+		} else {
+			escaped.WriteByte(c)
+		}
+	}
+	return escaped.String()
+}
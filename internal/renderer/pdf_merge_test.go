@@ -0,0 +1,125 @@
+package renderer
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	digitorus_pdf "github.com/digitorus/pdf"
+	"github.com/jung-kurt/gofpdf"
+)
+
+func buildTestPDF(t *testing.T, texts ...string) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := range img.Pix {
+		img.Pix[i] = 0x80
+	}
+	img.SetNRGBA(0, 0, color.NRGBA{R: 255, A: 255})
+	var imgBuf bytes.Buffer
+	if err := png.Encode(&imgBuf, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.RegisterImageOptionsReader("logo", gofpdf.ImageOptions{ImageType: "PNG"}, &imgBuf)
+	for _, text := range texts {
+		pdf.AddPage()
+		pdf.Image("logo", 10, 10, 20, 20, false, "", 0, "")
+		pdf.SetFont("Helvetica", "", 12)
+		pdf.Text(40, 20, text)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatalf("failed to build PDF: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func pageText(t *testing.T, page digitorus_pdf.Page) string {
+	t.Helper()
+
+	var text strings.Builder
+	for _, item := range page.Content().Text {
+		text.WriteString(item.S)
+	}
+	return text.String()
+}
+
+func TestPDFMerger(t *testing.T) {
+	var out bytes.Buffer
+	merger, err := NewPDFMerger(&out)
+	if err != nil {
+		t.Fatalf("NewPDFMerger() error = %v", err)
+	}
+
+	if pages, err := merger.AddDocument(buildTestPDF(t, "Alice")); err != nil || pages != 1 {
+		t.Fatalf("AddDocument(Alice) = %d, %v, want 1 page", pages, err)
+	}
+	if _, err := merger.AddDocument([]byte("%PDF-1.4\nnot really a pdf")); err == nil {
+		t.Error("expected an error for a malformed document")
+	}
+	if pages, err := merger.AddDocument(buildTestPDF(t, "Bob", "Carol")); err != nil || pages != 2 {
+		t.Fatalf("AddDocument(Bob, Carol) = %d, %v, want 2 pages", pages, err)
+	}
+	if merger.PageCount() != 3 {
+		t.Errorf("PageCount() = %d, want 3", merger.PageCount())
+	}
+	if err := merger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reader, err := digitorus_pdf.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("merged PDF is not readable: %v", err)
+	}
+	if reader.NumPage() != 3 {
+		t.Fatalf("merged PDF has %d pages, want 3", reader.NumPage())
+	}
+
+	for i, want := range []string{"Alice", "Bob", "Carol"} {
+		page := reader.Page(i + 1)
+		if got := pageText(t, page); got != want {
+			t.Errorf("page %d text = %q, want %q", i+1, got, want)
+		}
+		if page.V.Key("MediaBox").Len() != 4 {
+			t.Errorf("page %d lost its inherited media box", i+1)
+		}
+		if page.Resources().Key("XObject").Kind() != digitorus_pdf.Dict {
+			t.Errorf("page %d lost its image resources", i+1)
+		}
+	}
+}
+
+func TestPDFMergerEmpty(t *testing.T) {
+	var out bytes.Buffer
+	merger, err := NewPDFMerger(&out)
+	if err != nil {
+		t.Fatalf("NewPDFMerger() error = %v", err)
+	}
+	if err := merger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reader, err := digitorus_pdf.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("empty merged PDF is not readable: %v", err)
+	}
+	if reader.NumPage() != 0 {
+		t.Errorf("empty merged PDF has %d pages", reader.NumPage())
+	}
+}
+
+func TestEscapePDFName(t *testing.T) {
+	if got := escapePDFName("Im1"); got != "Im1" {
+		t.Errorf("escapePDFName(Im1) = %q", got)
+	}
+	if got := escapePDFName("A B#/"); got != "A#20B#23#2F" {
+		t.Errorf("escapePDFName(A B#/) = %q", got)
+	}
+}
@@ -0,0 +1,167 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const (
+	// DefaultQRSheetLabelField is the participant field printed under each QR code
+	DefaultQRSheetLabelField = "name"
+	// QRSheetArchiveEntry is the name of the QR codes sheet inside the certificate ZIP archive
+	QRSheetArchiveEntry = "qr_codes.pdf"
+
+	qrSheetColumns  = 3
+	qrSheetCellMm   = 60.0
+	qrSheetImageMm  = 42.0
+	qrSheetHeaderMm = 14.0
+)
+
+// QRSheetEntry is one participant's verification QR code (base64 PNG, as returned by GenerateQRCodes) and label
+type QRSheetEntry struct {
+	ParticipantID string
+	Label         string
+	QRCode        string
+}
+
+// archiveIncludesQRSheet reports whether generated ZIP archives also hold a QR codes sheet (archive_include_qr_sheet)
+func archiveIncludesQRSheet() bool {
+	return common.Config != nil && common.Config.ArchiveIncludeQRSheet != nil && *common.Config.ArchiveIncludeQRSheet
+}
+
+// QRSheetEntries pairs each participant with its generated QR code, labelled with labelField and falling back
+// to the participant ID, in participant order
+func QRSheetEntries(participants []any, qrCodes map[string]string, labelField string) []QRSheetEntry {
+	entries := make([]QRSheetEntry, 0, len(participants))
+	for _, p := range participants {
+		fields := participantFields(p)
+		participantID, _ := fields["id"].(string)
+		if participantID == "" {
+			continue
+		}
+
+		label := participantID
+		if value, ok := fields[labelField]; ok && value != nil {
+			if text := strings.TrimSpace(fmt.Sprint(value)); text != "" {
+				label = text
+			}
+		}
+
+		entries = append(entries, QRSheetEntry{ParticipantID: participantID, Label: label, QRCode: qrCodes[participantID]})
+	}
+	return entries
+}
+
+// ParticipantQRSheetEntries generates the verification QR codes of participants and labels them with labelField
+func ParticipantQRSheetEntries(participants []any, certificateID string, verifyHost string, labelField string) []QRSheetEntry {
+	// QR generation doesn't use the renderer process, so no renderer has to be started for it
+	var r EmbeddedRenderer
+	return QRSheetEntries(participants, r.GenerateQRCodes(participants, certificateID, verifyHost), labelField)
+}
+
+// BuildQRCodeSheet renders entries as an A4 PDF grid for printing, one labelled QR code per cell
+func BuildQRCodeSheet(title string, entries []QRSheetEntry) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(reportMarginMm, reportMarginMm, reportMarginMm)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-reportMarginMm + 2)
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 4, fmt.Sprintf("Page %d", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	addQRCodeSheetPages(pdf, title, entries)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate QR codes sheet: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// addQRCodeSheetPages lays entries out on new pages of pdf, qrSheetColumns per row, with title above each page's grid.
+// Entries whose QR code is missing or unreadable keep their cell with a note so the grid stays in participant order.
+func addQRCodeSheetPages(pdf *gofpdf.Fpdf, title string, entries []QRSheetEntry) {
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	autoPageBreak, breakMargin := pdf.GetAutoPageBreak()
+	pdf.SetAutoPageBreak(false, 0)
+	defer pdf.SetAutoPageBreak(autoPageBreak, breakMargin)
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	left, top, _, _ := pdf.GetMargins()
+	gridLeft := left + (pageWidth-2*left-qrSheetColumns*qrSheetCellMm)/2
+	rowsPerPage := max(1, int((pageHeight-2*top-qrSheetHeaderMm)/qrSheetCellMm))
+	perPage := rowsPerPage * qrSheetColumns
+
+	if len(entries) == 0 {
+		addQRCodeSheetPage(pdf, tr(title), pageWidth-2*left)
+		pdf.SetFont("Helvetica", "I", 9)
+		pdf.CellFormat(0, reportLineHeightMm, "There are no participants to print QR codes for.", "", 1, "L", false, 0, "")
+		return
+	}
+
+	for i, entry := range entries {
+		if i%perPage == 0 {
+			addQRCodeSheetPage(pdf, tr(title), pageWidth-2*left)
+		}
+
+		slot := i % perPage
+		x := gridLeft + float64(slot%qrSheetColumns)*qrSheetCellMm
+		y := top + qrSheetHeaderMm + float64(slot/qrSheetColumns)*qrSheetCellMm
+
+		pdf.SetDrawColor(209, 213, 219)
+		pdf.Rect(x, y, qrSheetCellMm, qrSheetCellMm, "D")
+
+		imageX := x + (qrSheetCellMm-qrSheetImageMm)/2
+		imageY := y + 3
+		if !drawQRCodeImage(pdf, entry, imageX, imageY) {
+			pdf.SetFont("Helvetica", "I", 8)
+			pdf.SetTextColor(128, 128, 128)
+			pdf.SetXY(imageX, imageY+qrSheetImageMm/2-2)
+			pdf.CellFormat(qrSheetImageMm, 4, "QR code unavailable", "", 0, "C", false, 0, "")
+		}
+
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetXY(x+2, imageY+qrSheetImageMm+1)
+		pdf.CellFormat(qrSheetCellMm-4, 5, fitReportCell(pdf, tr(entry.Label), qrSheetCellMm-6), "", 0, "C", false, 0, "")
+
+		pdf.SetTextColor(107, 114, 128)
+		pdf.SetFont("Helvetica", "", 6)
+		pdf.SetXY(x+2, imageY+qrSheetImageMm+6)
+		pdf.CellFormat(qrSheetCellMm-4, 4, fitReportCell(pdf, entry.ParticipantID, qrSheetCellMm-6), "", 0, "C", false, 0, "")
+	}
+}
+
+// addQRCodeSheetPage starts a page of the QR codes grid with its title
+func addQRCodeSheetPage(pdf *gofpdf.Fpdf, title string, contentWidth float64) {
+	pdf.AddPage()
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(contentWidth, 10, fitReportCell(pdf, "Verification QR Codes - "+title, contentWidth), "", 1, "L", false, 0, "")
+}
+
+// drawQRCodeImage places an entry's QR code at x, y and reports whether it could be decoded
+func drawQRCodeImage(pdf *gofpdf.Fpdf, entry QRSheetEntry, x float64, y float64) bool {
+	if entry.QRCode == "" {
+		return false
+	}
+	data, err := base64.StdEncoding.DecodeString(entry.QRCode)
+	if err != nil {
+		return false
+	}
+
+	name := "qr-" + entry.ParticipantID
+	if info := pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(data)); info == nil || !pdf.Ok() {
+		// A bad image puts the whole document in an error state, so drop it and carry on without the image
+		pdf.ClearError()
+		return false
+	}
+	pdf.ImageOptions(name, x, y, qrSheetImageMm, qrSheetImageMm, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	return true
+}
@@ -0,0 +1,96 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	digitorus_pdf "github.com/digitorus/pdf"
+)
+
+func TestQRSheetEntries(t *testing.T) {
+	participants := []any{
+		map[string]any{"id": "p1", "data": map[string]any{"name": "Alice"}},
+		map[string]any{"id": "p2", "data": map[string]any{"name": "  "}},
+		map[string]any{"data": map[string]any{"name": "No ID"}},
+	}
+
+	entries := QRSheetEntries(participants, map[string]string{"p1": "qr1"}, "name")
+	if len(entries) != 2 {
+		t.Fatalf("QRSheetEntries() returned %d entries, want 2", len(entries))
+	}
+	if entries[0] != (QRSheetEntry{ParticipantID: "p1", Label: "Alice", QRCode: "qr1"}) {
+		t.Errorf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].Label != "p2" || entries[1].QRCode != "" {
+		t.Errorf("blank label should fall back to the participant ID, got %+v", entries[1])
+	}
+}
+
+func TestBuildQRCodeSheet(t *testing.T) {
+	var participants []any
+	for i := 0; i < 14; i++ {
+		participants = append(participants, map[string]any{"id": fmt.Sprintf("p%d", i), "data": map[string]any{"name": fmt.Sprintf("Guest %d", i)}})
+	}
+	entries := ParticipantQRSheetEntries(participants, "cert-1", "https://verify.example.com", "name")
+	entries[1].QRCode = "not base64!"
+	entries[2].QRCode = ""
+
+	data, err := BuildQRCodeSheet("Award 2026", entries)
+	if err != nil {
+		t.Fatalf("BuildQRCodeSheet() error = %v", err)
+	}
+
+	reader, err := digitorus_pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("QR sheet is not a readable PDF: %v", err)
+	}
+	// 12 codes fit on an A4 page
+	if reader.NumPage() != 2 {
+		t.Fatalf("QR sheet has %d pages, want 2", reader.NumPage())
+	}
+
+	var text strings.Builder
+	for i := 1; i <= reader.NumPage(); i++ {
+		text.WriteString(pageText(t, reader.Page(i)))
+	}
+	for _, want := range []string{"Award2026", "Guest0", "Guest13", "p13", "QRcodeunavailable"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("QR sheet text is missing %q", want)
+		}
+	}
+}
+
+func TestBuildQRCodeSheetEmpty(t *testing.T) {
+	data, err := BuildQRCodeSheet("Award", nil)
+	if err != nil || !bytes.HasPrefix(data, []byte("%PDF")) {
+		t.Fatalf("BuildQRCodeSheet(nil) = %d bytes, %v", len(data), err)
+	}
+}
+
+func TestBuildVerificationReportWithQRCodes(t *testing.T) {
+	report := &VerificationReport{CertificateID: "cert-1", CertificateName: "Award", GeneratedAt: time.Now()}
+	withoutQR, err := BuildVerificationReport(report)
+	if err != nil {
+		t.Fatalf("BuildVerificationReport() error = %v", err)
+	}
+
+	report.QRCodes = ParticipantQRSheetEntries([]any{map[string]any{"id": "p1"}}, "cert-1", "https://verify.example.com", "name")
+	withQR, err := BuildVerificationReport(report)
+	if err != nil {
+		t.Fatalf("BuildVerificationReport() with QR codes error = %v", err)
+	}
+
+	pages := func(data []byte) int {
+		reader, err := digitorus_pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("report is not a readable PDF: %v", err)
+		}
+		return reader.NumPage()
+	}
+	if pages(withQR) != pages(withoutQR)+1 {
+		t.Errorf("expected the QR codes sheet on one extra page, got %d and %d pages", pages(withQR), pages(withoutQR))
+	}
+}
@@ -121,6 +121,7 @@ interface RenderRequest {
 	qrCodes?: { [participantId: string]: string }; // base64 QR codes from Go
 	signatures?: { [signerId: string]: string }; // base64 signature images from Go
 	watermark?: string; // base64 watermark image from Go
+	defaultFont?: string; // configured default font family from Go
 }
 
 interface ThumbnailRequest {
@@ -493,7 +494,10 @@ function cleanDesignForThumbnail(design: any): any {
 }
 
 // Load canvas with fallback handling
-async function loadCanvasWithImageFallback(designStr: string): Promise<fabric.Canvas> {
+async function loadCanvasWithImageFallback(
+	designStr: string,
+	defaultFont?: string
+): Promise<fabric.Canvas> {
 	const design = withTiming("loadCanvas:parseDesign", () => JSON.parse(designStr));
 
 	// Create fabric canvas with high-quality settings
@@ -510,6 +514,7 @@ async function loadCanvasWithImageFallback(designStr: string): Promise<fabric.Ca
 	);
 
 	// Add font fallbacks for production environment
+	const fallbackFonts = defaultFont ? `${defaultFont}, Arial, sans-serif` : "Arial, sans-serif";
 	if (design.objects && Array.isArray(design.objects)) {
 		try {
 			design.objects = design.objects.map((obj: any, idx: number) => {
@@ -522,9 +527,9 @@ async function loadCanvasWithImageFallback(designStr: string): Promise<fabric.Ca
 					) {
 						// Ensure font fallbacks for production
 						if (obj.fontFamily && !obj.fontFamily.includes(",")) {
-							obj.fontFamily = `${obj.fontFamily}, Arial, sans-serif`;
+							obj.fontFamily = `${obj.fontFamily}, ${fallbackFonts}`;
 						} else if (!obj.fontFamily) {
-							obj.fontFamily = "Arial, sans-serif";
+							obj.fontFamily = fallbackFonts;
 						}
 						vLog("loadCanvas:textObject", {
 							idx,
@@ -586,7 +591,8 @@ async function generateCertificateImage(
 	participant: ParticipantData,
 	qrCode?: string,
 	signatures?: { [signerId: string]: string },
-	watermark?: string
+	watermark?: string,
+	defaultFont?: string
 ): Promise<RenderResult> {
 	try {
 		vLog("generateCertificateImage:start", {
@@ -604,7 +610,7 @@ async function generateCertificateImage(
 
 		// Load canvas
 		const canvas = await withTimingAsync("generateCertificateImage:loadCanvas", () =>
-			loadCanvasWithImageFallback(processedDesign)
+			loadCanvasWithImageFallback(processedDesign, defaultFont)
 		);
 
 		// Export as base64 with high quality settings
@@ -826,7 +832,8 @@ async function processRenderRequest(request: RenderRequest): Promise<RenderResul
 			participant,
 			qrCode,
 			request.signatures,
-			request.watermark
+			request.watermark,
+			request.defaultFont
 		);
 	};
 
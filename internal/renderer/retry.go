@@ -0,0 +1,153 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const (
+	defaultRendererMaxRetries = 2
+	rendererRetryBackoff      = time.Second
+)
+
+// deterministicRendererErrors are stderr markers of failures that fail the same way on every attempt
+var deterministicRendererErrors = []string{
+	"JSON Parse error",
+	"SyntaxError",
+	"Unexpected token",
+	"No input data received",
+	"Invalid request format",
+	"Invalid thumbnail request format",
+}
+
+// rendererExitError is returned when the renderer subprocess exits with a non-zero status
+type rendererExitError struct {
+	err    error
+	stderr string
+}
+
+func (e *rendererExitError) Error() string {
+	return fmt.Sprintf("bun renderer failed: %v, stderr: %s", e.err, e.stderr)
+}
+
+func (e *rendererExitError) Unwrap() error {
+	return e.err
+}
+
+// rendererMaxRetries returns how many times a failed renderer run is retried (renderer_max_retries)
+func rendererMaxRetries() int {
+	if common.Config != nil && common.Config.RendererMaxRetries != nil && *common.Config.RendererMaxRetries >= 0 {
+		return *common.Config.RendererMaxRetries
+	}
+	return defaultRendererMaxRetries
+}
+
+// isTransientRendererFailure reports whether a renderer run failure may succeed when retried.
+// Only non-zero exits without a deterministic error marker (input/parse errors) are retried.
+func isTransientRendererFailure(err error) bool {
+	var exitErr *rendererExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	for _, marker := range deterministicRendererErrors {
+		if strings.Contains(exitErr.stderr, marker) {
+			return false
+		}
+	}
+	return true
+}
+
+// runRenderer executes the renderer subprocess once with requestJSON on stdin and returns its stdout
+func (r *EmbeddedRenderer) runRenderer(ctx context.Context, requestJSON []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, r.binary, "renderer.ts")
+	cmd.Dir = r.rendererDir
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+
+	// Start the command
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start Bun renderer: %w", err)
+	}
+
+	// Send request data
+	go func() {
+		defer stdin.Close()
+		stdin.Write(requestJSON)
+	}()
+
+	// Read output, bounded so a runaway render cannot exhaust memory
+	outputBytes, err := readRendererOutput(cmd, stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	errorBytes, err := io.ReadAll(stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stderr: %w", err)
+	}
+
+	// Wait for command to finish
+	if err := cmd.Wait(); err != nil {
+		return nil, &rendererExitError{err: err, stderr: string(errorBytes)}
+	}
+
+	return outputBytes, nil
+}
+
+// runRendererWithRetry runs the renderer, retrying transient non-zero exits up to renderer_max_retries
+// times with a linear backoff. Deterministic failures and context cancellation are returned immediately.
+func (r *EmbeddedRenderer) runRendererWithRetry(ctx context.Context, requestJSON []byte, certificateID string) ([]byte, error) {
+	maxRetries := rendererMaxRetries()
+
+	for attempt := 1; ; attempt++ {
+		output, err := r.runRenderer(ctx, requestJSON)
+		if err == nil {
+			if attempt > 1 {
+				slog.Info("Renderer succeeded after retry", "certificate_id", certificateID, "attempt", attempt)
+			}
+			return output, nil
+		}
+
+		if ctx.Err() != nil || !isTransientRendererFailure(err) || attempt > maxRetries {
+			slog.Error("Renderer attempt failed, giving up",
+				"certificate_id", certificateID,
+				"attempt", attempt,
+				"max_retries", maxRetries,
+				"error", err)
+			return nil, err
+		}
+
+		slog.Warn("Renderer attempt failed with transient error, retrying",
+			"certificate_id", certificateID,
+			"attempt", attempt,
+			"max_retries", maxRetries,
+			"error", err)
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(time.Duration(attempt) * rendererRetryBackoff):
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+	"github.com/sunthewhat/easy-cert-api/type/shared"
+)
+
+func TestIsTransientRendererFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "crash without marker", err: &rendererExitError{err: errors.New("signal: killed")}, want: true},
+		{name: "json parse error", err: &rendererExitError{err: errors.New("exit status 1"), stderr: `{"error":"JSON Parse error: Unexpected EOF","status":"error"}`}, want: false},
+		{name: "invalid request", err: &rendererExitError{err: errors.New("exit status 1"), stderr: `{"error":"Invalid request format: missing certificate or participants"}`}, want: false},
+		{name: "not an exit error", err: errors.New("failed to start Bun renderer"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientRendererFailure(tt.err); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// fakeRenderer writes a shell script standing in for renderer.ts and runs it with sh
+func fakeRenderer(t *testing.T, script string) *EmbeddedRenderer {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "renderer.ts"), []byte(script), 0o644); err != nil {
+		t.Fatalf("failed to write fake renderer: %v", err)
+	}
+	return &EmbeddedRenderer{rendererDir: dir, binary: "sh"}
+}
+
+func TestRunRendererWithRetry(t *testing.T) {
+	originalConfig := common.Config
+	defer func() { common.Config = originalConfig }()
+	retries := 1
+	common.Config = &shared.Config{RendererMaxRetries: &retries}
+
+	t.Run("transient failure is retried", func(t *testing.T) {
+		r := fakeRenderer(t, `if [ -f attempted ]; then cat >/dev/null; echo '[]'; exit 0; fi
+touch attempted
+exit 137
+`)
+		output, err := r.runRendererWithRetry(context.Background(), []byte("{}"), "cert-1")
+		if err != nil {
+			t.Fatalf("expected success after retry, got %v", err)
+		}
+		if string(output) != "[]\n" {
+			t.Errorf("unexpected output %q", output)
+		}
+	})
+
+	t.Run("deterministic failure is not retried", func(t *testing.T) {
+		r := fakeRenderer(t, `echo attempt >> attempts
+echo '{"error":"JSON Parse error: Unexpected EOF"}' >&2
+exit 1
+`)
+		if _, err := r.runRendererWithRetry(context.Background(), []byte("{}"), "cert-1"); err == nil {
+			t.Fatal("expected an error")
+		}
+		attempts, _ := os.ReadFile(filepath.Join(r.rendererDir, "attempts"))
+		if string(attempts) != "attempt\n" {
+			t.Errorf("expected a single attempt, got %q", attempts)
+		}
+	})
+}
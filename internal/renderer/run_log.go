@@ -0,0 +1,90 @@
+package renderer
+
+import (
+	"sync"
+	"time"
+)
+
+// GenerationFailure is a participant whose certificate could not be generated
+type GenerationFailure struct {
+	ParticipantID string `json:"participant_id"`
+	Error         string `json:"error"`
+}
+
+// GenerationRun summarizes the most recent full generation of a certificate
+type GenerationRun struct {
+	CertificateID string              `json:"certificate_id"`
+	StartedAt     time.Time           `json:"started_at"`
+	FinishedAt    time.Time           `json:"finished_at"`
+	Total         int                 `json:"total"`
+	SuccessCount  int                 `json:"success_count"`
+	FailedCount   int                 `json:"failed_count"`
+	SignedCount   int                 `json:"signed_count"`
+	UnsignedCount int                 `json:"unsigned_count"`
+	Error         string              `json:"error,omitempty"`
+	Failures      []GenerationFailure `json:"failures"`
+}
+
+// generationRuns keeps the last run per certificate in memory; it is reset on restart
+var generationRuns sync.Map
+
+// RecordGenerationRun stores the outcome of a generation run as the certificate's last run.
+// runErr is the error that aborted the whole run, if any.
+func RecordGenerationRun(certificateID string, startedAt time.Time, total int, results []CertificateResult, runErr error) *GenerationRun {
+	run := &GenerationRun{
+		CertificateID: certificateID,
+		StartedAt:     startedAt,
+		FinishedAt:    time.Now(),
+		Total:         total,
+		Failures:      []GenerationFailure{},
+	}
+
+	for _, result := range results {
+		if result.Status == "success" {
+			run.SuccessCount++
+			if result.Signed {
+				run.SignedCount++
+			} else {
+				run.UnsignedCount++
+			}
+			continue
+		}
+		run.Failures = append(run.Failures, GenerationFailure{
+			ParticipantID: result.ParticipantID,
+			Error:         result.Error,
+		})
+	}
+	run.FailedCount = len(run.Failures)
+
+	if runErr != nil {
+		run.Error = runErr.Error()
+		run.FailedCount = total - run.SuccessCount
+	}
+
+	generationRuns.Store(certificateID, run)
+	return run
+}
+
+// LastGenerationRun returns the most recent recorded generation run of a certificate
+func LastGenerationRun(certificateID string) (*GenerationRun, bool) {
+	value, ok := generationRuns.Load(certificateID)
+	if !ok {
+		return nil, false
+	}
+	return value.(*GenerationRun), true
+}
+
+// ParticipantFailure returns the certificate's last recorded generation run and the participant's failure in it,
+// which is nil when the participant was generated successfully or not part of the run
+func ParticipantFailure(certificateID string, participantID string) (*GenerationRun, *GenerationFailure) {
+	run, ok := LastGenerationRun(certificateID)
+	if !ok {
+		return nil, nil
+	}
+	for i := range run.Failures {
+		if run.Failures[i].ParticipantID == participantID {
+			return run, &run.Failures[i]
+		}
+	}
+	return run, nil
+}
@@ -0,0 +1,56 @@
+package renderer
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecordGenerationRun(t *testing.T) {
+	results := []CertificateResult{
+		{ParticipantID: "p1", Status: "success", FilePath: "cert/p1.pdf"},
+		{ParticipantID: "p2", Status: "error", Error: "render timeout"},
+	}
+
+	RecordGenerationRun("cert-run-log", time.Now(), 2, results, nil)
+
+	run, ok := LastGenerationRun("cert-run-log")
+	if !ok {
+		t.Fatal("expected a recorded run")
+	}
+	if run.SuccessCount != 1 || run.FailedCount != 1 {
+		t.Errorf("expected 1 success and 1 failure, got %d and %d", run.SuccessCount, run.FailedCount)
+	}
+	if len(run.Failures) != 1 || run.Failures[0].ParticipantID != "p2" || run.Failures[0].Error != "render timeout" {
+		t.Errorf("unexpected failures: %+v", run.Failures)
+	}
+}
+
+func TestRecordGenerationRun_RunError(t *testing.T) {
+	RecordGenerationRun("cert-run-error", time.Now(), 3, nil, errors.New("renderer crashed"))
+
+	run, ok := LastGenerationRun("cert-run-error")
+	if !ok {
+		t.Fatal("expected a recorded run")
+	}
+	if run.Error != "renderer crashed" || run.FailedCount != 3 {
+		t.Errorf("expected whole run failure, got error=%q failed=%d", run.Error, run.FailedCount)
+	}
+
+	if _, ok := LastGenerationRun("cert-never-generated"); ok {
+		t.Error("expected no run for a certificate that was never generated")
+	}
+}
+
+func TestRecordGenerationRun_SignedCounts(t *testing.T) {
+	results := []CertificateResult{
+		{ParticipantID: "p1", Status: "success", FilePath: "cert/p1.pdf", Signed: true},
+		{ParticipantID: "p2", Status: "success", FilePath: "cert/p2.pdf"},
+		{ParticipantID: "p3", Status: "error", Error: "render timeout"},
+	}
+
+	run := RecordGenerationRun("cert-run-signed", time.Now(), 3, results, nil)
+	if run.SignedCount != 1 || run.UnsignedCount != 1 {
+		t.Errorf("expected 1 signed and 1 unsigned, got %d and %d", run.SignedCount, run.UnsignedCount)
+	}
+}
@@ -0,0 +1,162 @@
+package renderer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const (
+	defaultSignatureMaxWidth  = 1200
+	defaultSignatureMaxHeight = 600
+
+	// maxSignatureImagePixels caps width x height of uploaded signatures before they are decoded;
+	// a small compressed file can otherwise expand into gigabytes of pixels
+	maxSignatureImagePixels = 40_000_000
+)
+
+var ErrSignatureImageTooLarge = errors.New("signature image dimensions are too large")
+
+// CheckSignatureImagePixels reads only the image header and rejects images whose decoded size would exceed
+// the pixel cap, so untrusted uploads are never fully decoded unless they are safe to hold in memory
+func CheckSignatureImagePixels(data []byte) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to read signature image: %w", err)
+	}
+	return checkImagePixels(config)
+}
+
+func checkImagePixels(config image.Config) error {
+	if config.Width < 0 || config.Height < 0 || int64(config.Width)*int64(config.Height) > maxSignatureImagePixels {
+		return fmt.Errorf("%w: %dx%d", ErrSignatureImageTooLarge, config.Width, config.Height)
+	}
+	return nil
+}
+
+// signatureMaxSize returns the box uploaded signature images are scaled down to fit
+// (signature_max_width x signature_max_height)
+func signatureMaxSize() (int, int) {
+	width, height := defaultSignatureMaxWidth, defaultSignatureMaxHeight
+	if common.Config == nil {
+		return width, height
+	}
+	if common.Config.SignatureMaxWidth != nil && *common.Config.SignatureMaxWidth > 0 {
+		width = *common.Config.SignatureMaxWidth
+	}
+	if common.Config.SignatureMaxHeight != nil && *common.Config.SignatureMaxHeight > 0 {
+		height = *common.Config.SignatureMaxHeight
+	}
+	return width, height
+}
+
+// ShrinkSignatureImage scales an uploaded signature down to the configured maximum size keeping its aspect
+// ratio and transparency, re-encoded as PNG. Images that already fit are returned unchanged.
+// It reports whether the image was resized.
+func ShrinkSignatureImage(data []byte) ([]byte, bool, error) {
+	maxWidth, maxHeight := signatureMaxSize()
+	return shrinkSignatureImage(data, maxWidth, maxHeight)
+}
+
+func shrinkSignatureImage(data []byte, maxWidth, maxHeight int) ([]byte, bool, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read signature image: %w", err)
+	}
+	if err := checkImagePixels(config); err != nil {
+		return nil, false, err
+	}
+
+	width, height := fitWithin(config.Width, config.Height, maxWidth, maxHeight)
+	if width == config.Width && height == config.Height {
+		return data, false, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode signature image: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, downscaleImage(src, width, height)); err != nil {
+		return nil, false, fmt.Errorf("failed to encode signature image: %w", err)
+	}
+	return buf.Bytes(), true, nil
+}
+
+// fitWithin fits width x height inside maxWidth x maxHeight keeping the aspect ratio; images are never upscaled
+func fitWithin(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= 0 || height <= 0 || (width <= maxWidth && height <= maxHeight) {
+		return width, height
+	}
+	// Scale by whichever side overflows the most: compare width/maxWidth with height/maxHeight
+	if width*maxHeight >= height*maxWidth {
+		return maxWidth, max(1, height*maxWidth/width)
+	}
+	return max(1, width*maxHeight/height), maxHeight
+}
+
+// FlattenSignatureImage composites a signature onto a solid background color (#rgb, #rgba, #rrggbb or
+// #rrggbbaa) and re-encodes it as PNG, so transparent signatures don't pick up the color of the design
+// underneath. An empty background keeps the image, and its transparency, unchanged.
+func FlattenSignatureImage(data []byte, background string) ([]byte, error) {
+	if background == "" {
+		return data, nil
+	}
+
+	bg, err := parseHexColor(background)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckSignatureImagePixels(data); err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), &image.Uniform{C: bg}, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, fmt.Errorf("failed to encode signature image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// parseHexColor parses a CSS style hex color with an optional alpha component
+func parseHexColor(value string) (color.NRGBA, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) == 3 || len(hex) == 4 {
+		var expanded strings.Builder
+		for _, c := range hex {
+			expanded.WriteRune(c)
+			expanded.WriteRune(c)
+		}
+		hex = expanded.String()
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid background color %q", value)
+	}
+	return color.NRGBA{R: uint8(n >> 24), G: uint8(n >> 16), B: uint8(n >> 8), A: uint8(n)}, nil
+}
@@ -0,0 +1,163 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestFitWithin(t *testing.T) {
+	tests := []struct {
+		name                  string
+		width, height         int
+		maxWidth, maxHeight   int
+		wantWidth, wantHeight int
+	}{
+		{name: "wide image limited by width", width: 4000, height: 1000, maxWidth: 1200, maxHeight: 600, wantWidth: 1200, wantHeight: 300},
+		{name: "tall image limited by height", width: 1000, height: 3000, maxWidth: 1200, maxHeight: 600, wantWidth: 200, wantHeight: 600},
+		{name: "small image kept", width: 800, height: 300, maxWidth: 1200, maxHeight: 600, wantWidth: 800, wantHeight: 300},
+		{name: "extreme ratio keeps one pixel", width: 10000, height: 2, maxWidth: 100, maxHeight: 100, wantWidth: 100, wantHeight: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := fitWithin(tt.width, tt.height, tt.maxWidth, tt.maxHeight)
+			if width != tt.wantWidth || height != tt.wantHeight {
+				t.Errorf("fitWithin() = %dx%d, want %dx%d", width, height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestShrinkSignatureImage(t *testing.T) {
+	// Black stroke on the left half, transparent background on the right
+	src := image.NewNRGBA(image.Rect(0, 0, 400, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 200; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("failed to encode source: %v", err)
+	}
+
+	out, resized, err := shrinkSignatureImage(buf.Bytes(), 100, 100)
+	if err != nil {
+		t.Fatalf("shrinkSignatureImage() error = %v", err)
+	}
+	if !resized {
+		t.Fatal("expected an oversized signature to be resized")
+	}
+
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("output is not a PNG: %v", err)
+	}
+	if img.Bounds().Dx() != 100 || img.Bounds().Dy() != 25 {
+		t.Errorf("output size = %dx%d, want 100x25", img.Bounds().Dx(), img.Bounds().Dy())
+	}
+	if _, _, _, a := img.At(10, 10).RGBA(); a != 0xffff {
+		t.Errorf("stroke alpha = %d, want opaque", a)
+	}
+	if _, _, _, a := img.At(90, 10).RGBA(); a != 0 {
+		t.Errorf("background alpha = %d, want transparent", a)
+	}
+
+	kept, resized, err := shrinkSignatureImage(buf.Bytes(), 1200, 600)
+	if err != nil || resized || !bytes.Equal(kept, buf.Bytes()) {
+		t.Errorf("expected a signature within limits to be returned unchanged")
+	}
+
+	if _, _, err := shrinkSignatureImage([]byte("not an image"), 100, 100); err == nil {
+		t.Error("expected an error for undecodable data")
+	}
+}
+
+// pngWithHeaderSize encodes a 1x1 PNG and rewrites its IHDR chunk to claim width x height
+func pngWithHeaderSize(t *testing.T, width, height uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatalf("failed to encode source: %v", err)
+	}
+	data := buf.Bytes()
+	// 8 byte signature, then IHDR length (4), type (4), width (4), height (4) ... CRC over type+data
+	binary.BigEndian.PutUint32(data[16:20], width)
+	binary.BigEndian.PutUint32(data[20:24], height)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestShrinkSignatureImage_RejectsDecompressionBomb(t *testing.T) {
+	bomb := pngWithHeaderSize(t, 100000, 100000)
+
+	if err := CheckSignatureImagePixels(bomb); !errors.Is(err, ErrSignatureImageTooLarge) {
+		t.Errorf("CheckSignatureImagePixels() error = %v, want ErrSignatureImageTooLarge", err)
+	}
+	if _, _, err := shrinkSignatureImage(bomb, 100, 100); !errors.Is(err, ErrSignatureImageTooLarge) {
+		t.Errorf("shrinkSignatureImage() error = %v, want ErrSignatureImageTooLarge", err)
+	}
+	if _, err := FlattenSignatureImage(bomb, "#ffffff"); !errors.Is(err, ErrSignatureImageTooLarge) {
+		t.Errorf("FlattenSignatureImage() error = %v, want ErrSignatureImageTooLarge", err)
+	}
+
+	if err := CheckSignatureImagePixels(pngWithHeaderSize(t, 2000, 1000)); err != nil {
+		t.Errorf("CheckSignatureImagePixels() error = %v for an ordinary image", err)
+	}
+}
+
+func TestFlattenSignatureImage(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{A: 0})
+	src.SetNRGBA(1, 0, color.NRGBA{R: 0, G: 0, B: 255, A: 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+
+	kept, err := FlattenSignatureImage(buf.Bytes(), "")
+	if err != nil || !bytes.Equal(kept, buf.Bytes()) {
+		t.Fatalf("empty background should keep the image unchanged, err = %v", err)
+	}
+
+	flattened, err := FlattenSignatureImage(buf.Bytes(), "#ff0")
+	if err != nil {
+		t.Fatalf("FlattenSignatureImage() error = %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(flattened))
+	if err != nil {
+		t.Fatalf("flattened image is not a PNG: %v", err)
+	}
+	if got := color.NRGBAModel.Convert(img.At(0, 0)).(color.NRGBA); got != (color.NRGBA{R: 255, G: 255, B: 0, A: 255}) {
+		t.Errorf("transparent pixel = %v, want the background color", got)
+	}
+	if got := color.NRGBAModel.Convert(img.At(1, 0)).(color.NRGBA); got != (color.NRGBA{R: 0, G: 0, B: 255, A: 255}) {
+		t.Errorf("opaque pixel = %v, want it unchanged", got)
+	}
+
+	if _, err := FlattenSignatureImage(buf.Bytes(), "#12345"); err == nil {
+		t.Error("expected an error for an invalid color")
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	tests := map[string]color.NRGBA{
+		"#ffffff":   {R: 255, G: 255, B: 255, A: 255},
+		"#f0f8":     {R: 255, G: 0, B: 255, A: 136},
+		"#11223344": {R: 0x11, G: 0x22, B: 0x33, A: 0x44},
+	}
+	for input, want := range tests {
+		if got, err := parseHexColor(input); err != nil || got != want {
+			t.Errorf("parseHexColor(%q) = %v, %v, want %v", input, got, err, want)
+		}
+	}
+	if _, err := parseHexColor("#zzzzzz"); err == nil {
+		t.Error("expected an error for non-hex digits")
+	}
+}
@@ -2,13 +2,18 @@ package renderer
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"sync"
 	"time"
 
 	digitorus_pdf "github.com/digitorus/pdf"
@@ -22,13 +27,113 @@ type CertificateSigner struct {
 	enabled     bool
 }
 
+// SigningCertificateInfo describes whether PDF signing is active and the expiry state of the signing certificate.
+// Configured without Enabled means signing was requested but the signer failed to load, so PDFs go out unsigned.
+type SigningCertificateInfo struct {
+	Enabled       bool       `json:"enabled"`
+	Configured    bool       `json:"configured"`
+	Error         string     `json:"error,omitempty"`
+	Subject       string     `json:"subject,omitempty"`
+	NotAfter      *time.Time `json:"not_after,omitempty"`
+	DaysRemaining int        `json:"days_remaining"`
+	ExpiringSoon  bool       `json:"expiring_soon"`
+	Expired       bool       `json:"expired"`
+
+	// Details of the loaded certificate, published separately through the public signature info route
+	Details *SignerCertificateDetails `json:"-"`
+}
+
+const defaultSigningCertWarnDays = 30
+
+var (
+	signingCertInfoMu sync.RWMutex
+	signingCertInfo   = SigningCertificateInfo{}
+)
+
+// GetSigningCertificateInfo returns the expiry state recorded by the last NewCertificateSigner call
+func GetSigningCertificateInfo() SigningCertificateInfo {
+	signingCertInfoMu.RLock()
+	defer signingCertInfoMu.RUnlock()
+	return signingCertInfo
+}
+
+// checkCertificateExpiry records the certificate's expiry state and warns
+// when it falls within the configured number of days of NotAfter
+func checkCertificateExpiry(certificate *x509.Certificate) SigningCertificateInfo {
+	warnDays := defaultSigningCertWarnDays
+	if common.Config.SigningCertWarnDays != nil {
+		warnDays = *common.Config.SigningCertWarnDays
+	}
+
+	notAfter := certificate.NotAfter
+	remaining := time.Until(notAfter)
+	info := SigningCertificateInfo{
+		Enabled:       true,
+		Configured:    true,
+		Subject:       certificate.Subject.String(),
+		NotAfter:      &notAfter,
+		DaysRemaining: int(remaining.Hours() / 24),
+		ExpiringSoon:  remaining <= time.Duration(warnDays)*24*time.Hour,
+		Expired:       remaining <= 0,
+		Details:       certificateDetails(certificate),
+	}
+
+	if info.Expired {
+		slog.Error("Signing certificate has expired, signed PDFs will fail validation",
+			"cert_subject", info.Subject,
+			"cert_expiry", notAfter)
+	} else if info.ExpiringSoon {
+		slog.Warn("Signing certificate is close to expiry, rotate it soon",
+			"cert_subject", info.Subject,
+			"cert_expiry", notAfter,
+			"days_remaining", info.DaysRemaining,
+			"warn_days", warnDays)
+	}
+
+	signingCertInfoMu.Lock()
+	signingCertInfo = info
+	signingCertInfoMu.Unlock()
+
+	return info
+}
+
+// recordSignerUnavailable records that signing is off, with the load error when it was configured
+func recordSignerUnavailable(configured bool, err error) {
+	info := SigningCertificateInfo{Configured: configured}
+	if err != nil {
+		info.Error = err.Error()
+	}
+
+	signingCertInfoMu.Lock()
+	signingCertInfo = info
+	signingCertInfoMu.Unlock()
+}
+
+// IsSigningDegraded reports whether signing is configured but not working, either because the signer
+// failed to load or because its certificate has expired
+func (info SigningCertificateInfo) IsSigningDegraded() bool {
+	return info.Configured && (!info.Enabled || info.Expired)
+}
+
 func NewCertificateSigner() (*CertificateSigner, error) {
 	// Check if signing is enabled
 	if common.Config.SigningEnabled == nil || !*common.Config.SigningEnabled {
 		slog.Info("PDF signing disabled in configuration")
+		recordSignerUnavailable(false, nil)
 		return &CertificateSigner{enabled: false}, nil
 	}
 
+	signer, err := loadCertificateSigner()
+	if err != nil {
+		recordSignerUnavailable(true, err)
+		return nil, err
+	}
+	return signer, nil
+}
+
+// loadCertificateSigner reads the configured signing certificate and private key
+func loadCertificateSigner() (*CertificateSigner, error) {
+
 	// Validate required configuration
 	if common.Config.SigningCertPath == nil || common.Config.SigningKeyPath == nil {
 		return nil, fmt.Errorf("signing enabled but certificate or key path not configured")
@@ -78,6 +183,8 @@ func NewCertificateSigner() (*CertificateSigner, error) {
 		}
 	}
 
+	checkCertificateExpiry(certificate)
+
 	slog.Info("Certificate signer initialized successfully",
 		"cert_subject", certificate.Subject.String(),
 		"cert_expiry", certificate.NotAfter)
@@ -215,4 +322,62 @@ func (s *CertificateSigner) SignPDF(pdfBytes []byte, certificateID, participantI
 
 func (s *CertificateSigner) IsEnabled() bool {
 	return s.enabled
-}
\ No newline at end of file
+}
+
+// SignerCertificateDetails is the public metadata of the signing certificate, safe to share with recipients
+type SignerCertificateDetails struct {
+	Subject                       string    `json:"subject"`
+	Issuer                        string    `json:"issuer"`
+	SerialNumber                  string    `json:"serial_number"`
+	NotBefore                     time.Time `json:"not_before"`
+	NotAfter                      time.Time `json:"not_after"`
+	CertificateSignatureAlgorithm string    `json:"certificate_signature_algorithm"`
+	SigningAlgorithm              string    `json:"signing_algorithm"`
+	FingerprintSHA256             string    `json:"fingerprint_sha256"`
+}
+
+// Details returns read-only metadata about the signing certificate, or nil when signing is disabled
+func (s *CertificateSigner) Details() *SignerCertificateDetails {
+	if !s.enabled || s.certificate == nil {
+		return nil
+	}
+	return certificateDetails(s.certificate)
+}
+
+func certificateDetails(certificate *x509.Certificate) *SignerCertificateDetails {
+	fingerprint := sha256.Sum256(certificate.Raw)
+
+	return &SignerCertificateDetails{
+		Subject:                       certificate.Subject.String(),
+		Issuer:                        certificate.Issuer.String(),
+		SerialNumber:                  certificate.SerialNumber.String(),
+		NotBefore:                     certificate.NotBefore,
+		NotAfter:                      certificate.NotAfter,
+		CertificateSignatureAlgorithm: certificate.SignatureAlgorithm.String(),
+		// pdfsign defaults to SHA-256 when SignData.DigestAlgorithm is unset
+		SigningAlgorithm:  "RSA-SHA256",
+		FingerprintSHA256: hex.EncodeToString(fingerprint[:]),
+	}
+}
+
+// manifestSignatureAlgorithm is how SignDetached signs, recorded in the archive manifest for recipients
+const manifestSignatureAlgorithm = "RSA-PKCS1v15-SHA256"
+
+// SignDetached signs data with the PDF signing key, producing a signature that verifies with
+// openssl dgst -sha256 -verify <public key> -signature <signature file> <data file>
+func (s *CertificateSigner) SignDetached(data []byte) ([]byte, error) {
+	if !s.enabled || s.privateKey == nil {
+		return nil, fmt.Errorf("signing is disabled")
+	}
+
+	digest := sha256.Sum256(data)
+	return rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+}
+
+// CertificatePEM returns the signing certificate PEM encoded, or nil when signing is disabled
+func (s *CertificateSigner) CertificatePEM() []byte {
+	if !s.enabled || s.certificate == nil {
+		return nil
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.certificate.Raw})
+}
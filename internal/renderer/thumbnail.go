@@ -0,0 +1,106 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+
+	"github.com/sunthewhat/easy-cert-api/common"
+)
+
+const (
+	defaultThumbnailMaxDimension = 640
+	defaultThumbnailJPEGQuality  = 80
+)
+
+// thumbnailMaxDimension returns the longest side thumbnails are scaled down to (thumbnail_max_dimension)
+func thumbnailMaxDimension() int {
+	if common.Config != nil && common.Config.ThumbnailMaxDimension != nil && *common.Config.ThumbnailMaxDimension > 0 {
+		return *common.Config.ThumbnailMaxDimension
+	}
+	return defaultThumbnailMaxDimension
+}
+
+// thumbnailJPEGQuality returns the JPEG quality thumbnails are encoded with (thumbnail_jpeg_quality)
+func thumbnailJPEGQuality() int {
+	if common.Config != nil && common.Config.ThumbnailJPEGQuality != nil && *common.Config.ThumbnailJPEGQuality > 0 {
+		return min(*common.Config.ThumbnailJPEGQuality, 100)
+	}
+	return defaultThumbnailJPEGQuality
+}
+
+// optimizeThumbnail scales a rendered thumbnail so its longest side is at most maxDimension and re-encodes it
+// as JPEG. Transparent areas are flattened onto white since JPEG has no alpha channel.
+func optimizeThumbnail(imageBytes []byte, maxDimension int, quality int) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(imageBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode thumbnail: %w", err)
+	}
+
+	width, height := thumbnailSize(src.Bounds().Dx(), src.Bounds().Dy(), maxDimension)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flattenOnWhite(downscaleImage(src, width, height)), &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// thumbnailSize fits width x height within maxDimension keeping the aspect ratio; images are never upscaled
+func thumbnailSize(width, height, maxDimension int) (int, int) {
+	longest := max(width, height)
+	if maxDimension <= 0 || longest <= maxDimension {
+		return width, height
+	}
+	return max(1, width*maxDimension/longest), max(1, height*maxDimension/longest)
+}
+
+// downscaleImage resizes src to width x height by averaging the premultiplied source pixels each target
+// pixel covers, so transparent areas stay transparent without dark fringes
+func downscaleImage(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcHeight/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcHeight/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcWidth/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcWidth/width)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					a += uint64(pa)
+					count++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r / count) >> 8),
+				G: uint8((g / count) >> 8),
+				B: uint8((b / count) >> 8),
+				A: uint8((a / count) >> 8),
+			})
+		}
+	}
+
+	return dst
+}
+
+// flattenOnWhite composites img over a white background
+func flattenOnWhite(img image.Image) *image.RGBA {
+	dst := image.NewRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Over)
+	return dst
+}
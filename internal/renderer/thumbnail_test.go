@@ -0,0 +1,77 @@
+package renderer
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func TestThumbnailSize(t *testing.T) {
+	tests := []struct {
+		name                   string
+		width, height, maxSide int
+		wantWidth, wantHeight  int
+	}{
+		{name: "landscape scaled", width: 2000, height: 1400, maxSide: 640, wantWidth: 640, wantHeight: 448},
+		{name: "portrait scaled", width: 1000, height: 2000, maxSide: 500, wantWidth: 250, wantHeight: 500},
+		{name: "small image kept", width: 300, height: 200, maxSide: 640, wantWidth: 300, wantHeight: 200},
+		{name: "extreme ratio keeps one pixel", width: 5000, height: 2, maxSide: 100, wantWidth: 100, wantHeight: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := thumbnailSize(tt.width, tt.height, tt.maxSide)
+			if width != tt.wantWidth || height != tt.wantHeight {
+				t.Errorf("thumbnailSize() = %dx%d, want %dx%d", width, height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestOptimizeThumbnail(t *testing.T) {
+	// Left half opaque red, right half fully transparent
+	src := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+
+	var pngBuf bytes.Buffer
+	if err := png.Encode(&pngBuf, src); err != nil {
+		t.Fatalf("Failed to encode test image: %v", err)
+	}
+
+	optimized, err := optimizeThumbnail(pngBuf.Bytes(), 50, 90)
+	if err != nil {
+		t.Fatalf("optimizeThumbnail() error = %v", err)
+	}
+
+	decoded, err := jpeg.Decode(bytes.NewReader(optimized))
+	if err != nil {
+		t.Fatalf("Optimized thumbnail is not a JPEG: %v", err)
+	}
+
+	if got := decoded.Bounds(); got.Dx() != 50 || got.Dy() != 25 {
+		t.Fatalf("Expected 50x25 thumbnail, got %dx%d", got.Dx(), got.Dy())
+	}
+
+	r, g, b, _ := decoded.At(10, 12).RGBA()
+	if r>>8 < 200 || g>>8 > 60 || b>>8 > 60 {
+		t.Errorf("Expected red on the left, got (%d, %d, %d)", r>>8, g>>8, b>>8)
+	}
+
+	r, g, b, _ = decoded.At(40, 12).RGBA()
+	if r>>8 < 230 || g>>8 < 230 || b>>8 < 230 {
+		t.Errorf("Expected transparency flattened to white, got (%d, %d, %d)", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestOptimizeThumbnail_InvalidImage(t *testing.T) {
+	if _, err := optimizeThumbnail([]byte("not an image"), 640, 80); err == nil {
+		t.Error("Expected error for invalid image data")
+	}
+}
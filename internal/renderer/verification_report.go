@@ -0,0 +1,177 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// VerificationReport is the data summarised in a certificate's verification report PDF
+type VerificationReport struct {
+	CertificateID   string
+	CertificateName string
+	GeneratedAt     time.Time
+	Signers         []VerificationReportSigner
+	Participants    []VerificationReportParticipant
+
+	// QRCodes, when set, are appended as a printable QR codes sheet
+	QRCodes []QRSheetEntry
+}
+
+// VerificationReportSigner is one required signature of the certificate
+type VerificationReportSigner struct {
+	Name      string
+	Email     string
+	SignOrder int32
+	Signed    bool
+}
+
+// VerificationReportParticipant is one participant row with the link their QR code points at
+type VerificationReportParticipant struct {
+	ID              string
+	Name            string
+	VerificationURL string
+	Revoked         bool
+	Generated       bool
+}
+
+const (
+	reportMarginMm     = 12.0
+	reportLineHeightMm = 6.0
+)
+
+// BuildVerificationReport renders report as an A4 portrait PDF for auditors: a summary, the signer list with
+// signing status and a table of every participant with their verification URL and revoke status. The core
+// Helvetica font is used, so characters outside Windows-1252 are not rendered.
+func BuildVerificationReport(report *VerificationReport) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(reportMarginMm, reportMarginMm, reportMarginMm)
+	pdf.SetAutoPageBreak(true, reportMarginMm)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	generatedAt := report.GeneratedAt.UTC().Format("2006-01-02 15:04:05 UTC")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-reportMarginMm + 2)
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 4, fmt.Sprintf("Certificate %s - generated %s - page %d", report.CertificateID, generatedAt, pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pageWidth, _ := pdf.GetPageSize()
+	contentWidth := pageWidth - 2*reportMarginMm
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(contentWidth, 10, "Certificate Verification Report", "", 1, "L", false, 0, "")
+
+	revoked, generated := 0, 0
+	for _, p := range report.Participants {
+		if p.Revoked {
+			revoked++
+		}
+		if p.Generated {
+			generated++
+		}
+	}
+	signed := 0
+	for _, s := range report.Signers {
+		if s.Signed {
+			signed++
+		}
+	}
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range [][2]string{
+		{"Certificate", report.CertificateName},
+		{"Certificate ID", report.CertificateID},
+		{"Generated at", generatedAt},
+		{"Participants", fmt.Sprintf("%d (%d generated, %d revoked)", len(report.Participants), generated, revoked)},
+		{"Signatures", fmt.Sprintf("%d of %d signed", signed, len(report.Signers))},
+	} {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(35, reportLineHeightMm, line[0], "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(contentWidth-35, reportLineHeightMm, tr(line[1]), "", 1, "L", false, 0, "")
+	}
+
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(contentWidth, 8, "Signers", "", 1, "L", false, 0, "")
+	if len(report.Signers) == 0 {
+		pdf.SetFont("Helvetica", "I", 9)
+		pdf.CellFormat(contentWidth, reportLineHeightMm, "This certificate does not require signatures.", "", 1, "L", false, 0, "")
+	} else {
+		signerWidths := []float64{12, 66, 76, contentWidth - 154}
+		reportTableHeader(pdf, signerWidths, []string{"#", "Name", "Email", "Status"})
+		for _, s := range report.Signers {
+			status := "Pending"
+			if s.Signed {
+				status = "Signed"
+			}
+			reportTableRow(pdf, signerWidths, []string{fmt.Sprintf("%d", s.SignOrder), tr(s.Name), tr(s.Email), status})
+		}
+	}
+
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(contentWidth, 8, "Participants", "", 1, "L", false, 0, "")
+	participantWidths := []float64{45, contentWidth - 69, 24}
+	reportTableHeader(pdf, participantWidths, []string{"Name", "Verification URL", "Status"})
+	for _, p := range report.Participants {
+		status := "Valid"
+		switch {
+		case p.Revoked:
+			status = "Revoked"
+		case !p.Generated:
+			status = "Not generated"
+		}
+		name := p.Name
+		if name == "" {
+			name = p.ID
+		}
+		reportTableRow(pdf, participantWidths, []string{tr(name), p.VerificationURL, status})
+	}
+
+	if len(report.QRCodes) > 0 {
+		addQRCodeSheetPages(pdf, report.CertificateName, report.QRCodes)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate verification report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func reportTableHeader(pdf *gofpdf.Fpdf, widths []float64, titles []string) {
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(229, 231, 235)
+	for i, title := range titles {
+		pdf.CellFormat(widths[i], reportLineHeightMm, title, "1", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// reportTableRow writes one row, shortening values that don't fit their column
+func reportTableRow(pdf *gofpdf.Fpdf, widths []float64, values []string) {
+	pdf.SetFont("Helvetica", "", 7)
+	for i, value := range values {
+		pdf.CellFormat(widths[i], reportLineHeightMm, fitReportCell(pdf, value, widths[i]-2), "1", 0, "L", false, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// fitReportCell truncates value with an ellipsis so it is at most width millimetres wide
+func fitReportCell(pdf *gofpdf.Fpdf, value string, width float64) string {
+	if pdf.GetStringWidth(value) <= width {
+		return value
+	}
+	runes := []rune(value)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
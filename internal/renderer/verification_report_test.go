@@ -0,0 +1,63 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	digitorus_pdf "github.com/digitorus/pdf"
+)
+
+func TestBuildVerificationReport(t *testing.T) {
+	report := &VerificationReport{
+		CertificateID:   "cert-1",
+		CertificateName: "Award 2026",
+		GeneratedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Signers: []VerificationReportSigner{
+			{Name: "Dean", Email: "dean@example.com", SignOrder: 1, Signed: true},
+			{Name: "Head", Email: "head@example.com", SignOrder: 2},
+		},
+	}
+	for i := 0; i < 80; i++ {
+		report.Participants = append(report.Participants, VerificationReportParticipant{
+			ID:              fmt.Sprintf("p%d", i),
+			Name:            fmt.Sprintf("Participant %d", i),
+			VerificationURL: VerificationURL("https://verify.example.com", fmt.Sprintf("p%d", i)),
+			Revoked:         i == 0,
+			Generated:       i%2 == 0,
+		})
+	}
+
+	data, err := BuildVerificationReport(report)
+	if err != nil {
+		t.Fatalf("BuildVerificationReport() error = %v", err)
+	}
+
+	reader, err := digitorus_pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("report is not a readable PDF: %v", err)
+	}
+	if reader.NumPage() < 2 {
+		t.Fatalf("expected the participant table to span pages, got %d page(s)", reader.NumPage())
+	}
+
+	var text strings.Builder
+	for i := 1; i <= reader.NumPage(); i++ {
+		text.WriteString(pageText(t, reader.Page(i)))
+	}
+	// Text extraction drops the spaces between words
+	for _, want := range []string{"Award2026", "1of2signed", "80(40generated,1revoked)", "Participant79", "https://verify.example.com/validate/result/p79", "Revoked"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("report text is missing %q", want)
+		}
+	}
+}
+
+func TestBuildVerificationReportLongName(t *testing.T) {
+	data, err := BuildVerificationReport(&VerificationReport{CertificateID: "c", CertificateName: strings.Repeat("x", 500)})
+	if err != nil || !bytes.HasPrefix(data, []byte("%PDF")) {
+		t.Fatalf("BuildVerificationReport() with a long name = %v", err)
+	}
+}
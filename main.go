@@ -3,12 +3,15 @@ package main
 import (
 	"flag"
 	"log/slog"
+	"os"
 
 	"github.com/sunthewhat/easy-cert-api/api"
+	"github.com/sunthewhat/easy-cert-api/common"
 	"github.com/sunthewhat/easy-cert-api/common/config"
 	"github.com/sunthewhat/easy-cert-api/common/gorm"
 	"github.com/sunthewhat/easy-cert-api/common/mongo"
 	"github.com/sunthewhat/easy-cert-api/common/util"
+	"github.com/sunthewhat/easy-cert-api/internal/renderer"
 )
 
 func main() {
@@ -37,19 +40,44 @@ func main() {
 
 	gorm.InitGorm()
 	mongo.InitMongo()
-	util.InitDialer()
+	util.InitMailer()
 
 	if err := util.InitMinIO(); err != nil {
 		slog.Error("Failed to initialize MinIO", "error", err)
+		if common.Config.RequireMinIO != nil && *common.Config.RequireMinIO {
+			os.Exit(1)
+		}
 	} else {
 		slog.Info("MinIO initialized successfully")
 	}
 
+	// Check the renderer up front so a missing runtime is reported now rather than on the first generation
+	if capability := renderer.CheckRendererCapability(); capability.Disabled {
+		slog.Warn("Certificate rendering is disabled, generation endpoints will return 503")
+	} else if !capability.Available {
+		slog.Error("Certificate renderer is unavailable, generation endpoints will return 503", "error", capability.Error)
+		if common.Config.RequireRenderer != nil && *common.Config.RequireRenderer {
+			os.Exit(1)
+		}
+	} else if capability.FallbackInstall {
+		slog.Warn("No pre-installed renderer found, dependencies will be installed on first render", "binary", capability.Binary)
+	} else {
+		slog.Info("Certificate renderer available", "binary", capability.Binary, "renderer_dir", capability.RendererDir)
+	}
+
+	// Load the signing certificate once so expiry warnings surface at startup
+	if _, err := renderer.NewCertificateSigner(); err != nil {
+		slog.Warn("Failed to load PDF signing certificate", "error", err)
+	}
+
 	// Start signature reminder job for daily email reminders
 	util.StartSignatureReminderJob()
 
 	// Start preview cleanup job for removing old preview images (30 days)
 	util.StartPreviewCleanupJob()
 
+	// Start failed email retry job for resending queued failed emails
+	util.StartFailedEmailRetryJob()
+
 	api.InitFiber()
 }
@@ -0,0 +1,6 @@
+package payload
+
+// TestMailPayload is the recipient of a mail connectivity test; it may only be the caller's own address
+type TestMailPayload struct {
+	Email string `json:"email" validate:"omitempty,email"`
+}
@@ -1,5 +1,7 @@
 package payload
 
+import "time"
+
 type UpdateCertificatePayload struct {
 	Name   string `json:"name"`
 	Design string `json:"design"`
@@ -8,6 +10,68 @@ type UpdateCertificatePayload struct {
 type CreateCertificatePayload struct {
 	Name   string `json:"name" validate:"required"`
 	Design string `json:"design" validate:"required"`
+	// TemplateID records the template the design was created from, so design fixes can be propagated
+	TemplateID string `json:"template_id" validate:"omitempty,max=100"`
+}
+
+// SetVerifyHostPayload sets the per-certificate verification host; an empty value restores the global default
+type SetVerifyHostPayload struct {
+	VerifyHost string `json:"verify_host" validate:"omitempty,url"`
+}
+
+// SetArchiveFilenameTemplatePayload sets how PDFs are named inside the ZIP archive, e.g. "{{name}}-certificate.pdf"
+type SetArchiveFilenameTemplatePayload struct {
+	Template string `json:"template" validate:"max=200"`
+}
+
+// SetPdfLayoutPayload overrides the PDF page margin and image fit mode; omitted values use the configured defaults
+type SetPdfLayoutPayload struct {
+	MarginMm *float64 `json:"margin_mm" validate:"omitempty,min=0,max=50"`
+	FitMode  string   `json:"fit_mode" validate:"omitempty,oneof=fill contain cover"`
+}
+
+// SetPdfFooterPayload toggles the traceability footer on generated PDFs
+type SetPdfFooterPayload struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+// SetSignatureBackgroundPayload sets the color transparent signatures are flattened onto; empty keeps transparency
+type SetSignatureBackgroundPayload struct {
+	Background string `json:"background" validate:"omitempty,hexcolor"`
+}
+
+// SetSigningOrderPayload toggles sequential signing and optionally reorders the certificate's signers
+type SetSigningOrderPayload struct {
+	Sequential *bool    `json:"sequential" validate:"required"`
+	SignerIds  []string `json:"signer_ids" validate:"omitempty,unique,dive,required"`
+}
+
+type BulkRevokeParticipantsPayload struct {
+	ParticipantIds []string `json:"participantIds" validate:"required,min=1"`
+}
+
+// BulkRenameCertificatesPayload renames several certificates in one transaction
+type BulkRenameCertificatesPayload struct {
+	Items []BulkRenameCertificateItem `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+type BulkRenameCertificateItem struct {
+	Id   string `json:"id" validate:"required,uuid"`
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type BatchGetCertificatePayload struct {
+	Ids []string `json:"ids" validate:"required,min=1"`
+}
+
+// CertificateDefinition is the portable export format of a certificate's design and settings
+type CertificateDefinition struct {
+	Version    int       `json:"version" validate:"required"`
+	Name       string    `json:"name" validate:"required"`
+	Design     string    `json:"design" validate:"required"`
+	Anchors    []string  `json:"anchors"`
+	SignerIds  []string  `json:"signer_ids"`
+	ExportedAt time.Time `json:"exported_at"`
 }
 
 type renderCertificateResult struct {
@@ -26,3 +90,14 @@ type RenderThumbnailPayload struct {
 	Message       string `json:"message"`
 	ThumbnailPath string `json:"thumbnailPath"`
 }
+
+// DistributeSelectedPayload emails a hand-picked set of participants; EmailField defaults to "email"
+type DistributeSelectedPayload struct {
+	ParticipantIds []string `json:"participant_ids" validate:"required,min=1,unique,dive,required"`
+	EmailField     string   `json:"email_field"`
+}
+
+// NotifyCompletePayload re-sends the signing completion email; Message is an optional note added to the body
+type NotifyCompletePayload struct {
+	Message string `json:"message" validate:"max=2000"`
+}
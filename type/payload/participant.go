@@ -2,8 +2,18 @@ package payload
 
 type AddParticipantPayload struct {
 	Participants []map[string]any `json:"participants" validate:"required"`
+	// Upsert updates participants whose email already exists instead of adding duplicates
+	Upsert bool `json:"upsert"`
 }
 
 type UpdateParticipantIsDistributed struct {
 	Ids []string `json:"participantIds" validate:"required"`
 }
+
+type SetParticipantTagsPayload struct {
+	Tags []string `json:"tags" validate:"required"`
+}
+
+type VerifyCertificatePayload struct {
+	Payload string `json:"payload" validate:"required"`
+}
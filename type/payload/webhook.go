@@ -0,0 +1,8 @@
+package payload
+
+// MailBouncePayload is a bounce notification posted by the mail provider
+type MailBouncePayload struct {
+	Email         string `json:"email" validate:"required,email"`
+	Reason        string `json:"reason"`
+	CertificateId string `json:"certificate_id" validate:"required,uuid"`
+}
@@ -10,10 +10,23 @@ func SendUnauthorized(c *fiber.Ctx, msg string) error {
 	return c.Status(fiber.StatusUnauthorized).JSON(Error(msg))
 }
 
+func SendForbidden(c *fiber.Ctx, msg string) error {
+	return c.Status(fiber.StatusForbidden).JSON(Error(msg))
+}
+
 func SendFailed(c *fiber.Ctx, msg string) error {
 	return c.Status(fiber.StatusBadRequest).JSON(Error(msg))
 }
 
+// SendValidationFailed responds 400 with the first message and the per-field errors under data.errors
+func SendValidationFailed(c *fiber.Ctx, msg string, fieldErrors any) error {
+	return c.Status(fiber.StatusBadRequest).JSON(&BaseResponse{
+		Success: false,
+		Msg:     msg,
+		Data:    fiber.Map{"errors": fieldErrors},
+	})
+}
+
 func SendError(c *fiber.Ctx, msg string) error {
 	return c.Status(fiber.StatusInternalServerError).JSON(Error(msg))
 }
@@ -21,3 +34,35 @@ func SendError(c *fiber.Ctx, msg string) error {
 func SendInternalError(c *fiber.Ctx, err error) error {
 	return c.Status(fiber.StatusInternalServerError).JSON(Error(err.Error()))
 }
+
+func SendNotFound(c *fiber.Ctx, msg string) error {
+	return c.Status(fiber.StatusNotFound).JSON(Error(msg))
+}
+
+func SendConflict(c *fiber.Ctx, msg string, data any) error {
+	return c.Status(fiber.StatusConflict).JSON(&BaseResponse{
+		Success: false,
+		Msg:     msg,
+		Data:    data,
+	})
+}
+
+func SendPayloadTooLarge(c *fiber.Ctx, msg string) error {
+	return c.Status(fiber.StatusRequestEntityTooLarge).JSON(Error(msg))
+}
+
+func SendUnsupportedMediaType(c *fiber.Ctx, msg string) error {
+	return c.Status(fiber.StatusUnsupportedMediaType).JSON(Error(msg))
+}
+
+func SendTooManyRequests(c *fiber.Ctx, msg string, data any) error {
+	return c.Status(fiber.StatusTooManyRequests).JSON(&BaseResponse{
+		Success: false,
+		Msg:     msg,
+		Data:    data,
+	})
+}
+
+func SendServiceUnavailable(c *fiber.Ctx, msg string) error {
+	return c.Status(fiber.StatusServiceUnavailable).JSON(Error(msg))
+}
@@ -26,4 +26,84 @@ type Config struct {
 	SigningCertPath   *string   `yaml:"signing_cert_path"`
 	SigningKeyPath    *string   `yaml:"signing_key_path"`
 	EncryptionKey     *string   `yaml:"encryption_key" validate:"required"`
+
+	MailMaxAttachmentBytes *int64  `yaml:"mail_max_attachment_bytes"`
+	RequireMinIO           *bool   `yaml:"require_minio"`
+	RendererBinary         *string `yaml:"renderer_binary"`
+	RendererDefaultFont    *string `yaml:"renderer_default_font"`
+	RendererMaxRetries     *int    `yaml:"renderer_max_retries"`
+	RendererMaxOutputMB    *int    `yaml:"renderer_max_output_mb"`
+	GenerationDeadlineSecs *int    `yaml:"generation_deadline_seconds"`
+	SigningCertWarnDays    *int    `yaml:"signing_cert_warn_days"`
+	SigningLinkTTLHours    *int    `yaml:"signing_link_ttl_hours"`
+
+	ParticipantCollectionMode *string `yaml:"participant_collection_mode" validate:"omitempty,oneof=per_certificate shared"`
+	DedupByEmail              *bool   `yaml:"dedup_by_email"`
+
+	BodyLimitBytes       *int `yaml:"body_limit_bytes"`
+	ImportBodyLimitBytes *int `yaml:"import_body_limit_bytes"`
+	DesignBodyLimitBytes *int `yaml:"design_body_limit_bytes"`
+
+	BucketPreview *string `yaml:"bucket_preview"`
+
+	MaxConcurrentGenerations *int `yaml:"max_concurrent_generations"`
+	MaxQueuedGenerations     *int `yaml:"max_queued_generations"`
+
+	UserIdClaim *string `yaml:"user_id_claim"`
+
+	MongoOpTimeout *int `yaml:"mongo_op_timeout"`
+
+	PdfMarginMm *float64 `yaml:"pdf_margin_mm" validate:"omitempty,min=0,max=50"`
+	PdfFitMode  *string  `yaml:"pdf_fit_mode" validate:"omitempty,oneof=fill contain cover"`
+
+	MailWebhookSecret *string `yaml:"mail_webhook_secret"`
+
+	CertificateCacheTTLSeconds *int `yaml:"certificate_cache_ttl_seconds"`
+
+	MaxParticipantsPerCert *int `yaml:"max_participants_per_cert"`
+
+	MaxCertificatesPerUser *int `yaml:"max_certificates_per_user"`
+	MaxParticipantsPerUser *int `yaml:"max_participants_per_user"`
+
+	AnchorNameValidation *bool   `yaml:"anchor_name_validation"`
+	AnchorNamePattern    *string `yaml:"anchor_name_pattern"`
+
+	ThumbnailMaxDimension   *int  `yaml:"thumbnail_max_dimension"`
+	ThumbnailJPEGQuality    *int  `yaml:"thumbnail_jpeg_quality" validate:"omitempty,min=1,max=100"`
+	ThumbnailOnDesignChange *bool `yaml:"thumbnail_on_design_change"`
+
+	SignatureMaxWidth  *int `yaml:"signature_max_width"`
+	SignatureMaxHeight *int `yaml:"signature_max_height"`
+
+	AllowedAssetTypes []string `yaml:"allowed_asset_types"`
+
+	ParticipantEncryptedFields []string `yaml:"participant_encrypted_fields"`
+	ParticipantEncryptionKey   *string  `yaml:"participant_encryption_key"`
+
+	MinioUploadPartSizeMB *int `yaml:"minio_upload_part_size_mb"`
+	MinioUploadMaxRetries *int `yaml:"minio_upload_max_retries"`
+
+	FailedEmailRetryIntervalMinutes *int `yaml:"failed_email_retry_interval_minutes"`
+	FailedEmailMaxAttempts          *int `yaml:"failed_email_max_attempts"`
+
+	MailProvider *string `yaml:"mail_provider" validate:"omitempty,oneof=smtp http"`
+	MailApiUrl   *string `yaml:"mail_api_url"`
+	MailApiKey   *string `yaml:"mail_api_key"`
+
+	MailRateLimitPerSecond  *float64 `yaml:"mail_rate_limit_per_second" validate:"omitempty,min=0"`
+	SignatureRequestWorkers *int     `yaml:"signature_request_workers"`
+
+	MailFrom        *string `yaml:"mail_from"`
+	MailTemplateDir *string `yaml:"mail_template_dir"`
+
+	CorsMaxAgeSecs *int `yaml:"cors_max_age_seconds"`
+
+	MongoMaxRetries *int `yaml:"mongo_max_retries"`
+
+	ArchiveIncludeQRSheet *bool `yaml:"archive_include_qr_sheet"`
+
+	ArchiveIncludeManifest *bool `yaml:"archive_include_manifest"`
+
+	RenderingDisabled *bool `yaml:"rendering_disabled"`
+	RequireRenderer   *bool `yaml:"require_renderer"`
 }
@@ -12,16 +12,28 @@ const TableNameCertificate = "certificates"
 
 // Certificate mapped from table <certificates>
 type Certificate struct {
-	ID            string    `gorm:"column:id;primaryKey;default:gen_random_uuid()" json:"id"`
-	Name          string    `gorm:"column:name;not null" json:"name"`
-	Design        string    `gorm:"column:design;not null" json:"design"`
-	UserID        string    `gorm:"column:user_id;not null" json:"user_id"`
-	CreatedAt     time.Time `gorm:"column:created_at;not null;default:now()" json:"created_at"`
-	UpdatedAt     time.Time `gorm:"column:updated_at;not null;default:now()" json:"updated_at"`
-	ThumbnailURL  string    `gorm:"column:thumbnail_url" json:"thumbnail_url"`
-	ArchiveURL    string    `gorm:"column:archive_url" json:"archive_url"`
-	IsDistributed bool      `gorm:"column:is_distributed;not null" json:"is_distributed"`
-	IsSigned      bool      `gorm:"column:is_signed;not null" json:"is_signed"`
+	ID                      string    `gorm:"column:id;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name                    string    `gorm:"column:name;not null" json:"name"`
+	Design                  string    `gorm:"column:design;not null" json:"design"`
+	UserID                  string    `gorm:"column:user_id;not null" json:"user_id"`
+	CreatedAt               time.Time `gorm:"column:created_at;not null;default:now()" json:"created_at"`
+	UpdatedAt               time.Time `gorm:"column:updated_at;not null;default:now()" json:"updated_at"`
+	ThumbnailURL            string    `gorm:"column:thumbnail_url" json:"thumbnail_url"`
+	ArchiveURL              string    `gorm:"column:archive_url" json:"archive_url"`
+	IsDistributed           bool      `gorm:"column:is_distributed;not null" json:"is_distributed"`
+	IsSigned                bool      `gorm:"column:is_signed;not null" json:"is_signed"`
+	VerifyHost              string    `gorm:"column:verify_host" json:"verify_host"`
+	PdfFooter               bool      `gorm:"column:pdf_footer;not null;default:false" json:"pdf_footer"`
+	ArchiveFilenameTemplate string    `gorm:"column:archive_filename_template" json:"archive_filename_template"`
+	PdfMarginMm             *float64  `gorm:"column:pdf_margin_mm" json:"pdf_margin_mm"`
+	PdfFitMode              string    `gorm:"column:pdf_fit_mode" json:"pdf_fit_mode"`
+	SequentialSigning       bool      `gorm:"column:sequential_signing;not null;default:false" json:"sequential_signing"`
+	Anchors                 []string  `gorm:"column:anchors;type:jsonb;serializer:json" json:"anchors"`
+	SignatureBackground     string    `gorm:"column:signature_background;not null;default:''" json:"signature_background"`
+	SourceTemplateID        *string   `gorm:"column:source_template_id" json:"source_template_id"`
+	PreviousDesign          string    `gorm:"column:previous_design" json:"previous_design"`
+	SavedDesign             string    `gorm:"column:saved_design" json:"-"`
+	DistributionEmailField  string    `gorm:"column:distribution_email_field;not null;default:''" json:"distribution_email_field"`
 }
 
 // TableName Certificate's table name
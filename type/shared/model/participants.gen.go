@@ -20,6 +20,8 @@ type Participant struct {
 	CertificateURL string    `gorm:"column:certificate_url" json:"certificate_url"`
 	EmailStatus    string    `gorm:"column:email_status;not null;default:pending" json:"email_status"`
 	IsDownloaded   bool      `gorm:"column:is_downloaded;not null" json:"is_downloaded"`
+	IsStale        bool      `gorm:"column:is_stale;not null;default:false" json:"is_stale"`
+	PdfSigned      *bool     `gorm:"column:pdf_signed" json:"pdf_signed"`
 }
 
 // TableName Participant's table name
@@ -21,6 +21,7 @@ type Signature struct {
 	CreatedBy     string    `gorm:"column:created_by;not null" json:"created_by"`
 	IsRequested   bool      `gorm:"column:is_requested;not null" json:"is_requested"`
 	LastRequest   time.Time `gorm:"column:last_request;not null;default:now()" json:"last_request"`
+	SignOrder     int32     `gorm:"column:sign_order;not null;default:0" json:"sign_order"`
 }
 
 // TableName Signature's table name
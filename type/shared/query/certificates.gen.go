@@ -37,6 +37,18 @@ func newCertificate(db *gorm.DB, opts ...gen.DOOption) certificate {
 	_certificate.ArchiveURL = field.NewString(tableName, "archive_url")
 	_certificate.IsDistributed = field.NewBool(tableName, "is_distributed")
 	_certificate.IsSigned = field.NewBool(tableName, "is_signed")
+	_certificate.VerifyHost = field.NewString(tableName, "verify_host")
+	_certificate.PdfFooter = field.NewBool(tableName, "pdf_footer")
+	_certificate.ArchiveFilenameTemplate = field.NewString(tableName, "archive_filename_template")
+	_certificate.PdfMarginMm = field.NewFloat64(tableName, "pdf_margin_mm")
+	_certificate.PdfFitMode = field.NewString(tableName, "pdf_fit_mode")
+	_certificate.SequentialSigning = field.NewBool(tableName, "sequential_signing")
+	_certificate.Anchors = field.NewField(tableName, "anchors")
+	_certificate.SignatureBackground = field.NewString(tableName, "signature_background")
+	_certificate.SourceTemplateID = field.NewString(tableName, "source_template_id")
+	_certificate.PreviousDesign = field.NewString(tableName, "previous_design")
+	_certificate.SavedDesign = field.NewString(tableName, "saved_design")
+	_certificate.DistributionEmailField = field.NewString(tableName, "distribution_email_field")
 
 	_certificate.fillFieldMap()
 
@@ -46,17 +58,29 @@ func newCertificate(db *gorm.DB, opts ...gen.DOOption) certificate {
 type certificate struct {
 	certificateDo
 
-	ALL           field.Asterisk
-	ID            field.String
-	Name          field.String
-	Design        field.String
-	UserID        field.String
-	CreatedAt     field.Time
-	UpdatedAt     field.Time
-	ThumbnailURL  field.String
-	ArchiveURL    field.String
-	IsDistributed field.Bool
-	IsSigned      field.Bool
+	ALL                     field.Asterisk
+	ID                      field.String
+	Name                    field.String
+	Design                  field.String
+	UserID                  field.String
+	CreatedAt               field.Time
+	UpdatedAt               field.Time
+	ThumbnailURL            field.String
+	ArchiveURL              field.String
+	IsDistributed           field.Bool
+	IsSigned                field.Bool
+	VerifyHost              field.String
+	PdfFooter               field.Bool
+	ArchiveFilenameTemplate field.String
+	PdfMarginMm             field.Float64
+	PdfFitMode              field.String
+	SequentialSigning       field.Bool
+	Anchors                 field.Field
+	SignatureBackground     field.String
+	SourceTemplateID        field.String
+	PreviousDesign          field.String
+	SavedDesign             field.String
+	DistributionEmailField  field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -83,6 +107,18 @@ func (c *certificate) updateTableName(table string) *certificate {
 	c.ArchiveURL = field.NewString(table, "archive_url")
 	c.IsDistributed = field.NewBool(table, "is_distributed")
 	c.IsSigned = field.NewBool(table, "is_signed")
+	c.VerifyHost = field.NewString(table, "verify_host")
+	c.PdfFooter = field.NewBool(table, "pdf_footer")
+	c.ArchiveFilenameTemplate = field.NewString(table, "archive_filename_template")
+	c.PdfMarginMm = field.NewFloat64(table, "pdf_margin_mm")
+	c.PdfFitMode = field.NewString(table, "pdf_fit_mode")
+	c.SequentialSigning = field.NewBool(table, "sequential_signing")
+	c.Anchors = field.NewField(table, "anchors")
+	c.SignatureBackground = field.NewString(table, "signature_background")
+	c.SourceTemplateID = field.NewString(table, "source_template_id")
+	c.PreviousDesign = field.NewString(table, "previous_design")
+	c.SavedDesign = field.NewString(table, "saved_design")
+	c.DistributionEmailField = field.NewString(table, "distribution_email_field")
 
 	c.fillFieldMap()
 
@@ -99,7 +135,7 @@ func (c *certificate) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (c *certificate) fillFieldMap() {
-	c.fieldMap = make(map[string]field.Expr, 10)
+	c.fieldMap = make(map[string]field.Expr, 22)
 	c.fieldMap["id"] = c.ID
 	c.fieldMap["name"] = c.Name
 	c.fieldMap["design"] = c.Design
@@ -110,6 +146,18 @@ func (c *certificate) fillFieldMap() {
 	c.fieldMap["archive_url"] = c.ArchiveURL
 	c.fieldMap["is_distributed"] = c.IsDistributed
 	c.fieldMap["is_signed"] = c.IsSigned
+	c.fieldMap["verify_host"] = c.VerifyHost
+	c.fieldMap["pdf_footer"] = c.PdfFooter
+	c.fieldMap["archive_filename_template"] = c.ArchiveFilenameTemplate
+	c.fieldMap["pdf_margin_mm"] = c.PdfMarginMm
+	c.fieldMap["pdf_fit_mode"] = c.PdfFitMode
+	c.fieldMap["sequential_signing"] = c.SequentialSigning
+	c.fieldMap["anchors"] = c.Anchors
+	c.fieldMap["signature_background"] = c.SignatureBackground
+	c.fieldMap["source_template_id"] = c.SourceTemplateID
+	c.fieldMap["previous_design"] = c.PreviousDesign
+	c.fieldMap["saved_design"] = c.SavedDesign
+	c.fieldMap["distribution_email_field"] = c.DistributionEmailField
 }
 
 func (c certificate) clone(db *gorm.DB) certificate {
@@ -17,32 +17,41 @@ import (
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:          db,
-		Certificate: newCertificate(db, opts...),
-		Participant: newParticipant(db, opts...),
-		Signature:   newSignature(db, opts...),
-		Signer:      newSigner(db, opts...),
+		db:                  db,
+		Certificate:         newCertificate(db, opts...),
+		CertificateActivity: newCertificateActivity(db, opts...),
+		FailedEmail:         newFailedEmail(db, opts...),
+		Participant:         newParticipant(db, opts...),
+		Signature:           newSignature(db, opts...),
+		SignatureEvent:      newSignatureEvent(db, opts...),
+		Signer:              newSigner(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	Certificate certificate
-	Participant participant
-	Signature   signature
-	Signer      signer
+	Certificate         certificate
+	CertificateActivity certificateActivity
+	FailedEmail         failedEmail
+	Participant         participant
+	Signature           signature
+	SignatureEvent      signatureEvent
+	Signer              signer
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:          db,
-		Certificate: q.Certificate.clone(db),
-		Participant: q.Participant.clone(db),
-		Signature:   q.Signature.clone(db),
-		Signer:      q.Signer.clone(db),
+		db:                  db,
+		Certificate:         q.Certificate.clone(db),
+		CertificateActivity: q.CertificateActivity.clone(db),
+		FailedEmail:         q.FailedEmail.clone(db),
+		Participant:         q.Participant.clone(db),
+		Signature:           q.Signature.clone(db),
+		SignatureEvent:      q.SignatureEvent.clone(db),
+		Signer:              q.Signer.clone(db),
 	}
 }
 
@@ -56,27 +65,36 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:          db,
-		Certificate: q.Certificate.replaceDB(db),
-		Participant: q.Participant.replaceDB(db),
-		Signature:   q.Signature.replaceDB(db),
-		Signer:      q.Signer.replaceDB(db),
+		db:                  db,
+		Certificate:         q.Certificate.replaceDB(db),
+		CertificateActivity: q.CertificateActivity.replaceDB(db),
+		FailedEmail:         q.FailedEmail.replaceDB(db),
+		Participant:         q.Participant.replaceDB(db),
+		Signature:           q.Signature.replaceDB(db),
+		SignatureEvent:      q.SignatureEvent.replaceDB(db),
+		Signer:              q.Signer.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	Certificate *certificateDo
-	Participant *participantDo
-	Signature   *signatureDo
-	Signer      *signerDo
+	Certificate         *certificateDo
+	CertificateActivity *certificateActivityDo
+	FailedEmail         *failedEmailDo
+	Participant         *participantDo
+	Signature           *signatureDo
+	SignatureEvent      *signatureEventDo
+	Signer              *signerDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		Certificate: q.Certificate.WithContext(ctx),
-		Participant: q.Participant.WithContext(ctx),
-		Signature:   q.Signature.WithContext(ctx),
-		Signer:      q.Signer.WithContext(ctx),
+		Certificate:         q.Certificate.WithContext(ctx),
+		CertificateActivity: q.CertificateActivity.WithContext(ctx),
+		FailedEmail:         q.FailedEmail.WithContext(ctx),
+		Participant:         q.Participant.WithContext(ctx),
+		Signature:           q.Signature.WithContext(ctx),
+		SignatureEvent:      q.SignatureEvent.WithContext(ctx),
+		Signer:              q.Signer.WithContext(ctx),
 	}
 }
 
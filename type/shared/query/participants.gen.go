@@ -35,6 +35,8 @@ func newParticipant(db *gorm.DB, opts ...gen.DOOption) participant {
 	_participant.CertificateURL = field.NewString(tableName, "certificate_url")
 	_participant.EmailStatus = field.NewString(tableName, "email_status")
 	_participant.IsDownloaded = field.NewBool(tableName, "is_downloaded")
+	_participant.IsStale = field.NewBool(tableName, "is_stale")
+	_participant.PdfSigned = field.NewBool(tableName, "pdf_signed")
 
 	_participant.fillFieldMap()
 
@@ -53,6 +55,8 @@ type participant struct {
 	CertificateURL field.String
 	EmailStatus    field.String
 	IsDownloaded   field.Bool
+	IsStale        field.Bool
+	PdfSigned      field.Bool
 
 	fieldMap map[string]field.Expr
 }
@@ -77,6 +81,8 @@ func (p *participant) updateTableName(table string) *participant {
 	p.CertificateURL = field.NewString(table, "certificate_url")
 	p.EmailStatus = field.NewString(table, "email_status")
 	p.IsDownloaded = field.NewBool(table, "is_downloaded")
+	p.IsStale = field.NewBool(table, "is_stale")
+	p.PdfSigned = field.NewBool(table, "pdf_signed")
 
 	p.fillFieldMap()
 
@@ -93,7 +99,7 @@ func (p *participant) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (p *participant) fillFieldMap() {
-	p.fieldMap = make(map[string]field.Expr, 8)
+	p.fieldMap = make(map[string]field.Expr, 10)
 	p.fieldMap["id"] = p.ID
 	p.fieldMap["certificate_id"] = p.CertificateID
 	p.fieldMap["isrevoke"] = p.Isrevoke
@@ -102,6 +108,8 @@ func (p *participant) fillFieldMap() {
 	p.fieldMap["certificate_url"] = p.CertificateURL
 	p.fieldMap["email_status"] = p.EmailStatus
 	p.fieldMap["is_downloaded"] = p.IsDownloaded
+	p.fieldMap["is_stale"] = p.IsStale
+	p.fieldMap["pdf_signed"] = p.PdfSigned
 }
 
 func (p participant) clone(db *gorm.DB) participant {
@@ -36,6 +36,7 @@ func newSignature(db *gorm.DB, opts ...gen.DOOption) signature {
 	_signature.CreatedBy = field.NewString(tableName, "created_by")
 	_signature.IsRequested = field.NewBool(tableName, "is_requested")
 	_signature.LastRequest = field.NewTime(tableName, "last_request")
+	_signature.SignOrder = field.NewInt32(tableName, "sign_order")
 
 	_signature.fillFieldMap()
 
@@ -55,6 +56,7 @@ type signature struct {
 	CreatedBy     field.String
 	IsRequested   field.Bool
 	LastRequest   field.Time
+	SignOrder     field.Int32
 
 	fieldMap map[string]field.Expr
 }
@@ -80,6 +82,7 @@ func (s *signature) updateTableName(table string) *signature {
 	s.CreatedBy = field.NewString(table, "created_by")
 	s.IsRequested = field.NewBool(table, "is_requested")
 	s.LastRequest = field.NewTime(table, "last_request")
+	s.SignOrder = field.NewInt32(table, "sign_order")
 
 	s.fillFieldMap()
 
@@ -96,7 +99,7 @@ func (s *signature) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *signature) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 9)
+	s.fieldMap = make(map[string]field.Expr, 10)
 	s.fieldMap["id"] = s.ID
 	s.fieldMap["signer_id"] = s.SignerID
 	s.fieldMap["certificate_id"] = s.CertificateID
@@ -106,6 +109,7 @@ func (s *signature) fillFieldMap() {
 	s.fieldMap["created_by"] = s.CreatedBy
 	s.fieldMap["is_requested"] = s.IsRequested
 	s.fieldMap["last_request"] = s.LastRequest
+	s.fieldMap["sign_order"] = s.SignOrder
 }
 
 func (s signature) clone(db *gorm.DB) signature {
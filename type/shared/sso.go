@@ -49,4 +49,7 @@ type SsoJwtPayload struct {
 	GivenName         string         `json:"given_name"`
 	FamilyName        string         `json:"family_name"`
 	Email             string         `json:"email"`
+
+	// Claims holds every claim of the token, including ones without a typed field
+	Claims map[string]any `json:"-"`
 }